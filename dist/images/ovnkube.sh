@@ -76,6 +76,7 @@ fi
 # OVN_EGRESSIP_HEALTHCHECK_PORT - egress IP node check to use grpc on this port (0 ==> dial to port 9 instead)
 # OVN_EGRESSFIREWALL_ENABLE - enable egressFirewall for ovn-kubernetes
 # OVN_EGRESSQOS_ENABLE - enable egress QoS for ovn-kubernetes
+# OVN_EGRESSINTERFACE_ENABLE - enable egress interface for ovn-kubernetes
 # OVN_UNPRIVILEGED_MODE - execute CNI ovs/netns commands from host (default no)
 # OVNKUBE_NODE_MODE - ovnkube node mode of operation, one of: full, dpu, dpu-host (default: full)
 # OVNKUBE_NODE_MGMT_PORT_NETDEV - ovnkube node management port netdev.
@@ -221,6 +222,8 @@ ovn_egress_ip_healthcheck_port=${OVN_EGRESSIP_HEALTHCHECK_PORT:-9107}
 ovn_egressfirewall_enable=${OVN_EGRESSFIREWALL_ENABLE:-false}
 #OVN_EGRESSQOS_ENABLE - enable egress QoS for ovn-kubernetes
 ovn_egressqos_enable=${OVN_EGRESSQOS_ENABLE:-false}
+#OVN_EGRESSINTERFACE_ENABLE - enable egress interface for ovn-kubernetes
+ovn_egressinterface_enable=${OVN_EGRESSINTERFACE_ENABLE:-false}
 #OVN_DISABLE_OVN_IFACE_ID_VER - disable usage of the OVN iface-id-ver option
 ovn_disable_ovn_iface_id_ver=${OVN_DISABLE_OVN_IFACE_ID_VER:-false}
 #OVN_MULTI_NETWORK_ENABLE - enable multiple network support for ovn-kubernetes
@@ -243,6 +246,11 @@ ovnkube_node_mgmt_port_netdev=${OVNKUBE_NODE_MGMT_PORT_NETDEV:-}
 # OVNKUBE_NODE_MGMT_PORT_DP_RESOURCE_NAME - is the device plugin resource name that has
 # allocated interfaces to be used for the management port
 ovnkube_node_mgmt_port_dp_resource_name=${OVNKUBE_NODE_MGMT_PORT_DP_RESOURCE_NAME:-}
+# OVNKUBE_NODE_DPU_DEVICES - the DPU devices of the host, separated by ';', the first one being the primary DPU
+# (eg, "name=dpu0,pf=0000:03:00.0;name=dpu1,pf=0000:81:00.0")
+ovnkube_node_dpu_devices=${OVNKUBE_NODE_DPU_DEVICES:-}
+# OVNKUBE_NODE_DPU_NAME - in dpu mode, the name of the DPU device ovnkube-node runs on
+ovnkube_node_dpu_name=${OVNKUBE_NODE_DPU_NAME:-}
 ovnkube_config_duration_enable=${OVNKUBE_CONFIG_DURATION_ENABLE:-false}
 ovnkube_metrics_scale_enable=${OVNKUBE_METRICS_SCALE_ENABLE:-false}
 # OVN_ENCAP_IP - encap IP to be used for OVN traffic on the node
@@ -544,6 +552,7 @@ display_env() {
   echo OVNKUBE_LOGLEVEL ${ovnkube_loglevel}
   echo OVN_DAEMONSET_VERSION ${ovn_daemonset_version}
   echo OVNKUBE_NODE_MODE ${ovnkube_node_mode}
+  echo OVNKUBE_NODE_DPU_DEVICES ${ovnkube_node_dpu_devices}
   echo OVN_ENCAP_IP ${ovn_encap_ip}
   echo ovnkube.sh version ${ovnkube_version}
   echo OVN_HOST_NETWORK_NAMESPACE ${ovn_host_network_namespace}
@@ -965,7 +974,7 @@ ovn-master() {
 
   multicast_enabled_flag=
   if [[ ${ovn_multicast_enable} == "true" ]]; then
-      multicast_enabled_flag="--enable-multicast"
+      multicast_enabled_flag="--enable-multicast --enable-multicast-policy"
   fi
 
   egressip_enabled_flag=
@@ -987,6 +996,11 @@ ovn-master() {
   if [[ ${ovn_egressqos_enable} == "true" ]]; then
 	  egressqos_enabled_flag="--enable-egress-qos"
   fi
+  egressinterface_enabled_flag=
+  if [[ ${ovn_egressinterface_enable} == "true" ]]; then
+	  egressinterface_enabled_flag="--enable-egress-interface"
+  fi
+  echo "egressinterface_enabled_flag=${egressinterface_enabled_flag}"
   multi_network_enabled_flag=
   if [[ ${ovn_multi_network_enable} == "true" ]]; then
 	  multi_network_enabled_flag="--enable-multi-network"
@@ -1046,6 +1060,7 @@ ovn-master() {
     ${egressip_healthcheck_port_flag} \
     ${egressfirewall_enabled_flag} \
     ${egressqos_enabled_flag} \
+    ${egressinterface_enabled_flag} \
     ${ovnkube_config_duration_enable_flag} \
     ${ovnkube_metrics_scale_enable_flag} \
     ${multi_network_enabled_flag} \
@@ -1139,7 +1154,7 @@ ovn-network-controller-manager() {
 
   multicast_enabled_flag=
   if [[ ${ovn_multicast_enable} == "true" ]]; then
-      multicast_enabled_flag="--enable-multicast"
+      multicast_enabled_flag="--enable-multicast --enable-multicast-policy"
   fi
   echo "multicast_enabled_flag=${multicast_enabled_flag}"
 
@@ -1167,6 +1182,12 @@ ovn-network-controller-manager() {
   fi
   echo "egressqos_enabled_flag=${egressqos_enabled_flag}"
 
+  egressinterface_enabled_flag=
+  if [[ ${ovn_egressinterface_enable} == "true" ]]; then
+	  egressinterface_enabled_flag="--enable-egress-interface"
+  fi
+  echo "egressinterface_enabled_flag=${egressinterface_enabled_flag}"
+
   multi_network_enabled_flag=
   if [[ ${ovn_multi_network_enable} == "true" ]]; then
 	  multi_network_enabled_flag="--enable-multi-network"
@@ -1216,6 +1237,7 @@ ovn-network-controller-manager() {
     ${egressip_healthcheck_port_flag} \
     ${egressfirewall_enabled_flag} \
     ${egressqos_enabled_flag} \
+    ${egressinterface_enabled_flag} \
     ${ovnkube_config_duration_enable_flag} \
     ${multi_network_enabled_flag} \
     --metrics-bind-address ${ovnkube_master_metrics_bind_address} \
@@ -1408,6 +1430,12 @@ ovn-node() {
       egressip_enabled_flag="--enable-egress-ip"
   fi
 
+  egressinterface_enabled_flag=
+  if [[ ${ovn_egressinterface_enable} == "true" ]]; then
+      egressinterface_enabled_flag="--enable-egress-interface"
+  fi
+  echo "egressinterface_enabled_flag=${egressinterface_enabled_flag}"
+
   egressip_healthcheck_port_flag=
   if [[ -n "${ovn_egress_ip_healthcheck_port}" ]]; then
       egressip_healthcheck_port_flag="--egressip-node-healthcheck-port=${ovn_egress_ip_healthcheck_port}"
@@ -1512,6 +1540,14 @@ ovn-node() {
     node_mgmt_port_netdev_flags="$node_mgmt_port_netdev_flags --ovnkube-node-mgmt-port-dp-resource-name ${ovnkube_node_mgmt_port_dp_resource_name}"
   fi
 
+  ovnkube_node_dpu_flags=
+  if [[ -n "${ovnkube_node_dpu_devices}" ]]; then
+    ovnkube_node_dpu_flags="--ovnkube-node-dpu-devices=${ovnkube_node_dpu_devices}"
+  fi
+  if [[ -n "${ovnkube_node_dpu_name}" ]]; then
+    ovnkube_node_dpu_flags="${ovnkube_node_dpu_flags} --ovnkube-node-dpu-name=${ovnkube_node_dpu_name}"
+  fi
+
   local ovn_node_ssl_opts=""
   if [[ ${ovnkube_node_mode} != "dpu-host" ]]; then
       [[ "yes" == ${OVN_SSL_ENABLE} ]] && {
@@ -1575,6 +1611,7 @@ ovn-node() {
     ${lflow_cache_limit_kb} \
     ${multicast_enabled_flag} \
     ${egressip_enabled_flag} \
+    ${egressinterface_enabled_flag} \
     ${egressip_healthcheck_port_flag} \
     ${disable_ovn_iface_id_ver_flag} \
     ${multi_network_enabled_flag} \
@@ -1585,6 +1622,7 @@ ovn-node() {
     --ovn-metrics-bind-address ${ovn_metrics_bind_address} \
     --metrics-bind-address ${ovnkube_node_metrics_bind_address} \
      ${ovnkube_node_mode_flag} \
+    ${ovnkube_node_dpu_flags} \
     ${egress_interface} \
     --host-network-namespace ${ovn_host_network_namespace} \
      ${ovnkube_node_mgmt_port_netdev_flag} &
@@ -3,9 +3,7 @@ package main
 import (
 	"os"
 
-	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
-	"github.com/containernetworking/cni/pkg/version"
 	bv "github.com/containernetworking/plugins/pkg/utils/buildversion"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
 	"github.com/urfave/cli/v2"
@@ -19,12 +17,9 @@ func main() {
 
 	p := cni.NewCNIPlugin("")
 	c.Action = func(ctx *cli.Context) error {
-		skel.PluginMain(
-			p.CmdAdd,
-			p.CmdCheck,
-			p.CmdDel,
-			version.All,
-			bv.BuildString("ovn-k8s-cni-overlay"))
+		if err := p.PluginMain(bv.BuildString("ovn-k8s-cni-overlay")); err != nil {
+			return err
+		}
 		return nil
 	}
 
@@ -35,5 +30,7 @@ func main() {
 			e = &types.Error{Code: 100, Msg: err.Error()}
 		}
 		e.Print()
+		os.Exit(1)
 	}
 }
+
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"text/tabwriter"
 	"text/template"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovndbmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -29,7 +31,7 @@ const (
    {{.Name}} - {{.Usage}}
 
 USAGE:
-   {{.HelpName}} [global options]
+   {{.HelpName}} [global options] [command [command options]]
 
 VERSION:
    {{.Version}}{{if .Description}}
@@ -55,6 +57,7 @@ func getFlagsByCategory() map[string][]cli.Flag {
 	m["K8s-related Options"] = config.K8sFlags
 	m["OVN Northbound DB Options"] = config.OvnNBFlags
 	m["OVN Southbound DB Options"] = config.OvnSBFlags
+	m["Metrics Options"] = config.MetricsFlags
 	return m
 }
 
@@ -89,6 +92,53 @@ func main() {
 	c.Action = func(c *cli.Context) error {
 		return runOvnKubeDBChecker(c)
 	}
+	c.Commands = []*cli.Command{
+		{
+			Name:  "restore",
+			Usage: "restore a clustered db from a standalone snapshot taken by the db checker",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "snapshot",
+					Usage:    "path of the standalone snapshot to restore",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "local-address",
+					Usage:    "raft address of this db server (eg, ssl:1.2.3.4:9643)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "db-file",
+					Usage: "path of the clustered db to restore",
+					Value: util.OvnNbdbLocation,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if err := initDBCheckerConfig(c); err != nil {
+					return err
+				}
+				return ovndbmanager.RestoreDB(c.String("db-file"), c.String("snapshot"), c.String("local-address"))
+			},
+		},
+		{
+			Name: "reset",
+			Usage: "remove the clustered db of this server so that it joins the raft cluster again, " +
+				"to be run on the remaining members after a restore",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "db-file",
+					Usage: "path of the clustered db to reset",
+					Value: util.OvnNbdbLocation,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if err := initDBCheckerConfig(c); err != nil {
+					return err
+				}
+				return ovndbmanager.ResetDB(c.String("db-file"))
+			},
+		},
+	}
 
 	ctx := context.Background()
 
@@ -167,27 +217,40 @@ func runOvnKubeDBChecker(ctx *cli.Context) error {
 		}
 	}
 
-	exec := kexec.New()
-	_, err := config.InitConfig(ctx, exec, nil)
-	if err != nil {
+	if err := initDBCheckerConfig(ctx); err != nil {
 		return err
 	}
 
-	if err = util.SetExec(exec); err != nil {
-		return fmt.Errorf("failed to initialize exec helper: %v", err)
-	}
-
 	ovnClientset, err := util.NewOVNClientset(&config.Kubernetes)
 	if err != nil {
 		return err
 	}
 
 	stopChan := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	if config.Metrics.BindAddress != "" {
+		metrics.StartMetricsServer(config.Metrics.BindAddress, config.Metrics.EnablePprof,
+			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, stopChan, wg)
+	}
 	go ovndbmanager.RunDBChecker(
 		&kube.Kube{KClient: ovnClientset.KubeClient},
 		stopChan)
 	// run until cancelled
 	<-ctx.Context.Done()
 	close(stopChan)
+	wg.Wait()
+	return nil
+}
+
+// initDBCheckerConfig initializes the config and the exec helper shared by all the commands
+func initDBCheckerConfig(ctx *cli.Context) error {
+	exec := kexec.New()
+	if _, err := config.InitConfig(ctx, exec, nil); err != nil {
+		return err
+	}
+
+	if err := util.SetExec(exec); err != nil {
+		return fmt.Errorf("failed to initialize exec helper: %v", err)
+	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clustermanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clusternetworks"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
@@ -262,11 +263,18 @@ func startOvnKube(ctx *cli.Context, cancel context.CancelFunc) error {
 	}
 
 	exec := kexec.New()
-	_, err := config.InitConfig(ctx, exec, nil)
+	configFile, err := config.InitConfig(ctx, exec, nil)
 	if err != nil {
 		return err
 	}
 
+	// apply changes of the reloadable settings, like the log level, without restarting
+	if configFile != "" {
+		if err = config.WatchConfigFile(configFile, ctx.Done()); err != nil {
+			return err
+		}
+	}
+
 	if err = util.SetExec(exec); err != nil {
 		return fmt.Errorf("failed to initialize exec helper: %v", err)
 	}
@@ -418,6 +426,7 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 	}()
 
 	var masterWatchFactory *factory.WatchFactory
+	var gatewayModeChanged <-chan struct{}
 	var err error
 
 	if runMode.networkControllerManager {
@@ -427,6 +436,9 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 			return err
 		}
 		defer masterWatchFactory.Shutdown()
+		if err = watchClusterNetworks(ovnClientset, masterWatchFactory, stopChan); err != nil {
+			return err
+		}
 	}
 
 	if runMode.clusterManager {
@@ -439,6 +451,9 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				return err
 			}
 			defer clusterManagerWatchFactory.Shutdown()
+			if err = watchClusterNetworks(ovnClientset, clusterManagerWatchFactory, stopChan); err != nil {
+				return err
+			}
 		}
 
 		cm, err := clustermanager.NewClusterManager(ovnClientset.GetClusterManagerClientset(), clusterManagerWatchFactory,
@@ -492,6 +507,9 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				return err
 			}
 			defer nodeWatchFactory.Shutdown()
+			if err = watchClusterNetworks(ovnClientset, nodeWatchFactory, stopChan); err != nil {
+				return err
+			}
 		} else {
 			nodeWatchFactory = masterWatchFactory
 		}
@@ -510,6 +528,7 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 			return fmt.Errorf("failed to start node network manager: %w", err)
 		}
 		defer ncm.Stop()
+		gatewayModeChanged = ncm.GatewayModeChanged()
 
 		// record delay until ready
 		metrics.MetricNodeReadyDuration.Set(time.Since(startTime).Seconds())
@@ -526,11 +545,24 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, stopChan, wg)
 	}
 
-	// run until cancelled
-	<-ctx.Done()
+	// run until cancelled, or until the node must restart to migrate to another gateway mode
+	select {
+	case <-ctx.Done():
+	case <-gatewayModeChanged:
+		klog.Infof("Stopping to restart in the gateway mode selected for the node")
+	}
 	return nil
 }
 
+// watchClusterNetworks adds and retires cluster subnets without restarting. It tracks the nodes
+// using removed cluster subnets with the node informer of the watch factory of the process.
+func watchClusterNetworks(ovnClientset *util.OVNClientset, wf factory.NodeWatchFactory, stopChan <-chan struct{}) error {
+	if config.Kubernetes.ClusterNetworksConfigMap == "" {
+		return nil
+	}
+	return clusternetworks.Watch(ovnClientset.KubeClient, wf.NodeInformer(), stopChan)
+}
+
 type ovnkubeMasterMetrics struct {
 	runMode *ovnkubeRunMode
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/urfave/cli/v2"
@@ -394,4 +395,102 @@ var _ = ginkgo.Describe("Cluster Manager", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 	})
+
+	ginkgo.Context("Node gateway router join IP allocations", func() {
+		ginkgo.It("Linux nodes", func() {
+			app.Action = func(ctx *cli.Context) error {
+				existingNode, err := util.UpdateNodeGatewayRouterLRPAddrsAnnotation(nil,
+					[]*net.IPNet{ovntest.MustParseIPNet("100.64.0.5/16")}, ovntypes.DefaultNetworkName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				nodes := []v1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "node1",
+							Annotations: existingNode,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node2",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node3",
+						},
+					},
+				}
+				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
+					Items: nodes,
+				})
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient: kubeFakeClient,
+				}
+
+				_, err = config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				c, cancel := context.WithCancel(ctx.Context)
+				defer cancel()
+				clusterManager, err := NewClusterManager(fakeClient, f, "identity", wg, nil)
+				gomega.Expect(clusterManager).NotTo(gomega.BeNil())
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = clusterManager.Start(c)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				defer clusterManager.Stop()
+
+				getJoinIP := func(nodeName string) (string, error) {
+					updatedNode, err := fakeClient.KubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+					if err != nil {
+						return "", err
+					}
+					ips, err := util.ParseNodeGatewayRouterLRPAddrs(updatedNode, ovntypes.DefaultNetworkName)
+					if err != nil {
+						return "", err
+					}
+					return util.JoinIPNets(ips, " "), nil
+				}
+
+				// Check that the existing join IP is kept and that the other nodes get new
+				// IPs, skipping the first IP of the join subnet used by the cluster router.
+				gomega.Eventually(func() (string, error) { return getJoinIP("node1") }, 2).Should(gomega.Equal("100.64.0.5/16"))
+				joinIPs := []string{}
+				for _, nodeName := range []string{"node2", "node3"} {
+					gomega.Eventually(func() error {
+						_, err := getJoinIP(nodeName)
+						return err
+					}, 2).Should(gomega.Succeed())
+					joinIP, _ := getJoinIP(nodeName)
+					joinIPs = append(joinIPs, joinIP)
+				}
+				gomega.Expect(joinIPs).To(gomega.ConsistOf("100.64.0.2/16", "100.64.0.3/16"))
+
+				// Delete a node and make sure its join IP is handed over to a new node.
+				err = fakeClient.KubeClient.CoreV1().Nodes().Delete(context.TODO(), "node2", metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				releasedIP := joinIPs[0]
+				gomega.Eventually(func() error {
+					_, err := fakeClient.KubeClient.CoreV1().Nodes().Create(context.TODO(), &v1.Node{
+						ObjectMeta: metav1.ObjectMeta{Name: "node4"},
+					}, metav1.CreateOptions{})
+					return err
+				}, 2).Should(gomega.Succeed())
+				gomega.Eventually(func() (string, error) { return getJoinIP("node4") }, 2).Should(gomega.Equal(releasedIP))
+
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=" + clusterCIDR,
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
 })
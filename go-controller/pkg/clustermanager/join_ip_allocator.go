@@ -0,0 +1,122 @@
+package clustermanager
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	logicalswitchmanager "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// joinIPAllocator allocates the IPs of the node gateway router ports to the join
+// switch of a network. The first IPs of the join subnets are reserved for the port
+// of the cluster router.
+type joinIPAllocator struct {
+	sync.Mutex
+
+	lsManager   *logicalswitchmanager.LogicalSwitchManager
+	joinSubnets []*net.IPNet
+	// IPs allocated to each node
+	nodeIPs map[string][]*net.IPNet
+}
+
+func newJoinIPAllocator(joinSubnets []*net.IPNet) (*joinIPAllocator, error) {
+	a := &joinIPAllocator{
+		lsManager:   logicalswitchmanager.NewJoinSwitchManager(),
+		joinSubnets: joinSubnets,
+		nodeIPs:     map[string][]*net.IPNet{},
+	}
+	if err := a.lsManager.AddSwitch(types.OVNJoinSwitch, "", joinSubnets); err != nil {
+		return nil, err
+	}
+	clusterRouterIPs := make([]*net.IPNet, 0, len(joinSubnets))
+	for _, joinSubnet := range joinSubnets {
+		clusterRouterIPs = append(clusterRouterIPs, util.GetNodeGatewayIfAddr(joinSubnet))
+	}
+	if err := a.lsManager.AllocateIPs(types.OVNJoinSwitch, clusterRouterIPs); err != nil {
+		return nil, fmt.Errorf("failed to reserve the cluster router join IPs %s: %w", util.JoinIPNetIPs(clusterRouterIPs, " "), err)
+	}
+	return a, nil
+}
+
+// validIPs returns true if ips hold exactly one IP of each join subnet
+func (a *joinIPAllocator) validIPs(ips []*net.IPNet) bool {
+	if len(ips) != len(a.joinSubnets) {
+		return false
+	}
+	for _, joinSubnet := range a.joinSubnets {
+		found := false
+		for _, ip := range ips {
+			if joinSubnet.Contains(ip.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// reserveNodeIPs marks the given IPs as allocated to the node
+func (a *joinIPAllocator) reserveNodeIPs(nodeName string, ips []*net.IPNet) error {
+	a.Lock()
+	defer a.Unlock()
+	return a.reserveNodeIPsLocked(nodeName, ips)
+}
+
+func (a *joinIPAllocator) reserveNodeIPsLocked(nodeName string, ips []*net.IPNet) error {
+	if !a.validIPs(ips) {
+		return fmt.Errorf("join IPs %s of node %s don't match the join subnets %s", util.JoinIPNetIPs(ips, " "),
+			nodeName, util.JoinIPNets(a.joinSubnets, " "))
+	}
+	if err := a.lsManager.AllocateIPs(types.OVNJoinSwitch, ips); err != nil {
+		return fmt.Errorf("failed to reserve join IPs %s of node %s: %w", util.JoinIPNetIPs(ips, " "), nodeName, err)
+	}
+	a.nodeIPs[nodeName] = ips
+	return nil
+}
+
+// ensureNodeIPs returns the join IPs of the node. The existing IPs of the node are
+// kept if valid, new IPs are allocated otherwise. needsUpdate is set if the returned
+// IPs differ from the existing ones.
+func (a *joinIPAllocator) ensureNodeIPs(nodeName string, existingIPs []*net.IPNet) (ips []*net.IPNet, needsUpdate bool, err error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if ips, ok := a.nodeIPs[nodeName]; ok {
+		return ips, util.JoinIPNets(ips, " ") != util.JoinIPNets(existingIPs, " "), nil
+	}
+
+	if len(existingIPs) > 0 {
+		if err = a.reserveNodeIPsLocked(nodeName, existingIPs); err == nil {
+			return existingIPs, false, nil
+		}
+		klog.Warningf("Allocating new join IPs for node %s: %v", nodeName, err)
+	}
+
+	if ips, err = a.lsManager.AllocateNextIPs(types.OVNJoinSwitch); err != nil {
+		return nil, false, fmt.Errorf("failed to allocate join IPs for node %s: %w", nodeName, err)
+	}
+	a.nodeIPs[nodeName] = ips
+	return ips, true, nil
+}
+
+// releaseNodeIPs releases the join IPs of the node
+func (a *joinIPAllocator) releaseNodeIPs(nodeName string) {
+	a.Lock()
+	defer a.Unlock()
+	ips, ok := a.nodeIPs[nodeName]
+	if !ok {
+		return
+	}
+	delete(a.nodeIPs, nodeName)
+	if err := a.lsManager.ReleaseIPs(types.OVNJoinSwitch, ips); err != nil {
+		klog.Errorf("Failed to release join IPs %s of node %s: %v", util.JoinIPNetIPs(ips, " "), nodeName, err)
+	}
+}
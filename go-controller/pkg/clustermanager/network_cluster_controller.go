@@ -2,13 +2,20 @@ package clustermanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
 	cache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ref "k8s.io/client-go/tools/reference"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
@@ -18,10 +25,16 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
 	objretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// hostSubnetsExhaustedCondition is the node condition, scoped by network, set while no host
+// subnet could be allocated to the node because the cluster subnets of the network are exhausted
+const hostSubnetsExhaustedCondition = "HostSubnetsExhausted"
+
 // networkClusterController is the cluster controller for the networks.
 // It listens to the node events and allocates subnet from the
 // cluster subnet pool. It also allocates subnets from the hybrid overlay subnet pool
@@ -31,6 +44,8 @@ type networkClusterController struct {
 	watchFactory *factory.WatchFactory
 	stopChan     chan struct{}
 	wg           *sync.WaitGroup
+	// event recorder used to post events to k8s
+	recorder record.EventRecorder
 
 	// node events factory handler
 	nodeHandler *factory.Handler
@@ -41,16 +56,21 @@ type networkClusterController struct {
 	networkName            string
 	clusterSubnetAllocator *subnetallocator.HostSubnetAllocator
 	clusterSubnets         []config.CIDRNetworkEntry
+	// unregisterClusterSubnetsHandler unregisters the cluster subnets reload handler on Stop
+	unregisterClusterSubnetsHandler func()
 
 	enableHybridOverlaySubnetAllocator bool
 	hybridOverlaySubnetAllocator       *subnetallocator.HostSubnetAllocator
 
+	// allocator of the node gateway router join IPs, nil if the network has no gateway routers
+	joinIPAllocator *joinIPAllocator
+
 	util.NetInfo
 	util.NetConfInfo
 }
 
 func newNetworkClusterController(networkName string, clusterSubnets []config.CIDRNetworkEntry,
-	ovnClient *util.OVNClusterManagerClientset, wf *factory.WatchFactory,
+	ovnClient *util.OVNClusterManagerClientset, wf *factory.WatchFactory, recorder record.EventRecorder,
 	enableHybridOverlaySubnetAllocator bool, netInfo util.NetInfo, netConfInfo util.NetConfInfo) *networkClusterController {
 
 	kube := &kube.Kube{
@@ -61,15 +81,17 @@ func newNetworkClusterController(networkName string, clusterSubnets []config.CID
 
 	var hybridOverlaySubnetAllocator *subnetallocator.HostSubnetAllocator
 	if enableHybridOverlaySubnetAllocator {
-		hybridOverlaySubnetAllocator = subnetallocator.NewHostSubnetAllocator()
+		// hybrid overlay subnets are not accounted in the host subnet metrics
+		hybridOverlaySubnetAllocator = subnetallocator.NewHostSubnetAllocator("")
 	}
 	ncc := &networkClusterController{
 		kube:                               kube,
 		watchFactory:                       wf,
 		stopChan:                           make(chan struct{}),
 		wg:                                 wg,
+		recorder:                           recorder,
 		networkName:                        networkName,
-		clusterSubnetAllocator:             subnetallocator.NewHostSubnetAllocator(),
+		clusterSubnetAllocator:             subnetallocator.NewHostSubnetAllocator(networkName),
 		clusterSubnets:                     clusterSubnets,
 		hybridOverlaySubnetAllocator:       hybridOverlaySubnetAllocator,
 		enableHybridOverlaySubnetAllocator: enableHybridOverlaySubnetAllocator,
@@ -77,6 +99,14 @@ func newNetworkClusterController(networkName string, clusterSubnets []config.CID
 		NetConfInfo:                        netConfInfo,
 	}
 
+	if config.Default.SubnetQuarantinePeriod > 0 {
+		q := quarantine.NewList("subnets."+networkName,
+			time.Duration(config.Default.SubnetQuarantinePeriod)*time.Second,
+			quarantine.NewConfiguredStore(ovnClient.KubeClient),
+			func(count int) { metrics.RecordQuarantinedHostSubnets(networkName, count) })
+		ncc.clusterSubnetAllocator.SetQuarantine(q, config.Default.ReleaseQuarantineWhenExhausted)
+	}
+
 	ncc.initRetryFramework()
 	return ncc
 }
@@ -89,18 +119,37 @@ func (ncc *networkClusterController) initRetryFramework() {
 // It does the following
 //   - initializes the network subnet allocator ranges
 //     and hybrid network subnet allocator ranges if hybrid overlay is enabled.
+//   - initializes the join IP allocator if the network has gateway routers
+//   - updates the default network subnet allocator ranges when the cluster subnets change
 //   - Starts watching the kubernetes nodes
+//   - releases the quarantined host subnets once their quarantine expires
 func (ncc *networkClusterController) Start(ctx context.Context) error {
 	if err := ncc.clusterSubnetAllocator.InitRanges(ncc.clusterSubnets); err != nil {
 		return fmt.Errorf("failed to initialize cluster subnet allocator ranges: %w", err)
 	}
 
+	if joinSubnets, err := ncc.joinSubnets(); err != nil {
+		return err
+	} else if len(joinSubnets) > 0 {
+		if ncc.joinIPAllocator, err = newJoinIPAllocator(joinSubnets); err != nil {
+			return fmt.Errorf("failed to initialize join IP allocator: %w", err)
+		}
+	}
+
 	if ncc.enableHybridOverlaySubnetAllocator {
 		if err := ncc.hybridOverlaySubnetAllocator.InitRanges(config.HybridOverlay.ClusterSubnets); err != nil {
 			return fmt.Errorf("failed to initialize hybrid overlay subnet allocator ranges: %w", err)
 		}
 	}
 
+	// the cluster subnets of the default network can be changed at runtime
+	if !ncc.IsSecondary() {
+		if err := ncc.clusterSubnetAllocator.UpdateRanges(config.GetClusterSubnetsWithDraining()); err != nil {
+			return fmt.Errorf("failed to update cluster subnet allocator ranges: %w", err)
+		}
+		ncc.unregisterClusterSubnetsHandler = config.OnReload(config.ReloadableClusterSubnets, ncc.updateClusterSubnets)
+	}
+
 	nodeHandler, err := ncc.retryNodes.WatchResource()
 
 	if err != nil {
@@ -108,16 +157,42 @@ func (ncc *networkClusterController) Start(ctx context.Context) error {
 	}
 
 	ncc.nodeHandler = nodeHandler
+
+	ncc.wg.Add(1)
+	go func() {
+		defer ncc.wg.Done()
+		ncc.clusterSubnetAllocator.RunQuarantine(ncc.stopChan)
+	}()
 	return err
 }
 
 func (ncc *networkClusterController) Stop() {
+	if ncc.unregisterClusterSubnetsHandler != nil {
+		ncc.unregisterClusterSubnetsHandler()
+	}
 	close(ncc.stopChan)
 	ncc.wg.Wait()
 
 	if ncc.nodeHandler != nil {
 		ncc.watchFactory.RemoveNodeHandler(ncc.nodeHandler)
 	}
+	metrics.DeleteHostSubnetRangeUsage(ncc.networkName)
+}
+
+// updateClusterSubnets updates the allocator ranges after the cluster subnets of the
+// default network changed
+func (ncc *networkClusterController) updateClusterSubnets() {
+	select {
+	case <-ncc.stopChan:
+		return
+	default:
+	}
+	if err := ncc.clusterSubnetAllocator.UpdateRanges(config.GetClusterSubnetsWithDraining()); err != nil {
+		klog.Errorf("Failed to update cluster subnet allocator ranges: %v", err)
+	}
+	// nodes that didn't get a subnet may get one from the new ranges
+	ncc.retryNodes.SetRetryObjsWithNoBackoff()
+	ncc.retryNodes.RequestRetryObjs()
 }
 
 func (ncc *networkClusterController) newRetryFramework(objectType reflect.Type, hasUpdateFunc bool) *objretry.RetryFramework {
@@ -125,6 +200,7 @@ func (ncc *networkClusterController) newRetryFramework(objectType reflect.Type,
 		HasUpdateFunc:          hasUpdateFunc,
 		NeedsUpdateDuringRetry: false,
 		ObjType:                objectType,
+		NetworkName:            ncc.GetNetworkName(),
 		EventHandler: &networkClusterControllerEventHandler{
 			objType:  objectType,
 			ncc:      ncc,
@@ -190,7 +266,10 @@ func (ncc *networkClusterController) handleAddUpdateNodeEvent(node *corev1.Node)
 		return nil
 	}
 
-	return ncc.syncNodeClusterSubnet(node)
+	if err := ncc.syncNodeClusterSubnet(node); err != nil {
+		return err
+	}
+	return ncc.syncNodeJoinIPs(node)
 }
 
 func (ncc *networkClusterController) syncNodeClusterSubnet(node *corev1.Node) error {
@@ -208,10 +287,18 @@ func (ncc *networkClusterController) syncNodeClusterSubnet(node *corev1.Node) er
 	// any newly allocated subnets required to ensure that the node has one subnet
 	// from each enabled IP family.
 	ipv4Mode, ipv6Mode := ncc.IPMode()
-	validExistingSubnets, allocatedSubnets, err := ncc.clusterSubnetAllocator.AllocateNodeSubnets(node.Name, existingSubnets, ipv4Mode, ipv6Mode)
+	ipv4HostSubnetLen, ipv6HostSubnetLen := ncc.nodeHostSubnetLengths(node)
+	validExistingSubnets, allocatedSubnets, err := ncc.clusterSubnetAllocator.AllocateNodeSubnetsOfLength(node.Name, existingSubnets,
+		ipv4Mode, ipv6Mode, ipv4HostSubnetLen, ipv6HostSubnetLen)
 	if err != nil {
+		if errors.Is(err, subnetallocator.ErrSubnetAllocatorFull) {
+			ncc.handleHostSubnetsExhausted(node, err)
+		}
 		return err
 	}
+	if err := ncc.setHostSubnetsExhaustedCondition(node.Name, false, ""); err != nil {
+		klog.Warningf("Failed to clear the %s condition of node %s: %v", ncc.hostSubnetsExhaustedConditionType(), node.Name, err)
+	}
 
 	// If the existing subnets weren't OK, or new ones were allocated, update the node annotation.
 	// This happens in a couple cases:
@@ -232,8 +319,180 @@ func (ncc *networkClusterController) syncNodeClusterSubnet(node *corev1.Node) er
 	return nil
 }
 
+// joinSubnets returns the join subnets the gateway router ports of the network get their
+// IPs from. Secondary networks have no gateway routers.
+func (ncc *networkClusterController) joinSubnets() ([]*net.IPNet, error) {
+	if ncc.IsSecondary() {
+		return nil, nil
+	}
+	return util.GetJoinSubnets()
+}
+
+// syncNodeJoinIPs allocates the IPs of the node gateway router port to the join switch
+// and sets them in the node annotation
+func (ncc *networkClusterController) syncNodeJoinIPs(node *corev1.Node) error {
+	if ncc.joinIPAllocator == nil {
+		return nil
+	}
+
+	existingIPs, err := util.ParseNodeGatewayRouterLRPAddrs(node, ncc.networkName)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		// Log the error and try to allocate new IPs
+		klog.Warningf("Failed to get node %s gateway router join IPs for network %s: %v", node.Name, ncc.networkName, err)
+	}
+
+	ips, needsUpdate, err := ncc.joinIPAllocator.ensureNodeIPs(node.Name, existingIPs)
+	if err != nil {
+		return err
+	}
+	if !needsUpdate {
+		return nil
+	}
+	if err = ncc.updateNodeJoinIPsAnnotation(node.Name, ips); err != nil {
+		ncc.joinIPAllocator.releaseNodeIPs(node.Name)
+		return err
+	}
+	return nil
+}
+
+// updateNodeJoinIPsAnnotation patches the gateway router join IPs annotation of the node.
+// A patch is used rather than an update of the whole node so that the host subnets annotation
+// just written by syncNodeClusterSubnet is not overwritten from a stale informer cache.
+func (ncc *networkClusterController) updateNodeJoinIPsAnnotation(nodeName string, ips []*net.IPNet) error {
+	node, err := ncc.watchFactory.GetNode(nodeName)
+	if err != nil {
+		return err
+	}
+
+	// Informer cache should not be mutated, so work on a copy of the annotations
+	annotations := make(map[string]string, len(node.Annotations))
+	for k, v := range node.Annotations {
+		annotations[k] = v
+	}
+	annotations, err = util.UpdateNodeGatewayRouterLRPAddrsAnnotation(annotations, ips, ncc.networkName)
+	if err != nil {
+		return fmt.Errorf("failed to update node %q gateway router join IPs annotation %s: %v",
+			nodeName, util.JoinIPNets(ips, ","), err)
+	}
+
+	changes := map[string]interface{}{}
+	for k, v := range annotations {
+		if old, ok := node.Annotations[k]; !ok || old != v {
+			changes[k] = v
+		}
+	}
+	for k := range node.Annotations {
+		if _, ok := annotations[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err = ncc.kube.SetAnnotationsOnNode(nodeName, changes); err != nil {
+		return fmt.Errorf("failed to update node %s annotation: %w", nodeName, err)
+	}
+	return nil
+}
+
+// nodeHostSubnetLengths returns the IPv4 and IPv6 host subnet lengths of the first host subnet
+// pool that selects node, 0 standing for the host subnet length of the cluster subnets. Host
+// subnet pools only apply to the default network.
+func (ncc *networkClusterController) nodeHostSubnetLengths(node *corev1.Node) (int, int) {
+	if ncc.IsSecondary() {
+		return 0, 0
+	}
+	for _, pool := range config.Default.HostSubnetPools {
+		if pool.NodeSelector.Matches(labels.Set(node.Labels)) {
+			return pool.IPv4HostSubnetLength, pool.IPv6HostSubnetLength
+		}
+	}
+	return 0, 0
+}
+
+// handleHostSubnetsExhausted reports that no host subnet could be allocated to node
+// because the cluster subnets of the network are exhausted
+func (ncc *networkClusterController) handleHostSubnetsExhausted(node *corev1.Node, allocErr error) {
+	metrics.RecordHostSubnetAllocationFailure(ncc.networkName)
+	message := fmt.Sprintf("No host subnet available for network %s: %v", ncc.networkName, allocErr)
+
+	nodeRef, err := ref.GetReference(scheme.Scheme, node)
+	if err != nil {
+		klog.Errorf("Couldn't get a reference to node %s to post an event: %v", node.Name, err)
+	} else {
+		ncc.recorder.Eventf(nodeRef, corev1.EventTypeWarning, hostSubnetsExhaustedCondition, message)
+	}
+	if err := ncc.setHostSubnetsExhaustedCondition(node.Name, true, message); err != nil {
+		klog.Warningf("Failed to set the %s condition of node %s: %v", ncc.hostSubnetsExhaustedConditionType(), node.Name, err)
+	}
+}
+
+func (ncc *networkClusterController) hostSubnetsExhaustedConditionType() corev1.NodeConditionType {
+	return corev1.NodeConditionType(ncc.GetPrefix() + hostSubnetsExhaustedCondition)
+}
+
+// setHostSubnetsExhaustedCondition sets the status of the host subnets exhausted condition of the
+// node. The condition is only added to nodes once their subnet allocation failed.
+func (ncc *networkClusterController) setHostSubnetsExhaustedCondition(nodeName string, exhausted bool, message string) error {
+	conditionType := ncc.hostSubnetsExhaustedConditionType()
+	status := corev1.ConditionFalse
+	reason := "HostSubnetAllocated"
+	if exhausted {
+		status = corev1.ConditionTrue
+		reason = hostSubnetsExhaustedCondition
+	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		node, err := ncc.watchFactory.GetNode(nodeName)
+		if err != nil {
+			return err
+		}
+		var condition *corev1.NodeCondition
+		for i := range node.Status.Conditions {
+			if node.Status.Conditions[i].Type == conditionType {
+				condition = &node.Status.Conditions[i]
+				break
+			}
+		}
+		if condition == nil && !exhausted {
+			return nil
+		}
+		if condition != nil && condition.Status == status && condition.Message == message {
+			return nil
+		}
+
+		// Informer cache should not be mutated, so get a copy of the object
+		cnode := node.DeepCopy()
+		now := metav1.Now()
+		newCondition := corev1.NodeCondition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastHeartbeatTime:  now,
+			LastTransitionTime: now,
+		}
+		if condition == nil {
+			cnode.Status.Conditions = append(cnode.Status.Conditions, newCondition)
+		} else {
+			for i := range cnode.Status.Conditions {
+				if cnode.Status.Conditions[i].Type == conditionType {
+					if cnode.Status.Conditions[i].Status == status {
+						newCondition.LastTransitionTime = cnode.Status.Conditions[i].LastTransitionTime
+					}
+					cnode.Status.Conditions[i] = newCondition
+				}
+			}
+		}
+		return ncc.kube.UpdateNodeStatus(cnode)
+	})
+}
+
 // handleDeleteNode handles the delete node event
 func (ncc *networkClusterController) handleDeleteNode(node *corev1.Node) error {
+	if ncc.joinIPAllocator != nil {
+		ncc.joinIPAllocator.releaseNodeIPs(node.Name)
+	}
+
 	if ncc.enableHybridOverlaySubnetAllocator {
 		ncc.releaseHybridOverlayNodeSubnet(node.Name)
 		return nil
@@ -278,9 +537,24 @@ func (ncc *networkClusterController) syncNodes(nodes []interface{}) error {
 			} else {
 				klog.V(5).Infof("Node %s contains no subnets for network : %s", node.Name, ncc.networkName)
 			}
+
+			if ncc.joinIPAllocator != nil {
+				joinIPs, err := util.ParseNodeGatewayRouterLRPAddrs(node, ncc.networkName)
+				if err == nil {
+					if err := ncc.joinIPAllocator.reserveNodeIPs(node.Name, joinIPs); err != nil {
+						klog.Errorf("Failed to mark the join IPs %v as allocated for node %s: %v", joinIPs, node.Name, err)
+					}
+				}
+			}
 		}
 	}
 
+	// the subnets still held in quarantine by the previous cluster manager, if any, are
+	// restored once the subnets of the nodes are known
+	if err := ncc.clusterSubnetAllocator.RestoreQuarantine(); err != nil {
+		klog.Errorf("Failed to restore the quarantined subnets of network %s: %v", ncc.networkName, err)
+	}
+
 	return nil
 }
 
@@ -340,6 +614,10 @@ func (ncc *networkClusterController) Cleanup(netName string) error {
 		ncc.clusterSubnetAllocator.ReleaseAllNodeSubnets(node.Name)
 	}
 
+	if err := ncc.clusterSubnetAllocator.PurgeQuarantine(); err != nil {
+		return fmt.Errorf("failed to purge the quarantined subnets of network %s: %w", ncc.networkName, err)
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package clustermanager
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"sync"
 
@@ -11,6 +12,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
@@ -76,7 +78,7 @@ var _ = ginkgo.Describe("Network Cluster Controller", func() {
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
-					fakeClient, f, false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+					fakeClient, f, record.NewFakeRecorder(0), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
 				ncc.Start(ctx.Context)
 				defer ncc.Stop()
 
@@ -125,7 +127,7 @@ var _ = ginkgo.Describe("Network Cluster Controller", func() {
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
-					fakeClient, f, false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+					fakeClient, f, record.NewFakeRecorder(0), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
 				ncc.Start(ctx.Context)
 				defer ncc.Stop()
 
@@ -177,7 +179,7 @@ var _ = ginkgo.Describe("Network Cluster Controller", func() {
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
-					fakeClient, f, false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+					fakeClient, f, record.NewFakeRecorder(0), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
 				ncc.Start(ctx.Context)
 				defer ncc.Stop()
 
@@ -199,5 +201,294 @@ var _ = ginkgo.Describe("Network Cluster Controller", func() {
 			})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+
+		ginkgo.It("reports nodes that can't get a subnet because the cluster subnets are exhausted", func() {
+			app.Action = func(ctx *cli.Context) error {
+				nodes := []v1.Node{
+					{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+				}
+				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
+					Items: nodes,
+				})
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient: kubeFakeClient,
+				}
+
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				recorder := record.NewFakeRecorder(10)
+				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
+					fakeClient, f, recorder, false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+				ncc.Start(ctx.Context)
+				defer ncc.Stop()
+
+				// Only two of the three nodes can get a subnet
+				exhaustedNodes := func() ([]string, error) {
+					nodes, err := fakeClient.KubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+					exhausted := []string{}
+					for _, node := range nodes.Items {
+						for _, condition := range node.Status.Conditions {
+							if condition.Type == hostSubnetsExhaustedCondition && condition.Status == v1.ConditionTrue {
+								exhausted = append(exhausted, node.Name)
+							}
+						}
+					}
+					return exhausted, nil
+				}
+				gomega.Eventually(exhaustedNodes, 2).Should(gomega.HaveLen(1))
+				gomega.Eventually(recorder.Events).Should(gomega.Receive(gomega.ContainSubstring(hostSubnetsExhaustedCondition)))
+
+				// The condition is cleared once a subnet is released and allocated to the node
+				exhausted, err := exhaustedNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, node := range nodes {
+					if node.Name != exhausted[0] {
+						err = fakeClient.KubeClient.CoreV1().Nodes().Delete(context.TODO(), node.Name, metav1.DeleteOptions{})
+						gomega.Expect(err).NotTo(gomega.HaveOccurred())
+						break
+					}
+				}
+				gomega.Eventually(func() ([]string, error) {
+					// retry the node until the subnet of the deleted node was released
+					ncc.retryNodes.RequestRetryObjs()
+					return exhaustedNodes()
+				}, 2).Should(gomega.BeEmpty())
+
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=10.128.0.0/23/24",
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("allocates node subnets from cluster subnets added at runtime", func() {
+			app.Action = func(ctx *cli.Context) error {
+				nodes := []v1.Node{
+					{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+				}
+				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
+					Items: nodes,
+				})
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient: kubeFakeClient,
+				}
+
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
+					fakeClient, f, record.NewFakeRecorder(10), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+				ncc.Start(ctx.Context)
+				defer ncc.Stop()
+
+				nodeSubnets := func() (map[string]string, error) {
+					nodes, err := fakeClient.KubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+					subnets := map[string]string{}
+					for i := range nodes.Items {
+						hostSubnets, err := util.ParseNodeHostSubnetAnnotation(&nodes.Items[i], ovntypes.DefaultNetworkName)
+						if err == nil && len(hostSubnets) == 1 {
+							subnets[nodes.Items[i].Name] = hostSubnets[0].String()
+						}
+					}
+					return subnets, nil
+				}
+				// Only two of the three nodes can get a subnet
+				gomega.Eventually(nodeSubnets, 2).Should(gomega.HaveLen(2))
+				subnets, err := nodeSubnets()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// The third node gets a subnet from the new cluster subnet, while the
+				// existing nodes keep theirs from the draining one
+				clusterSubnets, err := config.ParseClusterSubnetEntries("10.128.0.0/23/24,10.130.0.0/23/24")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = config.UpdateClusterSubnets(clusterSubnets, []*net.IPNet{ovntest.MustParseIPNet("10.128.0.0/23")})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(nodeSubnets, 2).Should(gomega.HaveLen(3))
+				newSubnets, err := nodeSubnets()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, node := range nodes {
+					if subnet, ok := subnets[node.Name]; ok {
+						gomega.Expect(newSubnets[node.Name]).To(gomega.Equal(subnet))
+					} else {
+						gomega.Expect(newSubnets[node.Name]).To(gomega.Equal("10.130.0.0/24"))
+					}
+				}
+
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=10.128.0.0/23/24",
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("allocates node subnets of the host subnet pool selecting the node", func() {
+			app.Action = func(ctx *cli.Context) error {
+				nodes := []v1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "node1",
+							Labels: map[string]string{"node-pool": "edge"},
+							Annotations: map[string]string{
+								"k8s.ovn.org/node-subnets": "{\"default\":[\"10.128.0.0/24\"]}",
+							},
+						},
+					},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"node-pool": "edge"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: map[string]string{"node-pool": "bigmem"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node4"}},
+				}
+				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
+					Items: nodes,
+				})
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient: kubeFakeClient,
+				}
+
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
+					fakeClient, f, record.NewFakeRecorder(10), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+				ncc.Start(ctx.Context)
+				defer ncc.Stop()
+
+				// The existing node keeps its subnet, the new ones get a subnet of the
+				// length of their pool or of the cluster subnet
+				nodeSubnetLengths := func() (map[string]string, error) {
+					nodes, err := fakeClient.KubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+					lengths := map[string]string{}
+					for i := range nodes.Items {
+						hostSubnets, err := util.ParseNodeHostSubnetAnnotation(&nodes.Items[i], ovntypes.DefaultNetworkName)
+						if err != nil || len(hostSubnets) != 1 {
+							continue
+						}
+						if nodes.Items[i].Name == "node1" {
+							lengths["node1"] = hostSubnets[0].String()
+							continue
+						}
+						prefixLen, _ := hostSubnets[0].Mask.Size()
+						lengths[nodes.Items[i].Name] = fmt.Sprintf("/%d", prefixLen)
+					}
+					return lengths, nil
+				}
+				gomega.Eventually(nodeSubnetLengths, 2).Should(gomega.Equal(map[string]string{
+					"node1": "10.128.0.0/24",
+					"node2": "/26",
+					"node3": "/23",
+					"node4": "/24",
+				}))
+
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=10.128.0.0/21/24",
+				"-host-subnet-pools=node-pool=edge:26;node-pool=bigmem:23",
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("allocates dual-stack node subnets of the host subnet lengths of the pool selecting the node", func() {
+			app.Action = func(ctx *cli.Context) error {
+				nodes := []v1.Node{
+					{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"node-pool": "edge"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"node-pool": "bigmem"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: map[string]string{"node-pool": "v6"}}},
+				}
+				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
+					Items: nodes,
+				})
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient: kubeFakeClient,
+				}
+
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				ncc := newNetworkClusterController(ovntypes.DefaultNetworkName, config.Default.ClusterSubnets,
+					fakeClient, f, record.NewFakeRecorder(10), false, &util.DefaultNetInfo{}, &util.DefaultNetConfInfo{})
+				ncc.Start(ctx.Context)
+				defer ncc.Stop()
+
+				// an omitted length keeps the host subnet length of the cluster subnets of its family
+				nodeSubnetLengths := func() (map[string]string, error) {
+					nodes, err := fakeClient.KubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+					lengths := map[string]string{}
+					for i := range nodes.Items {
+						hostSubnets, err := util.ParseNodeHostSubnetAnnotation(&nodes.Items[i], ovntypes.DefaultNetworkName)
+						if err != nil || len(hostSubnets) != 2 {
+							continue
+						}
+						v4PrefixLen, _ := hostSubnets[0].Mask.Size()
+						v6PrefixLen, _ := hostSubnets[1].Mask.Size()
+						lengths[nodes.Items[i].Name] = fmt.Sprintf("/%d /%d", v4PrefixLen, v6PrefixLen)
+					}
+					return lengths, nil
+				}
+				gomega.Eventually(nodeSubnetLengths, 2).Should(gomega.Equal(map[string]string{
+					"node1": "/26 /62",
+					"node2": "/23 /64",
+					"node3": "/24 /60",
+				}))
+
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=10.128.0.0/21/24,fd00:10:128::/56/64",
+				"-k8s-service-cidrs=172.30.0.0/16,fd00:172:30::/112",
+				"-host-subnet-pools=node-pool=edge:26/62;node-pool=bigmem:23;node-pool=v6:/60",
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 	})
 })
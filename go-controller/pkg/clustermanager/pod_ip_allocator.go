@@ -0,0 +1,228 @@
+package clustermanager
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
+	logicalswitchmanager "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// ipConflictError is returned when an IP claimed by a pod is already owned by another pod
+type ipConflictError struct {
+	ip    net.IP
+	owner string
+}
+
+func (e *ipConflictError) Error() string {
+	return fmt.Sprintf("IP %s is already allocated to %s", e.ip, e.owner)
+}
+
+// podIPAllocator allocates the pod IPs and MACs of a layer2 network. Allocations
+// are tracked by owner, the NAD scoped name of the pod, so that conflicting claims
+// of the same IP by different pods can be detected.
+type podIPAllocator struct {
+	sync.Mutex
+
+	switchName     string
+	lsManager      *logicalswitchmanager.LogicalSwitchManager
+	requiresIPAM   bool
+	excludeSubnets []*net.IPNet
+
+	// owner of each allocated IP
+	ipOwners map[string]string
+	// IPs allocated to each owner
+	ownerIPs map[string][]*net.IPNet
+}
+
+func newPodIPAllocator(switchName string, subnets, excludeSubnets []*net.IPNet) (*podIPAllocator, error) {
+	a := &podIPAllocator{
+		switchName:     switchName,
+		lsManager:      logicalswitchmanager.NewL2SwitchManager(),
+		requiresIPAM:   len(subnets) > 0,
+		excludeSubnets: excludeSubnets,
+		ipOwners:       map[string]string{},
+		ownerIPs:       map[string][]*net.IPNet{},
+	}
+	if !a.requiresIPAM {
+		return a, nil
+	}
+
+	if err := a.lsManager.AddSwitch(switchName, "", subnets); err != nil {
+		return nil, err
+	}
+	for _, excludeSubnet := range excludeSubnets {
+		for excludeIP := excludeSubnet.IP; excludeSubnet.Contains(excludeIP); excludeIP = util.NextIP(excludeIP) {
+			var ipMask net.IPMask
+			if excludeIP.To4() != nil {
+				ipMask = net.CIDRMask(32, 32)
+			} else {
+				ipMask = net.CIDRMask(128, 128)
+			}
+			_ = a.lsManager.AllocateIPs(switchName, []*net.IPNet{{IP: excludeIP, Mask: ipMask}})
+		}
+	}
+	return a, nil
+}
+
+// reserve marks the given IPs as allocated to owner. It returns an ipConflictError if
+// any of them is already allocated to a different owner, in which case nothing is reserved.
+func (a *podIPAllocator) reserve(owner string, ips []*net.IPNet) error {
+	a.Lock()
+	defer a.Unlock()
+	return a.reserveLocked(owner, ips)
+}
+
+func (a *podIPAllocator) reserveLocked(owner string, ips []*net.IPNet) error {
+	var newIPs []*net.IPNet
+	for _, ip := range ips {
+		if currentOwner, ok := a.ipOwners[ip.IP.String()]; ok {
+			if currentOwner != owner {
+				return &ipConflictError{ip: ip.IP, owner: currentOwner}
+			}
+			continue
+		}
+		newIPs = append(newIPs, ip)
+	}
+	if len(newIPs) == 0 {
+		return nil
+	}
+
+	if a.requiresIPAM {
+		var managedIPs []*net.IPNet
+		for _, ip := range newIPs {
+			if a.isExcluded(ip.IP) {
+				klog.Warningf("IP %s of %s is excluded from allocation", ip.IP, owner)
+				continue
+			}
+			managedIPs = append(managedIPs, ip)
+		}
+		if len(managedIPs) > 0 {
+			if err := a.lsManager.AllocateIPs(a.switchName, managedIPs); err != nil && err != ipallocator.ErrAllocated {
+				return fmt.Errorf("failed to reserve IPs %s for %s: %w", util.JoinIPNetIPs(managedIPs, " "), owner, err)
+			}
+		}
+	}
+	for _, ip := range newIPs {
+		a.ipOwners[ip.IP.String()] = owner
+	}
+	a.ownerIPs[owner] = append(a.ownerIPs[owner], newIPs...)
+	return nil
+}
+
+// allocate allocates the next free IPs to owner, or the static IPs requested by the
+// network selection element of the pod if the network has no subnets, along with the
+// requested MAC.
+func (a *podIPAllocator) allocate(owner string, network *nadapi.NetworkSelectionElement) ([]*net.IPNet, net.HardwareAddr, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	var ips []*net.IPNet
+	var mac net.HardwareAddr
+	var err error
+	switch {
+	case network != nil && len(network.IPRequest) > 0 && !a.requiresIPAM:
+		if ips, err = parseStaticIPs(owner, network.IPRequest); err != nil {
+			return nil, nil, err
+		}
+		if err = a.reserveLocked(owner, ips); err != nil {
+			return nil, nil, err
+		}
+		mac = util.IPAddrToHWAddr(ips[0].IP)
+	case a.requiresIPAM:
+		if ips, err = a.lsManager.AllocateNextIPs(a.switchName); err != nil {
+			return nil, nil, err
+		}
+		for _, ip := range ips {
+			a.ipOwners[ip.IP.String()] = owner
+		}
+		a.ownerIPs[owner] = append(a.ownerIPs[owner], ips...)
+		mac = util.IPAddrToHWAddr(ips[0].IP)
+	default:
+		klog.V(5).Infof("Layer2 network without subnet; will only generate the MAC address for %s", owner)
+		if mac, err = logicalswitchmanager.GenerateRandMAC(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if network != nil && network.MacRequest != "" {
+		if mac, err = net.ParseMAC(network.MacRequest); err != nil {
+			a.releaseLocked(owner, false)
+			return nil, nil, fmt.Errorf("failed to parse mac %s requested for %s: %v", network.MacRequest, owner, err)
+		}
+	}
+	return ips, mac, nil
+}
+
+// release releases all the IPs allocated to owner
+func (a *podIPAllocator) release(owner string) {
+	a.Lock()
+	defer a.Unlock()
+	a.releaseLocked(owner, false)
+}
+
+// releaseDeleted releases all the IPs allocated to owner once its pod is deleted. The IPs
+// are quarantined if the allocator has a quarantine.
+func (a *podIPAllocator) releaseDeleted(owner string) {
+	a.Lock()
+	defer a.Unlock()
+	a.releaseLocked(owner, true)
+}
+
+func (a *podIPAllocator) releaseLocked(owner string, deleted bool) {
+	ips, ok := a.ownerIPs[owner]
+	if !ok {
+		return
+	}
+	for _, ip := range ips {
+		delete(a.ipOwners, ip.IP.String())
+	}
+	delete(a.ownerIPs, owner)
+	if !a.requiresIPAM {
+		return
+	}
+	var managedIPs []*net.IPNet
+	for _, ip := range ips {
+		if !a.isExcluded(ip.IP) {
+			managedIPs = append(managedIPs, ip)
+		}
+	}
+	release := a.lsManager.ReleaseIPs
+	if deleted {
+		release = a.lsManager.QuarantineIPs
+	}
+	if err := release(a.switchName, managedIPs); err != nil {
+		klog.Errorf("Failed to release IPs %s of %s: %v", util.JoinIPNetIPs(managedIPs, " "), owner, err)
+	}
+}
+
+// isExcluded returns true if ip belongs to the excluded subnets of the network,
+// which are never released back to the allocator
+func (a *podIPAllocator) isExcluded(ip net.IP) bool {
+	for _, excludeSubnet := range a.excludeSubnets {
+		if excludeSubnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseStaticIPs(owner string, ips []string) ([]*net.IPNet, error) {
+	var staticIPs []*net.IPNet
+	klog.V(5).Infof("%s requested static IPs: %s", owner, strings.Join(ips, ";"))
+	for _, ip := range ips {
+		ipAddr, ipNet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse IP %s requested for %s: %v", ip, owner, err)
+		}
+		ipNet.IP = ipAddr
+		staticIPs = append(staticIPs, ipNet)
+	}
+	return staticIPs, nil
+}
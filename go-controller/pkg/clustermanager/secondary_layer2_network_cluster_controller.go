@@ -0,0 +1,458 @@
+package clustermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	cache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ref "k8s.io/client-go/tools/reference"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
+	objretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// ipAddressConflictEvent is the reason of the event posted on a pod that claims
+// an IP already allocated to another pod
+const ipAddressConflictEvent = "IPAddressConflict"
+
+// secondaryLayer2NetworkClusterController is the cluster controller for the layer2
+// secondary networks. It listens to the pod events and allocates the pod IPs and MAC
+// on the network, setting the pod annotation consumed by the network controllers.
+type secondaryLayer2NetworkClusterController struct {
+	kube         kube.Interface
+	watchFactory *factory.WatchFactory
+	stopChan     chan struct{}
+	wg           *sync.WaitGroup
+	// event recorder used to post events to k8s
+	recorder record.EventRecorder
+
+	// pod events factory handler
+	podHandler *factory.Handler
+
+	// retry framework for pods
+	retryPods *objretry.RetryFramework
+
+	podAllocator *podIPAllocator
+
+	util.NetInfo
+	util.NetConfInfo
+}
+
+func newSecondaryLayer2NetworkClusterController(ovnClient *util.OVNClusterManagerClientset, wf *factory.WatchFactory,
+	recorder record.EventRecorder, netInfo util.NetInfo, netConfInfo *util.Layer2NetConfInfo) (*secondaryLayer2NetworkClusterController, error) {
+	podAllocator, err := newPodIPAllocator(netInfo.GetPrefix()+ovntypes.OVNLayer2Switch,
+		netConfInfo.ClusterSubnets, netConfInfo.ExcludeSubnets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the pod IP allocator of network %s: %w", netInfo.GetNetworkName(), err)
+	}
+
+	if config.Default.PodIPQuarantinePeriod > 0 && podAllocator.requiresIPAM {
+		networkName := netInfo.GetNetworkName()
+		q := quarantine.NewList("pod-ips."+networkName,
+			time.Duration(config.Default.PodIPQuarantinePeriod)*time.Second,
+			quarantine.NewConfiguredStore(ovnClient.KubeClient),
+			func(count int) { metrics.RecordQuarantinedClusterManagerPodIPs(networkName, count) })
+		podAllocator.lsManager.SetQuarantine(q, config.Default.ReleaseQuarantineWhenExhausted)
+	}
+
+	l2cc := &secondaryLayer2NetworkClusterController{
+		kube: &kube.Kube{
+			KClient: ovnClient.KubeClient,
+		},
+		watchFactory: wf,
+		stopChan:     make(chan struct{}),
+		wg:           &sync.WaitGroup{},
+		recorder:     recorder,
+		podAllocator: podAllocator,
+		NetInfo:      netInfo,
+		NetConfInfo:  netConfInfo,
+	}
+	l2cc.retryPods = objretry.NewRetryFramework(l2cc.stopChan, l2cc.wg, wf, &objretry.ResourceHandler{
+		HasUpdateFunc:          true,
+		NeedsUpdateDuringRetry: false,
+		ObjType:                factory.PodType,
+		NetworkName:            netInfo.GetNetworkName(),
+		EventHandler: &secondaryLayer2NetworkClusterControllerEventHandler{
+			objType: factory.PodType,
+			l2cc:    l2cc,
+		},
+	})
+	return l2cc, nil
+}
+
+// Start starts watching the pods. The allocator is first rebuilt from the
+// quarantined IPs and the annotations of the existing pods.
+func (l2cc *secondaryLayer2NetworkClusterController) Start(ctx context.Context) error {
+	if err := l2cc.podAllocator.lsManager.RestoreQuarantine(); err != nil {
+		return fmt.Errorf("failed to restore the quarantined pod IPs of network %s: %w", l2cc.GetNetworkName(), err)
+	}
+	podHandler, err := l2cc.retryPods.WatchResource()
+	if err != nil {
+		return fmt.Errorf("unable to watch pods: %w", err)
+	}
+	l2cc.podHandler = podHandler
+
+	l2cc.wg.Add(1)
+	go func() {
+		defer l2cc.wg.Done()
+		l2cc.podAllocator.lsManager.RunQuarantine(l2cc.stopChan)
+	}()
+	return nil
+}
+
+func (l2cc *secondaryLayer2NetworkClusterController) Stop() {
+	close(l2cc.stopChan)
+	l2cc.wg.Wait()
+
+	if l2cc.podHandler != nil {
+		l2cc.watchFactory.RemovePodHandler(l2cc.podHandler)
+	}
+	metrics.DeleteQuarantinedClusterManagerPodIPs(l2cc.GetNetworkName())
+}
+
+// Cleanup cleans up the network. The pod annotations are removed along with the pods
+// by the network controllers, only the quarantined pod IPs are left to drop.
+func (l2cc *secondaryLayer2NetworkClusterController) Cleanup(netName string) error {
+	return l2cc.podAllocator.lsManager.PurgeQuarantine()
+}
+
+// podOwner returns the name the IPs of the pod on the given NAD are allocated to
+func podOwner(pod *corev1.Pod, nadName string) string {
+	return fmt.Sprintf("%s/%s/%s", nadName, pod.Namespace, pod.Name)
+}
+
+// handleAddUpdatePodEvent allocates the IPs and MAC of the pod on each NAD of the network
+// the pod is attached to, unless they were already allocated.
+func (l2cc *secondaryLayer2NetworkClusterController) handleAddUpdatePodEvent(pod *corev1.Pod) error {
+	if !util.PodScheduled(pod) || util.PodWantsHostNetwork(pod) {
+		return nil
+	}
+
+	on, networkMap, err := util.GetPodNADToNetworkMapping(pod, l2cc.NetInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get the networks of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	if !on {
+		return nil
+	}
+
+	var errs []error
+	for nadName, network := range networkMap {
+		if err := l2cc.allocatePodAnnotation(pod, nadName, network); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func (l2cc *secondaryLayer2NetworkClusterController) allocatePodAnnotation(pod *corev1.Pod, nadName string,
+	network *nadapi.NetworkSelectionElement) error {
+	owner := podOwner(pod, nadName)
+
+	podNetworks, err := util.UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		return err
+	}
+	if _, ok := podNetworks[nadName]; ok {
+		// already allocated, make sure the IPs are reserved
+		podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+		if err != nil {
+			return err
+		}
+		if err = l2cc.podAllocator.reserve(owner, podAnnotation.IPs); err != nil {
+			l2cc.handleIPConflict(pod, owner, err)
+			return err
+		}
+		return nil
+	}
+
+	ips, mac, err := l2cc.podAllocator.allocate(owner, network)
+	if err != nil {
+		l2cc.handleIPConflict(pod, owner, err)
+		return fmt.Errorf("failed to allocate IPs for %s: %w", owner, err)
+	}
+
+	podAnnotation := &util.PodAnnotation{
+		IPs: ips,
+		MAC: mac,
+	}
+	if network != nil {
+		podAnnotation.Gateways = network.GatewayRequest
+	}
+	klog.V(5).Infof("Allocated pod annotation of %s: ip=%v ; mac=%s ; gw=%s", owner, ips, mac, podAnnotation.Gateways)
+	if err = l2cc.updatePodAnnotationWithRetry(pod, podAnnotation, nadName); err != nil {
+		l2cc.podAllocator.release(owner)
+		return err
+	}
+	return nil
+}
+
+// handleIPConflict posts an event on the pod if err is an IP conflict with another pod
+func (l2cc *secondaryLayer2NetworkClusterController) handleIPConflict(pod *corev1.Pod, owner string, err error) {
+	var conflictErr *ipConflictError
+	if !errors.As(err, &conflictErr) {
+		return
+	}
+	message := fmt.Sprintf("Failed to allocate IPs of %s on network %s: %v", owner, l2cc.GetNetworkName(), err)
+	klog.Warning(message)
+	podRef, refErr := ref.GetReference(scheme.Scheme, pod)
+	if refErr != nil {
+		klog.Errorf("Couldn't get a reference to pod %s/%s to post an event: %v", pod.Namespace, pod.Name, refErr)
+		return
+	}
+	l2cc.recorder.Eventf(podRef, corev1.EventTypeWarning, ipAddressConflictEvent, message)
+}
+
+func (l2cc *secondaryLayer2NetworkClusterController) updatePodAnnotationWithRetry(origPod *corev1.Pod, podInfo *util.PodAnnotation, nadName string) error {
+	resultErr := retry.RetryOnConflict(util.OvnConflictBackoff, func() error {
+		// Informer cache should not be mutated, so get a copy of the object
+		pod, err := l2cc.watchFactory.GetPod(origPod.Namespace, origPod.Name)
+		if err != nil {
+			return err
+		}
+
+		cpod := pod.DeepCopy()
+		cpod.Annotations, err = util.MarshalPodAnnotation(cpod.Annotations, podInfo, nadName)
+		if err != nil {
+			return err
+		}
+		return l2cc.kube.UpdatePod(cpod)
+	})
+	if resultErr != nil {
+		return fmt.Errorf("failed to update annotation on pod %s/%s: %v", origPod.Namespace, origPod.Name, resultErr)
+	}
+	return nil
+}
+
+// handleDeletePodEvent releases the IPs of the pod on each NAD of the network. The NADs
+// requested by the pod are considered too as the deleted pod might not show the
+// annotation that was just set.
+func (l2cc *secondaryLayer2NetworkClusterController) handleDeletePodEvent(pod *corev1.Pod) error {
+	podNetworks, err := util.UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		return err
+	}
+	nadNames := sets.New[string]()
+	for nadName := range podNetworks {
+		nadNames.Insert(nadName)
+	}
+	if on, networkMap, err := util.GetPodNADToNetworkMapping(pod, l2cc.NetInfo); err == nil && on {
+		for nadName := range networkMap {
+			nadNames.Insert(nadName)
+		}
+	}
+	for nadName := range nadNames {
+		if !l2cc.HasNAD(nadName) {
+			continue
+		}
+		l2cc.podAllocator.releaseDeleted(podOwner(pod, nadName))
+	}
+	return nil
+}
+
+// syncPods reserves the IPs of the existing pods. Pods are processed from the oldest
+// so that when two pods claim the same IP, the IP stays allocated to the oldest one.
+func (l2cc *secondaryLayer2NetworkClusterController) syncPods(pods []interface{}) error {
+	existingPods := make([]*corev1.Pod, 0, len(pods))
+	for _, podInterface := range pods {
+		pod, ok := podInterface.(*corev1.Pod)
+		if !ok {
+			return fmt.Errorf("spurious object in syncPods: %v", podInterface)
+		}
+		if util.PodCompleted(pod) || util.PodWantsHostNetwork(pod) {
+			continue
+		}
+		existingPods = append(existingPods, pod)
+	}
+	sort.SliceStable(existingPods, func(i, j int) bool {
+		if !existingPods[i].CreationTimestamp.Equal(&existingPods[j].CreationTimestamp) {
+			return existingPods[i].CreationTimestamp.Before(&existingPods[j].CreationTimestamp)
+		}
+		return existingPods[i].Namespace+"/"+existingPods[i].Name < existingPods[j].Namespace+"/"+existingPods[j].Name
+	})
+
+	for _, pod := range existingPods {
+		on, networkMap, err := util.GetPodNADToNetworkMapping(pod, l2cc.NetInfo)
+		if err != nil || !on {
+			if err != nil {
+				klog.Warningf("Failed to determine if pod %s/%s is attached to network %s: %v",
+					pod.Namespace, pod.Name, l2cc.GetNetworkName(), err)
+			}
+			continue
+		}
+		for nadName := range networkMap {
+			podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+			if err != nil {
+				// not allocated yet, will be allocated on the pod add event
+				continue
+			}
+			owner := podOwner(pod, nadName)
+			if err = l2cc.podAllocator.reserve(owner, podAnnotation.IPs); err != nil {
+				l2cc.handleIPConflict(pod, owner, err)
+				klog.Errorf("Failed to reserve IPs %s of %s: %v", util.JoinIPNetIPs(podAnnotation.IPs, " "), owner, err)
+			}
+		}
+	}
+	return nil
+}
+
+// secondaryLayer2NetworkClusterControllerEventHandler object handles the events
+// from retry framework.
+type secondaryLayer2NetworkClusterControllerEventHandler struct {
+	objretry.EventHandler
+
+	objType reflect.Type
+	l2cc    *secondaryLayer2NetworkClusterController
+}
+
+// AddResource adds the specified object to the cluster according to its type and
+// returns the error, if any, yielded during object creation.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) AddResource(obj interface{}, fromRetryLoop bool) error {
+	switch h.objType {
+	case factory.PodType:
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *corev1.Pod", obj)
+		}
+		if err := h.l2cc.handleAddUpdatePodEvent(pod); err != nil {
+			klog.Infof("Pod add failed for %s/%s on network %s, will try again later: %v",
+				pod.Namespace, pod.Name, h.l2cc.GetNetworkName(), err)
+			return err
+		}
+	default:
+		return fmt.Errorf("no add function for object type %s", h.objType)
+	}
+	return nil
+}
+
+// UpdateResource updates the specified object in the cluster to its version in newObj according
+// to its type and returns the error, if any, yielded during the object update.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) UpdateResource(oldObj, newObj interface{}, inRetryCache bool) error {
+	switch h.objType {
+	case factory.PodType:
+		pod, ok := newObj.(*corev1.Pod)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *corev1.Pod", newObj)
+		}
+		if err := h.l2cc.handleAddUpdatePodEvent(pod); err != nil {
+			klog.Infof("Pod update failed for %s/%s on network %s, will try again later: %v",
+				pod.Namespace, pod.Name, h.l2cc.GetNetworkName(), err)
+			return err
+		}
+	default:
+		return fmt.Errorf("no update function for object type %s", h.objType)
+	}
+	return nil
+}
+
+// DeleteResource deletes the object from the cluster according to the delete logic of its resource type.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) DeleteResource(obj, cachedObj interface{}) error {
+	switch h.objType {
+	case factory.PodType:
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return fmt.Errorf("could not cast obj of type %T to *corev1.Pod", obj)
+		}
+		return h.l2cc.handleDeletePodEvent(pod)
+	}
+	return nil
+}
+
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) SyncFunc(objs []interface{}) error {
+	switch h.objType {
+	case factory.PodType:
+		return h.l2cc.syncPods(objs)
+	default:
+		return fmt.Errorf("no sync function for object type %s", h.objType)
+	}
+}
+
+// RecordAddEvent records the add event on this object. Not used here.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) RecordAddEvent(obj interface{}) {
+}
+
+// RecordUpdateEvent records the update event on this object. Not used here.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) RecordUpdateEvent(obj interface{}) {
+}
+
+// RecordDeleteEvent records the delete event on this object. Not used here.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) RecordDeleteEvent(obj interface{}) {
+}
+
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) RecordSuccessEvent(obj interface{}) {
+}
+
+// RecordErrorEvent records an error event on this object. Not used here.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) RecordErrorEvent(obj interface{}, reason string, err error) {
+}
+
+// IsResourceScheduled returns true if the object has been scheduled.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) IsResourceScheduled(obj interface{}) bool {
+	if h.objType == factory.PodType {
+		pod := obj.(*corev1.Pod)
+		return util.PodScheduled(pod)
+	}
+	return true
+}
+
+// IsObjectInTerminalState returns true if the object is a in terminal state. Completed pods
+// are deleted so that their IPs are released.
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) IsObjectInTerminalState(obj interface{}) bool {
+	if h.objType == factory.PodType {
+		pod := obj.(*corev1.Pod)
+		return util.PodCompleted(pod)
+	}
+	return false
+}
+
+// AreResourcesEqual returns false so that pod updates are always processed
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) AreResourcesEqual(obj1, obj2 interface{}) (bool, error) {
+	return false, nil
+}
+
+// GetInternalCacheEntry returns the internal cache entry for this object
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) GetInternalCacheEntry(obj interface{}) interface{} {
+	return nil
+}
+
+// GetResourceFromInformerCache returns the latest state of the object from the informers cache
+// given an object key and its type
+func (h *secondaryLayer2NetworkClusterControllerEventHandler) GetResourceFromInformerCache(key string) (interface{}, error) {
+	var obj interface{}
+	var namespace, name string
+	var err error
+
+	namespace, name, err = cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split key %s: %v", key, err)
+	}
+
+	switch h.objType {
+	case factory.PodType:
+		obj, err = h.l2cc.watchFactory.GetPod(namespace, name)
+
+	default:
+		err = fmt.Errorf("object type %s not supported, cannot retrieve it from informers cache",
+			h.objType)
+	}
+	return obj, err
+}
@@ -20,6 +20,8 @@ type secondaryNetworkClusterManager struct {
 	nadController *nad.NetAttachDefinitionController
 	ovnClient     *util.OVNClusterManagerClientset
 	watchFactory  *factory.WatchFactory
+	// event recorder used to post events to k8s
+	recorder record.EventRecorder
 }
 
 func newSecondaryNetworkClusterManager(ovnClient *util.OVNClusterManagerClientset,
@@ -28,6 +30,7 @@ func newSecondaryNetworkClusterManager(ovnClient *util.OVNClusterManagerClientse
 	sncm := &secondaryNetworkClusterManager{
 		ovnClient:    ovnClient,
 		watchFactory: wf,
+		recorder:     recorder,
 	}
 	var err error
 	sncm.nadController, err = nad.NewNetAttachDefinitionController(
@@ -52,15 +55,21 @@ func (sncm *secondaryNetworkClusterManager) Stop() {
 
 // NewNetworkController implements the networkAttachDefController.NetworkControllerManager
 // interface function.  This function is called by the net-attach-def controller when
-// a layer2 or layer3 secondary network is created.  Layer2 type is not handled here.
+// a layer2 or layer3 secondary network is created. Layer3 networks get their node subnets
+// allocated, layer2 networks their pod IPs and MACs.
 func (sncm *secondaryNetworkClusterManager) NewNetworkController(nInfo util.NetInfo,
 	netConfInfo util.NetConfInfo) (nad.NetworkController, error) {
 	topoType := netConfInfo.TopologyType()
-	if topoType == ovntypes.Layer3Topology {
+	switch topoType {
+	case ovntypes.Layer3Topology:
 		layer3NetConfInfo := netConfInfo.(*util.Layer3NetConfInfo)
 		sncc := newNetworkClusterController(nInfo.GetNetworkName(), layer3NetConfInfo.ClusterSubnets,
-			sncm.ovnClient, sncm.watchFactory, false, nInfo, netConfInfo)
+			sncm.ovnClient, sncm.watchFactory, sncm.recorder, false, nInfo, netConfInfo)
 		return sncc, nil
+	case ovntypes.Layer2Topology:
+		layer2NetConfInfo := netConfInfo.(*util.Layer2NetConfInfo)
+		return newSecondaryLayer2NetworkClusterController(sncm.ovnClient, sncm.watchFactory, sncm.recorder,
+			nInfo, layer2NetConfInfo)
 	}
 
 	// Secondary network cluster manager doesn't manage other topology types
@@ -123,5 +132,5 @@ func (sncm *secondaryNetworkClusterManager) newDummyLayer3NetworkController(netN
 	netInfo := util.NewNetInfo(&ovncnitypes.NetConf{NetConf: types.NetConf{Name: netName}, Topology: ovntypes.Layer3Topology})
 	layer3NetConfInfo := &util.Layer3NetConfInfo{}
 	return newNetworkClusterController(netInfo.GetNetworkName(), layer3NetConfInfo.ClusterSubnets,
-		sncm.ovnClient, sncm.watchFactory, false, netInfo, layer3NetConfInfo)
+		sncm.ovnClient, sncm.watchFactory, sncm.recorder, false, netInfo, layer3NetConfInfo)
 }
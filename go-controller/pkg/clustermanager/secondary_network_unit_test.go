@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/containernetworking/cni/pkg/types"
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	"github.com/urfave/cli/v2"
@@ -19,6 +20,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	nad "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/network-attach-def-controller"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -121,25 +123,45 @@ var _ = ginkgo.Describe("Secondary Layer3 Cluster Controller Manager", func() {
 
 		ginkgo.It("Attach secondary layer2 network", func() {
 			app.Action = func(ctx *cli.Context) error {
-				nodes := []v1.Node{
+				pods := []v1.Pod{
 					{
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "node1",
+							Name:              "pod1",
+							Namespace:         "ns1",
+							CreationTimestamp: metav1.Unix(1, 0),
+							Annotations: map[string]string{
+								nadapi.NetworkAttachmentAnnot: `[{"name":"blue","namespace":"ns1"}]`,
+								util.OvnPodAnnotationName:     `{"ns1/blue":{"ip_addresses":["192.168.0.2/24"],"mac_address":"0a:58:c0:a8:00:02"}}`,
+							},
 						},
+						Spec: v1.PodSpec{NodeName: "node1"},
 					},
 					{
+						// claims the IP of the older pod1
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "node2",
+							Name:              "pod2",
+							Namespace:         "ns1",
+							CreationTimestamp: metav1.Unix(2, 0),
+							Annotations: map[string]string{
+								nadapi.NetworkAttachmentAnnot: `[{"name":"blue","namespace":"ns1"}]`,
+								util.OvnPodAnnotationName:     `{"ns1/blue":{"ip_addresses":["192.168.0.2/24"],"mac_address":"0a:58:c0:a8:00:02"}}`,
+							},
 						},
+						Spec: v1.PodSpec{NodeName: "node1"},
 					},
 					{
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "node3",
+							Name:      "pod3",
+							Namespace: "ns1",
+							Annotations: map[string]string{
+								nadapi.NetworkAttachmentAnnot: `[{"name":"blue","namespace":"ns1"}]`,
+							},
 						},
+						Spec: v1.PodSpec{NodeName: "node2"},
 					},
 				}
-				kubeFakeClient := fake.NewSimpleClientset(&v1.NodeList{
-					Items: nodes,
+				kubeFakeClient := fake.NewSimpleClientset(&v1.PodList{
+					Items: pods,
 				})
 				fakeClient := &util.OVNClusterManagerClientset{
 					KubeClient: kubeFakeClient,
@@ -148,19 +170,49 @@ var _ = ginkgo.Describe("Secondary Layer3 Cluster Controller Manager", func() {
 				_, err := config.InitConfig(ctx, nil, nil)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				config.Kubernetes.HostNetworkNamespace = ""
+				config.OVNKubernetesFeature.EnableMultiNetwork = true
 
 				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				err = f.Start()
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
-				sncm, err := newSecondaryNetworkClusterManager(fakeClient, f, record.NewFakeRecorder(0))
+				recorder := record.NewFakeRecorder(10)
+				sncm, err := newSecondaryNetworkClusterManager(fakeClient, f, recorder)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				netInfo := util.NewNetInfo(&ovncnitypes.NetConf{NetConf: types.NetConf{Name: "blue"}, Topology: ovntypes.Layer2Topology})
-				layer2NetConfInfo := &util.Layer2NetConfInfo{}
+				netInfo.AddNAD("ns1/blue")
+				_, subnet, err := net.ParseCIDR("192.168.0.0/24")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, excludeSubnet, err := net.ParseCIDR("192.168.0.0/31")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				layer2NetConfInfo := &util.Layer2NetConfInfo{
+					ClusterSubnets: []*net.IPNet{subnet},
+					ExcludeSubnets: []*net.IPNet{excludeSubnet},
+				}
 				nc, err := sncm.NewNetworkController(netInfo, layer2NetConfInfo)
-				gomega.Expect(err).To(gomega.Equal(nad.ErrNetworkControllerTopologyNotManaged))
-				gomega.Expect(nc).To(gomega.BeNil())
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(nc).NotTo(gomega.BeNil())
+
+				err = nc.Start(ctx.Context)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				defer nc.Stop()
+
+				// pod3 gets the next IP after the excluded ones and the one of pod1
+				gomega.Eventually(func() ([]*net.IPNet, error) {
+					pod, err := kubeFakeClient.CoreV1().Pods("ns1").Get(context.TODO(), "pod3", metav1.GetOptions{})
+					if err != nil {
+						return nil, err
+					}
+					podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, "ns1/blue")
+					if err != nil {
+						return nil, err
+					}
+					return podAnnotation.IPs, nil
+				}, 2).Should(gomega.Equal([]*net.IPNet{ovntest.MustParseIPNet("192.168.0.3/24")}))
+
+				// the conflicting claim of pod2 is reported
+				gomega.Eventually(recorder.Events).Should(gomega.Receive(gomega.ContainSubstring(ipAddressConflictEvent)))
 
 				return nil
 			}
@@ -227,7 +279,7 @@ var _ = ginkgo.Describe("Secondary Layer3 Cluster Controller Manager", func() {
 				netInfo := util.NewNetInfo(&ovncnitypes.NetConf{NetConf: types.NetConf{Name: "blue"}, Topology: ovntypes.Layer3Topology})
 				layer3NetConfInfo := &util.Layer3NetConfInfo{}
 				oc := newNetworkClusterController(netInfo.GetNetworkName(), layer3NetConfInfo.ClusterSubnets,
-					sncm.ovnClient, sncm.watchFactory, false, netInfo, layer3NetConfInfo)
+					sncm.ovnClient, sncm.watchFactory, sncm.recorder, false, netInfo, layer3NetConfInfo)
 				nadControllers := []nad.NetworkController{oc}
 
 				err = sncm.CleanupDeletedNetworks(nadControllers)
@@ -2,6 +2,7 @@ package subnetallocator
 
 import (
 	"fmt"
+	"math/big"
 	"net"
 	"sync"
 
@@ -13,18 +14,48 @@ var ErrSubnetAllocatorFull = fmt.Errorf("no subnets available.")
 
 type SubnetAllocator interface {
 	AddNetworkRange(network *net.IPNet, hostSubnetLen int) error
+	// RemoveNetworkRange removes the given range if none of its subnets are
+	// allocated
+	RemoveNetworkRange(network *net.IPNet) error
+	// SetNetworkRangeDraining stops, or resumes, allocating new subnets from
+	// the given range. Subnets of a draining range can still be marked as
+	// allocated and released.
+	SetNetworkRangeDraining(network *net.IPNet, draining bool) error
 	MarkAllocatedNetworks(string, ...*net.IPNet) error
 	// Usage returns the number of available and used v4 subnets, and
 	// the number of available and used v6 subnets
 	Usage() (uint64, uint64, uint64, uint64)
+	// RangeUsage returns the number of available and used subnets of each
+	// network range
+	RangeUsage() []RangeUsage
 	AllocateNetworks(string) ([]*net.IPNet, error)
 	AllocateIPv4Network(string) (*net.IPNet, error)
 	AllocateIPv6Network(string) (*net.IPNet, error)
+	// AllocateIPv4NetworkOfLength and AllocateIPv6NetworkOfLength allocate a
+	// network with the given prefix length instead of the host subnet length
+	// of the range it is allocated from
+	AllocateIPv4NetworkOfLength(string, int) (*net.IPNet, error)
+	AllocateIPv6NetworkOfLength(string, int) (*net.IPNet, error)
 	// ReleaseNetworks releases the given networks if they are owned by the
 	// given owner
 	ReleaseNetworks(string, ...*net.IPNet) error
 	// ReleaseAllNetworks releases all networks owned by the given owner
 	ReleaseAllNetworks(string)
+	// TransferNetworks hands the given networks owned by the given owner over
+	// to the new owner, all or none of them
+	TransferNetworks(owner, newOwner string, subnets ...*net.IPNet) error
+	// TransferAllNetworks hands all networks owned by the given owner over
+	// to the new owner and returns them
+	TransferAllNetworks(owner, newOwner string) []*net.IPNet
+}
+
+// RangeUsage is the number of subnets available and used in a network range,
+// in networks of the host subnet length of the range
+type RangeUsage struct {
+	Range    *net.IPNet
+	Count    uint64
+	Used     uint64
+	Draining bool
 }
 
 type BaseSubnetAllocator struct {
@@ -57,6 +88,19 @@ func (sna *BaseSubnetAllocator) Usage() (uint64, uint64, uint64, uint64) {
 	return v4count, v4used, v6count, v6used
 }
 
+// RangeUsage returns the number of available and used subnets of each IPv4
+// range followed by each IPv6 range, in the order they were added.
+func (sna *BaseSubnetAllocator) RangeUsage() []RangeUsage {
+	sna.Lock()
+	defer sna.Unlock()
+	usage := make([]RangeUsage, 0, len(sna.v4ranges)+len(sna.v6ranges))
+	for _, snr := range append(append([]*subnetAllocatorRange{}, sna.v4ranges...), sna.v6ranges...) {
+		c, u := snr.usage()
+		usage = append(usage, RangeUsage{Range: snr.network, Count: c, Used: u, Draining: snr.draining})
+	}
+	return usage
+}
+
 // AddNetworkRange makes the given range available for allocation and returns
 // nil, or an error on failure.
 func (sna *BaseSubnetAllocator) AddNetworkRange(network *net.IPNet, hostSubnetLen int) error {
@@ -76,6 +120,44 @@ func (sna *BaseSubnetAllocator) AddNetworkRange(network *net.IPNet, hostSubnetLe
 	return nil
 }
 
+// RemoveNetworkRange removes the given range and returns nil, or an error if
+// the range is unknown or some of its subnets are still allocated.
+func (sna *BaseSubnetAllocator) RemoveNetworkRange(network *net.IPNet) error {
+	sna.Lock()
+	defer sna.Unlock()
+
+	ranges := &sna.v4ranges
+	if utilnet.IsIPv6(network.IP) {
+		ranges = &sna.v6ranges
+	}
+	for i, snr := range *ranges {
+		if snr.network.String() != network.String() {
+			continue
+		}
+		if snr.used > 0 {
+			return fmt.Errorf("network range %s has %d allocated subnets", network, snr.used)
+		}
+		*ranges = append((*ranges)[:i], (*ranges)[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("network range %s not found", network)
+}
+
+// SetNetworkRangeDraining sets whether new subnets are allocated from the
+// given range and returns nil, or an error if the range is unknown.
+func (sna *BaseSubnetAllocator) SetNetworkRangeDraining(network *net.IPNet, draining bool) error {
+	sna.Lock()
+	defer sna.Unlock()
+
+	for _, snr := range append(append([]*subnetAllocatorRange{}, sna.v4ranges...), sna.v6ranges...) {
+		if snr.network.String() == network.String() {
+			snr.draining = draining
+			return nil
+		}
+	}
+	return fmt.Errorf("network range %s not found", network)
+}
+
 // MarkAllocatedNetworks will mark the given subnets as already allocated by
 // the given owner. Marking is all-or-nothing; if marking one of the subnets
 // fails then none of them are marked as allocated.
@@ -146,33 +228,52 @@ func (sna *BaseSubnetAllocator) AllocateNetworks(owner string) ([]*net.IPNet, er
 
 // AllocateIPv4Network tries to allocate an IPv4 network if there are ranges available
 func (sna *BaseSubnetAllocator) AllocateIPv4Network(owner string) (*net.IPNet, error) {
-	sna.Lock()
-	defer sna.Unlock()
-	if len(sna.v4ranges) == 0 {
-		return nil, nil
-	}
-	for _, snr := range sna.v4ranges {
-		sn := snr.allocateNetwork(owner)
-		if sn != nil {
-			return sn, nil
-		}
-	}
-	return nil, ErrSubnetAllocatorFull
+	return sna.AllocateIPv4NetworkOfLength(owner, 0)
 }
 
 // AllocateIPv6Network tries to allocate an IPv6 network if there are ranges available
 func (sna *BaseSubnetAllocator) AllocateIPv6Network(owner string) (*net.IPNet, error) {
+	return sna.AllocateIPv6NetworkOfLength(owner, 0)
+}
+
+// AllocateIPv4NetworkOfLength tries to allocate an IPv4 network with the given
+// prefix length if there are ranges available. A length of 0 stands for the
+// host subnet length of the range.
+func (sna *BaseSubnetAllocator) AllocateIPv4NetworkOfLength(owner string, hostSubnetLen int) (*net.IPNet, error) {
+	sna.Lock()
+	defer sna.Unlock()
+	return allocateNetworkOfLength(sna.v4ranges, owner, hostSubnetLen)
+}
+
+// AllocateIPv6NetworkOfLength tries to allocate an IPv6 network with the given
+// prefix length if there are ranges available. A length of 0 stands for the
+// host subnet length of the range.
+func (sna *BaseSubnetAllocator) AllocateIPv6NetworkOfLength(owner string, hostSubnetLen int) (*net.IPNet, error) {
 	sna.Lock()
 	defer sna.Unlock()
-	if len(sna.v6ranges) == 0 {
+	return allocateNetworkOfLength(sna.v6ranges, owner, hostSubnetLen)
+}
+
+func allocateNetworkOfLength(ranges []*subnetAllocatorRange, owner string, hostSubnetLen int) (*net.IPNet, error) {
+	if len(ranges) == 0 {
 		return nil, nil
 	}
-	for _, snr := range sna.v6ranges {
-		sn := snr.allocateNetwork(owner)
-		if sn != nil {
+	fits := false
+	for _, snr := range ranges {
+		if !snr.fits(hostSubnetLen) {
+			continue
+		}
+		fits = true
+		if snr.draining {
+			continue
+		}
+		if sn := snr.allocateNetworkOfLength(owner, hostSubnetLen); sn != nil {
 			return sn, nil
 		}
 	}
+	if !fits {
+		return nil, fmt.Errorf("no network range can provide a /%d network", hostSubnetLen)
+	}
 	return nil, ErrSubnetAllocatorFull
 }
 
@@ -188,6 +289,54 @@ func (sna *BaseSubnetAllocator) ReleaseAllNetworks(owner string) {
 	sna.releaseAllNetworks(owner)
 }
 
+// TransferNetworks hands the given subnets owned by owner over to newOwner without
+// releasing them in between, so that no other owner can allocate them. Transferring is
+// all-or-nothing; if transferring one of the subnets fails then none of them are
+// transferred.
+func (sna *BaseSubnetAllocator) TransferNetworks(owner, newOwner string, subnets ...*net.IPNet) error {
+	sna.Lock()
+	defer sna.Unlock()
+
+	transferred := make([]*net.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		err := sna.transferNetwork(owner, newOwner, subnet)
+		if err != nil {
+			for _, t := range transferred {
+				_ = sna.transferNetwork(newOwner, owner, t)
+			}
+			return err
+		}
+		transferred = append(transferred, subnet)
+	}
+	return nil
+}
+
+func (sna *BaseSubnetAllocator) transferNetwork(owner, newOwner string, subnet *net.IPNet) error {
+	ranges := sna.v4ranges
+	if utilnet.IsIPv6CIDR(subnet) {
+		ranges = sna.v6ranges
+	}
+	for _, snr := range ranges {
+		if ok, err := snr.transferNetwork(owner, newOwner, subnet); ok || err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("network %s is not allocated to %s in any known range", subnet.String(), owner)
+}
+
+func (sna *BaseSubnetAllocator) TransferAllNetworks(owner, newOwner string) []*net.IPNet {
+	sna.Lock()
+	defer sna.Unlock()
+	var networks []*net.IPNet
+	for _, snr := range sna.v4ranges {
+		networks = append(networks, snr.transferAllNetworks(owner, newOwner)...)
+	}
+	for _, snr := range sna.v6ranges {
+		networks = append(networks, snr.transferAllNetworks(owner, newOwner)...)
+	}
+	return networks
+}
+
 // releaseNetworks attempts to release all given subnets, even if a failure
 // occurs during release. It returns nil, or an aggregate error for any
 // failures that occurred.
@@ -244,7 +393,17 @@ type subnetAllocatorRange struct {
 	subnetBits uint32
 	next       uint32
 	allocMap   map[string]string
-	used       uint32
+	// used is the number of networks of the host subnet length that are
+	// allocated, or overlapped by an allocated network of another length
+	used uint64
+	// draining ranges are not used for new allocations
+	draining bool
+	// sized holds the allocated networks whose prefix length differs from
+	// the host subnet length of the range
+	sized map[string]*net.IPNet
+	// partial counts the allocated networks, smaller than the host subnet
+	// length, within each network of the host subnet length
+	partial map[string]uint32
 
 	// IPv4-only address-alignment hackery; see below
 	leftShift  uint32
@@ -269,6 +428,8 @@ func newSubnetAllocatorRange(network *net.IPNet, hostSubnetLen int) (*subnetAllo
 		subnetBits: subnetBits,
 		next:       0,
 		allocMap:   make(map[string]string),
+		sized:      make(map[string]*net.IPNet),
+		partial:    make(map[string]uint32),
 	}
 
 	// In the simple case, the subnet part of the 32-bit IP address is just the subnet
@@ -302,10 +463,55 @@ func newSubnetAllocatorRange(network *net.IPNet, hostSubnetLen int) (*subnetAllo
 	return snr, nil
 }
 
-// usage returns the number of available subnets and the number of allocated subnets
+// usage returns the number of available subnets and the number of allocated
+// subnets, both in networks of the host subnet length
 func (snr *subnetAllocatorRange) usage() (uint64, uint64) {
 	var one uint64 = 1
-	return one << snr.subnetBits, uint64(snr.used)
+	return one << snr.subnetBits, snr.used
+}
+
+// markUsed accounts for network being allocated. A network larger than the
+// host subnet length uses all the networks of that length it contains, and
+// networks smaller than it use the one network of that length they are part of.
+func (snr *subnetAllocatorRange) markUsed(network *net.IPNet) {
+	networkLen, addrLen := network.Mask.Size()
+	hostSubnetLen := snr.hostSubnetLen()
+	if networkLen <= hostSubnetLen {
+		snr.used += subnetCount(hostSubnetLen - networkLen)
+		return
+	}
+	mask := net.CIDRMask(hostSubnetLen, addrLen)
+	containing := (&net.IPNet{IP: network.IP.Mask(mask), Mask: mask}).String()
+	if snr.partial[containing] == 0 {
+		snr.used++
+	}
+	snr.partial[containing]++
+}
+
+// markUnused reverts markUsed for network being released.
+func (snr *subnetAllocatorRange) markUnused(network *net.IPNet) {
+	networkLen, addrLen := network.Mask.Size()
+	hostSubnetLen := snr.hostSubnetLen()
+	if networkLen <= hostSubnetLen {
+		snr.used -= subnetCount(hostSubnetLen - networkLen)
+		return
+	}
+	mask := net.CIDRMask(hostSubnetLen, addrLen)
+	containing := (&net.IPNet{IP: network.IP.Mask(mask), Mask: mask}).String()
+	snr.partial[containing]--
+	if snr.partial[containing] == 0 {
+		delete(snr.partial, containing)
+		snr.used--
+	}
+}
+
+// subnetCount returns the number of networks of a given length in a network
+// bits shorter, capped to fit in an uint64
+func subnetCount(bits int) uint64 {
+	if bits > 63 {
+		bits = 63
+	}
+	return uint64(1) << bits
 }
 
 type alreadyOwnedError struct {
@@ -323,66 +529,153 @@ func IsAlreadyOwnedError(err error) bool {
 	return ok
 }
 
+// hostSubnetLen returns the prefix length of the networks allocated by default
+func (snr *subnetAllocatorRange) hostSubnetLen() int {
+	clusterCIDRLen, _ := snr.network.Mask.Size()
+	return clusterCIDRLen + int(snr.subnetBits)
+}
+
+// fits returns whether networks with the given prefix length can be allocated
+// from snr's range. A length of 0 stands for the host subnet length of the range.
+func (snr *subnetAllocatorRange) fits(hostSubnetLen int) bool {
+	if hostSubnetLen == 0 {
+		return true
+	}
+	clusterCIDRLen, addrLen := snr.network.Mask.Size()
+	return hostSubnetLen >= clusterCIDRLen && hostSubnetLen < addrLen
+}
+
+// findOverlap returns an allocated network, and its owner, that overlaps network
+// without being equal to it, or an empty string if there is none. Networks of
+// the host subnet length of the range are looked up in allocMap rather than
+// compared one by one.
+func (snr *subnetAllocatorRange) findOverlap(network *net.IPNet) (string, string) {
+	str := network.String()
+	for sizedStr, sized := range snr.sized {
+		if sizedStr != str && (sized.Contains(network.IP) || network.Contains(sized.IP)) {
+			return sizedStr, snr.allocMap[sizedStr]
+		}
+	}
+	networkLen, addrLen := network.Mask.Size()
+	hostSubnetLen := snr.hostSubnetLen()
+	mask := net.CIDRMask(hostSubnetLen, addrLen)
+	switch {
+	case networkLen > hostSubnetLen:
+		// network is part of a network of the host subnet length
+		containing := &net.IPNet{IP: network.IP.Mask(mask), Mask: mask}
+		if owner, ok := snr.allocMap[containing.String()]; ok {
+			return containing.String(), owner
+		}
+	case networkLen < hostSubnetLen && networkLen+16 >= hostSubnetLen:
+		// network contains up to 64k networks of the host subnet length
+		base := new(big.Int).SetBytes(network.IP.Mask(network.Mask))
+		step := new(big.Int).Lsh(big.NewInt(1), uint(addrLen-hostSubnetLen))
+		for i := 0; i < 1<<(hostSubnetLen-networkLen); i++ {
+			ip := make(net.IP, addrLen/8)
+			base.FillBytes(ip)
+			contained := &net.IPNet{IP: ip, Mask: mask}
+			if owner, ok := snr.allocMap[contained.String()]; ok {
+				return contained.String(), owner
+			}
+			base.Add(base, step)
+		}
+	case networkLen < hostSubnetLen:
+		for allocatedStr, owner := range snr.allocMap {
+			_, allocated, err := net.ParseCIDR(allocatedStr)
+			if err == nil && network.Contains(allocated.IP) {
+				return allocatedStr, owner
+			}
+		}
+	}
+	return "", ""
+}
+
 // markAllocatedNetwork marks network as being in use, if it is part of snr's range.
 // It returns whether the network was in snr's range, and returns an error if
-// network was already allocated to a different owner.
+// network, or a network overlapping it, was already allocated to a different owner.
 func (snr *subnetAllocatorRange) markAllocatedNetwork(owner string, network *net.IPNet) (bool, error) {
 	str := network.String()
-	if !snr.network.Contains(network.IP) {
+	networkLen, _ := network.Mask.Size()
+	if !snr.network.Contains(network.IP) || !snr.fits(networkLen) {
 		return false, nil
 	}
 
 	existingOwner, ok := snr.allocMap[str]
-	if !ok {
-		snr.allocMap[str] = owner
-		snr.used++
-		return true, nil
-	} else if existingOwner == owner {
-		return true, nil
+	if ok {
+		if existingOwner == owner {
+			return true, nil
+		}
+		return false, alreadyOwnedError{str, existingOwner}
+	}
+	if overlap, overlapOwner := snr.findOverlap(network); overlap != "" {
+		return false, fmt.Errorf("network %s overlaps network %s owned by %s", str, overlap, overlapOwner)
 	}
 
-	return false, alreadyOwnedError{str, existingOwner}
+	snr.allocMap[str] = owner
+	if networkLen != snr.hostSubnetLen() {
+		snr.sized[str] = network
+	}
+	snr.markUsed(network)
+	return true, nil
 }
 
-// allocateNetwork returns a new subnet, or nil if the range is full
-func (snr *subnetAllocatorRange) allocateNetwork(owner string) *net.IPNet {
-	netMaskSize, addrLen := snr.network.Mask.Size()
-	numSubnets := uint32(1) << snr.subnetBits
+// numSubnets returns the number of networks of the host subnet length in the range
+func (snr *subnetAllocatorRange) numSubnets() uint32 {
 	if snr.subnetBits > 24 {
-		// We need to make sure that the uint32 math below won't overflow. If
-		// snr.subnetBits > 32 then numSubnets has already overflowed, but also if
+		// We need to make sure that the uint32 math in subnet() won't overflow. If
+		// snr.subnetBits > 32 then numSubnets would overflow, but also if
 		// numSubnets is between 1<<24 and 1<<32 then "base << (snr.hostBits % 8)"
-		// below could overflow if snr.hostBits%8 is non-0. So we cap numSubnets
+		// could overflow if snr.hostBits%8 is non-0. So we cap numSubnets
 		// at 1<<24. "16M subnets ought to be enough for anybody."
-		numSubnets = 1 << 24
+		return 1 << 24
+	}
+	return uint32(1) << snr.subnetBits
+}
+
+// subnet returns the n-th network of the host subnet length in the range, or
+// nil if it must not be allocated
+func (snr *subnetAllocatorRange) subnet(n uint32) *net.IPNet {
+	netMaskSize, addrLen := snr.network.Mask.Size()
+	base := n
+	if snr.leftShift != 0 {
+		base = ((base << snr.leftShift) & snr.leftMask) | ((base >> snr.rightShift) & snr.rightMask)
+	} else if addrLen == 128 && snr.subnetBits >= 16 {
+		// Skip the 0 subnet (and other subnets with all 0s in the low word)
+		// since the extra 0 word will get compressed out and make the address
+		// look different from addresses on other subnets.
+		if (base & 0xFFFF) == 0 {
+			return nil
+		}
+	}
+
+	genIP := append([]byte{}, []byte(snr.network.IP)...)
+	subnetBits := base << (snr.hostBits % 8)
+	b := (uint32(addrLen) - snr.hostBits - 1) / 8
+	for subnetBits != 0 {
+		genIP[b] |= byte(subnetBits)
+		subnetBits >>= 8
+		b--
 	}
 
+	return &net.IPNet{IP: genIP, Mask: net.CIDRMask(int(snr.subnetBits)+netMaskSize, addrLen)}
+}
+
+// allocateNetwork returns a new subnet, or nil if the range is full
+func (snr *subnetAllocatorRange) allocateNetwork(owner string) *net.IPNet {
+	numSubnets := snr.numSubnets()
 	var i uint32
 	for i = 0; i < numSubnets; i++ {
 		n := (i + snr.next) % numSubnets
-		base := n
-		if snr.leftShift != 0 {
-			base = ((base << snr.leftShift) & snr.leftMask) | ((base >> snr.rightShift) & snr.rightMask)
-		} else if addrLen == 128 && snr.subnetBits >= 16 {
-			// Skip the 0 subnet (and other subnets with all 0s in the low word)
-			// since the extra 0 word will get compressed out and make the address
-			// look different from addresses on other subnets.
-			if (base & 0xFFFF) == 0 {
-				continue
-			}
-		}
-
-		genIP := append([]byte{}, []byte(snr.network.IP)...)
-		subnetBits := base << (snr.hostBits % 8)
-		b := (uint32(addrLen) - snr.hostBits - 1) / 8
-		for subnetBits != 0 {
-			genIP[b] |= byte(subnetBits)
-			subnetBits >>= 8
-			b--
+		genSubnet := snr.subnet(n)
+		if genSubnet == nil {
+			continue
 		}
-
-		genSubnet := &net.IPNet{IP: genIP, Mask: net.CIDRMask(int(snr.subnetBits)+netMaskSize, addrLen)}
 		if _, ok := snr.allocMap[genSubnet.String()]; !ok {
+			if len(snr.sized) > 0 {
+				if overlap, _ := snr.findOverlap(genSubnet); overlap != "" {
+					continue
+				}
+			}
 			snr.allocMap[genSubnet.String()] = owner
 			snr.next = n + 1
 			snr.used++
@@ -394,6 +687,41 @@ func (snr *subnetAllocatorRange) allocateNetwork(owner string) *net.IPNet {
 	return nil
 }
 
+// allocateNetworkOfLength returns a new subnet with the given prefix length,
+// or nil if the range is full. A length of 0 stands for the host subnet length
+// of the range. Networks of other lengths are allocated from the end of the
+// range, away from the ones of the host subnet length, to limit fragmentation.
+func (snr *subnetAllocatorRange) allocateNetworkOfLength(owner string, hostSubnetLen int) *net.IPNet {
+	if hostSubnetLen == 0 || hostSubnetLen == snr.hostSubnetLen() {
+		return snr.allocateNetwork(owner)
+	}
+	sized, err := newSubnetAllocatorRange(snr.network, hostSubnetLen)
+	if err != nil {
+		return nil
+	}
+	// pack the networks together rather than spreading them over the octets
+	sized.leftShift = 0
+	numSubnets := sized.numSubnets()
+	var i uint32
+	for i = 0; i < numSubnets; i++ {
+		genSubnet := sized.subnet(numSubnets - 1 - i)
+		if genSubnet == nil {
+			continue
+		}
+		if _, ok := snr.allocMap[genSubnet.String()]; ok {
+			continue
+		}
+		if overlap, _ := snr.findOverlap(genSubnet); overlap != "" {
+			continue
+		}
+		snr.allocMap[genSubnet.String()] = owner
+		snr.sized[genSubnet.String()] = genSubnet
+		snr.markUsed(genSubnet)
+		return genSubnet
+	}
+	return nil
+}
+
 // releaseNetwork marks network as being not in use, if it is part of snr's range.
 // It returns whether the network was in snr's range.
 func (snr *subnetAllocatorRange) releaseNetwork(owner string, network *net.IPNet) (bool, error) {
@@ -407,7 +735,8 @@ func (snr *subnetAllocatorRange) releaseNetwork(owner string, network *net.IPNet
 		return false, nil
 	} else if existingOwner == owner {
 		delete(snr.allocMap, str)
-		snr.used--
+		delete(snr.sized, str)
+		snr.markUnused(network)
 		return true, nil
 	}
 
@@ -418,8 +747,44 @@ func (snr *subnetAllocatorRange) releaseNetwork(owner string, network *net.IPNet
 func (snr *subnetAllocatorRange) releaseAllNetworks(owner string) {
 	for network, existingOwner := range snr.allocMap {
 		if existingOwner == owner {
+			if _, ipNet, err := net.ParseCIDR(network); err == nil {
+				snr.markUnused(ipNet)
+			}
 			delete(snr.allocMap, network)
-			snr.used--
+			delete(snr.sized, network)
+		}
+	}
+}
+
+// transferAllNetworks hands all networks of a given owner over to newOwner and returns them.
+// transferNetwork hands network over from owner to newOwner, if it is part of snr's range.
+// It returns whether network was allocated in snr's range.
+func (snr *subnetAllocatorRange) transferNetwork(owner, newOwner string, network *net.IPNet) (bool, error) {
+	if !snr.network.Contains(network.IP) {
+		return false, nil
+	}
+
+	str := network.String()
+	existingOwner, ok := snr.allocMap[str]
+	if !ok {
+		return false, nil
+	} else if existingOwner != owner {
+		return false, alreadyOwnedError{str, existingOwner}
+	}
+	snr.allocMap[str] = newOwner
+	return true, nil
+}
+
+func (snr *subnetAllocatorRange) transferAllNetworks(owner, newOwner string) []*net.IPNet {
+	var networks []*net.IPNet
+	for network, existingOwner := range snr.allocMap {
+		if existingOwner != owner {
+			continue
+		}
+		snr.allocMap[network] = newOwner
+		if _, ipNet, err := net.ParseCIDR(network); err == nil {
+			networks = append(networks, ipNet)
 		}
 	}
+	return networks
 }
@@ -581,3 +581,221 @@ func TestDualStack(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRangeUsage(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/16", 18)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	err = sna.AddNetworkRange(ovntest.MustParseIPNet("fd01::/48"), 64)
+	if err != nil {
+		t.Fatal("Failed to add network range: ", err)
+	}
+	err = sna.AddNetworkRange(ovntest.MustParseIPNet("10.2.0.0/16"), 17)
+	if err != nil {
+		t.Fatal("Failed to add network range: ", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := sna.AllocateIPv4Network(testNodeName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := sna.AllocateIPv6Network(testNodeName); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"10.1.0.0/16: 4/4",
+		"10.2.0.0/16: 1/2",
+		"fd01::/48: 1/65536",
+	}
+	usage := sna.RangeUsage()
+	if len(usage) != len(expected) {
+		t.Fatalf("expected usage of %d ranges but got %d", len(expected), len(usage))
+	}
+	for i := range usage {
+		if got := fmt.Sprintf("%s: %d/%d", usage[i].Range, usage[i].Used, usage[i].Count); got != expected[i] {
+			t.Errorf("expected usage %s but got %s", expected[i], got)
+		}
+	}
+}
+
+func TestDrainRemoveNetworkRange(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/16", 18)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	err = sna.AddNetworkRange(ovntest.MustParseIPNet("10.2.0.0/16"), 18)
+	if err != nil {
+		t.Fatal("Failed to add network range: ", err)
+	}
+
+	if err := allocateExpected(sna, 0, "10.1.0.0/18"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.SetNetworkRangeDraining(ovntest.MustParseIPNet("10.1.0.0/16"), true); err != nil {
+		t.Fatal(err)
+	}
+	// new subnets come from the other range
+	if err := allocateExpected(sna, 1, "10.2.0.0/18"); err != nil {
+		t.Fatal(err)
+	}
+	// existing subnets of a draining range can still be marked and released
+	if err := sna.MarkAllocatedNetworks("other", ovntest.MustParseIPNet("10.1.64.0/18")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.RemoveNetworkRange(ovntest.MustParseIPNet("10.1.0.0/16")); err == nil {
+		t.Fatal("Unexpectedly removed a network range with allocated subnets")
+	}
+	sna.ReleaseAllNetworks("other")
+	if err := sna.ReleaseNetworks(testNodeName, ovntest.MustParseIPNet("10.1.0.0/18")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.RemoveNetworkRange(ovntest.MustParseIPNet("10.1.0.0/16")); err != nil {
+		t.Fatal(err)
+	}
+	if err := expectNumSubnets(t, sna, 4, 0); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 4; i++ {
+		if err := allocateExpected(sna, i+1, fmt.Sprintf("10.2.%d.0/18", i*64)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := allocateNotExpected(sna, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.SetNetworkRangeDraining(ovntest.MustParseIPNet("10.3.0.0/16"), true); err == nil {
+		t.Fatal("Unexpectedly drained an unknown network range")
+	}
+}
+
+func TestAllocateNetworkOfLength(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/22", 24)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+
+	// smaller networks are allocated from the end of the range
+	for _, expected := range []string{"10.1.3.192/26", "10.1.3.128/26"} {
+		sn, err := sna.AllocateIPv4NetworkOfLength("small", 26)
+		if err != nil {
+			t.Fatal("Failed to allocate network: ", err)
+		}
+		if sn.String() != expected {
+			t.Fatalf("Expected to allocate %s but got %s", expected, sn)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := allocateExpected(sna, i, fmt.Sprintf("10.1.%d.0/24", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the last network of the host subnet length overlaps the smaller ones
+	if err := allocateNotExpected(sna, 3); err != nil {
+		t.Fatal(err)
+	}
+	if sn, err := sna.AllocateIPv4NetworkOfLength("big", 23); err != ErrSubnetAllocatorFull {
+		t.Fatalf("Expected ErrSubnetAllocatorFull but got %v (sn=%v)", err, sn)
+	}
+	if sn, err := sna.AllocateIPv4NetworkOfLength("huge", 20); err == nil || err == ErrSubnetAllocatorFull {
+		t.Fatalf("Expected an error for a length the range can't provide but got %v (sn=%v)", err, sn)
+	}
+
+	// marking networks of other lengths fails only if they overlap
+	if err := sna.MarkAllocatedNetworks("other", ovntest.MustParseIPNet("10.1.3.0/25")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.MarkAllocatedNetworks("thief", ovntest.MustParseIPNet("10.1.3.0/24")); err == nil {
+		t.Fatal("Unexpectedly marked a network overlapping allocated networks")
+	}
+	if err := sna.MarkAllocatedNetworks("thief", ovntest.MustParseIPNet("10.1.2.0/25")); err == nil {
+		t.Fatal("Unexpectedly marked a network overlapping an allocated network")
+	}
+
+	sna.ReleaseAllNetworks("small")
+	sna.ReleaseAllNetworks("other")
+	if err := allocateExpected(sna, 4, "10.1.3.0/24"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRangeUsageOfLength(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/22", 24)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	expectUsed := func(expected uint64) {
+		t.Helper()
+		if usage := sna.RangeUsage(); usage[0].Count != 4 || usage[0].Used != expected {
+			t.Fatalf("expected %d/4 used subnets but got %d/%d", expected, usage[0].Used, usage[0].Count)
+		}
+	}
+
+	// smaller networks use the one network of the host subnet length they are part of
+	for i := 0; i < 2; i++ {
+		if _, err := sna.AllocateIPv4NetworkOfLength("small", 26); err != nil {
+			t.Fatal("Failed to allocate network: ", err)
+		}
+	}
+	expectUsed(1)
+	// larger networks use all the networks of the host subnet length they contain
+	if _, err := sna.AllocateIPv4NetworkOfLength("big", 23); err != nil {
+		t.Fatal("Failed to allocate network: ", err)
+	}
+	expectUsed(3)
+	if err := sna.ReleaseNetworks("small", ovntest.MustParseIPNet("10.1.3.192/26")); err != nil {
+		t.Fatal(err)
+	}
+	expectUsed(3)
+	sna.ReleaseAllNetworks("small")
+	expectUsed(2)
+	sna.ReleaseAllNetworks("big")
+	expectUsed(0)
+}
+
+func TestTransferNetworks(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/22", 24)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	if err := sna.MarkAllocatedNetworks("node1", ovntest.MustParseIPNet("10.1.0.0/24"), ovntest.MustParseIPNet("10.1.1.0/24")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.MarkAllocatedNetworks("node2", ovntest.MustParseIPNet("10.1.2.0/24")); err != nil {
+		t.Fatal(err)
+	}
+
+	// transferring is all-or-nothing
+	if err := sna.TransferNetworks("node1", "quarantine", ovntest.MustParseIPNet("10.1.0.0/24"),
+		ovntest.MustParseIPNet("10.1.2.0/24")); err == nil {
+		t.Fatal("Unexpectedly transferred a network of another owner")
+	}
+	if err := sna.TransferNetworks("node1", "quarantine", ovntest.MustParseIPNet("10.1.3.0/24")); err == nil {
+		t.Fatal("Unexpectedly transferred a network that is not allocated")
+	}
+	if err := sna.ReleaseNetworks("node1", ovntest.MustParseIPNet("10.1.0.0/24")); err != nil {
+		t.Fatalf("Expected node1 to still own 10.1.0.0/24: %v", err)
+	}
+
+	// the transferred network is never free
+	if err := sna.TransferNetworks("node1", "quarantine", ovntest.MustParseIPNet("10.1.1.0/24")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.ReleaseNetworks("node1", ovntest.MustParseIPNet("10.1.1.0/24")); err == nil {
+		t.Fatal("Unexpectedly released a transferred network from its previous owner")
+	}
+	if err := allocateExpected(sna, 0, "10.1.0.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if err := allocateExpected(sna, 1, "10.1.3.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if err := allocateNotExpected(sna, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.ReleaseNetworks("quarantine", ovntest.MustParseIPNet("10.1.1.0/24")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,27 +1,151 @@
 package subnetallocator
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"sync"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
+// quarantineOwner owns the quarantined host subnets; it can't be a node name
+const quarantineOwner = "<quarantine>"
+
 type HostSubnetAllocator struct {
 	sync.Mutex
 	// Don't inherit from BaseSubnetAllocator to ensure users of
 	// hostSubnetAllocator can't directly call the underlying methods
 	base SubnetAllocator
+	// networkName is the network the host subnets are allocated for, used to
+	// label the usage metrics. Usage metrics are not recorded if it is empty.
+	networkName string
+	// quarantine, if set, holds the released host subnets back from allocation
+	quarantine *quarantine.List
+	// releaseQuarantineWhenExhausted allows quarantined subnets to be allocated
+	// before their quarantine expired when the ranges are exhausted
+	releaseQuarantineWhenExhausted bool
+	// removedRanges are the network ranges that were removed by UpdateRanges while they
+	// still had allocated subnets; they are removed once their last subnet is released
+	removedRanges     sets.Set[string]
+	removedRangesLock sync.Mutex
 }
 
-func NewHostSubnetAllocator() *HostSubnetAllocator {
+func NewHostSubnetAllocator(networkName string) *HostSubnetAllocator {
 	return &HostSubnetAllocator{
-		base: NewSubnetAllocator(),
+		base:          NewSubnetAllocator(),
+		networkName:   networkName,
+		removedRanges: sets.New[string](),
+	}
+}
+
+// SetQuarantine makes the allocator hold the host subnets released from now on back in q
+// until their quarantine expires. If releaseWhenExhausted is set, quarantined subnets are
+// allocated again, oldest first, when no other subnet is available.
+func (sna *HostSubnetAllocator) SetQuarantine(q *quarantine.List, releaseWhenExhausted bool) {
+	sna.quarantine = q
+	sna.releaseQuarantineWhenExhausted = releaseWhenExhausted
+}
+
+// RestoreQuarantine loads the quarantined subnets from the store of the quarantine and
+// keeps them allocated. It must be called once the subnets of the existing nodes are
+// marked as allocated; quarantined subnets that are allocated to a node already are taken
+// out of quarantine.
+func (sna *HostSubnetAllocator) RestoreQuarantine() error {
+	if sna.quarantine == nil {
+		return nil
+	}
+	subnets, err := sna.quarantine.Load()
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err == nil {
+			err = sna.base.MarkAllocatedNetworks(quarantineOwner, ipNet)
+		}
+		if err != nil {
+			klog.Warningf("Removing subnet %s from quarantine %s: %v", subnet, sna.quarantine.Name(), err)
+			sna.quarantine.Remove(subnet)
+		}
+	}
+	sna.recordUsage()
+	return nil
+}
+
+// RunQuarantine releases the quarantined subnets whose quarantine expired until
+// stopChan is closed
+func (sna *HostSubnetAllocator) RunQuarantine(stopChan <-chan struct{}) {
+	if sna.quarantine == nil {
+		return
+	}
+	sna.quarantine.Run(stopChan, sna.releaseQuarantined)
+}
+
+// PurgeQuarantine drops the quarantined subnets, from the store of the quarantine as well,
+// once the network is gone
+func (sna *HostSubnetAllocator) PurgeQuarantine() error {
+	if sna.quarantine == nil {
+		return nil
+	}
+	return sna.quarantine.Purge()
+}
+
+func (sna *HostSubnetAllocator) releaseQuarantined(subnet string) {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err == nil {
+		err = sna.base.ReleaseNetworks(quarantineOwner, ipNet)
+	}
+	if err != nil {
+		klog.Warningf("Failed to release quarantined subnet %s: %v", subnet, err)
+	}
+	sna.removeReleasedRanges()
+	sna.recordUsage()
+}
+
+// quarantineSubnets hands the given subnets of the node over to the quarantine. Each subnet
+// changes owner atomically, so that it can't be allocated to another node in between.
+func (sna *HostSubnetAllocator) quarantineSubnets(nodeName string, subnets ...*net.IPNet) error {
+	var errs []error
+	for _, subnet := range subnets {
+		if err := sna.base.TransferNetworks(nodeName, quarantineOwner, subnet); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sna.quarantine.Add(subnet.String())
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// allocateNetworkOfLength allocates an IPv4 or IPv6 network of the given length to the
+// node. If the ranges are exhausted and releaseQuarantineWhenExhausted is set, quarantined
+// subnets of the IP family are released, oldest first, until the allocation succeeds.
+func (sna *HostSubnetAllocator) allocateNetworkOfLength(nodeName string, ipv6 bool, hostSubnetLen int) (*net.IPNet, error) {
+	allocate := sna.base.AllocateIPv4NetworkOfLength
+	if ipv6 {
+		allocate = sna.base.AllocateIPv6NetworkOfLength
+	}
+	for {
+		subnet, err := allocate(nodeName, hostSubnetLen)
+		if !errors.Is(err, ErrSubnetAllocatorFull) || sna.quarantine == nil || !sna.releaseQuarantineWhenExhausted {
+			return subnet, err
+		}
+		released, ok := sna.quarantine.PopOldest(func(subnet string) bool {
+			_, ipNet, err := net.ParseCIDR(subnet)
+			return err == nil && utilnet.IsIPv6CIDR(ipNet) == ipv6
+		})
+		if !ok {
+			return subnet, err
+		}
+		sna.releaseQuarantined(released)
 	}
 }
 
@@ -34,11 +158,96 @@ func (sna *HostSubnetAllocator) InitRanges(subnets []config.CIDRNetworkEntry) er
 	}
 
 	// update metrics for host subnets
-	v4count, _, v6count, _ := sna.base.Usage()
-	metrics.RecordSubnetCount(float64(v4count), float64(v6count))
+	sna.recordUsage()
 	return nil
 }
 
+// UpdateRanges adds the subnets that are not known yet to the allocator, removes the
+// known ranges that are not in subnets, and stops allocating from the draining subnets.
+// Ranges that still have allocated subnets are not removed but drained instead, so that
+// nodes keep their subnets; they must be removed again once all nodes released them.
+func (sna *HostSubnetAllocator) UpdateRanges(subnets []config.CIDRNetworkEntry, draining []*net.IPNet) error {
+	var errs []error
+	wanted := sets.New[string]()
+	known := sets.New[string]()
+	for _, usage := range sna.base.RangeUsage() {
+		known.Insert(usage.Range.String())
+	}
+	for _, entry := range subnets {
+		wanted.Insert(entry.CIDR.String())
+		if known.Has(entry.CIDR.String()) {
+			continue
+		}
+		if err := sna.base.AddNetworkRange(entry.CIDR, entry.HostSubnetLength); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		klog.Infof("Added network range %s to host subnet allocator", entry.CIDR)
+	}
+	drainingSet := sets.New[string]()
+	for _, subnet := range draining {
+		drainingSet.Insert(subnet.String())
+	}
+	sna.removedRangesLock.Lock()
+	defer sna.removedRangesLock.Unlock()
+	for _, usage := range sna.base.RangeUsage() {
+		network := usage.Range.String()
+		if !wanted.Has(network) {
+			err := sna.removeRange(usage.Range)
+			if err == nil {
+				continue
+			}
+			klog.Warningf("Draining network range %s instead of removing it: %v", network, err)
+			sna.removedRanges.Insert(network)
+			drainingSet.Insert(network)
+		} else {
+			sna.removedRanges.Delete(network)
+		}
+		if usage.Draining != drainingSet.Has(network) {
+			if err := sna.base.SetNetworkRangeDraining(usage.Range, drainingSet.Has(network)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			klog.Infof("Set draining of network range %s to %t", network, drainingSet.Has(network))
+		}
+	}
+
+	sna.recordUsage()
+	return utilerrors.NewAggregate(errs)
+}
+
+// removeRange removes the given network range from the allocator, if none of its subnets
+// is allocated anymore. removedRangesLock must be held.
+func (sna *HostSubnetAllocator) removeRange(network *net.IPNet) error {
+	if err := sna.base.RemoveNetworkRange(network); err != nil {
+		return err
+	}
+	klog.Infof("Removed network range %s from host subnet allocator", network)
+	sna.removedRanges.Delete(network.String())
+	if sna.networkName != "" {
+		metrics.DeleteHostSubnetRange(sna.networkName, network.String())
+	}
+	return nil
+}
+
+// removeReleasedRanges removes the ranges that were removed by UpdateRanges while they
+// still had allocated subnets, once their last subnet was released
+func (sna *HostSubnetAllocator) removeReleasedRanges() {
+	sna.removedRangesLock.Lock()
+	defer sna.removedRangesLock.Unlock()
+	if sna.removedRanges.Len() == 0 {
+		return
+	}
+	for _, usage := range sna.base.RangeUsage() {
+		if usage.Used > 0 || !sna.removedRanges.Has(usage.Range.String()) {
+			continue
+		}
+		if err := sna.removeRange(usage.Range); err != nil {
+			klog.Warningf("Failed to remove drained network range %s: %v", usage.Range, err)
+		}
+	}
+}
+
 // MarkSubnetsAllocated will mark the given subnets as already allocated by
 // the given owner. Marking is all-or-nothing; if marking one of the subnets
 // fails then none of them are marked as allocated.
@@ -46,14 +255,21 @@ func (sna *HostSubnetAllocator) MarkSubnetsAllocated(nodeName string, subnets ..
 	if err := sna.base.MarkAllocatedNetworks(nodeName, subnets...); err != nil {
 		return err
 	}
-	_, v4used, _, v6used := sna.base.Usage()
-	metrics.RecordSubnetUsage(float64(v4used), float64(v6used))
+	sna.recordUsage()
 	return nil
 }
 
 // AllocateNodeSubnets either validates existing node subnets against the allocators
 // ranges, or allocates new subnets if the node doesn't have any yet, or returns an error
 func (sna *HostSubnetAllocator) AllocateNodeSubnets(nodeName string, existingSubnets []*net.IPNet, ipv4Mode, ipv6Mode bool) ([]*net.IPNet, []*net.IPNet, error) {
+	return sna.AllocateNodeSubnetsOfLength(nodeName, existingSubnets, ipv4Mode, ipv6Mode, 0, 0)
+}
+
+// AllocateNodeSubnetsOfLength is like AllocateNodeSubnets, but new IPv4 and IPv6 subnets get
+// the given prefix length of their IP family instead of the host subnet length of their range,
+// unless it is 0. Existing subnets are kept whatever their length.
+func (sna *HostSubnetAllocator) AllocateNodeSubnetsOfLength(nodeName string, existingSubnets []*net.IPNet, ipv4Mode, ipv6Mode bool,
+	ipv4HostSubnetLen, ipv6HostSubnetLen int) ([]*net.IPNet, []*net.IPNet, error) {
 	allocatedSubnets := []*net.IPNet{}
 
 	// OVN can work in single-stack or dual-stack only.
@@ -119,7 +335,7 @@ func (sna *HostSubnetAllocator) AllocateNodeSubnets(nodeName string, existingSub
 	// allocateOneSubnet is a helper to process the result of a subnet allocation
 	allocateOneSubnet := func(allocatedHostSubnet *net.IPNet, allocErr error) error {
 		if allocErr != nil {
-			return fmt.Errorf("error allocating network for node %s: %v", nodeName, allocErr)
+			return fmt.Errorf("error allocating network for node %s: %w", nodeName, allocErr)
 		}
 		// the allocator returns nil if it can't provide a subnet
 		// we should filter them out or they will be appended to the slice
@@ -132,12 +348,12 @@ func (sna *HostSubnetAllocator) AllocateNodeSubnets(nodeName string, existingSub
 
 	// allocate new subnets if needed
 	if ipv4Mode && !foundIPv4 {
-		if err := allocateOneSubnet(sna.base.AllocateIPv4Network(nodeName)); err != nil {
+		if err := allocateOneSubnet(sna.allocateNetworkOfLength(nodeName, false, ipv4HostSubnetLen)); err != nil {
 			return nil, nil, err
 		}
 	}
 	if ipv6Mode && !foundIPv6 {
-		if err := allocateOneSubnet(sna.base.AllocateIPv6Network(nodeName)); err != nil {
+		if err := allocateOneSubnet(sna.allocateNetworkOfLength(nodeName, true, ipv6HostSubnetLen)); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -151,8 +367,7 @@ func (sna *HostSubnetAllocator) AllocateNodeSubnets(nodeName string, existingSub
 			nodeName, expectedHostSubnets, len(allocatedSubnets))
 	}
 
-	_, v4used, _, v6used := sna.base.Usage()
-	metrics.RecordSubnetUsage(float64(v4used), float64(v6used))
+	sna.recordUsage()
 
 	hostSubnets := append(existingSubnets, allocatedSubnets...)
 	klog.Infof("Allocated Subnets %v on Node %s", hostSubnets, nodeName)
@@ -162,15 +377,47 @@ func (sna *HostSubnetAllocator) AllocateNodeSubnets(nodeName string, existingSub
 	return hostSubnets, allocatedSubnets, nil
 }
 
+// ReleaseNodeSubnets releases the given subnets of the node, or quarantines them if the
+// allocator has a quarantine
 func (sna *HostSubnetAllocator) ReleaseNodeSubnets(nodeName string, subnets ...*net.IPNet) error {
-	err := sna.base.ReleaseNetworks(nodeName, subnets...)
-	_, v4used, _, v6used := sna.base.Usage()
-	metrics.RecordSubnetUsage(float64(v4used), float64(v6used))
+	var err error
+	if sna.quarantine != nil {
+		err = sna.quarantineSubnets(nodeName, subnets...)
+	} else {
+		err = sna.base.ReleaseNetworks(nodeName, subnets...)
+		sna.removeReleasedRanges()
+	}
+	sna.recordUsage()
 	return err
 }
 
+// ReleaseAllNodeSubnets releases all the subnets of the node, or quarantines them if the
+// allocator has a quarantine
 func (sna *HostSubnetAllocator) ReleaseAllNodeSubnets(nodeName string) {
-	sna.base.ReleaseAllNetworks(nodeName)
-	_, v4used, _, v6used := sna.base.Usage()
-	metrics.RecordSubnetUsage(float64(v4used), float64(v6used))
+	if sna.quarantine != nil {
+		for _, subnet := range sna.base.TransferAllNetworks(nodeName, quarantineOwner) {
+			klog.Infof("Quarantining subnet %s of node %s", subnet, nodeName)
+			sna.quarantine.Add(subnet.String())
+		}
+	} else {
+		sna.base.ReleaseAllNetworks(nodeName)
+		sna.removeReleasedRanges()
+	}
+	sna.recordUsage()
+}
+
+// recordUsage updates the host subnet metrics with the current allocator usage
+func (sna *HostSubnetAllocator) recordUsage() {
+	if sna.networkName == "" {
+		return
+	}
+	// the cluster wide metrics predate multiple networks and only account for the default network
+	if sna.networkName == types.DefaultNetworkName {
+		v4count, v4used, v6count, v6used := sna.base.Usage()
+		metrics.RecordSubnetCount(float64(v4count), float64(v6count))
+		metrics.RecordSubnetUsage(float64(v4used), float64(v6used))
+	}
+	for _, usage := range sna.base.RangeUsage() {
+		metrics.RecordHostSubnetRangeUsage(sna.networkName, usage.Range.String(), float64(usage.Count), float64(usage.Used))
+	}
 }
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 )
 
@@ -203,7 +206,7 @@ func TestController_allocateNodeSubnets(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sna := NewHostSubnetAllocator()
+			sna := NewHostSubnetAllocator("")
 
 			ranges, err := rangesFromStrings(tt.networkRanges, tt.networkLens)
 			if err != nil {
@@ -258,7 +261,7 @@ func TestController_allocateNodeSubnets_ReleaseOnError(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	sna := NewHostSubnetAllocator()
+	sna := NewHostSubnetAllocator("")
 	if err := sna.InitRanges(ranges); err != nil {
 		t.Fatalf("Failed to initialize network ranges: %v", err)
 	}
@@ -379,7 +382,7 @@ func TestController_markSubnetsAllocated(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sna := NewHostSubnetAllocator()
+			sna := NewHostSubnetAllocator("")
 
 			ranges, err := rangesFromStrings(tt.networkRanges, tt.networkLens)
 			if err != nil {
@@ -408,3 +411,170 @@ func TestController_markSubnetsAllocated(t *testing.T) {
 		})
 	}
 }
+
+func TestController_updateRanges(t *testing.T) {
+	sna := NewHostSubnetAllocator("")
+	ranges, err := rangesFromStrings([]string{"10.1.0.0/16", "10.2.0.0/16"}, []int{18, 18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.InitRanges(ranges); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := sna.AllocateNodeSubnets("node1", nil, true, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// add a range, drain the first one and retire the unused second one
+	ranges, err = rangesFromStrings([]string{"10.1.0.0/16", "10.3.0.0/16"}, []int{18, 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.UpdateRanges(ranges, []*net.IPNet{ovntest.MustParseIPNet("10.1.0.0/16")}); err != nil {
+		t.Fatal(err)
+	}
+	subnets, _, err := sna.AllocateNodeSubnets("node2", nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subnets) != 1 || subnets[0].String() != "10.3.0.0/24" {
+		t.Fatalf("expected subnet 10.3.0.0/24 from the new range but got %v", subnets)
+	}
+
+	// the first range is still in use, so it keeps draining instead of being removed
+	ranges = ranges[1:]
+	if err := sna.UpdateRanges(ranges, nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"10.1.0.0/16 draining", "10.3.0.0/16"}
+	got := []string{}
+	for _, usage := range sna.base.RangeUsage() {
+		if usage.Draining {
+			got = append(got, usage.Range.String()+" draining")
+		} else {
+			got = append(got, usage.Range.String())
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected ranges %v but got %v", expected, got)
+	}
+
+	// the drained range is removed as soon as its last subnet is released
+	sna.ReleaseAllNodeSubnets("node1")
+	if usage := sna.base.RangeUsage(); len(usage) != 1 || usage[0].Range.String() != "10.3.0.0/16" {
+		t.Fatalf("expected only range 10.3.0.0/16 but got %v", usage)
+	}
+}
+
+func TestController_quarantine(t *testing.T) {
+	sna := NewHostSubnetAllocator("")
+	ranges, err := rangesFromStrings([]string{"10.1.0.0/16"}, []int{17})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.InitRanges(ranges); err != nil {
+		t.Fatal(err)
+	}
+	q := quarantine.NewList("subnets.test", time.Hour, nil, nil)
+	sna.SetQuarantine(q, false)
+	for _, node := range []string{"node1", "node2"} {
+		if _, _, err := sna.AllocateNodeSubnets(node, nil, true, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the released subnet stays allocated while quarantined
+	sna.ReleaseAllNodeSubnets("node1")
+	if !q.Has("10.1.0.0/17") {
+		t.Fatalf("expected subnet 10.1.0.0/17 to be quarantined but got %v", q.Addrs())
+	}
+	if _, _, err := sna.AllocateNodeSubnets("node3", nil, true, false); err == nil {
+		t.Fatalf("expected the allocation to fail while the subnet is quarantined")
+	}
+
+	// a quarantined subnet is allocated again when the ranges are exhausted, if allowed
+	sna.SetQuarantine(q, true)
+	subnets, _, err := sna.AllocateNodeSubnets("node3", nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subnets) != 1 || subnets[0].String() != "10.1.0.0/17" {
+		t.Fatalf("expected the quarantined subnet 10.1.0.0/17 but got %v", subnets)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("expected an empty quarantine but got %v", q.Addrs())
+	}
+
+	// the subnet is released for good once its quarantine expires
+	if err := sna.ReleaseNodeSubnets("node2", ovntest.MustParseIPNet("10.1.128.0/17")); err != nil {
+		t.Fatal(err)
+	}
+	if _, v4used, _, _ := sna.base.Usage(); v4used != 2 {
+		t.Fatalf("expected 2 allocated subnets but got %d", v4used)
+	}
+	expired := quarantine.NewList("subnets.test", 0, nil, nil)
+	for _, subnet := range q.Addrs() {
+		expired.Add(subnet)
+	}
+	expired.Sync(sna.releaseQuarantined)
+	if _, v4used, _, _ := sna.base.Usage(); v4used != 1 {
+		t.Fatalf("expected 1 allocated subnet but got %d", v4used)
+	}
+}
+
+func TestController_quarantineConcurrentAllocation(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		sna := NewHostSubnetAllocator("")
+		ranges, err := rangesFromStrings([]string{"10.1.0.0/16"}, []int{17})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sna.InitRanges(ranges); err != nil {
+			t.Fatal(err)
+		}
+		q := quarantine.NewList("subnets.test", time.Hour, nil, nil)
+		sna.SetQuarantine(q, false)
+		var released *net.IPNet
+		for _, node := range []string{"node1", "node2"} {
+			subnets, _, err := sna.AllocateNodeSubnets(node, nil, true, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			released = subnets[0]
+		}
+
+		// the subnet of node2 is never free while it changes owner, so node3 can't get it
+		var wg sync.WaitGroup
+		var allocated []*net.IPNet
+		started, done := make(chan struct{}), make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			close(started)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if subnets, _, err := sna.AllocateNodeSubnets("node3", nil, true, false); err == nil {
+					allocated = subnets
+					return
+				}
+			}
+		}()
+		<-started
+		err = sna.ReleaseNodeSubnets("node2", released)
+		close(done)
+		wg.Wait()
+		if err != nil {
+			t.Fatalf("expected subnet %s to be quarantined but got %v", released, err)
+		}
+		if allocated != nil {
+			t.Fatalf("expected the allocation to fail while subnet %s is quarantined but got %v", released, allocated)
+		}
+		if !q.Has(released.String()) {
+			t.Fatalf("expected subnet %s to be quarantined but got %v", released, q.Addrs())
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package clusternetworks keeps the cluster subnets in sync with the cluster networks ConfigMap,
+// so that cluster subnets can be added and retired without restarting ovnkube.
+package clusternetworks
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// Keys of the cluster networks ConfigMap data
+const (
+	// ClusterSubnetsKey holds the cluster subnets in the format of the cluster-subnets option.
+	// The cluster-subnets option is used if it isn't set.
+	ClusterSubnetsKey = "cluster-subnets"
+	// DrainingClusterSubnetsKey holds a comma separated list of cluster subnets from which
+	// no new node subnets are allocated. A cluster subnet can be removed from ClusterSubnetsKey
+	// once no node uses a subnet from it anymore, until then it is kept as draining.
+	DrainingClusterSubnetsKey = "draining-cluster-subnets"
+)
+
+// watcher applies the cluster networks ConfigMap. Cluster subnets removed from the ConfigMap
+// that host subnets of nodes still belong to are kept as draining until those nodes release them,
+// so that every ovnkube process keeps routing to the pods of those nodes.
+type watcher struct {
+	sync.Mutex
+	cmStore     cache.Store
+	nodeStore   cache.Store
+	nodesSynced cache.InformerSynced
+	cmKey       string
+	// retained is true when the last applied cluster subnets kept removed cluster subnets in use
+	retained bool
+}
+
+// Watch applies the cluster networks ConfigMap to the config and keeps applying it when it changes,
+// until stopChan is closed. It returns once the current ConfigMap, if any, was applied. nodeInformer
+// is the node informer of the watch factory of the process, it is used to know which removed cluster
+// subnets nodes still use and may be started later: until it synced, removed cluster subnets are
+// kept as draining.
+func Watch(client kubernetes.Interface, nodeInformer cache.SharedIndexInformer, stopChan <-chan struct{}) error {
+	namespace := config.Kubernetes.OVNConfigNamespace
+	name := config.Kubernetes.ClusterNetworksConfigMap
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	informer := informerFactory.Core().V1().ConfigMaps().Informer()
+	w := &watcher{
+		cmStore:     informer.GetStore(),
+		nodeStore:   nodeInformer.GetStore(),
+		nodesSynced: nodeInformer.HasSynced,
+		cmKey:       namespace + "/" + name,
+	}
+
+	_, err := nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj.(*kapi.Node).Annotations[nodeSubnetsAnnotation] != newObj.(*kapi.Node).Annotations[nodeSubnetsAnnotation] {
+				w.reapply()
+			}
+		},
+		DeleteFunc: func(_ interface{}) {
+			w.reapply()
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add node event handler for cluster networks: %v", err)
+	}
+	go func() {
+		// release the removed cluster subnets kept while the nodes were unknown
+		if cache.WaitForCacheSync(stopChan, nodeInformer.HasSynced) {
+			w.reapply()
+		}
+	}()
+
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.apply(obj.(*kapi.ConfigMap))
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			w.apply(newObj.(*kapi.ConfigMap))
+		},
+		DeleteFunc: func(_ interface{}) {
+			// falling back to the configured cluster subnets could retire subnets that are in use
+			klog.Warningf("Cluster networks ConfigMap %s/%s was deleted, keeping cluster subnets %v",
+				namespace, name, config.GetClusterSubnets())
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add event handler for cluster networks ConfigMap %s/%s: %v", namespace, name, err)
+	}
+	informerFactory.Start(stopChan)
+	if !cache.WaitForCacheSync(stopChan, informer.HasSynced) {
+		return fmt.Errorf("failed to sync cluster networks ConfigMap %s/%s", namespace, name)
+	}
+	// the event handler might not have run yet, apply the synced ConfigMap now; applying it
+	// twice is a no-op
+	obj, exists, err := w.cmStore.GetByKey(w.cmKey)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster networks ConfigMap %s/%s: %v", namespace, name, err)
+	}
+	if exists {
+		w.apply(obj.(*kapi.ConfigMap))
+	}
+	klog.Infof("Watching cluster networks ConfigMap %s/%s", namespace, name)
+	return nil
+}
+
+// nodeSubnetsAnnotation is the node annotation holding the host subnets of the node
+const nodeSubnetsAnnotation = "k8s.ovn.org/node-subnets"
+
+// reapply applies the current ConfigMap again if removed cluster subnets were kept because they
+// were in use, so that they are removed once nodes released their host subnets
+func (w *watcher) reapply() {
+	w.Lock()
+	retained := w.retained
+	w.Unlock()
+	if !retained {
+		return
+	}
+	obj, exists, err := w.cmStore.GetByKey(w.cmKey)
+	if err != nil || !exists {
+		return
+	}
+	w.apply(obj.(*kapi.ConfigMap))
+}
+
+// apply updates the cluster subnets from cm. Invalid data is only logged, the current cluster
+// subnets are kept until it is fixed.
+func (w *watcher) apply(cm *kapi.ConfigMap) {
+	w.Lock()
+	defer w.Unlock()
+	clusterSubnets, draining, err := parse(cm)
+	if err == nil {
+		clusterSubnets, draining, w.retained = w.retainUsedSubnets(clusterSubnets, draining)
+		err = config.UpdateClusterSubnets(clusterSubnets, draining)
+	}
+	if err != nil {
+		klog.Errorf("Failed to apply cluster networks ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+}
+
+// retainUsedSubnets adds the current cluster subnets missing from clusterSubnets that host subnets
+// of nodes belong to, or all of them until the nodes are synced, back to clusterSubnets, as draining
+// subnets. It returns true if any was added.
+func (w *watcher) retainUsedSubnets(clusterSubnets []config.CIDRNetworkEntry, draining []*net.IPNet) (
+	[]config.CIDRNetworkEntry, []*net.IPNet, bool) {
+	var removed []config.CIDRNetworkEntry
+	for _, current := range config.GetClusterSubnets() {
+		if !containsSubnet(clusterSubnets, current.CIDR) {
+			removed = append(removed, current)
+		}
+	}
+	if len(removed) == 0 {
+		return clusterSubnets, draining, false
+	}
+	nodesSynced := w.nodesSynced()
+	retained := false
+	for _, subnet := range removed {
+		if !nodesSynced {
+			klog.Warningf("Cluster subnet %s was removed, keeping it as draining until the nodes using it are known",
+				subnet.CIDR)
+		} else if node := w.getNodeUsingSubnet(subnet.CIDR); node != "" {
+			klog.Warningf("Cluster subnet %s was removed but node %s still uses it, keeping it as draining until "+
+				"no node uses it", subnet.CIDR, node)
+		} else {
+			continue
+		}
+		clusterSubnets = append(clusterSubnets, subnet)
+		if !containsIPNet(draining, subnet.CIDR) {
+			draining = append(draining, subnet.CIDR)
+		}
+		retained = true
+	}
+	return clusterSubnets, draining, retained
+}
+
+// getNodeUsingSubnet returns the name of a node with a host subnet of the default network in
+// subnet, or an empty string if there is none
+func (w *watcher) getNodeUsingSubnet(subnet *net.IPNet) string {
+	for _, obj := range w.nodeStore.List() {
+		node := obj.(*kapi.Node)
+		hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+		if err != nil {
+			continue
+		}
+		for _, hostSubnet := range hostSubnets {
+			if subnet.Contains(hostSubnet.IP) {
+				return node.Name
+			}
+		}
+	}
+	return ""
+}
+
+func containsIPNet(subnets []*net.IPNet, subnet *net.IPNet) bool {
+	for _, s := range subnets {
+		if s.String() == subnet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSubnet(clusterSubnets []config.CIDRNetworkEntry, subnet *net.IPNet) bool {
+	for _, clusterSubnet := range clusterSubnets {
+		if clusterSubnet.CIDR.String() == subnet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func parse(cm *kapi.ConfigMap) ([]config.CIDRNetworkEntry, []*net.IPNet, error) {
+	rawClusterSubnets := cm.Data[ClusterSubnetsKey]
+	if rawClusterSubnets == "" {
+		rawClusterSubnets = config.Default.RawClusterSubnets
+	}
+	clusterSubnets, err := config.ParseClusterSubnetEntries(rawClusterSubnets)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %v", ClusterSubnetsKey, err)
+	}
+	var draining []*net.IPNet
+	for _, rawSubnet := range strings.Split(cm.Data[DrainingClusterSubnetsKey], ",") {
+		rawSubnet = strings.TrimSpace(rawSubnet)
+		if rawSubnet == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(rawSubnet)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %v", DrainingClusterSubnetsKey, err)
+		}
+		draining = append(draining, subnet)
+	}
+	return clusterSubnets, draining, nil
+}
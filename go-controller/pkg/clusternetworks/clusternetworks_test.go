@@ -0,0 +1,218 @@
+package clusternetworks
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func clusterSubnetsString() string {
+	s := ""
+	for _, entry := range config.Default.ClusterSubnets {
+		if s != "" {
+			s += ","
+		}
+		s += fmt.Sprintf("%s/%d", entry.CIDR, entry.HostSubnetLength)
+	}
+	for _, subnet := range config.Default.DrainingClusterSubnets {
+		s += " draining " + subnet.String()
+	}
+	return s
+}
+
+func startNodeInformer(t *testing.T, client *fake.Clientset, stopChan chan struct{}) cache.SharedIndexInformer {
+	t.Helper()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes().Informer()
+	informerFactory.Start(stopChan)
+	if !cache.WaitForCacheSync(stopChan, nodeInformer.HasSynced) {
+		t.Fatal("failed to sync nodes")
+	}
+	return nodeInformer
+}
+
+func expectClusterSubnets(t *testing.T, desc, expected string) {
+	t.Helper()
+	var got string
+	err := wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		got = clusterSubnetsString()
+		return got == expected, nil
+	})
+	if err != nil {
+		t.Fatalf("%s: expected cluster subnets %s but got %s", desc, expected, got)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Kubernetes.ClusterNetworksConfigMap = "cluster-networks"
+	cm := &kapi.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Kubernetes.ClusterNetworksConfigMap,
+			Namespace: config.Kubernetes.OVNConfigNamespace,
+		},
+		Data: map[string]string{
+			ClusterSubnetsKey: "10.128.0.0/14/23,10.132.0.0/14/23",
+		},
+	}
+	client := fake.NewSimpleClientset(cm)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	// the cluster subnets are read by the reload handler, which runs after they were updated
+	var reloads int32
+	var reloaded atomic.Value
+	config.OnReload(config.ReloadableClusterSubnets, func() {
+		atomic.AddInt32(&reloads, 1)
+		reloaded.Store(clusterSubnetsString())
+	})
+	nodeInformer := startNodeInformer(t, client, stopChan)
+	if err := Watch(client, nodeInformer, stopChan); err != nil {
+		t.Fatal(err)
+	}
+	// the current ConfigMap is applied before Watch returns
+	if got, expected := reloaded.Load(), "10.128.0.0/14/23,10.132.0.0/14/23"; got != expected {
+		t.Fatalf("expected cluster subnets %s but got %s", expected, got)
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected string
+	}{
+		{
+			name: "drains a cluster subnet",
+			data: map[string]string{
+				ClusterSubnetsKey:         "10.128.0.0/14/23,10.132.0.0/14/23",
+				DrainingClusterSubnetsKey: "10.128.0.0/14",
+			},
+			expected: "10.128.0.0/14/23,10.132.0.0/14/23 draining 10.128.0.0/14",
+		},
+		{
+			name: "keeps the cluster subnets on invalid data",
+			data: map[string]string{
+				ClusterSubnetsKey:         "10.132.0.0/14/23",
+				DrainingClusterSubnetsKey: "10.128.0.0/14",
+			},
+			expected: "10.128.0.0/14/23,10.132.0.0/14/23 draining 10.128.0.0/14",
+		},
+		{
+			name: "removes a cluster subnet",
+			data: map[string]string{
+				ClusterSubnetsKey: "10.132.0.0/14/23",
+			},
+			expected: "10.132.0.0/14/23",
+		},
+		{
+			name:     "falls back to the configured cluster subnets",
+			data:     map[string]string{},
+			expected: "10.128.0.0/14/23",
+		},
+	}
+	for _, tt := range tests {
+		cm = cm.DeepCopy()
+		cm.Data = tt.data
+		if _, err := client.CoreV1().ConfigMaps(cm.Namespace).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		err := wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+			got, _ = reloaded.Load().(string)
+			return got == tt.expected, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: expected cluster subnets %s but got %s", tt.name, tt.expected, got)
+		}
+	}
+	if got := atomic.LoadInt32(&reloads); got != 4 {
+		t.Fatalf("expected the cluster subnets to be updated 4 times but got %d", got)
+	}
+}
+
+func TestWatchKeepsUsedClusterSubnets(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Kubernetes.ClusterNetworksConfigMap = "cluster-networks"
+	cm := &kapi.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Kubernetes.ClusterNetworksConfigMap,
+			Namespace: config.Kubernetes.OVNConfigNamespace,
+		},
+		Data: map[string]string{
+			ClusterSubnetsKey:         "10.128.0.0/14/23,10.132.0.0/14/23",
+			DrainingClusterSubnetsKey: "10.128.0.0/14",
+		},
+	}
+	node := &kapi.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node1",
+			Annotations: map[string]string{nodeSubnetsAnnotation: `{"default":["10.128.2.0/23"]}`},
+		},
+	}
+	client := fake.NewSimpleClientset(cm, node)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	nodeInformer := startNodeInformer(t, client, stopChan)
+	if err := Watch(client, nodeInformer, stopChan); err != nil {
+		t.Fatal(err)
+	}
+	expectClusterSubnets(t, "initial", "10.128.0.0/14/23,10.132.0.0/14/23 draining 10.128.0.0/14")
+
+	cm = cm.DeepCopy()
+	cm.Data = map[string]string{ClusterSubnetsKey: "10.132.0.0/14/23"}
+	if _, err := client.CoreV1().ConfigMaps(cm.Namespace).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// the removed cluster subnet is kept while node1 has a host subnet in it
+	expectClusterSubnets(t, "removed subnet in use", "10.132.0.0/14/23,10.128.0.0/14/23 draining 10.128.0.0/14")
+
+	if err := client.CoreV1().Nodes().Delete(context.TODO(), node.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectClusterSubnets(t, "removed subnet released", "10.132.0.0/14/23")
+}
+
+func TestWatchKeepsRemovedClusterSubnetsUntilNodesSynced(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatal(err)
+	}
+	config.Kubernetes.ClusterNetworksConfigMap = "cluster-networks"
+	cm := &kapi.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Kubernetes.ClusterNetworksConfigMap,
+			Namespace: config.Kubernetes.OVNConfigNamespace,
+		},
+		Data: map[string]string{
+			ClusterSubnetsKey: "10.132.0.0/14/23",
+		},
+	}
+	client := fake.NewSimpleClientset(cm)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	// the node informer of the watch factory is started after the ConfigMap is applied
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes().Informer()
+	if err := Watch(client, nodeInformer, stopChan); err != nil {
+		t.Fatal(err)
+	}
+	expectClusterSubnets(t, "nodes unknown", "10.132.0.0/14/23,10.128.0.0/14/23 draining 10.128.0.0/14")
+
+	informerFactory.Start(stopChan)
+	expectClusterSubnets(t, "nodes synced", "10.132.0.0/14/23")
+}
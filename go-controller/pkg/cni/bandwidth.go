@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func clearPodBandwidth(sandboxID string) error {
@@ -36,6 +39,44 @@ func clearPodBandwidth(sandboxID string) error {
 	return nil
 }
 
+// clearInterfaceBandwidth removes the QoS and the ingress policing of an OVS interface, unlike
+// clearPodBandwidth it keeps the bandwidth of the other interfaces of the sandbox
+func clearInterfaceBandwidth(ifname string) error {
+	qos, err := ovsGet("port", ifname, "qos", "")
+	if err != nil {
+		return err
+	}
+	// the port has no QoS when the column is empty, []
+	if qos != "" && qos != "[]" {
+		if err := ovsClear("port", ifname, "qos"); err != nil {
+			return err
+		}
+		if err := ovsDestroy("qos", qos); err != nil {
+			return err
+		}
+	}
+	return ovsSet("interface", ifname, "ingress_policing_rate=0", "ingress_policing_burst=0")
+}
+
+// clearDefaultNetworkPodBandwidth removes the OVS bandwidth limits of the running pods on the default
+// network, which are limited by the QoS rules of their logical switch port instead
+func clearDefaultNetworkPodBandwidth() error {
+	interfaces, err := listOVSPodInterfaces()
+	if err != nil {
+		return fmt.Errorf("failed to list the OVS interfaces: %v", err)
+	}
+	for _, iface := range interfaces {
+		if iface.netName != types.DefaultNetworkName {
+			continue
+		}
+		if err := clearInterfaceBandwidth(iface.name); err != nil {
+			return fmt.Errorf("failed to clear the bandwidth of OVS interface %s of sandbox %s: %v", iface.name, iface.sandboxID, err)
+		}
+		klog.V(5).Infof("Cleared the OVS bandwidth of interface %s of sandbox %s", iface.name, iface.sandboxID)
+	}
+	return nil
+}
+
 func setPodBandwidth(sandboxID, ifname string, ingressBPS, egressBPS int64) error {
 	// note pod ingress == OVS egress and vice versa
 
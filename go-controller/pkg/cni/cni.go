@@ -3,6 +3,7 @@ package cni
 import (
 	"fmt"
 	"net"
+	"sync/atomic"
 
 	kapi "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -36,6 +37,22 @@ const (
 	Ingress
 )
 
+// ovnPodQoSEnabled is set once the masters limit the bandwidth of the pods with the QoS rules of their
+// logical switch port, then the OVS interfaces of the pods on the default network are not policed anymore
+var ovnPodQoSEnabled int32
+
+// EnableOVNPodQoS stops the policing of the OVS interfaces of the pods on the default network and
+// removes it from the running pods, so that they are not limited twice
+func EnableOVNPodQoS() {
+	if atomic.SwapInt32(&ovnPodQoSEnabled, 1) == 0 {
+		klog.Info("OVN QoS pod bandwidth support now enabled")
+		if err := clearDefaultNetworkPodBandwidth(); err != nil {
+			klog.Warningf("Failed to clear the OVS bandwidth of the running pods, they stay limited by OVS "+
+				"until they are recreated: %v", err)
+		}
+	}
+}
+
 type notFoundError struct{}
 
 func (*notFoundError) Error() string {
@@ -148,6 +165,7 @@ func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet, useOVS
 		if err != nil {
 			return nil, err
 		}
+		pr.podInterfaces.add(pr, podInterfaceInfo, 0)
 	} else {
 		response.PodIFInfo = podInterfaceInfo
 	}
@@ -165,6 +183,7 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	if namespace == "" || podName == "" {
 		return nil, fmt.Errorf("required CNI variable missing")
 	}
+	pr.podInterfaces.delete(pr.SandboxID, pr.IfName)
 
 	vfNetdevName := ""
 	if pr.CNIConf.DeviceID != "" {
@@ -223,13 +242,6 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	return response, nil
 }
 
-func (pr *PodRequest) cmdCheck() error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
-}
-
 // HandlePodRequest is the callback for all the requests
 // coming to the cniserver after being processed into PodRequest objects
 // Argument '*PodRequest' encapsulates all the necessary information
@@ -247,7 +259,7 @@ func HandlePodRequest(request *PodRequest, clientset *ClientSet, useOVSExternalI
 	case CNIDel:
 		response, err = request.cmdDel(clientset)
 	case CNICheck:
-		err = request.cmdCheck()
+		response, err = request.cmdCheck(clientset, useOVSExternalIDs)
 	default:
 	}
 
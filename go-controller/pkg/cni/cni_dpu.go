@@ -3,6 +3,7 @@ package cni
 import (
 	"fmt"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -50,7 +51,17 @@ func (pr *PodRequest) addDPUConnectionDetailsAnnot(k kube.Interface, podLister c
 		return err
 	}
 
-	// 3. Set dpu connection-details pod annotation
+	// 3. Bind the pod to the DPU owning the PF of its VF, when the host has several
+	dpuName := ""
+	if len(config.OvnKubeNode.DPUDevices) > 0 {
+		device := config.OvnKubeNode.GetDPUDeviceByPF(pfPciAddress)
+		if device == nil {
+			return fmt.Errorf("none of the DPU devices owns the PF %s of VF %s", pfPciAddress, pciAddress)
+		}
+		dpuName = device.Name
+	}
+
+	// 4. Set dpu connection-details pod annotation
 	var domain, bus, dev, fn int
 	parsed, err := fmt.Sscanf(pfPciAddress, "%04x:%02x:%02x.%d", &domain, &bus, &dev, &fn)
 	if err != nil {
@@ -65,6 +76,7 @@ func (pr *PodRequest) addDPUConnectionDetailsAnnot(k kube.Interface, podLister c
 		VfId:         fmt.Sprint(vfindex),
 		SandboxId:    pr.SandboxID,
 		VfNetdevName: vfNetdevName,
+		DPUName:      dpuName,
 	}
 
 	return pr.updatePodDPUConnDetailsWithRetry(k, podLister, &dpuConnDetails)
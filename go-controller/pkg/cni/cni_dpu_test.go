@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	kubeMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -26,6 +27,7 @@ var _ = Describe("cni_dpu tests", func() {
 	var podNamespaceLister v1mocks.PodNamespaceLister
 
 	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
 		fakeKubeInterface = kubeMocks.Interface{}
 		fakeSriovnetOps = utilMocks.SriovnetOps{}
 		util.SetSriovnetOpsInst(&fakeSriovnetOps)
@@ -76,6 +78,43 @@ var _ = Describe("cni_dpu tests", func() {
 
 		})
 
+		It("Binds the pod to the DPU owning the PF of its VF", func() {
+			var err error
+			config.OvnKubeNode.DPUDevices = []config.DPUDevice{
+				{Name: "dpu0", PfPciAddresses: []string{"0000:03:00.0"}},
+				{Name: "dpu1", PfPciAddresses: []string{"0000:05:00.0", "0000:05:00.1"}},
+			}
+			pr.CNIConf.DeviceID = "0000:05:00.4"
+			fakeSriovnetOps.On("GetPfPciFromVfPci", pr.CNIConf.DeviceID).Return("0000:05:00.0", nil)
+			fakeSriovnetOps.On("GetVfIndexByPciAddress", pr.CNIConf.DeviceID).Return(2, nil)
+			dpuCd := util.DPUConnectionDetails{
+				PfId:      "0",
+				VfId:      "2",
+				SandboxId: pr.SandboxID,
+				DPUName:   "dpu1",
+			}
+			podLister.On("Pods", pr.PodNamespace).Return(&podNamespaceLister)
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+			cpod := pod.DeepCopy()
+			cpod.Annotations, err = util.MarshalPodDPUConnDetails(cpod.Annotations, &dpuCd, ovntypes.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			fakeKubeInterface.On("UpdatePod", cpod).Return(nil)
+			err = pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Fails if none of the DPUs owns the PF of the VF", func() {
+			config.OvnKubeNode.DPUDevices = []config.DPUDevice{
+				{Name: "dpu0", PfPciAddresses: []string{"0000:03:00.0"}},
+			}
+			pr.CNIConf.DeviceID = "0000:05:00.4"
+			fakeSriovnetOps.On("GetPfPciFromVfPci", pr.CNIConf.DeviceID).Return("0000:05:00.0", nil)
+			fakeSriovnetOps.On("GetVfIndexByPciAddress", pr.CNIConf.DeviceID).Return(2, nil)
+			err := pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("none of the DPU devices owns the PF 0000:05:00.0"))
+		})
+
 		It("Fails if DeviceID is not present in CNI config", func() {
 			err := pr.addDPUConnectionDetailsAnnot(&fakeKubeInterface, &podLister, "")
 			Expect(err).To(HaveOccurred())
@@ -0,0 +1,199 @@
+package cni
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// gcAttachment is an attachment still in use by the container runtime, as listed by CNI GC
+type gcAttachment struct {
+	ContainerID string `json:"containerID"`
+	IfName      string `json:"ifname"`
+}
+
+// validAttachmentsKey is the key of the CNI GC configuration listing the attachments still in use
+const validAttachmentsKey = "cni.dev/valid-attachments"
+
+// gcConfig is the part of the CNI GC configuration listing the attachments still in use
+type gcConfig struct {
+	ValidAttachments []gcAttachment `json:"cni.dev/valid-attachments"`
+}
+
+// ovsPodInterface is an OVS interface plumbed by CNI ADD for a pod sandbox
+type ovsPodInterface struct {
+	name      string
+	ifaceID   string
+	podUID    string
+	sandboxID string
+	netName   string
+	nadName   string
+	ips       []string
+	// representor is set for the VF representors of SR-IOV pods, whose netdev is not deleted
+	representor bool
+}
+
+// listOVSPodInterfaces returns the OVS interfaces with the iface-id and sandbox external IDs set by
+// ConfigureOVS
+func listOVSPodInterfaces() ([]ovsPodInterface, error) {
+	output, err := ovsExec("--format=json", "--columns=name,external_ids", "list", "Interface")
+	if err != nil {
+		return nil, err
+	}
+	var table struct {
+		Data [][]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &table); err != nil {
+		return nil, fmt.Errorf("failed to parse the OVS interfaces: %v", err)
+	}
+
+	var interfaces []ovsPodInterface
+	for _, row := range table.Data {
+		if len(row) != 2 {
+			continue
+		}
+		name, _ := row[0].(string)
+		externalIDs := ovsJSONMap(row[1])
+		if name == "" || externalIDs["iface-id"] == "" || externalIDs["sandbox"] == "" {
+			continue
+		}
+		netName := externalIDs[types.NetworkExternalID]
+		nadName := externalIDs[types.NADExternalID]
+		if netName == "" {
+			netName = types.DefaultNetworkName
+			nadName = types.DefaultNetworkName
+		}
+		iface := ovsPodInterface{
+			name:        name,
+			ifaceID:     externalIDs["iface-id"],
+			podUID:      externalIDs["iface-id-ver"],
+			sandboxID:   externalIDs["sandbox"],
+			netName:     netName,
+			nadName:     nadName,
+			representor: externalIDs["vf-netdev-name"] != "",
+		}
+		for _, ip := range strings.Split(externalIDs["ip_addresses"], ",") {
+			if ipAddr, _, err := net.ParseCIDR(ip); err == nil {
+				iface.ips = append(iface.ips, ipAddr.String())
+			}
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
+
+// ovsJSONMap converts an OVSDB map in JSON format, ["map", [[key, value], ...]], to a go map
+func ovsJSONMap(value interface{}) map[string]string {
+	result := map[string]string{}
+	pair, ok := value.([]interface{})
+	if !ok || len(pair) != 2 || pair[0] != "map" {
+		return result
+	}
+	entries, _ := pair[1].([]interface{})
+	for _, entry := range entries {
+		kv, ok := entry.([]interface{})
+		if !ok || len(kv) != 2 {
+			continue
+		}
+		k, _ := kv[0].(string)
+		v, _ := kv[1].(string)
+		result[k] = v
+	}
+	return result
+}
+
+// gcPodInterfaces removes the OVS ports of the given network whose sandbox is not in validSandboxes,
+// along with their host interface, their bandwidth settings and the conntrack entries of their IPs,
+// and returns the removed sandboxes. Those are left behind by the sandboxes whose CNI DEL was lost.
+func gcPodInterfaces(netName string, validSandboxes sets.String) (sets.String, error) {
+	interfaces, err := listOVSPodInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the OVS interfaces: %v", err)
+	}
+
+	// the IPs of the stale sandboxes may already be reused by running pods, whose conntrack
+	// entries must be kept
+	usedIPs := sets.NewString()
+	for _, iface := range interfaces {
+		if validSandboxes.Has(iface.sandboxID) {
+			usedIPs.Insert(iface.ips...)
+		}
+	}
+
+	staleSandboxes := sets.NewString()
+	for _, iface := range interfaces {
+		if iface.netName != netName || validSandboxes.Has(iface.sandboxID) {
+			continue
+		}
+		klog.Infof("Removing the stale OVS interface %s of sandbox %s for network %s", iface.name, iface.sandboxID, netName)
+		out, err := ovsExec("--if-exists", "del-port", "br-int", iface.name)
+		if err != nil {
+			klog.Warningf("Failed to delete the stale OVS port %s: %v\n  %q", iface.name, err, out)
+			continue
+		}
+		if !iface.representor {
+			if err := util.LinkDelete(iface.name); err != nil {
+				klog.Warningf("Failed to delete the stale interface %s: %v", iface.name, err)
+			}
+		}
+		for _, ip := range iface.ips {
+			if usedIPs.Has(ip) {
+				continue
+			}
+			if err := util.DeleteConntrack(ip, 0, "", netlink.ConntrackReplyAnyIP, nil); err != nil {
+				klog.Warningf("Failed to delete the conntrack entries of stale sandbox %s IP %s: %v", iface.sandboxID, ip, err)
+			}
+		}
+		staleSandboxes.Insert(iface.sandboxID)
+	}
+
+	for _, sandboxID := range staleSandboxes.List() {
+		if err := clearPodBandwidth(sandboxID); err != nil {
+			klog.Warningf("Failed to clear the bandwidth of stale sandbox %s: %v", sandboxID, err)
+		}
+	}
+	return staleSandboxes, nil
+}
+
+// handleCNIGC removes the OVS ports left behind by the sandboxes that are not in the valid attachments
+// of the CNI GC request, for the network of the request
+func (s *Server) handleCNIGC(cr *Request) ([]byte, error) {
+	conf, err := config.ReadCNIConfig(cr.Config)
+	if err != nil {
+		return nil, fmt.Errorf("broken stdin args")
+	}
+	var gcConf gcConfig
+	if err := json.Unmarshal(cr.Config, &gcConf); err != nil {
+		return nil, fmt.Errorf("failed to parse the valid attachments: %v", err)
+	}
+	if gcConf.ValidAttachments == nil {
+		// unlike an empty list, a missing one must not remove every pod interface
+		return nil, fmt.Errorf("missing %s", validAttachmentsKey)
+	}
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// the OVS ports of the pods are on the DPU, where they are removed along with the pods
+		return (&Response{}).Marshal()
+	}
+
+	validSandboxes := sets.NewString()
+	for _, attachment := range gcConf.ValidAttachments {
+		validSandboxes.Insert(attachment.ContainerID)
+	}
+	staleSandboxes, err := gcPodInterfaces(conf.Name, validSandboxes)
+	if err != nil {
+		return nil, err
+	}
+	for _, sandboxID := range staleSandboxes.UnsortedList() {
+		s.podInterfaces.deleteSandbox(sandboxID)
+	}
+	return (&Response{}).Marshal()
+}
@@ -0,0 +1,161 @@
+package cni
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	util_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	validSandbox   = "aaaaaaaaaaaaaaaaaaaa"
+	staleSandbox   = "bbbbbbbbbbbbbbbbbbbb"
+	blueSandbox    = "cccccccccccccccccccc"
+	listOVSIfesCmd = "ovs-vsctl --timeout=30 --format=json --columns=name,external_ids list Interface"
+)
+
+// ovsPodInterfacesJSON is the output of the OVS interfaces listing, with the management port, the
+// interface of a running pod, the interface of a pod whose DEL was lost, which had the IP now used
+// by the running pod, and the interface of a pod on a secondary network whose DEL was lost
+var ovsPodInterfacesJSON = fmt.Sprintf(`{"data":[`+
+	`["ovn-k8s-mp0",["map",[["iface-id","k8s-node1"]]]],`+
+	`["aaaaaaaaaaaaaaa",["map",[["iface-id","ns_pod1"],["ip_addresses","10.244.0.5/24"],["sandbox","%s"]]]],`+
+	`["bbbbbbbbbbbbbbb",["map",[["iface-id","ns_pod2"],["ip_addresses","10.244.0.5/24,fd00:10:244::6/64"],["sandbox","%s"]]]],`+
+	`["cccccccccccc_5",["map",[["iface-id","ns_blue_pod3"],["%s","blue"],["sandbox","%s"]]]]`+
+	`],"headings":["name","external_ids"]}`, validSandbox, staleSandbox, ovntypes.NetworkExternalID, blueSandbox)
+
+func gcRequest(netConf map[string]interface{}) *Request {
+	conf, err := json.Marshal(netConf)
+	Expect(err).NotTo(HaveOccurred())
+	return &Request{
+		Env:    map[string]string{"CNI_COMMAND": string(CNIGC)},
+		Config: conf,
+	}
+}
+
+var _ = Describe("CNI GC and STATUS tests", func() {
+	var fexec *ovntest.FakeExec
+	var mockNetLinkOps *util_mocks.NetLinkOps
+	var s *Server
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(SetExec(fexec)).To(Succeed())
+		mockNetLinkOps = new(util_mocks.NetLinkOps)
+		util.SetNetLinkOpMockInst(mockNetLinkOps)
+		s = &Server{podInterfaces: newPodInterfaceCache()}
+	})
+
+	AfterEach(func() {
+		util.ResetNetLinkOpMockInst()
+	})
+
+	It("lists the OVS interfaces of the pods", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: ovsPodInterfacesJSON})
+		interfaces, err := listOVSPodInterfaces()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interfaces).To(Equal([]ovsPodInterface{
+			{name: "aaaaaaaaaaaaaaa", ifaceID: "ns_pod1", sandboxID: validSandbox, netName: ovntypes.DefaultNetworkName,
+				nadName: ovntypes.DefaultNetworkName, ips: []string{"10.244.0.5"}},
+			{name: "bbbbbbbbbbbbbbb", ifaceID: "ns_pod2", sandboxID: staleSandbox, netName: ovntypes.DefaultNetworkName,
+				nadName: ovntypes.DefaultNetworkName, ips: []string{"10.244.0.5", "fd00:10:244::6"}},
+			{name: "cccccccccccc_5", ifaceID: "ns_blue_pod3", sandboxID: blueSandbox, netName: "blue"},
+		}))
+	})
+
+	It("removes the OVS ports of the network left behind by the sandboxes missing from the valid attachments", func() {
+		pr := &PodRequest{
+			PodNamespace: "ns",
+			PodName:      "pod2",
+			SandboxID:    staleSandbox,
+			IfName:       "eth0",
+			CNIConf:      &types.NetConf{},
+			netName:      ovntypes.DefaultNetworkName,
+			nadName:      ovntypes.DefaultNetworkName,
+		}
+		s.podInterfaces.add(pr, &PodInterfaceInfo{}, 0)
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: ovsPodInterfacesJSON})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			// the port of the stale sandbox on the default network only
+			"ovs-vsctl --timeout=30 --if-exists del-port br-int bbbbbbbbbbbbbbb",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --no-heading --format=csv --data=bare --columns=name find interface external-ids:sandbox=" + staleSandbox,
+			Output: "",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --no-heading --format=csv --data=bare --columns=_uuid find qos external-ids:sandbox=" + staleSandbox,
+			Output: "2e1f6f59-6fd4-4f87-a3c9-0d7d1d2bb7f4",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=30 --if-exists destroy qos 2e1f6f59-6fd4-4f87-a3c9-0d7d1d2bb7f4",
+		})
+		mockLink := new(netlink_mocks.Link)
+		mockNetLinkOps.On("LinkByName", "bbbbbbbbbbbbbbb").Return(mockLink, nil).Once()
+		mockNetLinkOps.On("LinkDelete", mockLink).Return(nil).Once()
+		// the conntrack entries of 10.244.0.5 belong to the running pod now
+		mockNetLinkOps.On("ConntrackDeleteFilter", mock.Anything, mock.Anything, mock.Anything).Return(uint(1), nil).Once()
+
+		response, err := s.handleCNIGC(gcRequest(map[string]interface{}{
+			"cniVersion": "1.1.0",
+			"name":       "ovn-kubernetes",
+			"type":       "ovn-k8s-cni-overlay",
+			validAttachmentsKey: []map[string]string{
+				{"containerID": validSandbox, "ifname": "eth0"},
+			},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).NotTo(BeNil())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		mockNetLinkOps.AssertExpectations(GinkgoT())
+		Expect(s.podInterfaces.get(staleSandbox, "eth0")).To(BeNil())
+	})
+
+	It("keeps the OVS ports of the other networks", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: ovsPodInterfacesJSON})
+		removed, err := gcPodInterfaces("red", sets.NewString(validSandbox, staleSandbox))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed.List()).To(BeEmpty())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("refuses a GC without valid attachments", func() {
+		_, err := s.handleCNIGC(gcRequest(map[string]interface{}{
+			"cniVersion": "1.1.0",
+			"name":       "ovn-kubernetes",
+			"type":       "ovn-k8s-cni-overlay",
+		}))
+		Expect(err).To(MatchError("missing " + validAttachmentsKey))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("reports the readiness of the node", func() {
+		_, err := s.handleCNIStatus()
+		Expect(err).NotTo(HaveOccurred())
+
+		ready := false
+		s.SetReadyFunc(func() (bool, error) { return ready, nil })
+		_, err = s.handleCNIStatus()
+		Expect(err).To(MatchError("gateway or management port not ready"))
+
+		ready = true
+		_, err = s.handleCNIStatus()
+		Expect(err).NotTo(HaveOccurred())
+
+		s.SetReadyFunc(func() (bool, error) { return false, fmt.Errorf("ovn-controller is not running") })
+		_, err = s.handleCNIStatus()
+		Expect(err).To(MatchError(ContainSubstring("ovn-controller is not running")))
+	})
+})
@@ -76,6 +76,7 @@ func NewCNIServer(useOVSExternalIDs bool, factory factory.NodeWatchFactory, kcli
 			KubeAPITokenFile: config.Kubernetes.TokenFile,
 		},
 		handlePodRequestFunc: HandlePodRequest,
+		podInterfaces:        newPodInterfaceCache(),
 	}
 
 	if len(config.Kubernetes.CAData) > 0 {
@@ -198,11 +199,19 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	if err := json.Unmarshal(b, &cr); err != nil {
 		return nil, err
 	}
+	// GC and STATUS are not about a pod
+	switch command(cr.Env["CNI_COMMAND"]) {
+	case CNIGC:
+		return s.handleCNIGC(&cr)
+	case CNIStatus:
+		return s.handleCNIStatus()
+	}
 	req, err := cniRequestToPodRequest(&cr)
 	if err != nil {
 		return nil, err
 	}
 	defer req.cancel()
+	req.podInterfaces = s.podInterfaces
 
 	useOVSExternalIDs := false
 	if atomic.LoadInt32(&s.useOVSExternalIDs) > 0 {
@@ -238,3 +247,22 @@ func (s *Server) EnableOVNPortUpSupport() {
 	atomic.StoreInt32(&s.useOVSExternalIDs, 1)
 	klog.Info("OVN Port Binding support now enabled in CNI Server")
 }
+
+// SetReadyFunc sets the function CNI STATUS reports the readiness of the node for new pods with. It
+// must be called before Start.
+func (s *Server) SetReadyFunc(readyFunc func() (bool, error)) {
+	s.readyFunc = readyFunc
+}
+
+func (s *Server) handleCNIStatus() ([]byte, error) {
+	if s.readyFunc != nil {
+		ready, err := s.readyFunc()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check the gateway and management port readiness: %v", err)
+		}
+		if !ready {
+			return nil, fmt.Errorf("gateway or management port not ready")
+		}
+	}
+	return (&Response{}).Marshal()
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +48,18 @@ func NewCNIPlugin(socketPath string) *Plugin {
 	return &Plugin{socketPath: socketPath}
 }
 
+// PluginMain runs the CNI command of the environment with the config read from stdin, and returns
+// the error the caller must print to stdout before exiting with a nonzero status
+func (p *Plugin) PluginMain(about string) *types.Error {
+	return skel.PluginMainFuncsWithError(skel.CNIFuncs{
+		Add:    p.CmdAdd,
+		Del:    p.CmdDel,
+		Check:  p.CmdCheck,
+		GC:     p.CmdGC,
+		Status: p.CmdStatus,
+	}, version.All, about)
+}
+
 // Create and fill a Request with this Plugin's environment and stdin which
 // contain the CNI variables and configuration
 func newCNIRequest(args *skel.CmdArgs) *Request {
@@ -312,9 +325,103 @@ func (p *Plugin) CmdDel(args *skel.CmdArgs) error {
 }
 
 // CmdCheck is the callback for 'checking' container's networking is as expected.
+// The CNI server checks the interface against the state cached by the ADD, which is cheap enough
+// for runtimes like CRIO that call CHECK right after ADD.
 func (p *Plugin) CmdCheck(args *skel.CmdArgs) error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
+	var err error
+	var body []byte
+	var pr *PodRequest
+	var conf *ovntypes.NetConf
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNICheck, err)
+		if err != nil {
+			klog.Errorf(err.Error())
+		}
+	}()
+
+	// read the config stdin args
+	conf, err = config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	req := newCNIRequest(args)
+	body, err = p.doCNI("http://dummy/", req)
+	if err != nil {
+		return err
+	}
+
+	response := &Response{}
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		err = fmt.Errorf("cmdCheck: failed to unmarshal response '%s': %v", string(body), err)
+		return err
+	}
+
+	// if PodIFInfo is set, then ovnkube-node is running in unprivileged mode so check the Interface from here.
+	if response.PodIFInfo != nil {
+		pr, err = cniRequestToPodRequest(req)
+		if err != nil {
+			err = fmt.Errorf("failed to create pod request: %v", err)
+			return err
+		}
+		defer pr.cancel()
+
+		if !response.PodIFInfo.IsDPUHostMode {
+			// Initialize OVS exec runner; find OVS binaries that the CNI code uses.
+			if err = SetExec(kexec.New()); err != nil {
+				err = fmt.Errorf("failed to initialize OVS exec runner: %v", err)
+				return err
+			}
+		}
+
+		_, err = pr.CheckInterface(response.PodIFInfo, 0)
+	}
+	return err
+}
+
+// cniErrPluginNotAvailable is the CNI 1.1 error code of a plugin that can't serve ADD requests
+const cniErrPluginNotAvailable uint = 50
+
+// CmdGC is the callback for 'gc' cni calls, removing the OVS ports of the sandboxes not in the valid
+// attachments given by the runtime, which were left behind when their DEL was lost
+func (p *Plugin) CmdGC(args *skel.CmdArgs) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNIGC, err)
+		if err != nil {
+			klog.Errorf(err.Error())
+		}
+	}()
+
+	// read the config stdin args
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	_, err = p.doCNI("http://dummy/", newCNIRequest(args))
+	return err
+}
+
+// CmdStatus is the callback for 'status' cni calls, reporting the plugin as not available until
+// ovnkube-node is running with its gateway and management port ready
+func (p *Plugin) CmdStatus(args *skel.CmdArgs) error {
+	// read the config stdin args
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	if _, err = p.doCNI("http://dummy/", newCNIRequest(args)); err != nil {
+		return &types.Error{Code: cniErrPluginNotAvailable, Msg: "ovnkube-node is not ready", Details: err.Error()}
+	}
 	return nil
 }
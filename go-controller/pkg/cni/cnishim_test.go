@@ -0,0 +1,164 @@
+//go:build linux
+// +build linux
+
+package cni
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
+	utiltesting "k8s.io/client-go/util/testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	util_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+// runPluginMain runs the shim entry point as the runtime does, with the CNI command in the
+// environment and the network config on stdin
+func runPluginMain(t *testing.T, p *Plugin, command string, netConf []byte) *cnitypes.Error {
+	t.Setenv("CNI_COMMAND", command)
+	t.Setenv("CNI_PATH", "/opt/cni/bin")
+
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatalf("failed to create stdin: %v", err)
+	}
+	defer stdin.Close()
+	if _, err := stdin.Write(netConf); err != nil {
+		t.Fatalf("failed to write stdin: %v", err)
+	}
+	if _, err := stdin.Seek(0, 0); err != nil {
+		t.Fatalf("failed to rewind stdin: %v", err)
+	}
+	origStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = origStdin }()
+
+	return p.PluginMain("")
+}
+
+// withValidAttachments returns the network config with the valid attachments the runtime adds to GC
+func withValidAttachments(t *testing.T, netConf []byte, sandboxIDs ...string) []byte {
+	conf := map[string]interface{}{}
+	if err := json.Unmarshal(netConf, &conf); err != nil {
+		t.Fatalf("failed to unmarshal the CNI config: %v", err)
+	}
+	attachments := []map[string]string{}
+	for _, sandboxID := range sandboxIDs {
+		attachments = append(attachments, map[string]string{"containerID": sandboxID, "ifname": "eth0"})
+	}
+	conf[validAttachmentsKey] = attachments
+	data, err := json.Marshal(conf)
+	if err != nil {
+		t.Fatalf("failed to marshal the CNI config: %v", err)
+	}
+	return data
+}
+
+func TestPluginMainGCAndStatus(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatalf("failed to prepare the test config: %v", err)
+	}
+	tmpDir, err := utiltesting.MkTmpdir("cnishim")
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// the runtime passes the config written for the node to the plugin
+	config.CNI.ConfDir = tmpDir
+	if err := config.WriteCNIConfig(); err != nil {
+		t.Fatalf("failed to write the CNI config: %v", err)
+	}
+	netConf, err := os.ReadFile(filepath.Join(tmpDir, config.CNIConfFileName))
+	if err != nil {
+		t.Fatalf("failed to read the CNI config: %v", err)
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, nodeName)
+	if err != nil {
+		t.Fatalf("failed to create watch factory: %v", err)
+	}
+	if err := wf.Start(); err != nil {
+		t.Fatalf("failed to start watch factory: %v", err)
+	}
+	defer wf.Shutdown()
+
+	s, err := NewCNIServer(false, wf, fakeClient)
+	if err != nil {
+		t.Fatalf("error creating CNI server: %v", err)
+	}
+	ready := false
+	s.SetReadyFunc(func() (bool, error) { return ready, nil })
+	if err := s.Start(tmpDir); err != nil {
+		t.Fatalf("error starting CNI server: %v", err)
+	}
+	p := NewCNIPlugin(filepath.Join(tmpDir, serverSocketName))
+
+	if err := runPluginMain(t, p, "STATUS", netConf); err == nil || err.Code != cniErrPluginNotAvailable {
+		t.Fatalf("expected STATUS to report the plugin as not available but got %v", err)
+	}
+	ready = true
+	if err := runPluginMain(t, p, "STATUS", netConf); err != nil {
+		t.Fatalf("expected STATUS to succeed but got %v", err)
+	}
+
+	fexec := ovntest.NewFakeExec()
+	if err := SetExec(fexec); err != nil {
+		t.Fatalf("failed to set exec: %v", err)
+	}
+	defer ResetRunner()
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+	defer util.ResetNetLinkOpMockInst()
+
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd: listOVSIfesCmd,
+		Output: fmt.Sprintf(`{"data":[`+
+			`["aaaaaaaaaaaaaaa",["map",[["iface-id","ns_pod1"],["sandbox","%s"]]]],`+
+			`["bbbbbbbbbbbbbbb",["map",[["iface-id","ns_pod2"],["sandbox","%s"]]]]`+
+			`],"headings":["name","external_ids"]}`, validSandbox, staleSandbox),
+	})
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		"ovs-vsctl --timeout=30 --if-exists del-port br-int bbbbbbbbbbbbbbb",
+		"ovs-vsctl --timeout=30 --no-heading --format=csv --data=bare --columns=name find interface external-ids:sandbox=" + staleSandbox,
+		"ovs-vsctl --timeout=30 --no-heading --format=csv --data=bare --columns=_uuid find qos external-ids:sandbox=" + staleSandbox,
+	})
+	mockLink := new(netlink_mocks.Link)
+	mockNetLinkOps.On("LinkByName", "bbbbbbbbbbbbbbb").Return(mockLink, nil).Once()
+	mockNetLinkOps.On("LinkDelete", mock.Anything).Return(nil).Once()
+
+	if err := runPluginMain(t, p, "GC", withValidAttachments(t, netConf, validSandbox)); err != nil {
+		t.Fatalf("expected GC to succeed but got %v", err)
+	}
+	if !fexec.CalledMatchesExpected() {
+		t.Fatalf("GC didn't remove the port of the stale sandbox: %s", fexec.ErrorDesc())
+	}
+	mockNetLinkOps.AssertExpectations(t)
+
+	// the runtimes don't send GC and STATUS to the configs of the versions before 1.1.0, and the
+	// plugin refuses them
+	oldConf := map[string]interface{}{}
+	if err := json.Unmarshal(netConf, &oldConf); err != nil {
+		t.Fatalf("failed to unmarshal the CNI config: %v", err)
+	}
+	oldConf["cniVersion"] = "0.4.0"
+	oldNetConf, err := json.Marshal(oldConf)
+	if err != nil {
+		t.Fatalf("failed to marshal the CNI config: %v", err)
+	}
+	if err := runPluginMain(t, p, "STATUS", oldNetConf); err == nil || err.Code != cnitypes.ErrIncompatibleCNIVersion {
+		t.Fatalf("expected STATUS to be refused for a 0.4.0 config but got %v", err)
+	}
+}
@@ -525,6 +525,143 @@ func (pr *PodRequest) ConfigureInterface(getter PodInfoGetter, ifInfo *PodInterf
 	return []*current.Interface{hostIface, contIface}, nil
 }
 
+// CheckInterface verifies that the pod interface configured by ConfigureInterface is still in
+// place: the container interface with its MAC, addresses and routes, the host interface and its
+// OVS interface with the iface-id of the pod. ofPort is the OpenFlow port of the OVS interface on
+// a previous check, 0 if unknown, and the current one is returned. Nothing is retried so that the
+// check completes in milliseconds.
+func (pr *PodRequest) CheckInterface(ifInfo *PodInterfaceInfo, ofPort int) (int, error) {
+	netns, err := ns.GetNS(pr.Netns)
+	if err != nil {
+		return ofPort, fmt.Errorf("failed to open netns %q: %v", pr.Netns, err)
+	}
+	defer netns.Close()
+
+	// the host-side interface name of secondary networks is postfixed with the container interface index
+	ifnameSuffix := ""
+	isSecondary := pr.netName != types.DefaultNetworkName
+	err = netns.Do(func(_ ns.NetNS) error {
+		link, err := util.GetNetLinkOps().LinkByName(pr.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to find container interface %s: %v", pr.IfName, err)
+		}
+		if isSecondary && !ifInfo.IsDPUHostMode {
+			ifnameSuffix = fmt.Sprintf("_%d", link.Attrs().Index)
+		}
+		return checkContainerInterface(link, ifInfo)
+	})
+	if err != nil {
+		return ofPort, err
+	}
+
+	if ifInfo.IsDPUHostMode {
+		// the OVS interface is on the DPU
+		return ofPort, nil
+	}
+	hostIfName := pr.SandboxID[:(15-len(ifnameSuffix))] + ifnameSuffix
+	if pr.CNIConf.DeviceID == "" {
+		if _, err := util.GetNetLinkOps().LinkByName(hostIfName); err != nil {
+			return ofPort, fmt.Errorf("failed to find host interface %s: %v", hostIfName, err)
+		}
+	}
+	ifaceID := util.GetIfaceId(pr.PodNamespace, pr.PodName)
+	if isSecondary {
+		ifaceID = util.GetSecondaryNetworkIfaceId(pr.PodNamespace, pr.PodName, pr.nadName)
+	}
+	return checkOVSInterface(hostIfName, ifaceID, ofPort)
+}
+
+// checkContainerInterface verifies that the container interface is up with the MAC, the
+// addresses and the routes of the pod annotation
+func checkContainerInterface(link netlink.Link, ifInfo *PodInterfaceInfo) error {
+	name := link.Attrs().Name
+	if link.Attrs().Flags&net.FlagUp == 0 {
+		return fmt.Errorf("container interface %s is down", name)
+	}
+	if ifInfo.MAC != nil && link.Attrs().HardwareAddr.String() != ifInfo.MAC.String() {
+		return fmt.Errorf("container interface %s has MAC %s, expected %s", name, link.Attrs().HardwareAddr, ifInfo.MAC)
+	}
+
+	if len(ifInfo.IPs) > 0 {
+		addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return fmt.Errorf("failed to list the addresses of container interface %s: %v", name, err)
+		}
+		for _, ip := range ifInfo.IPs {
+			found := false
+			for _, addr := range addrs {
+				if addr.IPNet != nil && addr.IPNet.String() == ip.String() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("container interface %s is missing address %s", name, ip)
+			}
+		}
+	}
+
+	if len(ifInfo.Gateways) == 0 && len(ifInfo.Routes) == 0 {
+		return nil
+	}
+	routes, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list the routes of container interface %s: %v", name, err)
+	}
+	hasRoute := func(dst *net.IPNet, gw net.IP) bool {
+		for _, route := range routes {
+			if !route.Gw.Equal(gw) {
+				continue
+			}
+			if dst == nil {
+				if route.Dst == nil {
+					return true
+				}
+				if ones, _ := route.Dst.Mask.Size(); ones == 0 {
+					return true
+				}
+			} else if route.Dst != nil && route.Dst.String() == dst.String() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, gw := range ifInfo.Gateways {
+		if !hasRoute(nil, gw) {
+			return fmt.Errorf("container interface %s is missing the default route via %s", name, gw)
+		}
+	}
+	for _, route := range ifInfo.Routes {
+		if !hasRoute(route.Dest, route.NextHop) {
+			return fmt.Errorf("container interface %s is missing the route to %s via %s", name, route.Dest, route.NextHop)
+		}
+	}
+	return nil
+}
+
+// checkOVSInterface verifies that the OVS interface of the pod exists with its iface-id and is
+// attached to the same OpenFlow port as on the previous check, if any, and returns the port
+func checkOVSInterface(ifName, ifaceID string, ofPort int) (int, error) {
+	output, err := ovsGetMultiOutput("Interface", ifName, []string{"external-ids:iface-id", "ofport"})
+	if err != nil {
+		return ofPort, fmt.Errorf("failed to get OVS interface %s: %v", ifName, err)
+	}
+	if len(output) != 2 {
+		return ofPort, fmt.Errorf("OVS interface %s not found", ifName)
+	}
+	if output[0] != ifaceID {
+		return ofPort, fmt.Errorf("OVS interface %s has iface-id %q, expected %q", ifName, output[0], ifaceID)
+	}
+	currentOFPort, err := strconv.Atoi(output[1])
+	if err != nil || currentOFPort <= 0 {
+		return ofPort, fmt.Errorf("OVS interface %s has no OpenFlow port (ofport %q)", ifName, output[1])
+	}
+	if ofPort > 0 && currentOFPort != ofPort {
+		return ofPort, fmt.Errorf("OVS interface %s OpenFlow port changed from %d to %d", ifName, ofPort, currentOFPort)
+	}
+	return currentOFPort, nil
+}
+
 func (pr *PodRequest) UnconfigureInterface(ifInfo *PodInterfaceInfo) error {
 	podDesc := fmt.Sprintf("for pod %s/%s NAD %s", pr.PodNamespace, pr.PodName, pr.nadName)
 	klog.V(5).Infof("Tear down interface (%+v) %s", *pr, podDesc)
@@ -337,6 +337,116 @@ func TestSetupNetwork(t *testing.T) {
 	}
 }
 
+func TestCheckContainerInterface(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+
+	podIfaceInfo := &PodInterfaceInfo{
+		PodAnnotation: util.PodAnnotation{
+			IPs:      ovntest.MustParseIPNets("192.168.0.5/24"),
+			MAC:      ovntest.MustParseMAC("0A:58:FD:98:00:01"),
+			Gateways: ovntest.MustParseIPs("192.168.0.1"),
+			Routes: []util.PodRoute{
+				{
+					Dest:    ovntest.MustParseIPNet("192.168.1.0/24"),
+					NextHop: net.ParseIP("192.168.1.1"),
+				},
+			},
+		},
+	}
+	upLink := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{
+		Name:         "eth0",
+		Flags:        net.FlagUp,
+		HardwareAddr: ovntest.MustParseMAC("0A:58:FD:98:00:01"),
+	}}
+	addrs := []netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.0.5/24")}}
+	defaultRoute := netlink.Route{Gw: net.ParseIP("192.168.0.1")}
+	podRoute := netlink.Route{Dst: ovntest.MustParseIPNet("192.168.1.0/24"), Gw: net.ParseIP("192.168.1.1")}
+
+	tests := []struct {
+		desc                 string
+		inpLink              netlink.Link
+		errMatch             error
+		netLinkOpsMockHelper []ovntest.TestifyMockHelper
+	}{
+		{
+			desc: "test code path when the container interface is down",
+			inpLink: &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{
+				Name:         "eth0",
+				HardwareAddr: ovntest.MustParseMAC("0A:58:FD:98:00:01"),
+			}},
+			errMatch: fmt.Errorf("container interface eth0 is down"),
+		},
+		{
+			desc: "test code path when the container interface MAC changed",
+			inpLink: &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{
+				Name:         "eth0",
+				Flags:        net.FlagUp,
+				HardwareAddr: ovntest.MustParseMAC("0A:58:FD:98:00:02"),
+			}},
+			errMatch: fmt.Errorf("container interface eth0 has MAC 0a:58:fd:98:00:02, expected 0a:58:fd:98:00:01"),
+		},
+		{
+			desc:     "test code path when the container interface is missing its address",
+			inpLink:  upLink,
+			errMatch: fmt.Errorf("container interface eth0 is missing address 192.168.0.5/24"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.0.6/24")}}, nil}},
+			},
+		},
+		{
+			desc:     "test code path when the container interface is missing its default route",
+			inpLink:  upLink,
+			errMatch: fmt.Errorf("container interface eth0 is missing the default route via 192.168.0.1"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Route{podRoute}, nil}},
+			},
+		},
+		{
+			desc:     "test code path when the container interface is missing a pod route",
+			inpLink:  upLink,
+			errMatch: fmt.Errorf("container interface eth0 is missing the route to 192.168.1.0/24 via 192.168.1.1"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Route{defaultRoute}, nil}},
+			},
+		},
+		{
+			desc:     "test code path when RouteList returns error",
+			inpLink:  upLink,
+			errMatch: fmt.Errorf("failed to list the routes of container interface eth0"),
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			},
+		},
+		{
+			desc:    "test success path when the container interface matches the pod annotation",
+			inpLink: upLink,
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{addrs, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Route{podRoute, defaultRoute}, nil}},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkOpsMockHelper)
+
+			err := checkContainerInterface(tc.inpLink, podIfaceInfo)
+			t.Log(err)
+			if tc.errMatch != nil {
+				assert.Contains(t, err.Error(), tc.errMatch.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSetupInterface(t *testing.T) {
 	mockNetLinkOps := new(util_mocks.NetLinkOps)
 	mockCNIPlugin := new(mocks.CNIPluginLibOps)
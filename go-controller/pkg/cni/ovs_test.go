@@ -87,4 +87,41 @@ d9af11aa-37c3-4ea9-8ba3-a74843cc0f47
 		Expect(err).NotTo(HaveOccurred())
 		Expect(ifaceID).To(Equal(`1234`))
 	})
+
+	Context("checkOVSInterface", func() {
+		const getInterfaceCmd = "ovs-vsctl --timeout=30 --if-exists get Interface 824bceff24af3 external-ids:iface-id ofport"
+
+		It("returns the OpenFlow port of the OVS interface of the pod", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getInterfaceCmd, Output: "\"foo-ns_bar-pod\"\n5\n"})
+			ofPort, err := checkOVSInterface("824bceff24af3", "foo-ns_bar-pod", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ofPort).To(Equal(5))
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		})
+
+		It("fails if the OVS interface does not exist", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getInterfaceCmd, Output: ""})
+			_, err := checkOVSInterface("824bceff24af3", "foo-ns_bar-pod", 0)
+			Expect(err).To(MatchError("OVS interface 824bceff24af3 not found"))
+		})
+
+		It("fails if the OVS interface belongs to another pod", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getInterfaceCmd, Output: "\"foo-ns_other-pod\"\n5\n"})
+			_, err := checkOVSInterface("824bceff24af3", "foo-ns_bar-pod", 0)
+			Expect(err).To(MatchError(`OVS interface 824bceff24af3 has iface-id "foo-ns_other-pod", expected "foo-ns_bar-pod"`))
+		})
+
+		It("fails if the OVS interface has no OpenFlow port", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getInterfaceCmd, Output: "\"foo-ns_bar-pod\"\n-1\n"})
+			_, err := checkOVSInterface("824bceff24af3", "foo-ns_bar-pod", 0)
+			Expect(err).To(MatchError(`OVS interface 824bceff24af3 has no OpenFlow port (ofport "-1")`))
+		})
+
+		It("fails if the OpenFlow port of the OVS interface changed", func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getInterfaceCmd, Output: "\"foo-ns_bar-pod\"\n6\n"})
+			ofPort, err := checkOVSInterface("824bceff24af3", "foo-ns_bar-pod", 5)
+			Expect(err).To(MatchError("OVS interface 824bceff24af3 OpenFlow port changed from 5 to 6"))
+			Expect(ofPort).To(Equal(5))
+		})
+	})
 })
@@ -0,0 +1,321 @@
+package cni
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+	ref "k8s.io/client-go/tools/reference"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// podInterfaceCheckInterval is how often the interfaces of the running pods are checked
+	podInterfaceCheckInterval = time.Minute
+	// podInterfaceBrokenReason is the reason of the events posted on the pods whose interface is broken
+	podInterfaceBrokenReason = "PodInterfaceBroken"
+)
+
+// podInterface is a pod interface configured by CNI ADD. It holds what CNI CHECK needs to verify the
+// interface without querying the apiserver: request is a copy of the ADD request and ofPort is the
+// OpenFlow port the OVS interface had on the last successful check, 0 until then.
+type podInterface struct {
+	request *PodRequest
+	ifInfo  *PodInterfaceInfo
+	ofPort  int
+	// ovsIfName and ifaceID are set for the interfaces seeded from OVS at startup, whose request
+	// only has the pod and the network since the container side of the interface is unknown until
+	// the next CNI CHECK or ADD of the sandbox
+	ovsIfName string
+	ifaceID   string
+	// lastErr is the failure of the last periodic check, so that an event is only posted when the
+	// failure changes
+	lastErr string
+}
+
+// podInterfaceCache holds the pod interfaces configured by the CNI server, by sandbox and
+// interface name
+type podInterfaceCache struct {
+	sync.Mutex
+	interfaces map[string]*podInterface
+}
+
+func newPodInterfaceCache() *podInterfaceCache {
+	return &podInterfaceCache{interfaces: map[string]*podInterface{}}
+}
+
+func podInterfaceKey(sandboxID, ifName string) string {
+	return sandboxID + "/" + ifName
+}
+
+// key returns the key of the interface in the cache, the seeded interfaces are keyed by their OVS
+// interface name instead of their unknown container interface name
+func (pi *podInterface) key() string {
+	if pi.ovsIfName != "" {
+		return podInterfaceKey(pi.request.SandboxID, pi.ovsIfName)
+	}
+	return podInterfaceKey(pi.request.SandboxID, pi.request.IfName)
+}
+
+// name describes the interface in the logs and events
+func (pi *podInterface) name() string {
+	if pi.ovsIfName != "" {
+		return "OVS interface " + pi.ovsIfName
+	}
+	return "interface " + pi.request.IfName
+}
+
+// check verifies the pod interface like CNI CHECK does, only the OVS interface is verified for the
+// seeded interfaces
+func (pi *podInterface) check() (int, error) {
+	if pi.ovsIfName != "" {
+		return checkOVSInterface(pi.ovsIfName, pi.ifaceID, pi.ofPort)
+	}
+	return pi.request.CheckInterface(pi.ifInfo, pi.ofPort)
+}
+
+// add caches the interface configured for the given request. Only the latest sandbox of a pod has
+// its OVS port bound (see ConfigureOVS), so the interfaces of the previous sandboxes of the pod on
+// the same network attachment are forgotten, along with the interface seeded for the sandbox.
+func (c *podInterfaceCache) add(pr *PodRequest, ifInfo *PodInterfaceInfo, ofPort int) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	for key, pi := range c.interfaces {
+		if pi.request.PodNamespace == pr.PodNamespace && pi.request.PodName == pr.PodName &&
+			pi.request.nadName == pr.nadName && (pi.request.SandboxID != pr.SandboxID || pi.ovsIfName != "") {
+			delete(c.interfaces, key)
+		}
+	}
+	c.interfaces[podInterfaceKey(pr.SandboxID, pr.IfName)] = &podInterface{
+		request: &PodRequest{
+			Command:      CNICheck,
+			PodNamespace: pr.PodNamespace,
+			PodName:      pr.PodName,
+			PodUID:       pr.PodUID,
+			SandboxID:    pr.SandboxID,
+			Netns:        pr.Netns,
+			IfName:       pr.IfName,
+			CNIConf:      pr.CNIConf,
+			netName:      pr.netName,
+			nadName:      pr.nadName,
+		},
+		ifInfo: ifInfo,
+		ofPort: ofPort,
+	}
+}
+
+func (c *podInterfaceCache) get(sandboxID, ifName string) *podInterface {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	pi, ok := c.interfaces[podInterfaceKey(sandboxID, ifName)]
+	if !ok {
+		return nil
+	}
+	copied := *pi
+	return &copied
+}
+
+func (c *podInterfaceCache) delete(sandboxID, ifName string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	delete(c.interfaces, podInterfaceKey(sandboxID, ifName))
+}
+
+// deleteSandbox forgets all the interfaces of the given sandbox
+func (c *podInterfaceCache) deleteSandbox(sandboxID string) {
+	c.Lock()
+	defer c.Unlock()
+	for key, pi := range c.interfaces {
+		if pi.request.SandboxID == sandboxID {
+			delete(c.interfaces, key)
+		}
+	}
+}
+
+// update records the result of a check of the pod interface, unless it was removed or replaced
+// in the meantime
+func (c *podInterfaceCache) update(pi *podInterface, ofPort int, lastErr string) {
+	c.Lock()
+	defer c.Unlock()
+	cached, ok := c.interfaces[pi.key()]
+	if !ok || cached.ifInfo != pi.ifInfo {
+		return
+	}
+	cached.ofPort = ofPort
+	cached.lastErr = lastErr
+}
+
+// seed caches the pod interfaces configured before the server started, from the OVS interfaces
+// with the iface-id and sandbox external IDs set by ConfigureOVS for the local pods. The
+// interfaces cached since the server started are kept.
+func (c *podInterfaceCache) seed(podLister corev1listers.PodLister) error {
+	pods, err := podLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list the local pods: %v", err)
+	}
+	interfaces, err := listOVSPodInterfaces()
+	if err != nil {
+		return fmt.Errorf("failed to list the OVS interfaces: %v", err)
+	}
+	podsByIfaceID := make(map[string]*kapi.Pod, len(pods))
+	for _, pod := range pods {
+		podsByIfaceID[util.GetIfaceId(pod.Namespace, pod.Name)] = pod
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	cachedSandboxes := sets.NewString()
+	for _, pi := range c.interfaces {
+		cachedSandboxes.Insert(pi.request.SandboxID + "/" + pi.request.nadName)
+	}
+	for _, iface := range interfaces {
+		ifaceID := iface.ifaceID
+		if iface.netName != types.DefaultNetworkName {
+			prefix := util.GetSecondaryNetworkPrefix(iface.nadName)
+			if !strings.HasPrefix(ifaceID, prefix) {
+				continue
+			}
+			ifaceID = strings.TrimPrefix(ifaceID, prefix)
+		}
+		pod, ok := podsByIfaceID[ifaceID]
+		if !ok || string(pod.UID) != iface.podUID || cachedSandboxes.Has(iface.sandboxID+"/"+iface.nadName) {
+			continue
+		}
+		pi := &podInterface{
+			request: &PodRequest{
+				Command:      CNICheck,
+				PodNamespace: pod.Namespace,
+				PodName:      pod.Name,
+				PodUID:       string(pod.UID),
+				SandboxID:    iface.sandboxID,
+				netName:      iface.netName,
+				nadName:      iface.nadName,
+			},
+			ovsIfName: iface.name,
+			ifaceID:   iface.ifaceID,
+		}
+		c.interfaces[pi.key()] = pi
+	}
+	return nil
+}
+
+func (c *podInterfaceCache) list() []*podInterface {
+	c.Lock()
+	defer c.Unlock()
+	interfaces := make([]*podInterface, 0, len(c.interfaces))
+	for _, pi := range c.interfaces {
+		copied := *pi
+		interfaces = append(interfaces, &copied)
+	}
+	return interfaces
+}
+
+// cmdCheck verifies the pod interface configured by CNI ADD against the state cached by the ADD.
+// When ovnkube-node was restarted since the ADD, the state is rebuilt from the pod annotation. In
+// unprivileged mode the interface is checked by the CNI shim with the returned PodIFInfo.
+func (pr *PodRequest) cmdCheck(clientset *ClientSet, useOVSExternalIDs bool) (*Response, error) {
+	namespace := pr.PodNamespace
+	podName := pr.PodName
+	if namespace == "" || podName == "" {
+		return nil, fmt.Errorf("required CNI variable missing")
+	}
+
+	var ifInfo *PodInterfaceInfo
+	var ofPort int
+	if pi := pr.podInterfaces.get(pr.SandboxID, pr.IfName); pi != nil {
+		ifInfo = pi.ifInfo
+		ofPort = pi.ofPort
+	} else {
+		pod, err := clientset.getPod(namespace, podName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod: %v", err)
+		}
+		if err = pr.checkOrUpdatePodUID(pod); err != nil {
+			return nil, err
+		}
+		ifInfo, err = PodAnnotation2PodInfo(pod.Annotations, nil, useOVSExternalIDs, pr.PodUID, "",
+			pr.nadName, pr.netName, pr.CNIConf.MTU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod annotation: %v", err)
+		}
+	}
+
+	if config.UnprivilegedMode {
+		return &Response{PodIFInfo: ifInfo}, nil
+	}
+	ofPort, err := pr.CheckInterface(ifInfo, ofPort)
+	if err != nil {
+		return nil, err
+	}
+	pr.podInterfaces.add(pr, ifInfo, ofPort)
+	return &Response{}, nil
+}
+
+// StartPodInterfaceChecker periodically checks the interfaces of the running pods configured by the
+// server, like CNI CHECK does, and posts a warning event on the pods whose interface is broken
+// until stopChan is closed. The interfaces configured before the server started are seeded from OVS.
+func (s *Server) StartPodInterfaceChecker(recorder record.EventRecorder, stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	if err := s.podInterfaces.seed(s.clientSet.podLister); err != nil {
+		klog.Warningf("Failed to seed the pod interfaces configured before the CNI server started, "+
+			"they are only checked on CNI CHECK: %v", err)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			s.checkPodInterfaces(recorder)
+		}, podInterfaceCheckInterval, stopChan)
+	}()
+}
+
+func (s *Server) checkPodInterfaces(recorder record.EventRecorder) {
+	for _, pi := range s.podInterfaces.list() {
+		pr := pi.request
+		pod, err := s.clientSet.podLister.Pods(pr.PodNamespace).Get(pr.PodName)
+		if err != nil || string(pod.UID) != pr.PodUID || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == kapi.PodSucceeded || pod.Status.Phase == kapi.PodFailed {
+			// the sandbox is going away, CNI DEL forgets it
+			continue
+		}
+		ofPort, err := pi.check()
+		if err == nil {
+			if pi.lastErr != "" {
+				klog.Infof("%s %s of NAD %s is not broken anymore", pr, pi.name(), pr.nadName)
+			}
+			s.podInterfaces.update(pi, ofPort, "")
+			continue
+		}
+		msg := fmt.Sprintf("%s of sandbox %s for NAD %s is broken: %v", pi.name(), pr.SandboxID, pr.nadName, err)
+		if msg != pi.lastErr {
+			klog.Warningf("%s %s", pr, msg)
+			podRef, refErr := ref.GetReference(scheme.Scheme, pod)
+			if refErr != nil {
+				klog.Errorf("Couldn't get a reference to pod %s/%s to post an event: %v", pod.Namespace, pod.Name, refErr)
+			} else {
+				recorder.Event(podRef, kapi.EventTypeWarning, podInterfaceBrokenReason, msg)
+			}
+		}
+		s.podInterfaces.update(pi, pi.ofPort, msg)
+	}
+}
@@ -0,0 +1,220 @@
+package cni
+
+import (
+	"fmt"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Pod interface check tests", func() {
+	var pr *PodRequest
+	var ifInfo *PodInterfaceInfo
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		pr = &PodRequest{
+			Command:      CNIAdd,
+			PodNamespace: "foo-ns",
+			PodName:      "bar-pod",
+			PodUID:       "2e4b6fc5-7b5a-4c4b-9e3f-7e5d4a3c2b1a",
+			SandboxID:    "824bceff24af3",
+			Netns:        "/var/run/netns/does-not-exist",
+			IfName:       "eth0",
+			CNIConf:      &types.NetConf{NetConf: cnitypes.NetConf{}},
+			netName:      ovntypes.DefaultNetworkName,
+			nadName:      ovntypes.DefaultNetworkName,
+		}
+		ifInfo = &PodInterfaceInfo{}
+	})
+
+	Context("podInterfaceCache", func() {
+		It("forgets the previous sandboxes of a pod on the same network attachment", func() {
+			c := newPodInterfaceCache()
+			c.add(pr, ifInfo, 0)
+
+			secondary := *pr
+			secondary.IfName = "net1"
+			secondary.nadName = "foo-ns/blue"
+			secondary.netName = "blue"
+			c.add(&secondary, ifInfo, 0)
+
+			newSandbox := *pr
+			newSandbox.SandboxID = "93b2cd1e87fa0"
+			c.add(&newSandbox, ifInfo, 3)
+
+			Expect(c.get(pr.SandboxID, pr.IfName)).To(BeNil())
+			Expect(c.get(secondary.SandboxID, secondary.IfName)).NotTo(BeNil())
+			pi := c.get(newSandbox.SandboxID, newSandbox.IfName)
+			Expect(pi).NotTo(BeNil())
+			Expect(pi.ofPort).To(Equal(3))
+			Expect(pi.request.Command).To(Equal(CNICheck))
+
+			c.delete(newSandbox.SandboxID, newSandbox.IfName)
+			Expect(c.get(newSandbox.SandboxID, newSandbox.IfName)).To(BeNil())
+			Expect(c.list()).To(HaveLen(1))
+		})
+
+		It("seeds the interfaces of the local pods from OVS", func() {
+			fexec := ovntest.NewFakeExec()
+			Expect(SetExec(fexec)).To(Succeed())
+			// the default network and blue interfaces of the pod, the interface of a previous
+			// incarnation of the pod and the interface of a pod of another node
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: fmt.Sprintf(`{"data":[`+
+				`["824bceff24af3",["map",[["iface-id","foo-ns_bar-pod"],["iface-id-ver","%[1]s"],["sandbox","824bceff24af3"]]]],`+
+				`["824bceff24af_5",["map",[["iface-id","foo.ns.blue_foo-ns_bar-pod"],["iface-id-ver","%[1]s"],`+
+				`["%[2]s","blue"],["%[3]s","foo-ns/blue"],["sandbox","824bceff24af3"]]]],`+
+				`["93b2cd1e87fa0",["map",[["iface-id","foo-ns_bar-pod"],["iface-id-ver","5a1f0b3c"],["sandbox","93b2cd1e87fa0"]]]],`+
+				`["a7c3d2e1f0b9a",["map",[["iface-id","foo-ns_other-pod"],["iface-id-ver","6b2e"],["sandbox","a7c3d2e1f0b9a"]]]]`+
+				`],"headings":["name","external_ids"]}`, pr.PodUID, ovntypes.NetworkExternalID, ovntypes.NADExternalID)})
+			podLister := v1mocks.PodLister{}
+			podLister.On("List", labels.Everything()).Return([]*v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: pr.PodName, Namespace: pr.PodNamespace, UID: k8stypes.UID(pr.PodUID)},
+			}}, nil)
+
+			c := newPodInterfaceCache()
+			Expect(c.seed(&podLister)).To(Succeed())
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+			Expect(c.list()).To(HaveLen(2))
+			pi := c.get(pr.SandboxID, "824bceff24af3")
+			Expect(pi).NotTo(BeNil())
+			Expect(pi.ifaceID).To(Equal("foo-ns_bar-pod"))
+			Expect(pi.request.nadName).To(Equal(ovntypes.DefaultNetworkName))
+			pi = c.get(pr.SandboxID, "824bceff24af_5")
+			Expect(pi).NotTo(BeNil())
+			Expect(pi.ifaceID).To(Equal("foo.ns.blue_foo-ns_bar-pod"))
+			Expect(pi.request.nadName).To(Equal("foo-ns/blue"))
+
+			// the seeded interface is checked on its OVS interface only
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface 824bceff24af3 external-ids:iface-id ofport",
+				Output: "\"foo-ns_bar-pod\"\n5\n",
+			})
+			ofPort, err := c.get(pr.SandboxID, "824bceff24af3").check()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ofPort).To(Equal(5))
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+			// the interface configured by the next CNI CHECK or ADD of the sandbox replaces it
+			c.add(pr, ifInfo, 5)
+			Expect(c.get(pr.SandboxID, "824bceff24af3")).To(BeNil())
+			Expect(c.get(pr.SandboxID, pr.IfName)).NotTo(BeNil())
+			Expect(c.list()).To(HaveLen(2))
+
+			// the interfaces cached since the server started are not seeded again
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: `{"data":[` +
+				`["824bceff24af3",["map",[["iface-id","foo-ns_bar-pod"],["iface-id-ver","` + pr.PodUID + `"],["sandbox","824bceff24af3"]]]]` +
+				`],"headings":["name","external_ids"]}`})
+			Expect(c.seed(&podLister)).To(Succeed())
+			Expect(c.get(pr.SandboxID, "824bceff24af3")).To(BeNil())
+		})
+
+		It("is a no-op when nil", func() {
+			var c *podInterfaceCache
+			c.add(pr, ifInfo, 0)
+			c.delete(pr.SandboxID, pr.IfName)
+			Expect(c.get(pr.SandboxID, pr.IfName)).To(BeNil())
+		})
+	})
+
+	Context("cmdCheck", func() {
+		It("returns the cached interface to the CNI shim without querying the apiserver in unprivileged mode", func() {
+			config.UnprivilegedMode = true
+			defer func() { config.UnprivilegedMode = false }()
+			pr.podInterfaces = newPodInterfaceCache()
+			pr.podInterfaces.add(pr, ifInfo, 0)
+			pr.Command = CNICheck
+
+			// a nil clientset would panic if the apiserver was queried
+			response, err := pr.cmdCheck(nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.PodIFInfo).To(BeIdenticalTo(ifInfo))
+		})
+
+		It("fails if the pod interface can't be verified", func() {
+			pr.podInterfaces = newPodInterfaceCache()
+			pr.podInterfaces.add(pr, ifInfo, 0)
+			pr.Command = CNICheck
+
+			_, err := pr.cmdCheck(nil, false)
+			Expect(err).To(MatchError(ContainSubstring("failed to open netns")))
+		})
+	})
+
+	Context("checkPodInterfaces", func() {
+		var podLister v1mocks.PodLister
+		var podNamespaceLister v1mocks.PodNamespaceLister
+		var pod *v1.Pod
+		var s *Server
+
+		BeforeEach(func() {
+			pod = &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pr.PodName,
+					Namespace: pr.PodNamespace,
+					UID:       "2e4b6fc5-7b5a-4c4b-9e3f-7e5d4a3c2b1a",
+				},
+				Status: v1.PodStatus{Phase: v1.PodRunning},
+			}
+			podLister = v1mocks.PodLister{}
+			podNamespaceLister = v1mocks.PodNamespaceLister{}
+			podLister.On("Pods", pr.PodNamespace).Return(&podNamespaceLister)
+			s = &Server{
+				clientSet:     NewClientSet(nil, &podLister),
+				podInterfaces: newPodInterfaceCache(),
+			}
+			s.podInterfaces.add(pr, ifInfo, 0)
+		})
+
+		It("posts a warning event on the pods whose interface is broken once", func() {
+			podNamespaceLister.On("Get", pr.PodName).Return(pod, nil)
+			recorder := record.NewFakeRecorder(10)
+
+			s.checkPodInterfaces(recorder)
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(And(
+				HavePrefix("Warning "+podInterfaceBrokenReason),
+				ContainSubstring("interface eth0 of sandbox 824bceff24af3"),
+				ContainSubstring("failed to open netns"),
+			))
+
+			// the failure didn't change
+			s.checkPodInterfaces(recorder)
+			Expect(recorder.Events).To(BeEmpty())
+			Expect(s.podInterfaces.get(pr.SandboxID, pr.IfName).lastErr).To(ContainSubstring("failed to open netns"))
+		})
+
+		It("skips the pods that are going away or were recreated", func() {
+			now := metav1.Now()
+			recreated := pod.DeepCopy()
+			recreated.UID = "5a1f0b3c-2d4e-4f6a-8b7c-9d0e1f2a3b4c"
+			deleting := pod.DeepCopy()
+			deleting.DeletionTimestamp = &now
+			completed := pod.DeepCopy()
+			completed.Status.Phase = v1.PodSucceeded
+			recorder := record.NewFakeRecorder(10)
+
+			for _, p := range []*v1.Pod{recreated, deleting, completed} {
+				podNamespaceLister = v1mocks.PodNamespaceLister{}
+				podNamespaceLister.On("Get", pr.PodName).Return(p, nil)
+				podLister = v1mocks.PodLister{}
+				podLister.On("Pods", pr.PodNamespace).Return(&podNamespaceLister)
+				s.clientSet = NewClientSet(nil, &podLister)
+
+				s.checkPodInterfaces(recorder)
+				Expect(recorder.Events).To(BeEmpty())
+			}
+		})
+	})
+})
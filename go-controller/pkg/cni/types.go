@@ -73,6 +73,13 @@ const CNIDel command = "DEL"
 // CNICheck is the command representing check operation on a pod
 const CNICheck command = "CHECK"
 
+// CNIGC is the command representing the removal of the leftovers of the pods the runtime does not
+// know anymore
+const CNIGC command = "GC"
+
+// CNIStatus is the command representing the readiness check of the plugin
+const CNIStatus command = "STATUS"
+
 // Request sent to the Server by the OVN CNI plugin
 type Request struct {
 	// CNI environment variables, like CNI_COMMAND and CNI_NETNS
@@ -157,6 +164,9 @@ type PodRequest struct {
 	// also, need to find the pod annotation, dpu pod connection/status annotations of the given NAD ("default"
 	// for default network).
 	nadName string
+
+	// podInterfaces caches the pod interfaces configured by the CNI server for CNI CHECK, nil in the CNI shim
+	podInterfaces *podInterfaceCache
 }
 
 type podRequestFunc func(request *PodRequest, clientset *ClientSet, useOVSExternalIDs bool, kubeAuth *KubeAPIAuth) ([]byte, error)
@@ -186,4 +196,7 @@ type Server struct {
 	useOVSExternalIDs    int32
 	clientSet            *ClientSet
 	kubeAuth             *KubeAPIAuth
+	podInterfaces        *podInterfaceCache
+	// readyFunc reports whether the node is ready for new pods, for CNI STATUS
+	readyFunc func() (bool, error)
 }
@@ -26,6 +26,10 @@ type NetConf struct {
 	ExcludeSubnets string `json:"excludeSubnets,omitempty"`
 	// VLANID, valid in localnet topology network only
 	VLANID int `json:"vlanID,omitempty"`
+	// EnableMulticast enables IGMP/MLD snooping and multicast between the pods
+	// of the same namespace attached to the network, valid for layer3 and
+	// layer2 network topology only
+	EnableMulticast bool `json:"enableMulticast,omitempty"`
 
 	// PciAddrs in case of using sriov
 	DeviceID string `json:"deviceID,omitempty"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -116,6 +117,10 @@ func PodAnnotation2PodInfo(podAnnotation map[string]string, podNADAnnotation *ut
 	if err != nil && !errors.Is(err, BandwidthNotFound) {
 		return nil, err
 	}
+	if netName == types.DefaultNetworkName && atomic.LoadInt32(&ovnPodQoSEnabled) > 0 {
+		// the bandwidth is limited by the QoS rules of the pod's logical switch port instead
+		ingress, egress = 0, 0
+	}
 
 	podInterfaceInfo := &PodInterfaceInfo{
 		PodAnnotation:        *podNADAnnotation,
@@ -3,6 +3,7 @@ package cni
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -291,5 +293,47 @@ var _ = Describe("CNI Utils tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(pif.EnableUDPAggregation).To(BeFalse())
 		})
+
+		It("Creates PodInterfaceInfo without the bandwidth of the default network once OVN QoS is enabled", func() {
+			bwAnnot := map[string]string{
+				util.PodIngressBandwidthAnnotation: "10M",
+				util.PodEgressBandwidthAnnotation:  "20M",
+			}
+			for k, v := range podAnnot {
+				bwAnnot[k] = v
+			}
+			pif, err := PodAnnotation2PodInfo(bwAnnot, nil, false, podUID, "", ovntypes.DefaultNetworkName, ovntypes.DefaultNetworkName, config.Default.MTU)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pif.Ingress).To(Equal(int64(10000000)))
+			Expect(pif.Egress).To(Equal(int64(20000000)))
+
+			// the OVS bandwidth of the running pods on the default network is cleared
+			fexec := ovntest.NewFakeExec()
+			Expect(SetExec(fexec)).To(Succeed())
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listOVSIfesCmd, Output: ovsPodInterfacesJSON})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=30 --if-exists get port aaaaaaaaaaaaaaa qos",
+				Output: "9d3b8c6e-1b7a-4f0e-8a2d-5c4e3f2a1b0c",
+			})
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=30 --if-exists clear port aaaaaaaaaaaaaaa qos",
+				"ovs-vsctl --timeout=30 --if-exists destroy qos 9d3b8c6e-1b7a-4f0e-8a2d-5c4e3f2a1b0c",
+				"ovs-vsctl --timeout=30 set interface aaaaaaaaaaaaaaa ingress_policing_rate=0 ingress_policing_burst=0",
+			})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=30 --if-exists get port bbbbbbbbbbbbbbb qos",
+				Output: "[]",
+			})
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=30 set interface bbbbbbbbbbbbbbb ingress_policing_rate=0 ingress_policing_burst=0",
+			})
+			EnableOVNPodQoS()
+			defer atomic.StoreInt32(&ovnPodQoSEnabled, 0)
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+			pif, err = PodAnnotation2PodInfo(bwAnnot, nil, false, podUID, "", ovntypes.DefaultNetworkName, ovntypes.DefaultNetworkName, config.Default.MTU)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pif.Ingress).To(BeZero())
+			Expect(pif.Egress).To(BeZero())
+		})
 	})
 })
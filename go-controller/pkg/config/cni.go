@@ -24,7 +24,7 @@ var ErrorAttachDefNotOvnManaged = errors.New("net-attach-def not managed by OVN"
 func WriteCNIConfig() error {
 	netConf := &ovncnitypes.NetConf{
 		NetConf: types.NetConf{
-			CNIVersion: "0.4.0",
+			CNIVersion: "1.1.0",
 			Name:       "ovn-kubernetes",
 			Type:       CNI.Plugin,
 		},
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/url"
 	"os"
@@ -79,6 +80,8 @@ var (
 		LogFileMaxBackups:   5,
 		LogFileMaxAge:       5, //days
 		ACLLoggingRateLimit: 20,
+		ACLLogExportSource:  "/var/log/ovn/ovn-controller.log",
+		ACLLogExportTarget:  "", // do not export ACL logs by default
 	}
 
 	// Monitoring holds monitoring-related parsed config file parameters and command-line overrides
@@ -116,11 +119,17 @@ var (
 
 	// OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
-		EgressIPReachabiltyTotalTimeout: 1,
+		EgressIPReachabiltyTotalTimeout:      1,
+		EgressIPRebalanceInterval:            300,
+		EgressIPReachabilityCheckInterval:    5,
+		EgressIPReachabilityFailureThreshold: 1,
 	}
 
 	// OvnNorth holds northbound OVN database client and server authentication and location details
-	OvnNorth OvnAuthConfig
+	OvnNorth = OvnAuthConfig{
+		BackupInterval:  3600,
+		BackupRetention: 24,
+	}
 
 	// OvnSouth holds southbound OVN database client and server authentication and location details
 	OvnSouth OvnAuthConfig
@@ -150,6 +159,12 @@ var (
 		VXLANPort: DefaultVXLANPort,
 	}
 
+	// BGP holds the route advertisement feature config options.
+	BGP = BGPConfig{
+		ConfigMode: BGPConfigModeVtysh,
+		ConfigFile: "/etc/frr/frr.conf",
+	}
+
 	// UnprivilegedMode allows ovnkube-node to run without SYS_ADMIN capability, by performing interface setup in the CNI plugin
 	UnprivilegedMode bool
 
@@ -225,9 +240,28 @@ type DefaultConfig struct {
 	// RawClusterSubnets holds the unparsed cluster subnets. Should only be
 	// used inside config module.
 	RawClusterSubnets string `gcfg:"cluster-subnets"`
-	// ClusterSubnets holds parsed cluster subnet entries and may be used
-	// outside the config module.
+	// ClusterSubnets holds parsed cluster subnet entries. They may be changed
+	// at runtime, use GetClusterSubnets outside the config module.
 	ClusterSubnets []CIDRNetworkEntry
+	// DrainingClusterSubnets holds the cluster subnets from which no new host
+	// subnets are allocated, set from the cluster networks ConfigMap.
+	// Use GetClusterSubnetsWithDraining outside the config module.
+	DrainingClusterSubnets []*net.IPNet
+	// RawHostSubnetPools holds the unparsed host subnet pools. Should only be
+	// used inside config module.
+	RawHostSubnetPools string `gcfg:"host-subnet-pools"`
+	// HostSubnetPools holds the parsed host subnet pools, the IPv4 and IPv6 host
+	// subnet lengths of the nodes matching their selector. The first matching pool wins.
+	HostSubnetPools []HostSubnetPool
+	// SubnetQuarantinePeriod is the number of seconds the host subnet of a deleted node is held back
+	// before it can be allocated to another node. 0 disables the quarantine.
+	SubnetQuarantinePeriod int `gcfg:"subnet-quarantine-period"`
+	// PodIPQuarantinePeriod is the number of seconds the IP of a deleted pod is held back before it
+	// can be allocated to another pod. 0 disables the quarantine.
+	PodIPQuarantinePeriod int `gcfg:"pod-ip-quarantine-period"`
+	// ReleaseQuarantineWhenExhausted allows quarantined host subnets and pod IPs to be allocated
+	// again before their quarantine period expired when no other one is available
+	ReleaseQuarantineWhenExhausted bool `gcfg:"release-quarantine-when-exhausted"`
 	// EnableUDPAggregation is true if ovn-kubernetes should use UDP Generic Receive
 	// Offload forwarding to improve the performance of containers that transmit lots
 	// of small UDP packets by allowing them to be aggregated before passing through
@@ -252,6 +286,14 @@ type LoggingConfig struct {
 	LogFileMaxAge int `gcfg:"logfile-maxage"`
 	// Logging rate-limiting meter
 	ACLLoggingRateLimit int `gcfg:"acl-logging-rate-limit"`
+	// ACLLogExportSource is where ovnkube-node reads the ACL logs of ovn-controller from:
+	// the path of its log file, or unix:<path> for a socket ovn-controller logs to with
+	// --syslog-method=unix:<path>
+	ACLLogExportSource string `gcfg:"acl-log-export-source"`
+	// ACLLogExportTarget is where ovnkube-node exports the ACL logs to as JSON lines: a file
+	// path, syslog for the local syslog daemon or syslog:<udp|tcp>:<host:port> for a remote
+	// one. ACL logs are not exported when empty.
+	ACLLogExportTarget string `gcfg:"acl-log-export-target"`
 }
 
 // MonitoringConfig holds monitoring-related parsed config file parameters and command-line overrides
@@ -314,6 +356,12 @@ type KubernetesConfig struct {
 	HostNetworkNamespace string `gcfg:"host-network-namespace"`
 	PlatformType         string `gcfg:"platform-type"`
 	HealthzBindAddress   string `gcfg:"healthz-bind-address"`
+	// ClusterNetworksConfigMap is the name of the ConfigMap in OVNConfigNamespace
+	// that overrides the cluster subnets at runtime
+	ClusterNetworksConfigMap string `gcfg:"cluster-networks-configmap"`
+	// AddressQuarantineConfigMap is the name of the ConfigMap in OVNConfigNamespace
+	// that persists the quarantined host subnets and pod IPs
+	AddressQuarantineConfigMap string `gcfg:"address-quarantine-configmap"`
 
 	// CompatMetricsBindAddress is overridden by the corresponding option in MetricsConfig
 	CompatMetricsBindAddress string `gcfg:"metrics-bind-address"`
@@ -345,9 +393,37 @@ type OVNKubernetesFeatureConfig struct {
 	EgressIPReachabiltyTotalTimeout int  `gcfg:"egressip-reachability-total-timeout"`
 	EnableEgressFirewall            bool `gcfg:"enable-egress-firewall"`
 	EnableEgressQoS                 bool `gcfg:"enable-egress-qos"`
+	EnableEgressInterface           bool `gcfg:"enable-egress-interface"`
+	EnableMulticastPolicy           bool `gcfg:"enable-multicast-policy"`
 	EgressIPNodeHealthCheckPort     int  `gcfg:"egressip-node-healthcheck-port"`
 	EnableMultiNetwork              bool `gcfg:"enable-multi-network"`
 	EnableStatelessNetPol           bool `gcfg:"enable-stateless-netpol"`
+	// DBGCInterval is the interval in seconds between runs of the stale NB objects garbage collector,
+	// 0 disables the garbage collector
+	DBGCInterval int `gcfg:"db-gc-interval"`
+	// DBGCDryRun only reports stale NB objects without deleting them
+	DBGCDryRun bool `gcfg:"db-gc-dry-run"`
+	// EgressIPRebalanceInterval is the interval in seconds between the rebalancing of the
+	// EgressIPs with the Periodic rebalance policy
+	EgressIPRebalanceInterval int `gcfg:"egressip-rebalance-interval"`
+	// EgressIPReachabilityCheckInterval is the interval in seconds between the reachability
+	// checks of the egress nodes
+	EgressIPReachabilityCheckInterval int `gcfg:"egressip-reachability-check-interval"`
+	// EgressIPReachabilityCheckJitter is the maximum percentage of the reachability check
+	// interval randomly added to each interval, so that the checks don't align
+	EgressIPReachabilityCheckJitter int `gcfg:"egressip-reachability-check-jitter"`
+	// EgressIPReachabilityFailureThreshold is the number of consecutive failed reachability
+	// checks after which an egress node is considered unreachable
+	EgressIPReachabilityFailureThreshold int `gcfg:"egressip-reachability-failure-threshold"`
+	// EgressIPHealthCheckCert and EgressIPHealthCheckPrivKey are the certificate and private
+	// key the gRPC health server of the nodes serves and the master presents as client
+	EgressIPHealthCheckCert    string `gcfg:"egressip-healthcheck-cert"`
+	EgressIPHealthCheckPrivKey string `gcfg:"egressip-healthcheck-privkey"`
+	// EgressIPHealthCheckCACert is the CA certificate the server certificate and, when the
+	// server has a certificate, the client certificate are verified with (mutual TLS)
+	EgressIPHealthCheckCACert string `gcfg:"egressip-healthcheck-ca-cert"`
+	// EgressIPHealthCheckCertCommonName is the common name of the server certificate
+	EgressIPHealthCheckCertCommonName string `gcfg:"egressip-healthcheck-cert-common-name"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -407,7 +483,14 @@ type OvnAuthConfig struct {
 	CertCommonName string `gcfg:"cert-common-name"`
 	Scheme         OvnDBScheme
 	ElectionTimer  uint `gcfg:"election-timer"`
-	northbound     bool
+	// BackupDir is the directory in which ovndbchecker stores periodic
+	// standalone snapshots of the database. Backups are disabled if empty.
+	BackupDir string `gcfg:"backup-dir"`
+	// BackupInterval is the number of seconds between two database backups
+	BackupInterval uint `gcfg:"backup-interval"`
+	// BackupRetention is the number of database backups to keep in BackupDir
+	BackupRetention uint `gcfg:"backup-retention"`
+	northbound      bool
 
 	exec kexec.Interface
 }
@@ -435,6 +518,39 @@ type HybridOverlayConfig struct {
 	VXLANPort uint `gcfg:"hybrid-overlay-vxlan-port"`
 }
 
+// BGPConfigMode describes how ovnkube-node pushes the route advertisements to FRR
+type BGPConfigMode string
+
+const (
+	// BGPConfigModeVtysh configures the routes of a running FRR instance through vtysh
+	BGPConfigModeVtysh BGPConfigMode = "vtysh"
+	// BGPConfigModeFile renders the whole FRR configuration into a file reloaded by FRR
+	BGPConfigModeFile BGPConfigMode = "file"
+)
+
+// BGPConfig holds configuration for advertising the node's routes with BGP
+// through a local FRR instance.
+type BGPConfig struct {
+	// Enabled indicates whether ovnkube-node advertises routes through FRR.
+	Enabled bool `gcfg:"enabled"`
+	// ASN is the autonomous system number of the node's BGP router.
+	ASN uint `gcfg:"asn"`
+	// ConfigMode is how the routes are pushed to FRR, vtysh or file.
+	ConfigMode BGPConfigMode `gcfg:"config-mode"`
+	// ConfigFile is the FRR configuration file rendered in file mode.
+	ConfigFile string `gcfg:"config-file"`
+	// RawNeighbors holds the unparsed BGP neighbor addresses.
+	// Should only be used inside config module.
+	RawNeighbors string `gcfg:"neighbors"`
+	// NeighborAddresses holds the parsed BGP neighbor addresses, only used in file mode.
+	NeighborAddresses []string
+	// NeighborASN is the autonomous system number of the neighbors, defaults to ASN.
+	NeighborASN uint `gcfg:"neighbor-asn"`
+	// DisableSNAT disables the SNAT to the node IP of pod traffic leaving the cluster
+	// as the pod subnets are routable on the underlay.
+	DisableSNAT bool `gcfg:"disable-snat"`
+}
+
 // OvnKubeNodeConfig holds ovnkube-node configurations
 type OvnKubeNodeConfig struct {
 	Mode                   string `gcfg:"mode"`
@@ -443,6 +559,15 @@ type OvnKubeNodeConfig struct {
 	MgmtPortDPResourceName string `gcfg:"mgmt-port-dp-resource-name"`
 	MgmtPortRepresentor    string
 	DisableOVNIfaceIdVer   bool `gcfg:"disable-ovn-iface-id-ver"`
+	// RawDPUDevices holds the unparsed DPU devices of the host. Should only be
+	// used inside config module.
+	RawDPUDevices string `gcfg:"dpu-devices"`
+	// DPUDevices holds the parsed DPU devices of the host. The first one is the
+	// primary DPU, which carries the management port and the gateway of the node.
+	DPUDevices []DPUDevice
+	// DPUName is the name of the DPU ovnkube-node runs on in dpu mode. It
+	// defaults to the primary DPU.
+	DPUName string `gcfg:"dpu-name"`
 }
 
 // OvnDBScheme describes the OVN database connection transport method
@@ -473,6 +598,7 @@ type config struct {
 	MasterHA             HAConfig
 	ClusterMgrHA         HAConfig
 	HybridOverlay        HybridOverlayConfig
+	BGP                  BGPConfig
 	OvnKubeNode          OvnKubeNodeConfig
 }
 
@@ -491,6 +617,7 @@ var (
 	savedMasterHA             HAConfig
 	savedClusterMgrHA         HAConfig
 	savedHybridOverlay        HybridOverlayConfig
+	savedBGP                  BGPConfig
 	savedOvnKubeNode          OvnKubeNodeConfig
 	// legacy service-cluster-ip-range CLI option
 	serviceClusterIPRange string
@@ -517,6 +644,7 @@ func init() {
 	savedGateway = Gateway
 	savedMasterHA = MasterHA
 	savedHybridOverlay = HybridOverlay
+	savedBGP = BGP
 	savedOvnKubeNode = OvnKubeNode
 	cli.VersionPrinter = func(c *cli.Context) {
 		fmt.Printf("Version: %s\n", Version)
@@ -546,8 +674,14 @@ func PrepareTestConfig() error {
 	Gateway = savedGateway
 	MasterHA = savedMasterHA
 	HybridOverlay = savedHybridOverlay
+	BGP = savedBGP
 	OvnKubeNode = savedOvnKubeNode
 
+	reloadLock.Lock()
+	fileConfig = config{}
+	reloadHandlers = map[string][]*reloadHandler{}
+	reloadLock.Unlock()
+
 	if err := completeConfig(); err != nil {
 		return err
 	}
@@ -759,6 +893,33 @@ var CommonFlags = []cli.Flag{
 			"it defaults to 24 if unspecified.",
 		Destination: &cliConfig.Default.RawClusterSubnets,
 	},
+	&cli.StringFlag{
+		Name: "host-subnet-pools",
+		Usage: "A semicolon separated list of node label selectors and the IPv4 and IPv6 " +
+			"hostsubnet prefix lengths, separated by '/', to use for the nodes they select instead of " +
+			"the ones of the cluster subnets (eg, \"node-pool=edge:26/64;node-pool=bigmem:22;node-pool=v6:/60\"). " +
+			"An omitted length keeps the one of the cluster subnets of its IP family. " +
+			"The first matching entry applies. Nodes keep the subnet they already have.",
+		Destination: &cliConfig.Default.RawHostSubnetPools,
+	},
+	&cli.IntFlag{
+		Name: "subnet-quarantine-period",
+		Usage: "The number of seconds the host subnet of a deleted node is held back before " +
+			"it is allocated to another node (default: 0, disabled)",
+		Destination: &cliConfig.Default.SubnetQuarantinePeriod,
+	},
+	&cli.IntFlag{
+		Name: "pod-ip-quarantine-period",
+		Usage: "The number of seconds the IP of a deleted pod is held back before " +
+			"it is allocated to another pod (default: 0, disabled)",
+		Destination: &cliConfig.Default.PodIPQuarantinePeriod,
+	},
+	&cli.BoolFlag{
+		Name: "release-quarantine-when-exhausted",
+		Usage: "Allocate quarantined host subnets and pod IPs before their quarantine period " +
+			"expired when no other one is available",
+		Destination: &cliConfig.Default.ReleaseQuarantineWhenExhausted,
+	},
 	&cli.BoolFlag{
 		Name:        "unprivileged-mode",
 		Usage:       "Run ovnkube-node container in unprivileged mode. Valid only with --init-node option.",
@@ -812,6 +973,19 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Logging.ACLLoggingRateLimit,
 		Value:       20,
 	},
+	&cli.StringFlag{
+		Name: "acl-log-export-source",
+		Usage: "Where ovnkube-node reads the ACL logs of ovn-controller from: the path of its log file, " +
+			"or unix:<path> for a socket ovn-controller logs to with --syslog-method=unix:<path>",
+		Destination: &cliConfig.Logging.ACLLogExportSource,
+		Value:       Logging.ACLLogExportSource,
+	},
+	&cli.StringFlag{
+		Name: "acl-log-export-target",
+		Usage: "Where ovnkube-node exports the ACL logs of ovn-controller to as JSON lines: a file path, " +
+			"syslog for the local syslog daemon or syslog:<udp|tcp>:<host:port> for a remote one (default: disabled)",
+		Destination: &cliConfig.Logging.ACLLogExportTarget,
+	},
 }
 
 // MonitoringFlags capture monitoring-related options
@@ -904,11 +1078,66 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableEgressQoS,
 		Value:       OVNKubernetesFeature.EnableEgressQoS,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-egress-interface",
+		Usage:       "Configure to use EgressInterface CRD feature with ovn-kubernetes.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableEgressInterface,
+		Value:       OVNKubernetesFeature.EnableEgressInterface,
+	},
+	&cli.BoolFlag{
+		Name:        "enable-multicast-policy",
+		Usage:       "Configure to use MulticastPolicy CRD feature with ovn-kubernetes. Requires --enable-multicast.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableMulticastPolicy,
+		Value:       OVNKubernetesFeature.EnableMulticastPolicy,
+	},
 	&cli.IntFlag{
 		Name:        "egressip-node-healthcheck-port",
 		Usage:       "Configure EgressIP node reachability using gRPC on this TCP port.",
 		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
 	},
+	&cli.IntFlag{
+		Name:        "egressip-rebalance-interval",
+		Usage:       "Interval in seconds between the rebalancing of the EgressIPs with the Periodic rebalance policy (default: 300)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPRebalanceInterval,
+		Value:       OVNKubernetesFeature.EgressIPRebalanceInterval,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-reachability-check-interval",
+		Usage:       "Interval in seconds between the EgressIP node reachability checks (default: 5)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPReachabilityCheckInterval,
+		Value:       OVNKubernetesFeature.EgressIPReachabilityCheckInterval,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-reachability-check-jitter",
+		Usage:       "Maximum percentage of the EgressIP node reachability check interval randomly added to it (default: 0)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPReachabilityCheckJitter,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-reachability-failure-threshold",
+		Usage:       "Number of consecutive failed EgressIP node reachability checks after which a node is unreachable (default: 1)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPReachabilityFailureThreshold,
+		Value:       OVNKubernetesFeature.EgressIPReachabilityFailureThreshold,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-healthcheck-cert",
+		Usage:       "Certificate the EgressIP node health server serves and the master presents as client over TLS.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPHealthCheckCert,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-healthcheck-privkey",
+		Usage:       "Private key of the EgressIP node health check certificate.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPHealthCheckPrivKey,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-healthcheck-ca-cert",
+		Usage:       "CA certificate the EgressIP node health check certificates are verified with, enables mutual TLS.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPHealthCheckCACert,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-healthcheck-cert-common-name",
+		Usage:       "Common name of the EgressIP node health server certificate.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPHealthCheckCertCommonName,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-multi-network",
 		Usage:       "Configure to use multiple NetworkAttachmentDefinition CRD feature with ovn-kubernetes.",
@@ -921,6 +1150,20 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableStatelessNetPol,
 		Value:       OVNKubernetesFeature.EnableStatelessNetPol,
 	},
+	&cli.IntFlag{
+		Name: "db-gc-interval",
+		Usage: "Interval in seconds between runs of the garbage collector of stale OVN NB objects. " +
+			"An object is only deleted after being found stale in two consecutive runs. " +
+			"Default is 0, which disables the garbage collector.",
+		Destination: &cliConfig.OVNKubernetesFeature.DBGCInterval,
+		Value:       OVNKubernetesFeature.DBGCInterval,
+	},
+	&cli.BoolFlag{
+		Name:        "db-gc-dry-run",
+		Usage:       "Only report the stale OVN NB objects found by the garbage collector, without deleting them.",
+		Destination: &cliConfig.OVNKubernetesFeature.DBGCDryRun,
+		Value:       OVNKubernetesFeature.DBGCDryRun,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -976,6 +1219,20 @@ var K8sFlags = []cli.Flag{
 		Destination: &cliConfig.Kubernetes.OVNConfigNamespace,
 		Value:       Kubernetes.OVNConfigNamespace,
 	},
+	&cli.StringFlag{
+		Name: "cluster-networks-configmap",
+		Usage: "The name of a ConfigMap in the OVN config namespace whose \"cluster-subnets\" and " +
+			"\"draining-cluster-subnets\" keys override the cluster subnets at runtime. " +
+			"Cluster subnets listed as draining are not used for new node subnets.",
+		Destination: &cliConfig.Kubernetes.ClusterNetworksConfigMap,
+	},
+	&cli.StringFlag{
+		Name: "address-quarantine-configmap",
+		Usage: "The name of a ConfigMap in the OVN config namespace that persists the quarantined " +
+			"host subnets and pod IPs, so that they stay quarantined across restarts. " +
+			"Quarantines are only kept in memory if unset.",
+		Destination: &cliConfig.Kubernetes.AddressQuarantineConfigMap,
+	},
 	&cli.BoolFlag{
 		Name: "ovn-empty-lb-events",
 		Usage: "If set, then load balancers do not get deleted when all backends are removed. " +
@@ -1098,6 +1355,24 @@ var OvnNBFlags = []cli.Flag{
 		Usage:       "The desired northbound database election timer.",
 		Destination: &cliConfig.OvnNorth.ElectionTimer,
 	},
+	&cli.StringFlag{
+		Name: "nb-backup-dir",
+		Usage: "Directory in which the db checker stores periodic standalone snapshots " +
+			"of the northbound database. Backups are disabled if empty.",
+		Destination: &cliConfig.OvnNorth.BackupDir,
+	},
+	&cli.UintFlag{
+		Name:        "nb-backup-interval",
+		Usage:       "Interval in seconds between two northbound database backups (default: 3600).",
+		Destination: &cliConfig.OvnNorth.BackupInterval,
+		Value:       OvnNorth.BackupInterval,
+	},
+	&cli.UintFlag{
+		Name:        "nb-backup-retention",
+		Usage:       "Number of northbound database backups to keep in the backup directory (default: 24).",
+		Destination: &cliConfig.OvnNorth.BackupRetention,
+		Value:       OvnNorth.BackupRetention,
+	},
 }
 
 // OvnSBFlags capture OVN southbound database options
@@ -1307,6 +1582,50 @@ var HybridOverlayFlags = []cli.Flag{
 	},
 }
 
+// BGPFlags capture the route advertisement feature options
+var BGPFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name: "enable-bgp",
+		Usage: "Advertise the node's host subnets, the EgressIPs assigned to the node and the external " +
+			"IPs of services with externalTrafficPolicy=Local and local endpoints through a local FRR instance",
+		Destination: &cliConfig.BGP.Enabled,
+	},
+	&cli.UintFlag{
+		Name:        "bgp-asn",
+		Usage:       "The autonomous system number of the node's BGP router",
+		Destination: &cliConfig.BGP.ASN,
+	},
+	&cli.StringFlag{
+		Name: "bgp-config-mode",
+		Usage: "How the routes are pushed to FRR: vtysh updates the running BGP router, " +
+			"file renders the whole FRR configuration into bgp-config-file",
+		Value:       string(BGP.ConfigMode),
+		Destination: (*string)(&cliConfig.BGP.ConfigMode),
+	},
+	&cli.StringFlag{
+		Name:        "bgp-config-file",
+		Usage:       "The FRR configuration file rendered in file mode",
+		Value:       BGP.ConfigFile,
+		Destination: &cliConfig.BGP.ConfigFile,
+	},
+	&cli.StringFlag{
+		Name:        "bgp-neighbors",
+		Usage:       "A comma separated set of BGP neighbor addresses, used in file mode",
+		Destination: &cliConfig.BGP.RawNeighbors,
+	},
+	&cli.UintFlag{
+		Name:        "bgp-neighbor-asn",
+		Usage:       "The autonomous system number of the BGP neighbors, defaults to bgp-asn",
+		Destination: &cliConfig.BGP.NeighborASN,
+	},
+	&cli.BoolFlag{
+		Name: "bgp-disable-snat",
+		Usage: "Do not SNAT pod traffic leaving the cluster to the node IP as the pod subnets are advertised. " +
+			"Must be set on both ovnkube-master and ovnkube-node",
+		Destination: &cliConfig.BGP.DisableSNAT,
+	},
+}
+
 // OvnKubeNodeFlags captures ovnkube-node specific configurations
 var OvnKubeNodeFlags = []cli.Flag{
 	&cli.StringFlag{
@@ -1337,6 +1656,20 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.DisableOVNIfaceIdVer,
 		Destination: &cliConfig.OvnKubeNode.DisableOVNIfaceIdVer,
 	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-dpu-devices",
+		Usage: "A semicolon separated list of the DPU devices of the host, each given as comma separated " +
+			"key=value pairs: its name and, on the host, the PCI addresses of its PFs " +
+			"(eg, \"name=dpu0,pf=0000:03:00.0;name=dpu1,pf=0000:81:00.0,pf=0000:81:00.1\"). " +
+			"The first device is the primary DPU, which carries the management port and the gateway of the node. " +
+			"Pods are plugged by the DPU owning the PF of their VF.",
+		Destination: &cliConfig.OvnKubeNode.RawDPUDevices,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-dpu-name",
+		Usage:       "In dpu mode, the name of the DPU device ovnkube-node runs on, defaults to the primary DPU",
+		Destination: &cliConfig.OvnKubeNode.DPUName,
+	},
 }
 
 // Flags are general command-line flags. Apps should add these flags to their
@@ -1357,6 +1690,7 @@ func GetFlags(customFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, MasterHAFlags...)
 	flags = append(flags, ClusterMgrHAFlags...)
 	flags = append(flags, HybridOverlayFlags...)
+	flags = append(flags, BGPFlags...)
 	flags = append(flags, MonitoringFlags...)
 	flags = append(flags, IPFIXFlags...)
 	flags = append(flags, OvnKubeNodeFlags...)
@@ -1627,6 +1961,10 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("gateway VLAN ID option: %d is supported only in shared gateway mode", Gateway.VLANID)
 	}
 
+	if OVNKubernetesFeature.EnableEgressInterface && Gateway.DisableSNATMultipleGWs {
+		return fmt.Errorf("enable-egress-interface is not supported together with disable-snat-multiple-gws")
+	}
+
 	return nil
 }
 
@@ -1657,6 +1995,33 @@ func buildOVNKubernetesFeatureConfig(ctx *cli.Context, cli, file *config) error
 	if err := overrideFields(&OVNKubernetesFeature, &cli.OVNKubernetesFeature, &savedOVNKubernetesFeature); err != nil {
 		return err
 	}
+	if OVNKubernetesFeature.DBGCInterval < 0 {
+		return fmt.Errorf("invalid db-gc-interval %d, must not be negative", OVNKubernetesFeature.DBGCInterval)
+	}
+	if OVNKubernetesFeature.EgressIPRebalanceInterval <= 0 {
+		return fmt.Errorf("invalid egressip-rebalance-interval %d, must be positive", OVNKubernetesFeature.EgressIPRebalanceInterval)
+	}
+	if OVNKubernetesFeature.EgressIPReachabilityCheckInterval <= 0 {
+		return fmt.Errorf("invalid egressip-reachability-check-interval %d, must be positive",
+			OVNKubernetesFeature.EgressIPReachabilityCheckInterval)
+	}
+	if OVNKubernetesFeature.EgressIPReachabilityCheckJitter < 0 || OVNKubernetesFeature.EgressIPReachabilityCheckJitter > 100 {
+		return fmt.Errorf("invalid egressip-reachability-check-jitter %d, must be between 0 and 100",
+			OVNKubernetesFeature.EgressIPReachabilityCheckJitter)
+	}
+	if OVNKubernetesFeature.EgressIPReachabilityFailureThreshold <= 0 {
+		return fmt.Errorf("invalid egressip-reachability-failure-threshold %d, must be positive",
+			OVNKubernetesFeature.EgressIPReachabilityFailureThreshold)
+	}
+	if (OVNKubernetesFeature.EgressIPHealthCheckCert == "") != (OVNKubernetesFeature.EgressIPHealthCheckPrivKey == "") {
+		return fmt.Errorf("egressip-healthcheck-cert and egressip-healthcheck-privkey must be set together")
+	}
+	if OVNKubernetesFeature.EgressIPHealthCheckCACert != "" && OVNKubernetesFeature.EgressIPHealthCheckCertCommonName == "" {
+		return fmt.Errorf("egressip-healthcheck-cert-common-name must be set with egressip-healthcheck-ca-cert")
+	}
+	if OVNKubernetesFeature.EnableMulticastPolicy && !EnableMulticast {
+		return fmt.Errorf("enable-multicast-policy requires enable-multicast")
+	}
 	return nil
 }
 
@@ -1790,6 +2155,57 @@ func completeHybridOverlayConfig(allSubnets *configSubnets) error {
 	return nil
 }
 
+func buildBGPConfig(cli, file *config) error {
+	// Copy config file values over default values
+	if err := overrideFields(&BGP, &file.BGP, &savedBGP); err != nil {
+		return err
+	}
+
+	// And CLI overrides over config file and default values
+	if err := overrideFields(&BGP, &cli.BGP, &savedBGP); err != nil {
+		return err
+	}
+
+	if BGP.DisableSNAT && Gateway.DisableSNATMultipleGWs {
+		return fmt.Errorf("bgp-disable-snat is not supported together with disable-snat-multiple-gws")
+	}
+	if !BGP.Enabled {
+		return nil
+	}
+	if BGP.ASN == 0 || BGP.ASN > math.MaxUint32 {
+		return fmt.Errorf("invalid BGP autonomous system number %d", BGP.ASN)
+	}
+	if BGP.NeighborASN > math.MaxUint32 {
+		return fmt.Errorf("invalid BGP neighbor autonomous system number %d", BGP.NeighborASN)
+	}
+	BGP.NeighborAddresses = nil
+	for _, neighbor := range strings.Split(BGP.RawNeighbors, ",") {
+		neighbor = strings.TrimSpace(neighbor)
+		if neighbor == "" {
+			continue
+		}
+		ip := net.ParseIP(neighbor)
+		if ip == nil {
+			return fmt.Errorf("invalid BGP neighbor address %q", neighbor)
+		}
+		BGP.NeighborAddresses = append(BGP.NeighborAddresses, ip.String())
+	}
+	switch BGP.ConfigMode {
+	case BGPConfigModeVtysh:
+	case BGPConfigModeFile:
+		if BGP.ConfigFile == "" {
+			return fmt.Errorf("bgp-config-file must be provided in %s config mode", BGP.ConfigMode)
+		}
+		if len(BGP.NeighborAddresses) == 0 {
+			return fmt.Errorf("bgp-neighbors must be provided in %s config mode", BGP.ConfigMode)
+		}
+	default:
+		return fmt.Errorf("invalid BGP config mode %q, expected %s or %s",
+			BGP.ConfigMode, BGPConfigModeVtysh, BGPConfigModeFile)
+	}
+	return nil
+}
+
 func buildDefaultConfig(cli, file *config) error {
 	if err := overrideFields(&Default, &file.Default, &savedDefault); err != nil {
 		return err
@@ -1822,9 +2238,43 @@ func completeDefaultConfig(allSubnets *configSubnets) error {
 		allSubnets.append(configSubnetCluster, subnet.CIDR)
 	}
 
+	if Default.SubnetQuarantinePeriod < 0 {
+		return fmt.Errorf("invalid subnet-quarantine-period %d: must not be negative", Default.SubnetQuarantinePeriod)
+	}
+	if Default.PodIPQuarantinePeriod < 0 {
+		return fmt.Errorf("invalid pod-ip-quarantine-period %d: must not be negative", Default.PodIPQuarantinePeriod)
+	}
+
+	Default.HostSubnetPools, err = ParseHostSubnetPools(Default.RawHostSubnetPools)
+	if err != nil {
+		return fmt.Errorf("host subnet pools invalid: %v", err)
+	}
+	for _, pool := range Default.HostSubnetPools {
+		if pool.IPv4HostSubnetLength != 0 && !clusterSubnetsProvide(false, pool.IPv4HostSubnetLength) {
+			return fmt.Errorf("host subnet pools invalid: no IPv4 cluster subnet can provide /%d host subnets for nodes %q",
+				pool.IPv4HostSubnetLength, pool.NodeSelector)
+		}
+		if pool.IPv6HostSubnetLength != 0 && !clusterSubnetsProvide(true, pool.IPv6HostSubnetLength) {
+			return fmt.Errorf("host subnet pools invalid: no IPv6 cluster subnet can provide /%d host subnets for nodes %q",
+				pool.IPv6HostSubnetLength, pool.NodeSelector)
+		}
+	}
+
 	return nil
 }
 
+// clusterSubnetsProvide returns true if a cluster subnet of the IP family is larger than
+// host subnets of the given length
+func clusterSubnetsProvide(isIPv6 bool, hostSubnetLength int) bool {
+	for _, subnet := range Default.ClusterSubnets {
+		clusterCIDRLen, _ := subnet.CIDR.Mask.Size()
+		if utilnet.IsIPv6CIDR(subnet.CIDR) == isIPv6 && clusterCIDRLen < hostSubnetLength {
+			return true
+		}
+	}
+	return false
+}
+
 // getConfigFilePath returns config file path and 'true' if the config file is
 // the fallback path (eg not given by the user), 'false' if given explicitly
 // by the user
@@ -1871,20 +2321,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	var configFileIsDefault bool
 	var err error
 	// initialize cfg with default values, allow file read to override
-	cfg := config{
-		Default:              savedDefault,
-		Logging:              savedLogging,
-		IPFIX:                savedIPFIX,
-		CNI:                  savedCNI,
-		OVNKubernetesFeature: savedOVNKubernetesFeature,
-		Kubernetes:           savedKubernetes,
-		OvnNorth:             savedOvnNorth,
-		OvnSouth:             savedOvnSouth,
-		Gateway:              savedGateway,
-		MasterHA:             savedMasterHA,
-		HybridOverlay:        savedHybridOverlay,
-		OvnKubeNode:          savedOvnKubeNode,
-	}
+	cfg := newFileConfig()
 
 	configFile, configFileIsDefault = getConfigFilePath(ctx)
 
@@ -1901,16 +2338,9 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	if f != nil {
 		defer f.Close()
 
-		// Parse ovn-k8s config file.
-		if err = gcfg.ReadInto(&cfg, f); err != nil {
-			if gcfg.FatalOnly(err) != nil {
-				return "", fmt.Errorf("failed to parse config file %s: %v", f.Name(), err)
-			}
-			// error is only a warning -> log it but continue
-			klog.Warningf("Warning on parsing config file: %s", err)
+		if err = parseConfigFile(f, &cfg); err != nil {
+			return "", err
 		}
-		klog.Infof("Parsed config file %s", f.Name())
-		klog.Infof("Parsed config: %+v", cfg)
 	}
 
 	if defaults == nil {
@@ -1933,9 +2363,8 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
-	var level klog.Level
-	if err := level.Set(strconv.Itoa(Logging.Level)); err != nil {
-		return "", fmt.Errorf("failed to set klog log level %v", err)
+	if err := setLogLevel(Logging.Level); err != nil {
+		return "", err
 	}
 	if Logging.File != "" {
 		klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
@@ -1997,6 +2426,10 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
+	if err = buildBGPConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	if err = buildOvnKubeNodeConfig(ctx, &cliConfig, &cfg); err != nil {
 		return "", err
 	}
@@ -2027,11 +2460,58 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	klog.V(5).Infof("OVN North config: %+v", OvnNorth)
 	klog.V(5).Infof("OVN South config: %+v", OvnSouth)
 	klog.V(5).Infof("Hybrid Overlay config: %+v", HybridOverlay)
+	klog.V(5).Infof("BGP config: %+v", BGP)
 	klog.V(5).Infof("Ovnkube Node config: %+v", OvnKubeNode)
 
+	// remember the config file values to detect changes when the config file is reloaded
+	setFileConfig(cfg)
+
 	return retConfigFile, nil
 }
 
+// newFileConfig returns a config initialized with default values, that the config file
+// is parsed into
+func newFileConfig() config {
+	return config{
+		Default:              savedDefault,
+		Logging:              savedLogging,
+		IPFIX:                savedIPFIX,
+		CNI:                  savedCNI,
+		OVNKubernetesFeature: savedOVNKubernetesFeature,
+		Kubernetes:           savedKubernetes,
+		OvnNorth:             savedOvnNorth,
+		OvnSouth:             savedOvnSouth,
+		Gateway:              savedGateway,
+		MasterHA:             savedMasterHA,
+		HybridOverlay:        savedHybridOverlay,
+		BGP:                  savedBGP,
+		OvnKubeNode:          savedOvnKubeNode,
+	}
+}
+
+// parseConfigFile parses the ovn-k8s config file f into cfg
+func parseConfigFile(f *os.File, cfg *config) error {
+	if err := gcfg.ReadInto(cfg, f); err != nil {
+		if gcfg.FatalOnly(err) != nil {
+			return fmt.Errorf("failed to parse config file %s: %v", f.Name(), err)
+		}
+		// error is only a warning -> log it but continue
+		klog.Warningf("Warning on parsing config file: %s", err)
+	}
+	klog.Infof("Parsed config file %s", f.Name())
+	klog.Infof("Parsed config: %+v", *cfg)
+	return nil
+}
+
+// setLogLevel sets the klog verbosity
+func setLogLevel(logLevel int) error {
+	var level klog.Level
+	if err := level.Set(strconv.Itoa(logLevel)); err != nil {
+		return fmt.Errorf("failed to set klog log level %v", err)
+	}
+	return nil
+}
+
 func completeConfig() error {
 	allSubnets := newConfigSubnets()
 
@@ -2145,6 +2625,8 @@ func buildOvnAuth(exec kexec.Interface, northbound bool, cliAuth, confAuth *OvnA
 		direction = "sb"
 		defaultAuth = &savedOvnSouth
 	}
+	auth.BackupInterval = defaultAuth.BackupInterval
+	auth.BackupRetention = defaultAuth.BackupRetention
 
 	// Determine final address so we know how to set cert/key defaults
 	address := cliAuth.Address
@@ -2169,6 +2651,15 @@ func buildOvnAuth(exec kexec.Interface, northbound bool, cliAuth, confAuth *OvnA
 		return nil, err
 	}
 
+	if auth.BackupDir != "" {
+		if auth.BackupInterval == 0 {
+			return nil, fmt.Errorf("%s-backup-interval must be greater than 0", direction)
+		}
+		if auth.BackupRetention == 0 {
+			return nil, fmt.Errorf("%s-backup-retention must be greater than 0", direction)
+		}
+	}
+
 	if address == "" {
 		if auth.PrivKey != "" || auth.Cert != "" || auth.CACert != "" {
 			return nil, fmt.Errorf("certificate or key given; perhaps you mean to use the 'ssl' scheme?")
@@ -2344,11 +2835,86 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 			OvnKubeNode.MgmtPortNetdev, OvnKubeNode.MgmtPortDPResourceName)
 	}
 
+	if err := buildDPUDevicesConfig(); err != nil {
+		return err
+	}
+
 	// when DPU is used, management port is backed by a VF. get management port VF information
 	if OvnKubeNode.Mode == types.NodeModeDPU || OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// only the primary DPU plugs the management port of the node
+		if !OvnKubeNode.IsPrimaryDPU() {
+			return nil
+		}
 		if OvnKubeNode.MgmtPortNetdev == "" && OvnKubeNode.MgmtPortDPResourceName == "" {
 			return fmt.Errorf("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided")
 		}
 	}
 	return nil
 }
+
+// buildDPUDevicesConfig parses the DPU devices of the host and checks the DPU ovnkube-node runs on
+// in dpu mode
+func buildDPUDevicesConfig() error {
+	var err error
+	OvnKubeNode.DPUDevices, err = ParseDPUDevices(OvnKubeNode.RawDPUDevices)
+	if err != nil {
+		return err
+	}
+	if len(OvnKubeNode.DPUDevices) == 0 {
+		if OvnKubeNode.DPUName != "" {
+			return fmt.Errorf("ovnkube-node-dpu-name %q is set without ovnkube-node-dpu-devices", OvnKubeNode.DPUName)
+		}
+		return nil
+	}
+	switch OvnKubeNode.Mode {
+	case types.NodeModeDPU:
+		if OvnKubeNode.DPUName == "" {
+			OvnKubeNode.DPUName = OvnKubeNode.DPUDevices[0].Name
+		}
+		if OvnKubeNode.GetDPUDevice(OvnKubeNode.DPUName) == nil {
+			return fmt.Errorf("ovnkube-node-dpu-name %q is not one of the DPU devices", OvnKubeNode.DPUName)
+		}
+	case types.NodeModeDPUHost:
+		if OvnKubeNode.DPUName != "" {
+			return fmt.Errorf("ovnkube-node-dpu-name is only supported with ovnkube-node mode %s", types.NodeModeDPU)
+		}
+		// the pods are bound to the DPU owning the PF of their VF
+		for _, device := range OvnKubeNode.DPUDevices {
+			if len(device.PfPciAddresses) == 0 {
+				return fmt.Errorf("DPU device %q must have a pf in ovnkube-node mode %s", device.Name, types.NodeModeDPUHost)
+			}
+		}
+	default:
+		return fmt.Errorf("ovnkube-node-dpu-devices is only supported with ovnkube-node mode %s and %s",
+			types.NodeModeDPU, types.NodeModeDPUHost)
+	}
+	return nil
+}
+
+// GetDPUDevice returns the DPU device with the given name, or nil
+func (c *OvnKubeNodeConfig) GetDPUDevice(name string) *DPUDevice {
+	for i := range c.DPUDevices {
+		if c.DPUDevices[i].Name == name {
+			return &c.DPUDevices[i]
+		}
+	}
+	return nil
+}
+
+// GetDPUDeviceByPF returns the DPU device owning the PF with the given PCI address, or nil
+func (c *OvnKubeNodeConfig) GetDPUDeviceByPF(pfPciAddress string) *DPUDevice {
+	for i := range c.DPUDevices {
+		for _, pf := range c.DPUDevices[i].PfPciAddresses {
+			if pf == pfPciAddress {
+				return &c.DPUDevices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// IsPrimaryDPU returns true unless ovnkube-node runs in dpu mode on another DPU than the
+// primary one of the host
+func (c *OvnKubeNodeConfig) IsPrimaryDPU() bool {
+	return c.Mode != types.NodeModeDPU || len(c.DPUDevices) == 0 || c.DPUName == c.DPUDevices[0].Name
+}
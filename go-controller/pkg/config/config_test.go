@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -214,6 +215,8 @@ mode=full
 [ovnkubernetesfeature]
 egressip-reachability-total-timeout=3
 egressip-node-healthcheck-port=1234
+egressip-reachability-check-interval=10
+egressip-reachability-failure-threshold=3
 enable-multi-network=false
 `
 
@@ -309,6 +312,9 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Gateway.DisableForwarding).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(1))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(0))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityCheckInterval).To(gomega.Equal(5))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityCheckJitter).To(gomega.Equal(0))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityFailureThreshold).To(gomega.Equal(1))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeFalse())
 
 			for _, a := range []OvnAuthConfig{OvnNorth, OvnSouth} {
@@ -561,6 +567,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Logging.File).To(gomega.Equal("/var/log/ovnkube.log"))
 			gomega.Expect(Logging.Level).To(gomega.Equal(5))
 			gomega.Expect(Logging.ACLLoggingRateLimit).To(gomega.Equal(20))
+			gomega.Expect(Logging.ACLLogExportSource).To(gomega.Equal("/var/log/ovn/ovn-controller.log"))
+			gomega.Expect(Logging.ACLLogExportTarget).To(gomega.Equal(""))
 			gomega.Expect(Monitoring.RawNetFlowTargets).To(gomega.Equal("2.2.2.2:2055"))
 			gomega.Expect(Monitoring.RawSFlowTargets).To(gomega.Equal("2.2.2.2:2056"))
 			gomega.Expect(Monitoring.RawIPFIXTargets).To(gomega.Equal("2.2.2.2:2057"))
@@ -618,6 +626,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(HybridOverlay.Enabled).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(3))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(1234))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityCheckInterval).To(gomega.Equal(10))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityFailureThreshold).To(gomega.Equal(3))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeTrue())
 			gomega.Expect(HybridOverlay.ClusterSubnets).To(gomega.Equal([]CIDRNetworkEntry{
 				{ovntest.MustParseIPNet("11.132.0.0/14"), 23},
@@ -629,6 +639,156 @@ var _ = Describe("Config Operations", func() {
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("reloads the reloadable config file options", func() {
+		kubeconfigFile, _, err := createTempFile("kubeconfig")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeconfigFile)
+
+		kubeCAFile, _, err := createTempFile("kube-ca.crt")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeCAFile)
+
+		err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err = InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Logging.Level).To(gomega.Equal(5))
+			gomega.Expect(Metrics.EnableScaleMetrics).To(gomega.BeTrue())
+
+			scaleMetricsReloads := 0
+			OnReload(ReloadableScaleMetrics, func() { scaleMetricsReloads++ })
+			logLevelReloads := 0
+			unregister := OnReload(ReloadableLogLevel, func() { logLevelReloads++ })
+
+			err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile,
+				"loglevel=4", "enable-scale-metrics=false", "mtu=1400")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = ReloadConfigFile(cfgFile.Name())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Expect(GetLogLevel()).To(gomega.Equal(4))
+			gomega.Expect(ScaleMetricsEnabled()).To(gomega.BeFalse())
+			gomega.Expect(scaleMetricsReloads).To(gomega.Equal(1))
+			gomega.Expect(logLevelReloads).To(gomega.Equal(1))
+			// mtu requires a restart
+			gomega.Expect(Default.MTU).To(gomega.Equal(1500))
+
+			// reloading an unchanged file doesn't call the handlers again
+			err = ReloadConfigFile(cfgFile.Name())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(scaleMetricsReloads).To(gomega.Equal(1))
+			gomega.Expect(logLevelReloads).To(gomega.Equal(1))
+
+			// unregistered handlers are not called anymore
+			unregister()
+			err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile,
+				"loglevel=3", "enable-scale-metrics=true", "mtu=1400")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = ReloadConfigFile(cfgFile.Name())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(GetLogLevel()).To(gomega.Equal(3))
+			gomega.Expect(scaleMetricsReloads).To(gomega.Equal(2))
+			gomega.Expect(logLevelReloads).To(gomega.Equal(1))
+			return nil
+		}
+		err = app.Run([]string{app.Name, "-config-file=" + cfgFile.Name()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("updates the cluster subnets at runtime", func() {
+		kubeconfigFile, _, err := createTempFile("kubeconfig")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeconfigFile)
+
+		kubeCAFile, _, err := createTempFile("kube-ca.crt")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeCAFile)
+
+		err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err = InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Default.RawClusterSubnets).To(gomega.Equal("10.132.0.0/14/23"))
+
+			reloads := 0
+			OnReload(ReloadableClusterSubnets, func() { reloads++ })
+
+			clusterSubnets, err := ParseClusterSubnetEntries("10.132.0.0/14/23,10.136.0.0/14/24")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			draining := []*net.IPNet{ovntest.MustParseIPNet("10.132.0.0/14")}
+			err = UpdateClusterSubnets(clusterSubnets, draining)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Default.ClusterSubnets).To(gomega.Equal(clusterSubnets))
+			gomega.Expect(Default.DrainingClusterSubnets).To(gomega.Equal(draining))
+			gomega.Expect(IsDrainingClusterSubnet(ovntest.MustParseIPNet("10.132.0.0/14"))).To(gomega.BeTrue())
+			gomega.Expect(IsDrainingClusterSubnet(ovntest.MustParseIPNet("10.136.0.0/14"))).To(gomega.BeFalse())
+			gomega.Expect(reloads).To(gomega.Equal(1))
+
+			// an unchanged update doesn't call the handlers again
+			err = UpdateClusterSubnets(clusterSubnets, draining)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(reloads).To(gomega.Equal(1))
+
+			for _, invalid := range []struct {
+				clusterSubnets string
+				draining       []*net.IPNet
+			}{
+				// host subnet length change
+				{"10.132.0.0/14/24", nil},
+				// overlaps the service subnet
+				{"10.132.0.0/14/23,172.18.0.0/16/24", nil},
+				// changes the IP families
+				{"10.132.0.0/14/23,fd01::/48/64", nil},
+				// drains a subnet that is not a cluster subnet
+				{"10.132.0.0/14/23", []*net.IPNet{ovntest.MustParseIPNet("10.136.0.0/14")}},
+			} {
+				entries, err := ParseClusterSubnetEntries(invalid.clusterSubnets)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = UpdateClusterSubnets(entries, invalid.draining)
+				gomega.Expect(err).To(gomega.HaveOccurred(), invalid.clusterSubnets)
+			}
+			err = UpdateClusterSubnets(nil, nil)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(Default.ClusterSubnets).To(gomega.Equal(clusterSubnets))
+			gomega.Expect(reloads).To(gomega.Equal(1))
+			return nil
+		}
+		err = app.Run([]string{app.Name, "-config-file=" + cfgFile.Name()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("does not reload config file options overridden by CLI options", func() {
+		kubeconfigFile, _, err := createTempFile("kubeconfig")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeconfigFile)
+
+		kubeCAFile, _, err := createTempFile("kube-ca.crt")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.Remove(kubeCAFile)
+
+		err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err = InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Logging.Level).To(gomega.Equal(3))
+
+			err = writeTestConfigFile(cfgFile.Name(), "kubeconfig="+kubeconfigFile, "cacert="+kubeCAFile, "loglevel=4")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = ReloadConfigFile(cfgFile.Name())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Logging.Level).To(gomega.Equal(3))
+			return nil
+		}
+		err = app.Run([]string{app.Name, "-config-file=" + cfgFile.Name(), "-loglevel=3"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("overrides config file and defaults with CLI options", func() {
 		kubeconfigFile, _, err := createTempFile("kubeconfig")
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -655,6 +815,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Logging.File).To(gomega.Equal("/some/logfile"))
 			gomega.Expect(Logging.Level).To(gomega.Equal(3))
 			gomega.Expect(Logging.ACLLoggingRateLimit).To(gomega.Equal(30))
+			gomega.Expect(Logging.ACLLogExportSource).To(gomega.Equal("unix:/var/run/ovn/acl-log.sock"))
+			gomega.Expect(Logging.ACLLogExportTarget).To(gomega.Equal("syslog"))
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/some/cni/dir"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("a-plugin"))
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(kubeconfigFile))
@@ -704,6 +866,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(HybridOverlay.Enabled).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(5))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(4321))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityCheckJitter).To(gomega.Equal(20))
+			gomega.Expect(OVNKubernetesFeature.EgressIPReachabilityFailureThreshold).To(gomega.Equal(2))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeTrue())
 			gomega.Expect(HybridOverlay.ClusterSubnets).To(gomega.Equal([]CIDRNetworkEntry{
 				{ovntest.MustParseIPNet("11.132.0.0/14"), 23},
@@ -723,6 +887,8 @@ var _ = Describe("Config Operations", func() {
 			"-loglevel=3",
 			"-logfile=/some/logfile",
 			"-acl-logging-rate-limit=30",
+			"-acl-log-export-source=unix:/var/run/ovn/acl-log.sock",
+			"-acl-log-export-target=syslog",
 			"-cni-conf-dir=/some/cni/dir",
 			"-cni-plugin=a-plugin",
 			"-cluster-subnets=10.130.0.0/15/24",
@@ -763,6 +929,8 @@ var _ = Describe("Config Operations", func() {
 			"-metrics-enable-config-duration=true",
 			"-egressip-reachability-total-timeout=5",
 			"-egressip-node-healthcheck-port=4321",
+			"-egressip-reachability-check-jitter=20",
+			"-egressip-reachability-failure-threshold=2",
 			"-enable-multi-network=true",
 			"-healthz-bind-address=0.0.0.0:4321",
 		}
@@ -1017,6 +1185,50 @@ enable-pprof=true
 		err := app.Run(cliArgs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
+	It("parses the BGP neighbors from the config file", func() {
+		err := ioutil.WriteFile(cfgFile.Name(), []byte(`[bgp]
+enabled=true
+asn=64512
+config-mode=file
+neighbors=172.18.0.1, fc00:f853:ccd:e793:0::1
+`), 0o644)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(BGP.Enabled).To(gomega.BeTrue())
+			gomega.Expect(BGP.ASN).To(gomega.Equal(uint(64512)))
+			gomega.Expect(BGP.ConfigMode).To(gomega.Equal(BGPConfigModeFile))
+			gomega.Expect(BGP.ConfigFile).To(gomega.Equal("/etc/frr/frr.conf"))
+			gomega.Expect(BGP.NeighborAddresses).To(gomega.Equal([]string{"172.18.0.1", "fc00:f853:ccd:e793::1"}))
+			return nil
+		}
+		err = app.Run([]string{app.Name, "-config-file=" + cfgFile.Name()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the BGP options are invalid", func() {
+		for _, tc := range []struct {
+			args []string
+			err  string
+		}{
+			{[]string{"-enable-bgp"}, "invalid BGP autonomous system number 0"},
+			{[]string{"-enable-bgp", "-bgp-asn=64512", "-bgp-config-mode=file"}, "bgp-neighbors must be provided in file config mode"},
+			{[]string{"-enable-bgp", "-bgp-asn=64512", "-bgp-neighbors=foo"}, "invalid BGP neighbor address \"foo\""},
+			{[]string{"-bgp-disable-snat", "-disable-snat-multiple-gws"}, "bgp-disable-snat is not supported together with disable-snat-multiple-gws"},
+		} {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := InitConfig(ctx, kexec.New(), nil)
+				gomega.Expect(err).To(gomega.MatchError(tc.err))
+				return nil
+			}
+			err := app.Run(append([]string{app.Name}, tc.args...))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(PrepareTestConfig()).To(gomega.Succeed())
+		}
+	})
+
 	It("returns an error when the v4 join subnet specified is invalid", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1447,6 +1659,21 @@ foo=bar
 			gomega.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
 		})
 
+		It("rejects a zero northbound backup interval or retention", func() {
+			cliConfig := &OvnAuthConfig{
+				BackupDir:       "/var/lib/ovn/backups",
+				BackupInterval:  0,
+				BackupRetention: 24,
+			}
+			_, err := buildOvnAuth(ovntest.NewFakeExec(), true, cliConfig, &OvnAuthConfig{}, false)
+			gomega.Expect(err).To(gomega.MatchError("nb-backup-interval must be greater than 0"))
+
+			cliConfig.BackupInterval = 60
+			cliConfig.BackupRetention = 0
+			_, err = buildOvnAuth(ovntest.NewFakeExec(), true, cliConfig, &OvnAuthConfig{}, false)
+			gomega.Expect(err).To(gomega.MatchError("nb-backup-retention must be greater than 0"))
+		})
+
 		It("configures client southbound SSL correctly", func() {
 			fexec := ovntest.NewFakeExec()
 			fexec.AddFakeCmdsNoOutputNoError([]string{
@@ -1761,6 +1988,90 @@ foo=bar
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
 
+		It("Binds the PFs of the host to the DPU devices in dpu-host mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeDPUHost,
+					MgmtPortNetdev: "enp3s0f0v0",
+					RawDPUDevices:  "name=dpu0,pf=0000:03:00.0;name=dpu1,pf=0000:81:00.0",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.DPUDevices).To(gomega.HaveLen(2))
+			gomega.Expect(OvnKubeNode.GetDPUDeviceByPF("0000:81:00.0").Name).To(gomega.Equal("dpu1"))
+			gomega.Expect(OvnKubeNode.GetDPUDeviceByPF("0000:04:00.0")).To(gomega.BeNil())
+		})
+
+		It("Fails if a DPU device has no PF in dpu-host mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeDPUHost,
+					MgmtPortNetdev: "enp3s0f0v0",
+					RawDPUDevices:  "name=dpu0,pf=0000:03:00.0;name=dpu1",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("must have a pf"))
+		})
+
+		It("Does not require a management port on a secondary DPU in dpu mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:          types.NodeModeDPU,
+					RawDPUDevices: "name=dpu0;name=dpu1",
+					DPUName:       "dpu1",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.IsPrimaryDPU()).To(gomega.BeFalse())
+			gomega.Expect(OvnKubeNode.MgmtPortNetdev).To(gomega.Equal(""))
+		})
+
+		It("Requires the management port of the node on the primary DPU in dpu mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:          types.NodeModeDPU,
+					RawDPUDevices: "name=dpu0;name=dpu1",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided"))
+		})
+
+		It("Fails if the DPU name is not one of the DPU devices", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:          types.NodeModeDPU,
+					RawDPUDevices: "name=dpu0",
+					DPUName:       "dpu1",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("is not one of the DPU devices"))
+		})
+
+		It("Fails if DPU devices are provided in the full mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:          types.NodeModeFull,
+					RawDPUDevices: "name=dpu0,pf=0000:03:00.0",
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("ovnkube-node-dpu-devices is only supported"))
+		})
+
 		It("Succeeds if management port device plugin resource name provided in the full mode", func() {
 			cliConfig := config{
 				OvnKubeNode: OvnKubeNodeConfig{
@@ -0,0 +1,396 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/fsnotify/fsnotify.v1"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// Settings that can be changed in the config file without restarting ovnkube,
+// named as "<section>.<key>" of the config file
+const (
+	// ReloadableLogLevel is applied to klog by the config package
+	ReloadableLogLevel            = "logging.loglevel"
+	ReloadableACLLoggingRateLimit = "logging.acl-logging-rate-limit"
+	ReloadableScaleMetrics        = "metrics.enable-scale-metrics"
+	// ReloadableClusterSubnets is changed from the cluster networks ConfigMap, see UpdateClusterSubnets
+	ReloadableClusterSubnets = "default.cluster-subnets"
+)
+
+var (
+	// reloadLock serializes config file reloads and protects fileConfig and reloadHandlers
+	reloadLock sync.Mutex
+	// fileConfig holds the values read from the config file by the last successful (re)load
+	fileConfig config
+	// reloadHandlers are called when the given reloadable setting changed
+	reloadHandlers = map[string][]*reloadHandler{}
+
+	// settingsLock protects the global values of the reloadable settings once ovnkube is running,
+	// they must be read with their accessors
+	settingsLock sync.RWMutex
+)
+
+// reloadHandler wraps a handler registered with OnReload, so that it can be unregistered
+type reloadHandler struct {
+	handler func()
+}
+
+func setFileConfig(cfg config) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	fileConfig = cfg
+}
+
+// OnReload registers handler to be called after the given reloadable setting was changed
+// in the config file and the new value was applied to the global config.
+// It returns a function that unregisters the handler, that must be called when the handler
+// owner is stopped before ovnkube exits.
+func OnReload(setting string, handler func()) func() {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	h := &reloadHandler{handler: handler}
+	reloadHandlers[setting] = append(reloadHandlers[setting], h)
+	return func() {
+		reloadLock.Lock()
+		defer reloadLock.Unlock()
+		handlers := reloadHandlers[setting]
+		for i := range handlers {
+			if handlers[i] == h {
+				reloadHandlers[setting] = append(handlers[:i:i], handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// getReloadHandlers returns the handlers registered for setting, reloadLock must be held
+func getReloadHandlers(setting string) []func() {
+	handlers := make([]func(), 0, len(reloadHandlers[setting]))
+	for _, h := range reloadHandlers[setting] {
+		handlers = append(handlers, h.handler)
+	}
+	return handlers
+}
+
+// GetLogLevel returns the current log level, that may be changed in the config file while running
+func GetLogLevel() int {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return Logging.Level
+}
+
+// GetACLLoggingRateLimit returns the current ACL logging rate limit, that may be changed
+// in the config file while running
+func GetACLLoggingRateLimit() int {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return Logging.ACLLoggingRateLimit
+}
+
+// ScaleMetricsEnabled returns true if the scale metrics are enabled, they may be toggled
+// in the config file while running
+func ScaleMetricsEnabled() bool {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return Metrics.EnableScaleMetrics
+}
+
+// WatchConfigFile reloads configFile whenever it changes, until stopChan is closed.
+// The parent directory is watched, so that config files mounted from a ConfigMap,
+// that are replaced rather than written to, are reloaded as well.
+func WatchConfigFile(configFile string, stopChan <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(configFile)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config file %s: %v", configFile, err)
+	}
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// unchanged settings are ignored, so there is no need to filter events
+				if err := ReloadConfigFile(configFile); err != nil {
+					klog.Errorf("Failed to reload config file %s: %v", configFile, err)
+				}
+			case err, ok := <-watcher.Errors:
+				if ok {
+					klog.Errorf("Error watching config file %s: %v", configFile, err)
+				}
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+	klog.Infof("Watching config file %s for changes", configFile)
+	return nil
+}
+
+// ReloadConfigFile reads configFile again and applies the changed reloadable settings.
+// Changes to any other setting are only logged, they require a restart to be applied.
+// Settings given on the command line take precedence over the config file and are never reloaded.
+func ReloadConfigFile(configFile string) error {
+	f, err := os.Open(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the file may be in the middle of being replaced
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	cfg := newFileConfig()
+	if err := parseConfigFile(f, &cfg); err != nil {
+		return err
+	}
+
+	reloadLock.Lock()
+	changed := changedSettings(&fileConfig, &cfg)
+	applied := []string{}
+	var errs []error
+	for _, setting := range changed {
+		if isSetOnCLI(setting) {
+			klog.Warningf("Ignoring change of %s in config file %s: it is set on the command line", setting, configFile)
+			continue
+		}
+		ok, err := applySetting(setting, &cfg)
+		if err != nil {
+			errs = append(errs, err)
+			// keep the previous value so that a fixed value is detected as a change
+			setSetting(&cfg, setting, getSetting(&fileConfig, setting))
+			continue
+		}
+		if !ok {
+			klog.Warningf("Ignoring change of %s in config file %s: ovnkube must be restarted to apply it", setting, configFile)
+			continue
+		}
+		klog.Infof("Applied change of %s from config file %s", setting, configFile)
+		applied = append(applied, setting)
+	}
+	fileConfig = cfg
+	handlers := []func(){}
+	for _, setting := range applied {
+		handlers = append(handlers, getReloadHandlers(setting)...)
+	}
+	reloadLock.Unlock()
+
+	// handlers may take a while, don't block other reloads or handler registration on them
+	for _, handler := range handlers {
+		handler()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// applySetting sets the global config value of setting from cfg. It returns false if setting can't be reloaded.
+func applySetting(setting string, cfg *config) (bool, error) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+	switch setting {
+	case ReloadableLogLevel:
+		if err := setLogLevel(cfg.Logging.Level); err != nil {
+			return false, err
+		}
+		Logging.Level = cfg.Logging.Level
+	case ReloadableACLLoggingRateLimit:
+		if cfg.Logging.ACLLoggingRateLimit <= 0 {
+			return false, fmt.Errorf("invalid %s %d, must be positive", setting, cfg.Logging.ACLLoggingRateLimit)
+		}
+		Logging.ACLLoggingRateLimit = cfg.Logging.ACLLoggingRateLimit
+	case ReloadableScaleMetrics:
+		Metrics.EnableScaleMetrics = cfg.Metrics.EnableScaleMetrics
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// isSetOnCLI returns true if the reloadable setting was given on the command line
+func isSetOnCLI(setting string) bool {
+	switch setting {
+	case ReloadableLogLevel:
+		return cliConfig.Logging.Level != savedLogging.Level
+	case ReloadableACLLoggingRateLimit:
+		return cliConfig.Logging.ACLLoggingRateLimit != savedLogging.ACLLoggingRateLimit
+	case ReloadableScaleMetrics:
+		return cliConfig.Metrics.EnableScaleMetrics != savedMetrics.EnableScaleMetrics
+	}
+	return false
+}
+
+// changedSettings returns the sorted names of the config file settings that differ between old and new
+func changedSettings(old, new *config) []string {
+	changed := []string{}
+	forEachSetting(new, func(setting string, value reflect.Value) {
+		if !reflect.DeepEqual(getSetting(old, setting), value.Interface()) {
+			changed = append(changed, setting)
+		}
+	})
+	sort.Strings(changed)
+	return changed
+}
+
+// forEachSetting calls f with the name and value of every config file setting of cfg
+func forEachSetting(cfg *config, f func(setting string, value reflect.Value)) {
+	cfgValue := reflect.ValueOf(cfg).Elem()
+	cfgType := cfgValue.Type()
+	for i := 0; i < cfgType.NumField(); i++ {
+		section := strings.ToLower(cfgType.Field(i).Name)
+		sectionValue := cfgValue.Field(i)
+		sectionType := sectionValue.Type()
+		for j := 0; j < sectionType.NumField(); j++ {
+			key, ok := sectionType.Field(j).Tag.Lookup("gcfg")
+			if !ok {
+				continue
+			}
+			f(section+"."+key, sectionValue.Field(j))
+		}
+	}
+}
+
+func getSetting(cfg *config, setting string) interface{} {
+	var result interface{}
+	forEachSetting(cfg, func(name string, value reflect.Value) {
+		if name == setting {
+			result = value.Interface()
+		}
+	})
+	return result
+}
+
+// setSetting sets setting in cfg, that must not be one of the global configs
+func setSetting(cfg *config, setting string, v interface{}) {
+	forEachSetting(cfg, func(name string, value reflect.Value) {
+		if name == setting {
+			value.Set(reflect.ValueOf(v))
+		}
+	})
+}
+
+// UpdateClusterSubnets replaces the cluster subnets, and the subset of them from which no new
+// host subnets must be allocated, and calls the ReloadableClusterSubnets handlers if they changed.
+// Cluster subnets may be added and removed, but the host subnet length of an existing cluster
+// subnet can't be changed, and the cluster IP families must stay the same.
+// Once ovnkube is running, the cluster subnets must be read with GetClusterSubnets or
+// GetClusterSubnetsWithDraining.
+func UpdateClusterSubnets(clusterSubnets []CIDRNetworkEntry, draining []*net.IPNet) error {
+	if len(clusterSubnets) == 0 {
+		return fmt.Errorf("cluster subnet is required")
+	}
+	allSubnets := newConfigSubnets()
+	for _, subnet := range clusterSubnets {
+		allSubnets.append(configSubnetCluster, subnet.CIDR)
+	}
+	for _, subnet := range Kubernetes.ServiceCIDRs {
+		allSubnets.append(configSubnetService, subnet)
+	}
+	for _, joinSubnet := range []string{Gateway.V4JoinSubnet, Gateway.V6JoinSubnet} {
+		// already validated by completeGatewayConfig
+		if _, subnet, err := net.ParseCIDR(joinSubnet); err == nil {
+			allSubnets.append(configSubnetJoin, subnet)
+		}
+	}
+	for _, subnet := range HybridOverlay.ClusterSubnets {
+		allSubnets.append(configSubnetHybrid, subnet.CIDR)
+	}
+	if err := allSubnets.checkForOverlaps(); err != nil {
+		return err
+	}
+	ipv4Mode, ipv6Mode, err := allSubnets.checkIPFamilies()
+	if err != nil {
+		return err
+	}
+	if ipv4Mode != IPv4Mode || ipv6Mode != IPv6Mode {
+		return fmt.Errorf("cluster subnets %v don't match the IP families of the cluster", clusterSubnets)
+	}
+
+	reloadLock.Lock()
+	for _, subnet := range clusterSubnets {
+		for _, current := range Default.ClusterSubnets {
+			if subnet.CIDR.String() == current.CIDR.String() && subnet.HostSubnetLength != current.HostSubnetLength {
+				reloadLock.Unlock()
+				return fmt.Errorf("host subnet length of cluster subnet %s can't be changed from %d to %d",
+					subnet.CIDR, current.HostSubnetLength, subnet.HostSubnetLength)
+			}
+		}
+	}
+	for _, subnet := range draining {
+		if !containsClusterSubnet(clusterSubnets, subnet) {
+			reloadLock.Unlock()
+			return fmt.Errorf("draining subnet %s is not a cluster subnet", subnet)
+		}
+	}
+	changed := !reflect.DeepEqual(Default.ClusterSubnets, clusterSubnets) ||
+		!reflect.DeepEqual(Default.DrainingClusterSubnets, draining)
+	if changed {
+		settingsLock.Lock()
+		Default.ClusterSubnets = clusterSubnets
+		Default.DrainingClusterSubnets = draining
+		settingsLock.Unlock()
+		klog.Infof("Updated cluster subnets to %v, draining %v", clusterSubnets, draining)
+	}
+	handlers := getReloadHandlers(ReloadableClusterSubnets)
+	reloadLock.Unlock()
+
+	if !changed {
+		return nil
+	}
+	for _, handler := range handlers {
+		handler()
+	}
+	return nil
+}
+
+// GetClusterSubnets returns the current cluster subnets, that may be changed at runtime by
+// UpdateClusterSubnets. The returned slice must not be modified.
+func GetClusterSubnets() []CIDRNetworkEntry {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return Default.ClusterSubnets
+}
+
+// GetClusterSubnetsWithDraining returns the current cluster subnets, and the subset of them from
+// which no new host subnets must be allocated, as set together by UpdateClusterSubnets.
+// The returned slices must not be modified.
+func GetClusterSubnetsWithDraining() ([]CIDRNetworkEntry, []*net.IPNet) {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return Default.ClusterSubnets, Default.DrainingClusterSubnets
+}
+
+// IsDrainingClusterSubnet returns true if no new host subnets must be allocated from subnet
+func IsDrainingClusterSubnet(subnet *net.IPNet) bool {
+	_, drainingSubnets := GetClusterSubnetsWithDraining()
+	for _, draining := range drainingSubnets {
+		if draining.String() == subnet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsClusterSubnet(clusterSubnets []CIDRNetworkEntry, subnet *net.IPNet) bool {
+	for _, clusterSubnet := range clusterSubnets {
+		if clusterSubnet.CIDR.String() == subnet.String() {
+			return utilnet.IsIPv6CIDR(clusterSubnet.CIDR) == utilnet.IsIPv6CIDR(subnet)
+		}
+	}
+	return false
+}
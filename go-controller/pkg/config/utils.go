@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -92,6 +94,129 @@ func ParseClusterSubnetEntries(clusterSubnetCmd string) ([]CIDRNetworkEntry, err
 	return parsedClusterList, nil
 }
 
+// HostSubnetPool is the object that holds the host subnet lengths of the nodes selected by a
+// node selector. A length of 0 stands for the host subnet length of the cluster subnets.
+type HostSubnetPool struct {
+	NodeSelector         labels.Selector
+	IPv4HostSubnetLength int
+	IPv6HostSubnetLength int
+}
+
+// ParseHostSubnetPools returns the parsed set of HostSubnetPools passed by the user on the
+// command line. Entries are separated by ';' and given in the form
+// [node-label-selector:ipv4-hostsubnet-prefix-length[/ipv6-hostsubnet-prefix-length]], where
+// either length can be omitted, eg "node-pool=edge:26/64;node-pool=bigmem:22;node-pool=v6:/60".
+func ParseHostSubnetPools(hostSubnetPools string) ([]HostSubnetPool, error) {
+	var parsedPools []HostSubnetPool
+	for _, entry := range strings.Split(hostSubnetPools, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("host subnet pool %q not properly formatted", entry)
+		}
+		selector, err := labels.Parse(entry[:i])
+		if err != nil {
+			return nil, fmt.Errorf("host subnet pool %q has an invalid node selector: %v", entry, err)
+		}
+		if selector.Empty() {
+			return nil, fmt.Errorf("host subnet pool %q must select nodes by label", entry)
+		}
+		pool := HostSubnetPool{NodeSelector: selector}
+		rawIPv4Length, rawIPv6Length, _ := strings.Cut(entry[i+1:], "/")
+		if rawIPv4Length == "" && rawIPv6Length == "" {
+			return nil, fmt.Errorf("host subnet pool %q has no host subnet length", entry)
+		}
+		if rawIPv4Length != "" {
+			pool.IPv4HostSubnetLength, err = strconv.Atoi(rawIPv4Length)
+			if err != nil {
+				return nil, fmt.Errorf("host subnet pool %q has an invalid IPv4 host subnet length: %v", entry, err)
+			}
+			if pool.IPv4HostSubnetLength <= 0 || pool.IPv4HostSubnetLength >= 32 {
+				return nil, fmt.Errorf("host subnet pool %q has an invalid IPv4 host subnet length", entry)
+			}
+		}
+		if rawIPv6Length != "" {
+			pool.IPv6HostSubnetLength, err = strconv.Atoi(rawIPv6Length)
+			if err != nil {
+				return nil, fmt.Errorf("host subnet pool %q has an invalid IPv6 host subnet length: %v", entry, err)
+			}
+			if pool.IPv6HostSubnetLength <= 0 || pool.IPv6HostSubnetLength >= 128 {
+				return nil, fmt.Errorf("host subnet pool %q has an invalid IPv6 host subnet length", entry)
+			}
+		}
+		parsedPools = append(parsedPools, pool)
+	}
+	return parsedPools, nil
+}
+
+// DPUDevice is the object that holds the configuration of one of the DPUs of a host
+type DPUDevice struct {
+	// Name identifies the DPU in the pods bound to it and in the annotation of its chassis ID
+	// on the node
+	Name string
+	// PfPciAddresses are the PCI addresses, on the host, of the PFs of the DPU. The pods
+	// with a VF of one of them are plugged by this DPU.
+	PfPciAddresses []string
+}
+
+// dpuNameMaxLength is the length of the longest DPU name that keeps the name of the annotation
+// of the chassis ID of the DPU, k8s.ovn.org/node-chassis-id.<name>, within 63 characters
+const dpuNameMaxLength = 47
+
+// ParseDPUDevices returns the parsed set of DPUDevices passed by the user on the command
+// line. Entries are separated by ';' and given as ',' separated key=value pairs, eg
+// "name=dpu0,pf=0000:03:00.0,pf=0000:03:00.1;name=dpu1,pf=0000:81:00.0".
+func ParseDPUDevices(dpuDevices string) ([]DPUDevice, error) {
+	var parsedDevices []DPUDevice
+	names := map[string]bool{}
+	pfs := map[string]bool{}
+	for _, entry := range strings.Split(dpuDevices, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		device := DPUDevice{}
+		for _, option := range strings.Split(entry, ",") {
+			key, value, found := strings.Cut(strings.TrimSpace(option), "=")
+			if !found || value == "" {
+				return nil, fmt.Errorf("DPU device %q option %q not properly formatted", entry, option)
+			}
+			switch key {
+			case "name":
+				device.Name = value
+			case "pf":
+				var domain, bus, dev, fn int
+				if _, err := fmt.Sscanf(value, "%04x:%02x:%02x.%d", &domain, &bus, &dev, &fn); err != nil {
+					return nil, fmt.Errorf("DPU device %q has an invalid PF PCI address %q: %v", entry, value, err)
+				}
+				if pfs[value] {
+					return nil, fmt.Errorf("DPU device %q has the PF %s of another DPU device", entry, value)
+				}
+				pfs[value] = true
+				device.PfPciAddresses = append(device.PfPciAddresses, value)
+			default:
+				return nil, fmt.Errorf("DPU device %q has an unknown option %q", entry, key)
+			}
+		}
+		if device.Name == "" {
+			return nil, fmt.Errorf("DPU device %q must have a name", entry)
+		}
+		if errs := validation.IsDNS1123Label(device.Name); len(errs) > 0 || len(device.Name) > dpuNameMaxLength {
+			return nil, fmt.Errorf("DPU device name %q must be a lowercase RFC 1123 label of at most %d characters",
+				device.Name, dpuNameMaxLength)
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("DPU device name %q is not unique", device.Name)
+		}
+		names[device.Name] = true
+		parsedDevices = append(parsedDevices, device)
+	}
+	return parsedDevices, nil
+}
+
 // ParseFlowCollectors returns the parsed set of HostPorts passed by the user on the command line
 // These entries define the flow collectors OVS will send flow metadata by using NetFlow/SFlow/IPFIX.
 func ParseFlowCollectors(flowCollectors string) ([]HostPort, error) {
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"net"
+	"reflect"
+	"strings"
 	"testing"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -234,6 +237,176 @@ func Test_checkForOverlap(t *testing.T) {
 	}
 }
 
+func TestParseHostSubnetPools(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmdLineArg  string
+		pools       []string
+		expectedErr bool
+	}{
+		{
+			name:       "Two pools correctly formatted",
+			cmdLineArg: "node-pool=edge:26; node-pool in (bigmem,gpu),zone=a:22",
+			pools:      []string{"node-pool=edge /26 /0", "node-pool in (bigmem,gpu),zone=a /22 /0"},
+		},
+		{
+			name:       "IPv4 and IPv6 host subnet lengths",
+			cmdLineArg: "node-pool=edge:26/62;node-pool=v6:/60",
+			pools:      []string{"node-pool=edge /26 /62", "node-pool=v6 /0 /60"},
+		},
+		{
+			name:        "No host subnet length of either family",
+			cmdLineArg:  "node-pool=edge:/",
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid IPv6 host subnet length",
+			cmdLineArg:  "node-pool=edge:26/128",
+			expectedErr: true,
+		},
+		{
+			name:       "Empty",
+			cmdLineArg: "",
+		},
+		{
+			name:        "Missing host subnet length",
+			cmdLineArg:  "node-pool=edge",
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid host subnet length",
+			cmdLineArg:  "node-pool=edge:32",
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid node selector",
+			cmdLineArg:  "node-pool=edge!:26",
+			expectedErr: true,
+		},
+		{
+			name:        "Empty node selector",
+			cmdLineArg:  ":26",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		pools, err := ParseHostSubnetPools(tc.cmdLineArg)
+		if err != nil {
+			if !tc.expectedErr {
+				t.Errorf("Test case \"%s\" expected no errors, got %v", tc.name, err)
+			}
+			continue
+		}
+		if tc.expectedErr {
+			t.Errorf("Test case \"%s\" expected an error but got %v", tc.name, pools)
+			continue
+		}
+		got := []string{}
+		for _, pool := range pools {
+			got = append(got, fmt.Sprintf("%s /%d /%d", pool.NodeSelector, pool.IPv4HostSubnetLength, pool.IPv6HostSubnetLength))
+		}
+		if len(got) != len(tc.pools) || (len(got) > 0 && !reflect.DeepEqual(got, tc.pools)) {
+			t.Errorf("Test case \"%s\" expected pools %v, got %v", tc.name, tc.pools, got)
+		}
+	}
+}
+
+func TestParseDPUDevices(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmdLineArg  string
+		devices     []DPUDevice
+		expectedErr bool
+	}{
+		{
+			name:       "Two devices correctly formatted",
+			cmdLineArg: "name=dpu0,pf=0000:03:00.0,pf=0000:03:00.1; name=dpu1,pf=0000:81:00.0",
+			devices: []DPUDevice{
+				{
+					Name:           "dpu0",
+					PfPciAddresses: []string{"0000:03:00.0", "0000:03:00.1"},
+				},
+				{
+					Name:           "dpu1",
+					PfPciAddresses: []string{"0000:81:00.0"},
+				},
+			},
+		},
+		{
+			name:       "Devices without PF",
+			cmdLineArg: "name=dpu0;name=dpu1",
+			devices:    []DPUDevice{{Name: "dpu0"}, {Name: "dpu1"}},
+		},
+		{
+			name:       "Empty",
+			cmdLineArg: "",
+		},
+		{
+			name:        "Missing name",
+			cmdLineArg:  "pf=0000:03:00.0",
+			expectedErr: true,
+		},
+		{
+			name:        "Duplicate name",
+			cmdLineArg:  "name=dpu0,pf=0000:03:00.0;name=dpu0,pf=0000:81:00.0",
+			expectedErr: true,
+		},
+		{
+			name:        "PF of two devices",
+			cmdLineArg:  "name=dpu0,pf=0000:03:00.0;name=dpu1,pf=0000:03:00.0",
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid PF PCI address",
+			cmdLineArg:  "name=dpu0,pf=enp3s0f0",
+			expectedErr: true,
+		},
+		{
+			name:        "Unknown option",
+			cmdLineArg:  "name=dpu0,vf=0000:03:00.2",
+			expectedErr: true,
+		},
+		{
+			name:        "Option without value",
+			cmdLineArg:  "name=dpu0,pf",
+			expectedErr: true,
+		},
+		{
+			name:        "Management port option",
+			cmdLineArg:  "name=dpu0,pf=0000:03:00.0,mgmt-port-netdev=enp3s0f0v0",
+			expectedErr: true,
+		},
+		{
+			name:        "Invalid name",
+			cmdLineArg:  "name=DPU_0,pf=0000:03:00.0",
+			expectedErr: true,
+		},
+		{
+			name:        "Name too long",
+			cmdLineArg:  "name=" + strings.Repeat("d", dpuNameMaxLength+1),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		devices, err := ParseDPUDevices(tc.cmdLineArg)
+		if err != nil {
+			if !tc.expectedErr {
+				t.Errorf("Test case \"%s\" expected no errors, got %v", tc.name, err)
+			}
+			continue
+		}
+		if tc.expectedErr {
+			t.Errorf("Test case \"%s\" expected an error but got %v", tc.name, devices)
+			continue
+		}
+		if !reflect.DeepEqual(devices, tc.devices) {
+			t.Errorf("Test case \"%s\" expected devices %v, got %v", tc.name, tc.devices, devices)
+		}
+	}
+}
+
 func TestParseFlowCollectors(t *testing.T) {
 	hp, err := ParseFlowCollectors("10.0.0.2:3030,:8888,[2020:1111:f::1:0933]:3333,10.0.0.3:3031")
 	if err != nil {
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +resource:path=egressinterface
+// +kubebuilder:resource:shortName=eif,scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Interface",type=string,JSONPath=".spec.interface"
+// EgressInterface is a CRD allowing the user to choose the node interface
+// that the egress traffic of the pods of the selected namespaces leaves
+// the nodes from, instead of the default gateway interface.
+type EgressInterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of EgressInterface.
+	Spec EgressInterfaceSpec `json:"spec"`
+}
+
+// EgressInterfaceSpec is a desired state description of EgressInterface.
+type EgressInterfaceSpec struct {
+	// Interface is the name of the NIC or of the OVS bridge on the nodes
+	// that the egress traffic of the selected namespaces is sent out of.
+	// A NIC is added to a new OVS bridge by ovnkube-node. Nodes that don't
+	// have the interface are ignored. This field is mandatory.
+	// +kubebuilder:validation:MinLength=1
+	Interface string `json:"interface"`
+	// NamespaceSelector applies the egress interface only to the namespace(s)
+	// whose label matches this definition. This field is mandatory.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=egressinterface
+// EgressInterfaceList is the list of EgressInterfaceList.
+type EgressInterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of EgressInterface.
+	Items []EgressInterface `json:"items"`
+}
@@ -33,8 +33,60 @@ type EgressIP struct {
 type EgressIPStatus struct {
 	// The list of assigned egress IPs and their corresponding node assignment.
 	Items []EgressIPStatusItem `json:"items"`
+	// Conditions report whether the egress IPs are assigned and, if they are
+	// not, why.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// EgressIPAssigned is True when all the egress IPs are assigned to a node
+	EgressIPAssigned = "Assigned"
+	// EgressIPPartiallyAssigned is True when some, but not all, of the egress
+	// IPs are assigned to a node
+	EgressIPPartiallyAssigned = "PartiallyAssigned"
+	// EgressIPUnreachable is True when egress IPs are not assigned because
+	// the egress nodes which could host them are not ready or not reachable
+	EgressIPUnreachable = "Unreachable"
+)
+
+// Reasons of the conditions of an EgressIP
+const (
+	EgressIPReasonAllAssigned          = "AllEgressIPsAssigned"
+	EgressIPReasonNoneAssigned         = "NoEgressIPAssigned"
+	EgressIPReasonSomeUnassigned       = "SomeEgressIPsUnassigned"
+	EgressIPReasonNodesReachable       = "EgressNodesReachable"
+	EgressIPReasonNoAssignableNode     = "NoAssignableNode"
+	EgressIPReasonNoNodeHostsSubnet    = "NoNodeHostsSubnet"
+	EgressIPReasonNodesUnreachable     = "EgressNodesUnreachable"
+	EgressIPReasonNotEnoughNodes       = "NotEnoughNodes"
+	EgressIPReasonCapacityExhausted    = "CapacityExhausted"
+	EgressIPReasonAlreadyAllocated     = "AlreadyAllocated"
+	EgressIPReasonNodeIP               = "NodeIP"
+	EgressIPReasonAssignmentInProgress = "AssignmentInProgress"
+)
+
+// EgressIPRebalancePolicy defines when assigned egress IPs are moved to other
+// egress nodes
+// +kubebuilder:validation:Enum=Never;WhenNodeReturns;Periodic
+type EgressIPRebalancePolicy string
+
+const (
+	// RebalanceNever only moves an egress IP when its node can no longer host
+	// it, e.g. because the node is not ready or reachable anymore. This is
+	// the default.
+	RebalanceNever EgressIPRebalancePolicy = "Never"
+	// RebalanceWhenNodeReturns also moves the egress IPs to an egress node
+	// preferred over their current node when that node becomes assignable,
+	// e.g. when it is ready and reachable again.
+	RebalanceWhenNodeReturns EgressIPRebalancePolicy = "WhenNodeReturns"
+	// RebalancePeriodic also moves the egress IPs to the egress nodes
+	// preferred over their current node periodically.
+	RebalancePeriodic EgressIPRebalancePolicy = "Periodic"
+)
+
 // The per node status, for those egress IPs who have been assigned.
 type EgressIPStatusItem struct {
 	// Assigned node name
@@ -60,6 +112,19 @@ type EgressIPSpec struct {
 	// match this pod selector.
 	// +optional
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// PreferredNodes is an ordered list of egress nodes the egress IPs should
+	// preferably be assigned to, the first nodes being the most preferred.
+	// The egress IPs are assigned to the other egress nodes only when none of
+	// the preferred nodes can host them. When it is not set the egress IPs are
+	// balanced across all the egress nodes.
+	// +optional
+	PreferredNodes []string `json:"preferredNodes,omitempty"`
+	// RebalancePolicy defines when the assigned egress IPs are moved to egress
+	// nodes preferred over their current node: a node listed earlier in
+	// PreferredNodes or, between equally preferred nodes, a node with fewer
+	// egress IPs. One of Never (default), WhenNodeReturns or Periodic.
+	// +optional
+	RebalancePolicy EgressIPRebalancePolicy `json:"rebalancePolicy,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
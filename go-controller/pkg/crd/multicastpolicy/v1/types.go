@@ -0,0 +1,62 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +resource:path=multicastpolicy
+// +kubebuilder:resource:shortName=mcp
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// MulticastPolicy restricts the multicast traffic of a Namespace that has
+// multicast enabled with the k8s.ovn.org/multicast-enabled annotation.
+// Without a MulticastPolicy the pods of the namespace can send and receive
+// traffic of any multicast group to and from the pods of the namespace.
+// The MulticastPolicy of a namespace must be named "default".
+type MulticastPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of MulticastPolicy.
+	Spec MulticastPolicySpec `json:"spec"`
+}
+
+// MulticastPolicySpec is a desired state description of MulticastPolicy.
+type MulticastPolicySpec struct {
+	// Groups are the multicast group ranges, in CIDR notation, that the pods
+	// of the namespace can send to and receive from, e.g. 239.1.0.0/16 or
+	// ff3e::/16. Any multicast group is allowed when empty.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// PeerNamespaces are the namespaces, besides the namespace of the policy,
+	// whose pods can send multicast traffic to the pods of the namespace.
+	// The peer namespaces must have multicast enabled for their pods to be
+	// allowed to send multicast traffic.
+	// +optional
+	PeerNamespaces []string `json:"peerNamespaces,omitempty"`
+	// IGMPSnooping, when set to false, disables IGMP/MLD snooping for the pods
+	// of the namespace: they receive the multicast traffic of the allowed
+	// groups whether or not they joined them. Snooping is enabled when unset.
+	// +optional
+	IGMPSnooping *bool `json:"igmpSnooping,omitempty"`
+	// FloodReports, when set to true, sets mcast_flood_reports on the logical
+	// switch ports of the pods of the namespace, so that they receive the
+	// multicast reports sent by the other pods of their node. A per-namespace
+	// querier is not implemented: this field doesn't enable, disable or
+	// configure a querier, which remains configured per node switch for all
+	// the namespaces.
+	// +optional
+	FloodReports bool `json:"floodReports,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=multicastpolicy
+// MulticastPolicyList is the list of MulticastPolicy.
+type MulticastPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of MulticastPolicy.
+	Items []MulticastPolicy `json:"items"`
+}
@@ -27,6 +27,16 @@ import (
 	egressqosinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/informers/externalversions"
 	egressqosinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/informers/externalversions/egressqos/v1"
 
+	egressinterfaceapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1"
+	egressinterfacescheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/clientset/versioned/scheme"
+	egressinterfaceinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/informers/externalversions"
+	egressinterfaceinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/informers/externalversions/egressinterface/v1"
+	egressinterfacelister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/listers/egressinterface/v1"
+	multicastpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
+	multicastpolicyscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/clientset/versioned/scheme"
+	multicastpolicyinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/informers/externalversions"
+	multicastpolicylister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/listers/multicastpolicy/v1"
+
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	nadscheme "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/scheme"
 	kapi "k8s.io/api/core/v1"
@@ -54,12 +64,14 @@ type WatchFactory struct {
 	// requirements with atomic accesses
 	handlerCounter uint64
 
-	iFactory         informerfactory.SharedInformerFactory
-	eipFactory       egressipinformerfactory.SharedInformerFactory
-	efFactory        egressfirewallinformerfactory.SharedInformerFactory
-	cpipcFactory     ocpcloudnetworkinformerfactory.SharedInformerFactory
-	egressQoSFactory egressqosinformerfactory.SharedInformerFactory
-	informers        map[reflect.Type]*informer
+	iFactory               informerfactory.SharedInformerFactory
+	eipFactory             egressipinformerfactory.SharedInformerFactory
+	efFactory              egressfirewallinformerfactory.SharedInformerFactory
+	cpipcFactory           ocpcloudnetworkinformerfactory.SharedInformerFactory
+	egressQoSFactory       egressqosinformerfactory.SharedInformerFactory
+	egressInterfaceFactory egressinterfaceinformerfactory.SharedInformerFactory
+	mcastPolicyFactory     multicastpolicyinformerfactory.SharedInformerFactory
+	informers              map[reflect.Type]*informer
 
 	stopChan chan struct{}
 }
@@ -124,6 +136,8 @@ var (
 	EgressFwNodeType                      reflect.Type = reflect.TypeOf(&egressFwNode{})
 	CloudPrivateIPConfigType              reflect.Type = reflect.TypeOf(&ocpcloudnetworkapi.CloudPrivateIPConfig{})
 	EgressQoSType                         reflect.Type = reflect.TypeOf(&egressqosapi.EgressQoS{})
+	EgressInterfaceType                   reflect.Type = reflect.TypeOf(&egressinterfaceapi.EgressInterface{})
+	MulticastPolicyType                   reflect.Type = reflect.TypeOf(&multicastpolicyapi.MulticastPolicy{})
 	AddressSetNamespaceAndPodSelectorType reflect.Type = reflect.TypeOf(&addressSetNamespaceAndPodSelector{})
 	PeerNamespaceSelectorType             reflect.Type = reflect.TypeOf(&peerNamespaceSelector{})
 	AddressSetPodSelectorType             reflect.Type = reflect.TypeOf(&addressSetPodSelector{})
@@ -148,13 +162,15 @@ func NewMasterWatchFactory(ovnClientset *util.OVNMasterClientset) (*WatchFactory
 	// the downside of making it tight (like 10 minutes) is needless spinning on all resources
 	// However, AddEventHandlerWithResyncPeriod can specify a per handler resync period
 	wf := &WatchFactory{
-		iFactory:         informerfactory.NewSharedInformerFactory(ovnClientset.KubeClient, resyncInterval),
-		eipFactory:       egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
-		efFactory:        egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
-		cpipcFactory:     ocpcloudnetworkinformerfactory.NewSharedInformerFactory(ovnClientset.CloudNetworkClient, resyncInterval),
-		egressQoSFactory: egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
-		informers:        make(map[reflect.Type]*informer),
-		stopChan:         make(chan struct{}),
+		iFactory:               informerfactory.NewSharedInformerFactory(ovnClientset.KubeClient, resyncInterval),
+		eipFactory:             egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
+		efFactory:              egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
+		cpipcFactory:           ocpcloudnetworkinformerfactory.NewSharedInformerFactory(ovnClientset.CloudNetworkClient, resyncInterval),
+		egressQoSFactory:       egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
+		egressInterfaceFactory: egressinterfaceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressInterfaceClient, resyncInterval),
+		mcastPolicyFactory:     multicastpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.MulticastPolicyClient, resyncInterval),
+		informers:              make(map[reflect.Type]*informer),
+		stopChan:               make(chan struct{}),
 	}
 
 	if err := egressipapi.AddToScheme(egressipscheme.Scheme); err != nil {
@@ -166,6 +182,12 @@ func NewMasterWatchFactory(ovnClientset *util.OVNMasterClientset) (*WatchFactory
 	if err := egressqosapi.AddToScheme(egressqosscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := egressinterfaceapi.AddToScheme(egressinterfacescheme.Scheme); err != nil {
+		return nil, err
+	}
+	if err := multicastpolicyapi.AddToScheme(multicastpolicyscheme.Scheme); err != nil {
+		return nil, err
+	}
 
 	if err := nadapi.AddToScheme(nadscheme.Scheme); err != nil {
 		return nil, err
@@ -244,6 +266,18 @@ func NewMasterWatchFactory(ovnClientset *util.OVNMasterClientset) (*WatchFactory
 			return nil, err
 		}
 	}
+	if config.OVNKubernetesFeature.EnableEgressInterface {
+		wf.informers[EgressInterfaceType], err = newInformer(EgressInterfaceType, wf.egressInterfaceFactory.K8s().V1().EgressInterfaces().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.OVNKubernetesFeature.EnableMulticastPolicy {
+		wf.informers[MulticastPolicyType], err = newInformer(MulticastPolicyType, wf.mcastPolicyFactory.K8s().V1().MulticastPolicies().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return wf, nil
 }
@@ -288,6 +322,22 @@ func (wf *WatchFactory) Start() error {
 			}
 		}
 	}
+	if config.OVNKubernetesFeature.EnableEgressInterface && wf.egressInterfaceFactory != nil {
+		wf.egressInterfaceFactory.Start(wf.stopChan)
+		for oType, synced := range wf.egressInterfaceFactory.WaitForCacheSync(wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+	if config.OVNKubernetesFeature.EnableMulticastPolicy && wf.mcastPolicyFactory != nil {
+		wf.mcastPolicyFactory.Start(wf.stopChan)
+		for oType, synced := range wf.mcastPolicyFactory.WaitForCacheSync(wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
 
 	return nil
 }
@@ -369,6 +419,31 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 		return nil, err
 	}
 
+	// EgressIPs assigned to the node are advertised with BGP
+	if config.BGP.Enabled && config.OVNKubernetesFeature.EnableEgressIP && ovnClientset.EgressIPClient != nil {
+		if err := egressipapi.AddToScheme(egressipscheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.eipFactory = egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval)
+		wf.informers[EgressIPType], err = newInformer(EgressIPType, wf.eipFactory.K8s().V1().EgressIPs().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// EgressInterfaces select the additional gateway bridges set up by the node
+	if config.OVNKubernetesFeature.EnableEgressInterface && ovnClientset.EgressInterfaceClient != nil {
+		if err := egressinterfaceapi.AddToScheme(egressinterfacescheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.egressInterfaceFactory = egressinterfaceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressInterfaceClient, resyncInterval)
+		wf.informers[EgressInterfaceType], err = newInformer(EgressInterfaceType,
+			wf.egressInterfaceFactory.K8s().V1().EgressInterfaces().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return wf, nil
 }
 
@@ -387,6 +462,15 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 	if err != nil {
 		return nil, err
 	}
+
+	// cluster manager allocates the pod IPs of the layer2 secondary networks
+	if config.OVNKubernetesFeature.EnableMultiNetwork {
+		wf.informers[PodType], err = newQueuedInformer(PodType, wf.iFactory.Core().V1().Pods().Informer(), wf.stopChan,
+			defaultNumEventQueues)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return wf, nil
 }
 
@@ -433,6 +517,14 @@ func getObjectMeta(objType reflect.Type, obj interface{}) (*metav1.ObjectMeta, e
 		if cloudPrivateIPConfig, ok := obj.(*ocpcloudnetworkapi.CloudPrivateIPConfig); ok {
 			return &cloudPrivateIPConfig.ObjectMeta, nil
 		}
+	case EgressInterfaceType:
+		if egressInterface, ok := obj.(*egressinterfaceapi.EgressInterface); ok {
+			return &egressInterface.ObjectMeta, nil
+		}
+	case MulticastPolicyType:
+		if mcastPolicy, ok := obj.(*multicastpolicyapi.MulticastPolicy); ok {
+			return &mcastPolicy.ObjectMeta, nil
+		}
 	case EndpointSliceType:
 		if endpointSlice, ok := obj.(*discovery.EndpointSlice); ok {
 			return &endpointSlice.ObjectMeta, nil
@@ -536,6 +628,18 @@ func (wf *WatchFactory) GetResourceHandlerFunc(objType reflect.Type) (AddHandler
 			return wf.AddCloudPrivateIPConfigHandler(funcs, processExisting)
 		}, nil
 
+	case EgressInterfaceType:
+		return func(namespace string, sel labels.Selector,
+			funcs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+			return wf.AddEgressInterfaceHandler(funcs, processExisting)
+		}, nil
+
+	case MulticastPolicyType:
+		return func(namespace string, sel labels.Selector,
+			funcs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+			return wf.AddMulticastPolicyHandler(funcs, processExisting)
+		}, nil
+
 	case EndpointSliceForStaleConntrackRemovalType, EndpointSliceForGatewayType:
 		return func(namespace string, sel labels.Selector,
 			funcs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
@@ -691,6 +795,26 @@ func (wf *WatchFactory) RemoveEgressIPHandler(handler *Handler) {
 	wf.removeHandler(EgressIPType, handler)
 }
 
+// AddEgressInterfaceHandler adds a handler function that will be executed on EgressInterface object changes
+func (wf *WatchFactory) AddEgressInterfaceHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+	return wf.addHandler(EgressInterfaceType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
+}
+
+// RemoveEgressInterfaceHandler removes an EgressInterface object event handler function
+func (wf *WatchFactory) RemoveEgressInterfaceHandler(handler *Handler) {
+	wf.removeHandler(EgressInterfaceType, handler)
+}
+
+// AddMulticastPolicyHandler adds a handler function that will be executed on MulticastPolicy object changes
+func (wf *WatchFactory) AddMulticastPolicyHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+	return wf.addHandler(MulticastPolicyType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
+}
+
+// RemoveMulticastPolicyHandler removes a MulticastPolicy object event handler function
+func (wf *WatchFactory) RemoveMulticastPolicyHandler(handler *Handler) {
+	wf.removeHandler(MulticastPolicyType, handler)
+}
+
 // AddCloudPrivateIPConfigHandler adds a handler function that will be executed on CloudPrivateIPConfig object changes
 func (wf *WatchFactory) AddCloudPrivateIPConfigHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
 	return wf.addHandler(CloudPrivateIPConfigType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
@@ -791,6 +915,12 @@ func (wf *WatchFactory) GetService(namespace, name string) (*kapi.Service, error
 	return serviceLister.Services(namespace).Get(name)
 }
 
+// GetServices returns all the services
+func (wf *WatchFactory) GetServices() ([]*kapi.Service, error) {
+	serviceLister := wf.informers[ServiceType].lister.(listers.ServiceLister)
+	return serviceLister.List(labels.Everything())
+}
+
 func (wf *WatchFactory) GetCloudPrivateIPConfig(name string) (*ocpcloudnetworkapi.CloudPrivateIPConfig, error) {
 	cloudPrivateIPConfigLister := wf.informers[CloudPrivateIPConfigType].lister.(ocpcloudnetworklister.CloudPrivateIPConfigLister)
 	return cloudPrivateIPConfigLister.Get(name)
@@ -806,6 +936,11 @@ func (wf *WatchFactory) GetEgressIPs() ([]*egressipapi.EgressIP, error) {
 	return egressIPLister.List(labels.Everything())
 }
 
+func (wf *WatchFactory) GetEgressInterfaces() ([]*egressinterfaceapi.EgressInterface, error) {
+	egressInterfaceLister := wf.informers[EgressInterfaceType].lister.(egressinterfacelister.EgressInterfaceLister)
+	return egressInterfaceLister.List(labels.Everything())
+}
+
 // GetNamespace returns a specific namespace
 func (wf *WatchFactory) GetNamespace(name string) (*kapi.Namespace, error) {
 	namespaceLister := wf.informers[NamespaceType].lister.(listers.NamespaceLister)
@@ -854,6 +989,16 @@ func (wf *WatchFactory) GetEgressFirewall(namespace, name string) (*egressfirewa
 	return egressFirewallLister.EgressFirewalls(namespace).Get(name)
 }
 
+func (wf *WatchFactory) GetMulticastPolicy(namespace, name string) (*multicastpolicyapi.MulticastPolicy, error) {
+	mcastPolicyLister := wf.informers[MulticastPolicyType].lister.(multicastpolicylister.MulticastPolicyLister)
+	return mcastPolicyLister.MulticastPolicies(namespace).Get(name)
+}
+
+func (wf *WatchFactory) GetMulticastPolicies() ([]*multicastpolicyapi.MulticastPolicy, error) {
+	mcastPolicyLister := wf.informers[MulticastPolicyType].lister.(multicastpolicylister.MulticastPolicyLister)
+	return mcastPolicyLister.List(labels.Everything())
+}
+
 func (wf *WatchFactory) NodeInformer() cache.SharedIndexInformer {
 	return wf.informers[NodeType].inf
 }
@@ -880,6 +1025,10 @@ func (wf *WatchFactory) NamespaceInformer() cache.SharedIndexInformer {
 	return wf.informers[NamespaceType].inf
 }
 
+func (wf *WatchFactory) NamespaceCoreInformer() v1coreinformers.NamespaceInformer {
+	return wf.iFactory.Core().V1().Namespaces()
+}
+
 func (wf *WatchFactory) ServiceInformer() cache.SharedIndexInformer {
 	return wf.informers[ServiceType].inf
 }
@@ -888,6 +1037,10 @@ func (wf *WatchFactory) EgressQoSInformer() egressqosinformer.EgressQoSInformer
 	return wf.egressQoSFactory.K8s().V1().EgressQoSes()
 }
 
+func (wf *WatchFactory) EgressInterfaceInformer() egressinterfaceinformer.EgressInterfaceInformer {
+	return wf.egressInterfaceFactory.K8s().V1().EgressInterfaces()
+}
+
 // withServiceNameAndNoHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices with a non-empty
 // "kubernetes.io/service-name" label and without "service.kubernetes.io/headless"
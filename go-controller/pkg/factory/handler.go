@@ -9,11 +9,14 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cryptorand"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 
 	networkattachmentdefinitionlister "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
 
 	egressfirewalllister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
+	egressinterfacelister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/listers/egressinterface/v1"
 	egressqoslister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/listers/egressqos/v1"
+	multicastpolicylister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/listers/multicastpolicy/v1"
 
 	cloudprivateipconfiglister "github.com/openshift/client-go/cloudnetwork/listers/cloudnetwork/v1"
 	egressiplister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
@@ -42,6 +45,22 @@ type Handler struct {
 	// example: a handler with priority 0 will process the received event first
 	// before a handler with priority 1.
 	priority int
+	// network is the name of the network whose controller added the handler,
+	// resource event metrics are reported per network
+	network string
+}
+
+// networkEventHandler is a resource event handler of a network controller
+type networkEventHandler struct {
+	cache.ResourceEventHandler
+	network string
+}
+
+// WithNetwork returns an event handler that calls funcs and reports its resource event metrics
+// for the given network. Handlers added without it, or with an empty network, are reported for
+// the default network.
+func WithNetwork(network string, funcs cache.ResourceEventHandler) cache.ResourceEventHandler {
+	return &networkEventHandler{ResourceEventHandler: funcs, network: network}
 }
 
 func (h *Handler) OnAdd(obj interface{}) {
@@ -165,6 +184,13 @@ func (i *informer) forEachHandlerReversed(obj interface{}, f func(h *Handler)) {
 }
 
 func (i *informer) addHandler(id uint64, priority int, filterFunc func(obj interface{}) bool, funcs cache.ResourceEventHandler, existingItems []interface{}) *Handler {
+	network := types.DefaultNetworkName
+	if nh, ok := funcs.(*networkEventHandler); ok {
+		if nh.network != "" {
+			network = nh.network
+		}
+		funcs = nh.ResourceEventHandler
+	}
 	handler := &Handler{
 		cache.FilteringResourceEventHandler{
 			FilterFunc: filterFunc,
@@ -173,6 +199,7 @@ func (i *informer) addHandler(id uint64, priority int, filterFunc func(obj inter
 		id,
 		handlerAlive,
 		priority,
+		network,
 	}
 
 	// Send existing items to the handler's add function; informers usually
@@ -375,36 +402,55 @@ func ensureObjectOnDelete(obj interface{}, expectedType reflect.Type) (interface
 	return obj, nil
 }
 
+// handlerLatencies stores the time the handlers of every network took to handle an event
+type handlerLatencies map[string]time.Duration
+
+// measure calls f and adds the time it took to the network of the handler h
+func (l handlerLatencies) measure(h *Handler, f func()) {
+	start := time.Now()
+	f()
+	l[h.network] += time.Since(start)
+}
+
+// record records the event of the named resource type for every network that handled it
+func (l handlerLatencies) record(name, eventName string) {
+	for network, duration := range l {
+		metrics.RecordResourceEvent(name, eventName, network, duration)
+	}
+}
+
 func (i *informer) newFederatedQueuedHandler(numEventQueues uint32) cache.ResourceEventHandlerFuncs {
 	name := i.oType.Elem().Name()
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			i.queueMap.enqueueEvent(nil, obj, i.oType, false, func(e *event) {
-				metrics.MetricResourceUpdateCount.WithLabelValues(name, "add").Inc()
-				start := time.Now()
+				latencies := handlerLatencies{}
 				i.forEachQueuedHandler(func(h *Handler) {
-					h.OnAdd(e.obj)
+					latencies.measure(h, func() {
+						h.OnAdd(e.obj)
+					})
 				})
-				metrics.MetricResourceAddLatency.Observe(time.Since(start).Seconds())
+				latencies.record(name, "add")
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			i.queueMap.enqueueEvent(oldObj, newObj, i.oType, false, func(e *event) {
-				metrics.MetricResourceUpdateCount.WithLabelValues(name, "update").Inc()
-				start := time.Now()
+				latencies := handlerLatencies{}
 				i.forEachQueuedHandler(func(h *Handler) {
 					old := oldObj.(metav1.Object)
 					new := newObj.(metav1.Object)
-					if old.GetUID() != new.GetUID() {
-						// This occurs not so often, so log this occurance.
-						klog.Infof("Object %s/%s is replaced, invoking delete followed by add handler", new.GetNamespace(), new.GetName())
-						h.OnDelete(e.oldObj)
-						h.OnAdd(e.obj)
-					} else {
-						h.OnUpdate(e.oldObj, e.obj)
-					}
+					latencies.measure(h, func() {
+						if old.GetUID() != new.GetUID() {
+							// This occurs not so often, so log this occurance.
+							klog.Infof("Object %s/%s is replaced, invoking delete followed by add handler", new.GetNamespace(), new.GetName())
+							h.OnDelete(e.oldObj)
+							h.OnAdd(e.obj)
+						} else {
+							h.OnUpdate(e.oldObj, e.obj)
+						}
+					})
 				})
-				metrics.MetricResourceUpdateLatency.Observe(time.Since(start).Seconds())
+				latencies.record(name, "update")
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -414,12 +460,13 @@ func (i *informer) newFederatedQueuedHandler(numEventQueues uint32) cache.Resour
 				return
 			}
 			i.queueMap.enqueueEvent(nil, realObj, i.oType, true, func(e *event) {
-				metrics.MetricResourceUpdateCount.WithLabelValues(name, "delete").Inc()
-				start := time.Now()
+				latencies := handlerLatencies{}
 				i.forEachQueuedHandlerReversed(func(h *Handler) {
-					h.OnDelete(e.obj)
+					latencies.measure(h, func() {
+						h.OnDelete(e.obj)
+					})
 				})
-				metrics.MetricResourceDeleteLatency.Observe(time.Since(start).Seconds())
+				latencies.record(name, "delete")
 			})
 		},
 	}
@@ -429,29 +476,31 @@ func (i *informer) newFederatedHandler() cache.ResourceEventHandlerFuncs {
 	name := i.oType.Elem().Name()
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			metrics.MetricResourceUpdateCount.WithLabelValues(name, "add").Inc()
-			start := time.Now()
+			latencies := handlerLatencies{}
 			i.forEachHandler(obj, func(h *Handler) {
-				h.OnAdd(obj)
+				latencies.measure(h, func() {
+					h.OnAdd(obj)
+				})
 			})
-			metrics.MetricResourceAddLatency.Observe(time.Since(start).Seconds())
+			latencies.record(name, "add")
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
-			metrics.MetricResourceUpdateCount.WithLabelValues(name, "update").Inc()
-			start := time.Now()
+			latencies := handlerLatencies{}
 			i.forEachHandler(newObj, func(h *Handler) {
 				old := oldObj.(metav1.Object)
 				new := newObj.(metav1.Object)
-				if old.GetUID() != new.GetUID() {
-					// This occurs not so often, so log this occurance.
-					klog.Infof("Object %s/%s is replaced, invoking delete followed by add handler", new.GetNamespace(), new.GetName())
-					h.OnDelete(oldObj)
-					h.OnAdd(newObj)
-				} else {
-					h.OnUpdate(oldObj, newObj)
-				}
+				latencies.measure(h, func() {
+					if old.GetUID() != new.GetUID() {
+						// This occurs not so often, so log this occurance.
+						klog.Infof("Object %s/%s is replaced, invoking delete followed by add handler", new.GetNamespace(), new.GetName())
+						h.OnDelete(oldObj)
+						h.OnAdd(newObj)
+					} else {
+						h.OnUpdate(oldObj, newObj)
+					}
+				})
 			})
-			metrics.MetricResourceUpdateLatency.Observe(time.Since(start).Seconds())
+			latencies.record(name, "update")
 		},
 		DeleteFunc: func(obj interface{}) {
 			realObj, err := ensureObjectOnDelete(obj, i.oType)
@@ -459,12 +508,13 @@ func (i *informer) newFederatedHandler() cache.ResourceEventHandlerFuncs {
 				klog.Errorf(err.Error())
 				return
 			}
-			metrics.MetricResourceUpdateCount.WithLabelValues(name, "delete").Inc()
-			start := time.Now()
+			latencies := handlerLatencies{}
 			i.forEachHandlerReversed(realObj, func(h *Handler) {
-				h.OnDelete(realObj)
+				latencies.measure(h, func() {
+					h.OnDelete(realObj)
+				})
 			})
-			metrics.MetricResourceDeleteLatency.Observe(time.Since(start).Seconds())
+			latencies.record(name, "delete")
 		},
 	}
 }
@@ -508,6 +558,10 @@ func newInformerLister(oType reflect.Type, sharedInformer cache.SharedIndexInfor
 		return discoverylisters.NewEndpointSliceLister(sharedInformer.GetIndexer()), nil
 	case EgressQoSType:
 		return egressqoslister.NewEgressQoSLister(sharedInformer.GetIndexer()), nil
+	case EgressInterfaceType:
+		return egressinterfacelister.NewEgressInterfaceLister(sharedInformer.GetIndexer()), nil
+	case MulticastPolicyType:
+		return multicastpolicylister.NewMulticastPolicyLister(sharedInformer.GetIndexer()), nil
 	case NetworkAttachmentDefinitionType:
 		return networkattachmentdefinitionlister.NewNetworkAttachmentDefinitionLister(sharedInformer.GetIndexer()), nil
 	}
@@ -38,6 +38,11 @@ func (it ObjectIDsType) HasKey(key ExternalIDKey) bool {
 	return it.externalIDsMap[key]
 }
 
+// GetOwnerType returns the value written to ExternalIDs[OwnerTypeKey] for objects of this type.
+func (it ObjectIDsType) GetOwnerType() string {
+	return string(it.ownerObjectType)
+}
+
 func (it ObjectIDsType) IsSameType(it2 *ObjectIDsType) bool {
 	return it.ownerObjectType == it2.ownerObjectType && it.dbTable == it2.dbTable
 }
@@ -64,6 +69,20 @@ const (
 // It is filled in newObjectIDsType when registering new ObjectIDsType
 var dbIDsMap = map[dbObjType]map[ownerType]bool{}
 
+// dbIDsTypes stores all registered ObjectIDsTypes per dbObjType in registration order.
+// It is filled in newObjectIDsType when registering new ObjectIDsType
+var dbIDsTypes = map[dbObjType][]*ObjectIDsType{}
+
+// GetAddressSetObjectIDsTypes returns all ObjectIDsTypes registered for the address set table.
+func GetAddressSetObjectIDsTypes() []*ObjectIDsType {
+	return append([]*ObjectIDsType{}, dbIDsTypes[addressSet]...)
+}
+
+// GetACLObjectIDsTypes returns all ObjectIDsTypes registered for the ACL table.
+func GetACLObjectIDsTypes() []*ObjectIDsType {
+	return append([]*ObjectIDsType{}, dbIDsTypes[acl]...)
+}
+
 func newObjectIDsType(dbTable dbObjType, ownerObjectType ownerType, keys []ExternalIDKey) *ObjectIDsType {
 	if dbIDsMap[dbTable][ownerObjectType] {
 		panic(fmt.Sprintf("ObjectIDsType for params %v %v is already registered", dbTable, ownerObjectType))
@@ -76,7 +95,9 @@ func newObjectIDsType(dbTable dbObjType, ownerObjectType ownerType, keys []Exter
 	for _, key := range keys {
 		keysMap[key] = true
 	}
-	return &ObjectIDsType{dbTable, ownerObjectType, keys, keysMap}
+	idsType := &ObjectIDsType{dbTable, ownerObjectType, keys, keysMap}
+	dbIDsTypes[dbTable] = append(dbIDsTypes[dbTable], idsType)
+	return idsType
 }
 
 // DbObjectIDs is a structure representing a set of db object ExternalIDs, used to identify
@@ -165,6 +186,16 @@ func (objectIDs *DbObjectIDs) RemoveIDs(idsToDelete ...ExternalIDKey) *DbObjectI
 	return &DbObjectIDs{objectIDs.idsType, objectIDs.ownerControllerName, ids}
 }
 
+// GetOwnerController returns the name of the controller that owns the object.
+func (objectIDs *DbObjectIDs) GetOwnerController() string {
+	return objectIDs.ownerControllerName
+}
+
+// GetIDsType returns the ObjectIDsType of the object.
+func (objectIDs *DbObjectIDs) GetIDsType() *ObjectIDsType {
+	return objectIDs.idsType
+}
+
 func (objectIDs *DbObjectIDs) HasSameOwner(ownerController string, objectIDsType *ObjectIDsType) bool {
 	return objectIDs.ownerControllerName == ownerController && objectIDs.idsType.IsSameType(objectIDsType)
 }
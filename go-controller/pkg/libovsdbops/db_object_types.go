@@ -3,6 +3,7 @@ package libovsdbops
 const (
 	addressSet dbObjType = iota
 	acl
+	qos
 )
 
 const (
@@ -17,6 +18,7 @@ const (
 	HybridNodeRouteOwnerType ownerType = "HybridNodeRoute"
 	EgressIPOwnerType        ownerType = "EgressIP"
 	EgressServiceOwnerType   ownerType = "EgressService"
+	PodOwnerType             ownerType = "Pod"
 
 	// owner extra IDs, make sure to define only 1 ExternalIDKey for every string value
 	PriorityKey           ExternalIDKey = "priority"
@@ -88,3 +90,10 @@ var ACLNetpolDefault = newObjectIDsType(acl, NetpolDefaultOwnerType, []ExternalI
 	// egress or ingress
 	PolicyDirectionKey,
 })
+
+var QoSPodBandwidth = newObjectIDsType(qos, PodOwnerType, []ExternalIDKey{
+	// namespace/name
+	ObjectNameKey,
+	// egress or ingress
+	PolicyDirectionKey,
+})
@@ -248,6 +248,20 @@ func DeletePortGroupsOps(nbClient libovsdbclient.Client, ops []libovsdb.Operatio
 	return m.DeleteOps(ops, opModels...)
 }
 
+// DeletePortGroupsWithPredicateOps returns the ops to delete the port groups
+// matching the provided predicate
+func DeletePortGroupsWithPredicateOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, p portGroupPredicate) ([]libovsdb.Operation, error) {
+	opModel := operationModel{
+		Model:          &nbdb.PortGroup{},
+		ModelPredicate: p,
+		ErrNotFound:    false,
+		BulkOp:         true,
+	}
+
+	m := newModelClient(nbClient)
+	return m.DeleteOps(ops, opModel)
+}
+
 // DeletePortGroups deletes the provided port groups and returns the
 // corresponding ops
 func DeletePortGroups(nbClient libovsdbclient.Client, names ...string) error {
@@ -12,6 +12,18 @@ import (
 
 type QoSPredicate func(*nbdb.QoS) bool
 
+// isEquivalentQoS returns true if the existing QoS is the searched one: the QoSes with a primary ID
+// are identified by it, the others by their match and priority
+func isEquivalentQoS(existing *nbdb.QoS, searched *nbdb.QoS) bool {
+	if searched.UUID != "" && existing.UUID == searched.UUID {
+		return true
+	}
+	if primaryID, ok := searched.ExternalIDs[PrimaryIDKey.String()]; ok {
+		return existing.ExternalIDs[PrimaryIDKey.String()] == primaryID
+	}
+	return strings.Contains(existing.Match, searched.Match) && existing.Priority == searched.Priority
+}
+
 // FindQoSesWithPredicate looks up QoSes from the cache based on a
 // given predicate
 func FindQoSesWithPredicate(nbClient libovsdbclient.Client, p QoSPredicate) ([]*nbdb.QoS, error) {
@@ -31,9 +43,10 @@ func CreateOrUpdateQoSesOps(nbClient libovsdbclient.Client, ops []libovsdb.Opera
 		opModel := operationModel{
 			Model: qos,
 			ModelPredicate: func(q *nbdb.QoS) bool {
-				return strings.Contains(q.Match, qos.Match) && q.Priority == qos.Priority
+				return isEquivalentQoS(q, qos)
 			},
-			OnModelUpdates: []interface{}{}, // update all fields
+			// update the empty columns too, to clear the bandwidth or the action a QoS doesn't have anymore
+			OnModelUpdates: []interface{}{&qos.Action, &qos.Bandwidth, &qos.Direction, &qos.ExternalIDs, &qos.Match, &qos.Priority},
 			ErrNotFound:    false,
 			BulkOp:         false,
 		}
@@ -52,9 +65,10 @@ func UpdateQoSesOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, qo
 		opModel := operationModel{
 			Model: qos,
 			ModelPredicate: func(q *nbdb.QoS) bool {
-				return strings.Contains(q.Match, qos.Match) && q.Priority == qos.Priority
+				return isEquivalentQoS(q, qos)
 			},
-			OnModelUpdates: []interface{}{}, // update all fields
+			// update the empty columns too, to clear the bandwidth or the action a QoS doesn't have anymore
+			OnModelUpdates: []interface{}{&qos.Action, &qos.Bandwidth, &qos.Direction, &qos.ExternalIDs, &qos.Match, &qos.Priority},
 			ErrNotFound:    true,
 			BulkOp:         false,
 		}
@@ -480,3 +480,52 @@ func UpdateLogicalSwitchPortSetOptions(nbClient libovsdbclient.Client, lsp *nbdb
 	_, err = m.CreateOrUpdate(opModel)
 	return err
 }
+
+// UpdateLogicalSwitchPortsSetOptionsOps returns the ops to set options on the
+// provided logical switch ports adding any missing, removing the ones set to
+// an empty value and updating existing. Ports whose options are already set
+// are left untouched.
+func UpdateLogicalSwitchPortsSetOptionsOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation,
+	lsps ...*nbdb.LogicalSwitchPort) ([]libovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, len(lsps))
+	for _, lsp := range lsps {
+		options := lsp.Options
+		lsp, err := GetLogicalSwitchPort(nbClient, lsp)
+		if err != nil {
+			return nil, err
+		}
+
+		if lsp.Options == nil {
+			lsp.Options = map[string]string{}
+		}
+
+		changed := false
+		for k, v := range options {
+			if lsp.Options[k] == v {
+				continue
+			}
+			if _, ok := lsp.Options[k]; !ok && v == "" {
+				continue
+			}
+			changed = true
+			if v == "" {
+				delete(lsp.Options, k)
+			} else {
+				lsp.Options[k] = v
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		opModels = append(opModels, operationModel{
+			Model:          lsp,
+			OnModelUpdates: []interface{}{&lsp.Options},
+			ErrNotFound:    true,
+			BulkOp:         false,
+		})
+	}
+
+	m := newModelClient(nbClient)
+	return m.CreateOrUpdateOps(ops, opModels...)
+}
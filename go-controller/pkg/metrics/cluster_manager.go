@@ -54,6 +54,63 @@ var metricV6AllocatedHostSubnetCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The total number of v6 host subnets currently allocated",
 })
 
+var metricHostSubnetRangeCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "host_subnet_range_subnets",
+	Help:      "The total number of host subnets possible in a cluster subnet range of a network",
+},
+	[]string{
+		"network",
+		"subnet",
+	},
+)
+
+var metricHostSubnetRangeAllocatedCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "host_subnet_range_allocated_subnets",
+	Help:      "The number of host subnets currently allocated from a cluster subnet range of a network, counting networks of other sizes by the host subnets they cover",
+},
+	[]string{
+		"network",
+		"subnet",
+	},
+)
+
+var metricHostSubnetAllocationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "host_subnet_allocation_failures_total",
+	Help:      "The total number of host subnet allocations that failed because the cluster subnets of a network were exhausted",
+},
+	[]string{
+		"network",
+	},
+)
+
+var metricQuarantinedHostSubnets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "quarantined_host_subnets",
+	Help:      "The number of released host subnets of a network held back from allocation until their quarantine expires",
+},
+	[]string{
+		"network",
+	},
+)
+
+var metricQuarantinedPodIPs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "quarantined_pod_ips",
+	Help:      "The number of released pod IPs of a network held back from allocation until their quarantine expires",
+},
+	[]string{
+		"network",
+	},
+)
+
 // RegisterClusterManagerBase registers ovnkube cluster manager base metrics with the Prometheus registry.
 // This function should only be called once.
 func RegisterClusterManagerBase() {
@@ -88,6 +145,11 @@ func RegisterClusterManagerFunctional() {
 	prometheus.MustRegister(metricV6HostSubnetCount)
 	prometheus.MustRegister(metricV4AllocatedHostSubnetCount)
 	prometheus.MustRegister(metricV6AllocatedHostSubnetCount)
+	prometheus.MustRegister(metricHostSubnetRangeCount)
+	prometheus.MustRegister(metricHostSubnetRangeAllocatedCount)
+	prometheus.MustRegister(metricHostSubnetAllocationFailures)
+	prometheus.MustRegister(metricQuarantinedHostSubnets)
+	prometheus.MustRegister(metricQuarantinedPodIPs)
 }
 
 func UnregisterClusterManagerFunctional() {
@@ -95,6 +157,11 @@ func UnregisterClusterManagerFunctional() {
 	prometheus.Unregister(metricV6HostSubnetCount)
 	prometheus.Unregister(metricV4AllocatedHostSubnetCount)
 	prometheus.Unregister(metricV6AllocatedHostSubnetCount)
+	prometheus.Unregister(metricHostSubnetRangeCount)
+	prometheus.Unregister(metricHostSubnetRangeAllocatedCount)
+	prometheus.Unregister(metricHostSubnetAllocationFailures)
+	prometheus.Unregister(metricQuarantinedHostSubnets)
+	prometheus.Unregister(metricQuarantinedPodIPs)
 }
 
 // RecordSubnetUsage records the number of subnets allocated for nodes
@@ -109,3 +176,46 @@ func RecordSubnetCount(v4SubnetCount, v6SubnetCount float64) {
 	metricV4HostSubnetCount.Set(v4SubnetCount)
 	metricV6HostSubnetCount.Set(v6SubnetCount)
 }
+
+// RecordHostSubnetRangeUsage records the number of host subnets possible and allocated
+// in the given cluster subnet range of a network
+func RecordHostSubnetRangeUsage(network, subnet string, subnetCount, subnetsAllocated float64) {
+	metricHostSubnetRangeCount.WithLabelValues(network, subnet).Set(subnetCount)
+	metricHostSubnetRangeAllocatedCount.WithLabelValues(network, subnet).Set(subnetsAllocated)
+}
+
+// DeleteHostSubnetRangeUsage deletes the host subnet range metrics of a network
+func DeleteHostSubnetRangeUsage(network string) {
+	metricHostSubnetRangeCount.DeletePartialMatch(prometheus.Labels{"network": network})
+	metricHostSubnetRangeAllocatedCount.DeletePartialMatch(prometheus.Labels{"network": network})
+	metricHostSubnetAllocationFailures.DeletePartialMatch(prometheus.Labels{"network": network})
+	metricQuarantinedHostSubnets.DeleteLabelValues(network)
+}
+
+// DeleteHostSubnetRange deletes the metrics of a cluster subnet range that was removed from a network
+func DeleteHostSubnetRange(network, subnet string) {
+	metricHostSubnetRangeCount.DeleteLabelValues(network, subnet)
+	metricHostSubnetRangeAllocatedCount.DeleteLabelValues(network, subnet)
+}
+
+// RecordHostSubnetAllocationFailure records a host subnet allocation that failed because
+// the cluster subnets of the network were exhausted
+func RecordHostSubnetAllocationFailure(network string) {
+	metricHostSubnetAllocationFailures.WithLabelValues(network).Inc()
+}
+
+// RecordQuarantinedHostSubnets records the number of quarantined host subnets of a network
+func RecordQuarantinedHostSubnets(network string, count int) {
+	metricQuarantinedHostSubnets.WithLabelValues(network).Set(float64(count))
+}
+
+// RecordQuarantinedClusterManagerPodIPs records the number of quarantined pod IPs of a
+// network allocated by the cluster manager
+func RecordQuarantinedClusterManagerPodIPs(network string, count int) {
+	metricQuarantinedPodIPs.WithLabelValues(network).Set(float64(count))
+}
+
+// DeleteQuarantinedClusterManagerPodIPs deletes the quarantined pod IPs metric of a network
+func DeleteQuarantinedClusterManagerPodIPs(network string) {
+	metricQuarantinedPodIPs.DeleteLabelValues(network)
+}
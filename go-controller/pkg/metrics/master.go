@@ -18,6 +18,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -52,13 +53,15 @@ var metricDbTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 
 // metricPodCreationLatency is the time between a pod being scheduled and
 // completing its logical switch port configuration.
-var metricPodCreationLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+var metricPodCreationLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "pod_creation_latency_seconds",
 	Help:      "The duration between a pod being scheduled and completing its logical switch port configuration",
-	Buckets:   prometheus.ExponentialBuckets(.1, 2, 15),
-})
+	Buckets:   prometheus.ExponentialBuckets(.1, 2, 15)},
+	[]string{
+		"network",
+	})
 
 // metricOvnCliLatency is the duration to execute OVN commands using CLI tools ovn-nbctl or ovn-sbctl.
 var metricOvnCliLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -76,41 +79,51 @@ var MetricResourceUpdateCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "resource_update_total",
-	Help:      "The number of times a given resource event (add, update, or delete) has been handled"},
+	Help:      "The number of times a given resource event (add, update, or delete) has been handled by the handlers of a network"},
 	[]string{
 		"name",
 		"event",
+		"network",
 	},
 )
 
 // MetricResourceAddLatency is the time taken to complete resource update by an handler.
-// This measures the latency for all of the handlers for a given resource.
-var MetricResourceAddLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+// This measures the latency for all of the handlers of a network for a given resource.
+var MetricResourceAddLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "resource_add_latency_seconds",
-	Help:      "The duration to process all handlers for a given resource event - add.",
+	Help:      "The duration to process all handlers of a network for a given resource event - add.",
 	Buckets:   prometheus.ExponentialBuckets(.1, 2, 15)},
+	[]string{
+		"network",
+	},
 )
 
 // MetricResourceUpdateLatency is the time taken to complete resource update by an handler.
-// This measures the latency for all of the handlers for a given resource.
-var MetricResourceUpdateLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+// This measures the latency for all of the handlers of a network for a given resource.
+var MetricResourceUpdateLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "resource_update_latency_seconds",
-	Help:      "The duration to process all handlers for a given resource event - update.",
+	Help:      "The duration to process all handlers of a network for a given resource event - update.",
 	Buckets:   prometheus.ExponentialBuckets(.1, 2, 15)},
+	[]string{
+		"network",
+	},
 )
 
 // MetricResourceDeleteLatency is the time taken to complete resource update by an handler.
-// This measures the latency for all of the handlers for a given resource.
-var MetricResourceDeleteLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+// This measures the latency for all of the handlers of a network for a given resource.
+var MetricResourceDeleteLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "resource_delete_latency_seconds",
-	Help:      "The duration to process all handlers for a given resource event - delete.",
+	Help:      "The duration to process all handlers of a network for a given resource event - delete.",
 	Buckets:   prometheus.ExponentialBuckets(.1, 2, 15)},
+	[]string{
+		"network",
+	},
 )
 
 // MetricRequeueServiceCount is the number of times a particular service has been requeued.
@@ -209,6 +222,7 @@ var metricNetpolEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOp
 	Buckets:   prometheus.ExponentialBuckets(.004, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricNetpolLocalPodEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -219,6 +233,7 @@ var metricNetpolLocalPodEventLatency = prometheus.NewHistogramVec(prometheus.His
 	Buckets:   prometheus.ExponentialBuckets(.002, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricNetpolPeerNamespaceEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -229,6 +244,7 @@ var metricNetpolPeerNamespaceEventLatency = prometheus.NewHistogramVec(prometheu
 	Buckets:   prometheus.ExponentialBuckets(.002, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricPodSelectorAddrSetPodEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -239,6 +255,7 @@ var metricPodSelectorAddrSetPodEventLatency = prometheus.NewHistogramVec(prometh
 	Buckets:   prometheus.ExponentialBuckets(.002, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricPodSelectorAddrSetNamespaceEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -249,6 +266,7 @@ var metricPodSelectorAddrSetNamespaceEventLatency = prometheus.NewHistogramVec(p
 	Buckets:   prometheus.ExponentialBuckets(.002, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricPodEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -259,6 +277,7 @@ var metricPodEventLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Buckets:   prometheus.ExponentialBuckets(.002, 2, 15)},
 	[]string{
 		"event",
+		"network",
 	})
 
 var metricEgressFirewallRuleCount = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -289,38 +308,64 @@ var metricEgressFirewallCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The number of egress firewall policies",
 })
 
+var metricDBGCStaleObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "db_gc_stale_objects",
+	Help:      "The number of stale OVN NB objects found by the last garbage collector run, per object type"},
+	[]string{
+		"object_type",
+	})
+
+var metricDBGCCollectedObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemMaster,
+	Name:      "db_gc_collected_objects_total",
+	Help:      "The total number of stale OVN NB objects deleted by the garbage collector, per object type"},
+	[]string{
+		"object_type",
+	})
+
 // metricFirstSeenLSPLatency is the time between a pod first seen in OVN-Kubernetes and its Logical Switch Port is created
-var metricFirstSeenLSPLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+var metricFirstSeenLSPLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "pod_first_seen_lsp_created_duration_seconds",
 	Help:      "The duration between a pod first observed in OVN-Kubernetes and Logical Switch Port created",
-	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15),
-})
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	[]string{
+		"network",
+	})
 
-var metricLSPPortBindingLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+var metricLSPPortBindingLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "pod_lsp_created_port_binding_duration_seconds",
 	Help:      "The duration between a pods Logical Switch Port created and port binding observed in cache",
-	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15),
-})
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	[]string{
+		"network",
+	})
 
-var metricPortBindingChassisLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+var metricPortBindingChassisLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "pod_port_binding_port_binding_chassis_duration_seconds",
 	Help:      "The duration between a pods port binding observed and port binding chassis update observed in cache",
-	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15),
-})
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	[]string{
+		"network",
+	})
 
-var metricPortBindingUpLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+var metricPortBindingUpLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemMaster,
 	Name:      "pod_port_binding_chassis_port_binding_up_duration_seconds",
 	Help:      "The duration between a pods port binding chassis update and port binding up observed in cache",
-	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15),
-})
+	Buckets:   prometheus.ExponentialBuckets(.01, 2, 15)},
+	[]string{
+		"network",
+	})
 
 var metricNetworkProgramming prometheus.ObserverVec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: MetricOvnkubeNamespace,
@@ -413,22 +458,27 @@ func RegisterMasterPerformance(nbClient libovsdbclient.Client) {
 func RegisterMasterFunctional() {
 	// No need to unregister because process exits when leadership is lost.
 	prometheus.MustRegister(metricEgressIPCount)
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		klog.Infof("Scale metrics are enabled")
-		prometheus.MustRegister(metricEgressIPAssignLatency)
-		prometheus.MustRegister(metricEgressIPUnassignLatency)
-		prometheus.MustRegister(metricNetpolEventLatency)
-		prometheus.MustRegister(metricNetpolLocalPodEventLatency)
-		prometheus.MustRegister(metricNetpolPeerNamespaceEventLatency)
-		prometheus.MustRegister(metricPodSelectorAddrSetPodEventLatency)
-		prometheus.MustRegister(metricPodSelectorAddrSetNamespaceEventLatency)
-		prometheus.MustRegister(metricPodEventLatency)
+		registerScaleMetrics()
 	}
+	// scale metrics may be toggled in the config file while running
+	config.OnReload(config.ReloadableScaleMetrics, func() {
+		if config.ScaleMetricsEnabled() {
+			klog.Infof("Scale metrics are enabled")
+			registerScaleMetrics()
+		} else {
+			klog.Infof("Scale metrics are disabled")
+			unregisterScaleMetrics()
+		}
+	})
 	prometheus.MustRegister(metricEgressIPNodeUnreacheableCount)
 	prometheus.MustRegister(metricEgressIPRebalanceCount)
 	prometheus.MustRegister(metricEgressFirewallRuleCount)
 	prometheus.MustRegister(metricEgressFirewallCount)
 	prometheus.MustRegister(metricEgressRoutingViaHost)
+	prometheus.MustRegister(metricDBGCStaleObjects)
+	prometheus.MustRegister(metricDBGCCollectedObjects)
 	if err := prometheus.Register(MetricResourceRetryFailuresCount); err != nil {
 		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
 			panic(err)
@@ -436,6 +486,174 @@ func RegisterMasterFunctional() {
 	}
 }
 
+// NetworkMetricsFuncs provide the values of the per-network metrics of a network controller.
+// NADs may be nil for networks that are not defined by net-attach-defs, like the default network.
+// NodeIPUsage may be nil for networks that don't allocate pod IPs from per-node subnets.
+type NetworkMetricsFuncs struct {
+	LogicalSwitchPorts func() float64
+	AllocatedIPs       func() float64
+	AvailableIPs       func() float64
+	NADs               func() float64
+	NodeIPUsage        func() map[string]NodeIPUsage
+	QuarantinedIPs     func() float64
+}
+
+// NodeIPUsage is the number of pod IPs allocated and still available in the host subnets of a node
+type NodeIPUsage struct {
+	Allocated float64
+	Available float64
+}
+
+// nodeIPUsageCollector exports the pod IP usage of every node of a network
+type nodeIPUsageCollector struct {
+	allocatedDesc *prometheus.Desc
+	availableDesc *prometheus.Desc
+	nodeIPUsage   func() map[string]NodeIPUsage
+}
+
+func newNodeIPUsageCollector(network string, nodeIPUsage func() map[string]NodeIPUsage) *nodeIPUsageCollector {
+	constLabels := prometheus.Labels{"network": network}
+	return &nodeIPUsageCollector{
+		allocatedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemMaster, "node_allocated_pod_ips"),
+			"The number of pod IPs allocated from the host subnets of the node",
+			[]string{"node"}, constLabels),
+		availableDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemMaster, "node_available_pod_ips"),
+			"The number of pod IPs still available in the host subnets of the node",
+			[]string{"node"}, constLabels),
+		nodeIPUsage: nodeIPUsage,
+	}
+}
+
+func (c *nodeIPUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.allocatedDesc
+	ch <- c.availableDesc
+}
+
+func (c *nodeIPUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	for node, usage := range c.nodeIPUsage() {
+		ch <- prometheus.MustNewConstMetric(c.allocatedDesc, prometheus.GaugeValue, usage.Allocated, node)
+		ch <- prometheus.MustNewConstMetric(c.availableDesc, prometheus.GaugeValue, usage.Available, node)
+	}
+}
+
+var (
+	networkMetricsMutex sync.Mutex
+	// networkMetrics stores the registered per-network metrics by network name
+	networkMetrics = map[string][]prometheus.Collector{}
+)
+
+// RegisterNetworkMetrics registers the per-network metrics of the given network, replacing the
+// ones already registered for it. They should be unregistered with UnregisterNetworkMetrics when
+// the network controller stops.
+func RegisterNetworkMetrics(network string, funcs NetworkMetricsFuncs) {
+	networkMetricsMutex.Lock()
+	defer networkMetricsMutex.Unlock()
+	unregisterNetworkMetrics(network)
+
+	newGauge := func(name, help string, f func() float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   MetricOvnkubeNamespace,
+			Subsystem:   MetricOvnkubeSubsystemMaster,
+			Name:        name,
+			Help:        help,
+			ConstLabels: prometheus.Labels{"network": network},
+		}, f)
+	}
+	collectors := []prometheus.Collector{
+		newGauge("network_logical_switch_ports", "The number of pod logical switch ports of the network",
+			funcs.LogicalSwitchPorts),
+		newGauge("network_allocated_ips", "The number of IPs allocated from the node subnets of the network",
+			funcs.AllocatedIPs),
+		newGauge("network_available_ips", "The number of IPs still available in the node subnets of the network",
+			funcs.AvailableIPs),
+	}
+	if funcs.NADs != nil {
+		collectors = append(collectors, newGauge("network_nads",
+			"The number of network attachment definitions of the network", funcs.NADs))
+	}
+	if funcs.NodeIPUsage != nil {
+		collectors = append(collectors, newNodeIPUsageCollector(network, funcs.NodeIPUsage))
+	}
+	if funcs.QuarantinedIPs != nil {
+		collectors = append(collectors, newGauge("network_quarantined_pod_ips",
+			"The number of released pod IPs of the network held back from allocation until their quarantine expires",
+			funcs.QuarantinedIPs))
+	}
+	for _, collector := range collectors {
+		prometheus.MustRegister(collector)
+	}
+	networkMetrics[network] = collectors
+}
+
+// networkLabeledMetrics are the controller metrics labeled by network
+var networkLabeledMetrics = []interface {
+	DeletePartialMatch(labels prometheus.Labels) int
+}{
+	metricPodCreationLatency,
+	MetricResourceUpdateCount,
+	MetricResourceAddLatency,
+	MetricResourceUpdateLatency,
+	MetricResourceDeleteLatency,
+	metricNetpolEventLatency,
+	metricNetpolLocalPodEventLatency,
+	metricNetpolPeerNamespaceEventLatency,
+	metricPodSelectorAddrSetPodEventLatency,
+	metricPodSelectorAddrSetNamespaceEventLatency,
+	metricPodEventLatency,
+	metricFirstSeenLSPLatency,
+	metricLSPPortBindingLatency,
+	metricPortBindingChassisLatency,
+	metricPortBindingUpLatency,
+}
+
+// UnregisterNetworkMetrics unregisters the per-network metrics of the given network, and
+// deletes its series from the controller metrics labeled by network.
+func UnregisterNetworkMetrics(network string) {
+	networkMetricsMutex.Lock()
+	defer networkMetricsMutex.Unlock()
+	unregisterNetworkMetrics(network)
+	for _, metric := range networkLabeledMetrics {
+		metric.DeletePartialMatch(prometheus.Labels{"network": network})
+	}
+}
+
+func unregisterNetworkMetrics(network string) {
+	for _, collector := range networkMetrics[network] {
+		prometheus.Unregister(collector)
+	}
+	delete(networkMetrics, network)
+}
+
+// scaleMetrics are only registered if config.Metrics.EnableScaleMetrics is set
+var scaleMetrics = []prometheus.Collector{
+	metricEgressIPAssignLatency,
+	metricEgressIPUnassignLatency,
+	metricNetpolEventLatency,
+	metricNetpolLocalPodEventLatency,
+	metricNetpolPeerNamespaceEventLatency,
+	metricPodSelectorAddrSetPodEventLatency,
+	metricPodSelectorAddrSetNamespaceEventLatency,
+	metricPodEventLatency,
+}
+
+func registerScaleMetrics() {
+	for _, metric := range scaleMetrics {
+		if err := prometheus.Register(metric); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				panic(err)
+			}
+		}
+	}
+}
+
+func unregisterScaleMetrics() {
+	for _, metric := range scaleMetrics {
+		prometheus.Unregister(metric)
+	}
+}
+
 // RunTimestamp adds a goroutine that registers and updates timestamp metrics.
 // This is so we can determine 'freshness' of the components NB/SB DB and northd.
 // Function must be called once.
@@ -485,10 +703,6 @@ func RunTimestamp(stopChan <-chan struct{}, sbClient, nbClient libovsdbclient.Cl
 // RecordPodCreated extracts the scheduled timestamp and records how long it took
 // us to notice this and set up the pod's scheduling.
 func RecordPodCreated(pod *kapi.Pod, netInfo util.NetInfo) {
-	if netInfo.IsSecondary() {
-		// TBD: no op for secondary network for now, TBD
-		return
-	}
 	t := time.Now()
 
 	// Find the scheduled timestamp
@@ -500,7 +714,7 @@ func RecordPodCreated(pod *kapi.Pod, netInfo util.NetInfo) {
 			return
 		}
 		creationLatency := t.Sub(cond.LastTransitionTime.Time).Seconds()
-		metricPodCreationLatency.Observe(creationLatency)
+		metricPodCreationLatency.WithLabelValues(netInfo.GetNetworkName()).Observe(creationLatency)
 		return
 	}
 }
@@ -531,28 +745,52 @@ func RecordEgressIPRebalance(count int) {
 	metricEgressIPRebalanceCount.Add(float64(count))
 }
 
-func RecordNetpolEvent(eventName string, duration time.Duration) {
-	metricNetpolEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+// RecordDBGCStaleObjects records how many stale objects of the given type the NB garbage collector found.
+func RecordDBGCStaleObjects(objectType string, count int) {
+	metricDBGCStaleObjects.WithLabelValues(objectType).Set(float64(count))
+}
+
+// RecordDBGCCollectedObjects records how many stale objects of the given type the NB garbage collector deleted.
+func RecordDBGCCollectedObjects(objectType string, count int) {
+	metricDBGCCollectedObjects.WithLabelValues(objectType).Add(float64(count))
+}
+
+func RecordNetpolEvent(network, eventName string, duration time.Duration) {
+	metricNetpolEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
+}
+
+func RecordNetpolLocalPodEvent(network, eventName string, duration time.Duration) {
+	metricNetpolLocalPodEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
 }
 
-func RecordNetpolLocalPodEvent(eventName string, duration time.Duration) {
-	metricNetpolLocalPodEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+func RecordNetpolPeerNamespaceEvent(network, eventName string, duration time.Duration) {
+	metricNetpolPeerNamespaceEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
 }
 
-func RecordNetpolPeerNamespaceEvent(eventName string, duration time.Duration) {
-	metricNetpolPeerNamespaceEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+func RecordPodSelectorAddrSetPodEvent(network, eventName string, duration time.Duration) {
+	metricPodSelectorAddrSetPodEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
 }
 
-func RecordPodSelectorAddrSetPodEvent(eventName string, duration time.Duration) {
-	metricPodSelectorAddrSetPodEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+func RecordPodSelectorAddrSetNamespaceEvent(network, eventName string, duration time.Duration) {
+	metricPodSelectorAddrSetNamespaceEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
 }
 
-func RecordPodSelectorAddrSetNamespaceEvent(eventName string, duration time.Duration) {
-	metricPodSelectorAddrSetNamespaceEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+// RecordResourceEvent records an event of the named resource type handled by the handlers
+// of a network, and the time they took to handle it.
+func RecordResourceEvent(name, eventName, network string, duration time.Duration) {
+	MetricResourceUpdateCount.WithLabelValues(name, eventName, network).Inc()
+	switch eventName {
+	case "add":
+		MetricResourceAddLatency.WithLabelValues(network).Observe(duration.Seconds())
+	case "update":
+		MetricResourceUpdateLatency.WithLabelValues(network).Observe(duration.Seconds())
+	case "delete":
+		MetricResourceDeleteLatency.WithLabelValues(network).Observe(duration.Seconds())
+	}
 }
 
-func RecordPodEvent(eventName string, duration time.Duration) {
-	metricPodEventLatency.WithLabelValues(eventName).Observe(duration.Seconds())
+func RecordPodEvent(network, eventName string, duration time.Duration) {
+	metricPodEventLatency.WithLabelValues(eventName, network).Observe(duration.Seconds())
 }
 
 // UpdateEgressFirewallRuleCount records the number of Egress firewall rules.
@@ -624,10 +862,8 @@ type (
 )
 
 const (
-	// pod event first handled by OVN-Kubernetes control plane
-	firstSeen timestampType = iota
 	// OVN-Kubernetes control plane created Logical Switch Port in northbound database
-	logicalSwitchPort
+	logicalSwitchPort timestampType = iota
 	// port binding seen in OVN-Kubernetes control plane southbound database libovsdb cache
 	portBinding
 	// port binding with updated chassis seen in OVN-Kubernetes control plane southbound database libovsdb cache
@@ -649,16 +885,27 @@ type record struct {
 	timestampType
 }
 
+// recordKey identifies the logical switch port of a pod on a network
+type recordKey struct {
+	uid     kapimtypes.UID
+	network string
+}
+
 type item struct {
 	op        operation
 	timestamp time.Time
 	old       model.Model
 	new       model.Model
 	uid       kapimtypes.UID
+	network   string
 }
 
 type PodRecorder struct {
-	records map[kapimtypes.UID]*record
+	// firstSeen stores the time every pod was first handled, until the pod is deleted
+	firstSeen map[kapimtypes.UID]time.Time
+	// records stores the last event of the logical switch port of a pod on every network,
+	// until the port binding is up
+	records map[recordKey]*record
 	queue   workqueue.Interface
 }
 
@@ -678,7 +925,8 @@ func (pr *PodRecorder) Run(sbClient libovsdbclient.Client, stop <-chan struct{})
 	})
 
 	pr.queue = workqueue.New()
-	pr.records = make(map[kapimtypes.UID]*record)
+	pr.firstSeen = make(map[kapimtypes.UID]time.Time)
+	pr.records = make(map[recordKey]*record)
 
 	sbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
 		AddFunc: func(table string, model model.Model) {
@@ -730,46 +978,42 @@ func (pr *PodRecorder) CleanPod(podUID kapimtypes.UID) {
 }
 
 func (pr *PodRecorder) AddLSP(podUID kapimtypes.UID, netInfo util.NetInfo) {
-	if netInfo.IsSecondary() {
-		// TBD: no op for secondary network for now, TBD
-		return
-	}
 	if pr.queue != nil && !pr.queueFull() {
-		pr.queue.Add(item{op: addLogicalSwitchPort, uid: podUID, timestamp: time.Now()})
+		pr.queue.Add(item{op: addLogicalSwitchPort, uid: podUID, network: netInfo.GetNetworkName(), timestamp: time.Now()})
 	}
 }
 
-func (pr *PodRecorder) addLSP(podUID kapimtypes.UID, t time.Time) {
-	var r *record
-	if r = pr.getRecord(podUID); r == nil {
+func (pr *PodRecorder) addLSP(podUID kapimtypes.UID, network string, t time.Time) {
+	seen, ok := pr.firstSeen[podUID]
+	if !ok {
 		klog.V(5).Infof("Add Logical Switch Port event expected pod with UID %q in cache", podUID)
 		return
 	}
-	if r.timestampType != firstSeen {
-		klog.V(5).Infof("Unexpected last event type (%d) in cache for pod with UID %q", r.timestampType, podUID)
+	key := recordKey{uid: podUID, network: network}
+	if _, ok := pr.records[key]; ok {
+		klog.V(5).Infof("Unexpected Logical Switch Port event for pod with UID %q on network %s already in cache", podUID, network)
 		return
 	}
-	metricFirstSeenLSPLatency.Observe(t.Sub(r.timestamp).Seconds())
-	r.timestamp = t
-	r.timestampType = logicalSwitchPort
+	metricFirstSeenLSPLatency.WithLabelValues(network).Observe(t.Sub(seen).Seconds())
+	pr.records[key] = &record{timestamp: t, timestampType: logicalSwitchPort}
 }
 
 func (pr *PodRecorder) addPortBinding(m model.Model, t time.Time) {
 	var r *record
 	row := m.(*sbdb.PortBinding)
-	podUID := getPodUIDFromPortBinding(row)
-	if podUID == "" {
+	key := getRecordKeyFromPortBinding(row)
+	if key.uid == "" {
 		return
 	}
-	if r = pr.getRecord(podUID); r == nil {
-		klog.V(5).Infof("Add port binding event expected pod with UID %q in cache", podUID)
+	if r = pr.getRecord(key); r == nil {
+		klog.V(5).Infof("Add port binding event expected pod with UID %q in cache", key.uid)
 		return
 	}
 	if r.timestampType != logicalSwitchPort {
-		klog.V(5).Infof("Unexpected last event entry (%d) in cache for pod with UID %q", r.timestampType, podUID)
+		klog.V(5).Infof("Unexpected last event entry (%d) in cache for pod with UID %q", r.timestampType, key.uid)
 		return
 	}
-	metricLSPPortBindingLatency.Observe(t.Sub(r.timestamp).Seconds())
+	metricLSPPortBindingLatency.WithLabelValues(key.network).Observe(t.Sub(r.timestamp).Seconds())
 	r.timestamp = t
 	r.timestampType = portBinding
 }
@@ -778,25 +1022,25 @@ func (pr *PodRecorder) updatePortBinding(old, new model.Model, t time.Time) {
 	var r *record
 	oldRow := old.(*sbdb.PortBinding)
 	newRow := new.(*sbdb.PortBinding)
-	podUID := getPodUIDFromPortBinding(newRow)
-	if podUID == "" {
+	key := getRecordKeyFromPortBinding(newRow)
+	if key.uid == "" {
 		return
 	}
-	if r = pr.getRecord(podUID); r == nil {
-		klog.V(5).Infof("Port binding update expected pod with UID %q in cache", podUID)
+	if r = pr.getRecord(key); r == nil {
+		klog.V(5).Infof("Port binding update expected pod with UID %q in cache", key.uid)
 		return
 	}
 
 	if oldRow.Chassis == nil && newRow.Chassis != nil && r.timestampType == portBinding {
-		metricPortBindingChassisLatency.Observe(t.Sub(r.timestamp).Seconds())
+		metricPortBindingChassisLatency.WithLabelValues(key.network).Observe(t.Sub(r.timestamp).Seconds())
 		r.timestamp = t
 		r.timestampType = portBindingChassis
 
 	}
 
 	if oldRow.Up != nil && !*oldRow.Up && newRow.Up != nil && *newRow.Up && r.timestampType == portBindingChassis {
-		metricPortBindingUpLatency.Observe(t.Sub(r.timestamp).Seconds())
-		delete(pr.records, podUID)
+		metricPortBindingUpLatency.WithLabelValues(key.network).Observe(t.Sub(r.timestamp).Seconds())
+		delete(pr.records, key)
 	}
 }
 
@@ -826,33 +1070,44 @@ func (pr *PodRecorder) processItem(i item) {
 	case updatePortBinding:
 		pr.updatePortBinding(i.old, i.new, i.timestamp)
 	case addPod:
-		pr.records[i.uid] = &record{timestamp: i.timestamp, timestampType: firstSeen}
+		pr.firstSeen[i.uid] = i.timestamp
 	case cleanPod:
-		delete(pr.records, i.uid)
+		delete(pr.firstSeen, i.uid)
+		for key := range pr.records {
+			if key.uid == i.uid {
+				delete(pr.records, key)
+			}
+		}
 	case addLogicalSwitchPort:
-		pr.addLSP(i.uid, i.timestamp)
+		pr.addLSP(i.uid, i.network, i.timestamp)
 	}
 }
 
 // getRecord returns record from map with func argument as the key
-func (pr *PodRecorder) getRecord(podUID kapimtypes.UID) *record {
-	r, ok := pr.records[podUID]
+func (pr *PodRecorder) getRecord(key recordKey) *record {
+	r, ok := pr.records[key]
 	if !ok {
-		klog.V(5).Infof("Cache entry expected pod with UID %q but failed to find it", podUID)
+		klog.V(5).Infof("Cache entry expected pod with UID %q on network %s but failed to find it", key.uid, key.network)
 		return nil
 	}
 	return r
 }
 
-func getPodUIDFromPortBinding(row *sbdb.PortBinding) kapimtypes.UID {
+// getRecordKeyFromPortBinding returns the pod UID and network of a pod port binding, the
+// pod UID is empty if the port binding doesn't belong to a pod
+func getRecordKeyFromPortBinding(row *sbdb.PortBinding) recordKey {
 	if isPod, ok := row.ExternalIDs["pod"]; !ok || isPod != "true" {
-		return ""
+		return recordKey{}
 	}
 	podUID, ok := row.Options["iface-id-ver"]
 	if !ok {
-		return ""
+		return recordKey{}
+	}
+	network := row.ExternalIDs[ovntypes.NetworkExternalID]
+	if network == "" {
+		network = ovntypes.DefaultNetworkName
 	}
-	return kapimtypes.UID(podUID)
+	return recordKey{uid: kapimtypes.UID(podUID), network: network}
 }
 
 const (
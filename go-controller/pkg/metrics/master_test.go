@@ -10,9 +10,13 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics/mocks"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kapimtypes "k8s.io/apimachinery/pkg/types"
 	fakeclientgo "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/onsi/ginkgo"
@@ -234,3 +238,36 @@ var _ = ginkgo.Describe("Config Duration Operations", func() {
 		})
 	})
 })
+
+var _ = ginkgo.Describe("Pod Recorder Operations", func() {
+	const podUID = kapimtypes.UID("pod-uid")
+
+	ginkgo.BeforeEach(func() {
+		metricFirstSeenLSPLatency.Reset()
+		metricLSPPortBindingLatency.Reset()
+	})
+
+	ginkgo.It("records the logical switch port of every network of a pod", func() {
+		pr := &PodRecorder{firstSeen: map[kapimtypes.UID]time.Time{}, records: map[recordKey]*record{}}
+		pr.processItem(item{op: addPod, uid: podUID, timestamp: time.Now()})
+		pr.processItem(item{op: addLogicalSwitchPort, uid: podUID, network: ovntypes.DefaultNetworkName, timestamp: time.Now()})
+		pr.processItem(item{op: addLogicalSwitchPort, uid: podUID, network: "blue", timestamp: time.Now()})
+		gomega.Expect(testutil.CollectAndCount(metricFirstSeenLSPLatency)).To(gomega.Equal(2))
+
+		pr.processItem(item{op: addPortBinding, timestamp: time.Now(), old: &sbdb.PortBinding{
+			ExternalIDs: map[string]string{"pod": "true", ovntypes.NetworkExternalID: "blue"},
+			Options:     map[string]string{"iface-id-ver": string(podUID)},
+		}})
+		gomega.Expect(testutil.CollectAndCount(metricLSPPortBindingLatency)).To(gomega.Equal(1))
+		gomega.Expect(pr.records[recordKey{uid: podUID, network: "blue"}].timestampType).To(gomega.Equal(portBinding))
+		gomega.Expect(pr.records[recordKey{uid: podUID, network: ovntypes.DefaultNetworkName}].timestampType).To(gomega.Equal(logicalSwitchPort))
+
+		UnregisterNetworkMetrics("blue")
+		gomega.Expect(testutil.CollectAndCount(metricFirstSeenLSPLatency)).To(gomega.Equal(1))
+		gomega.Expect(testutil.CollectAndCount(metricLSPPortBindingLatency)).To(gomega.Equal(0))
+
+		pr.processItem(item{op: cleanPod, uid: podUID})
+		gomega.Expect(pr.firstSeen).To(gomega.BeEmpty())
+		gomega.Expect(pr.records).To(gomega.BeEmpty())
+	})
+})
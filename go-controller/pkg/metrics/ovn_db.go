@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -241,6 +242,44 @@ var metricDBClusterConnOutErr = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	},
 )
 
+var metricDBBackupFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnNamespace,
+	Subsystem: MetricOvnSubsystemDB,
+	Name:      "backup_failures_total",
+	Help:      "The total number of failed attempts to back up the database labeled by database name"},
+	[]string{
+		"db_name",
+	},
+)
+
+// RegisterOvnDBBackupMetrics registers the metrics related to the periodic database backups
+// taken by ovndbchecker. lastBackup must return the time of the last successful backup, or the
+// zero time if there is none, in which case the reported age is +Inf.
+func RegisterOvnDBBackupMetrics(dbName string, lastBackup func() time.Time) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace:   MetricOvnNamespace,
+			Subsystem:   MetricOvnSubsystemDB,
+			Name:        "backup_age_seconds",
+			Help:        "The number of seconds since the last successful backup of the database",
+			ConstLabels: prometheus.Labels{"db_name": dbName},
+		},
+		func() float64 {
+			last := lastBackup()
+			if last.IsZero() {
+				return math.Inf(1)
+			}
+			return time.Since(last).Seconds()
+		},
+	))
+	prometheus.MustRegister(metricDBBackupFailures)
+}
+
+// RecordOvnDBBackupFailure records a failed attempt to back up the database
+func RecordOvnDBBackupFailure(dbName string) {
+	metricDBBackupFailures.WithLabelValues(dbName).Inc()
+}
+
 func ovnDBSizeMetricsUpdater(dbProps *util.OvsDbProperties) {
 	if size, err := getOvnDBSizeViaPath(dbProps); err != nil {
 		klog.Errorf("Failed to update OVN DB size metric: %v", err)
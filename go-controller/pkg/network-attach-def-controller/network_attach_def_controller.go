@@ -177,7 +177,7 @@ func (nadController *NetAttachDefinitionController) Stop() {
 	nadController.wg.Wait()
 
 	// stop each network controller
-	for _, oc := range nadController.getAllNetworkControllers() {
+	for _, oc := range nadController.GetAllNetworkControllers() {
 		oc.Stop()
 	}
 }
@@ -207,7 +207,7 @@ func (nadController *NetAttachDefinitionController) SyncNetworkControllers() (er
 		}
 	}
 
-	return nadController.ncm.CleanupDeletedNetworks(nadController.getAllNetworkControllers())
+	return nadController.ncm.CleanupDeletedNetworks(nadController.GetAllNetworkControllers())
 }
 
 func (nadController *NetAttachDefinitionController) worker() {
@@ -332,10 +332,10 @@ func (nadController *NetAttachDefinitionController) onNetworkAttachDefinitionDel
 	nadController.queueNetworkAttachDefinition(obj)
 }
 
-// getAllNetworkControllers returns a snapshot of all managed NAD associated network controllers.
+// GetAllNetworkControllers returns a snapshot of all managed NAD associated network controllers.
 // Caller needs to note that there are no guarantees the return results reflect the real time
 // condition. There maybe more controllers being added, and returned controllers may be deleted
-func (nadController *NetAttachDefinitionController) getAllNetworkControllers() []NetworkController {
+func (nadController *NetAttachDefinitionController) GetAllNetworkControllers() []NetworkController {
 	allNetworkNames := nadController.perNetworkNADInfo.GetKeys()
 	allNetworkControllers := make([]NetworkController, 0, len(allNetworkNames))
 	for _, netName := range allNetworkNames {
@@ -17,6 +17,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	nad "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/network-attach-def-controller"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/dbgc"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -312,12 +313,45 @@ func (cm *networkControllerManager) Start(ctx context.Context) error {
 
 	// nadController is nil if multi-network is disabled
 	if cm.nadController != nil {
-		return cm.nadController.Start()
+		err = cm.nadController.Start()
+		if err != nil {
+			return err
+		}
 	}
 
+	cm.startDBGarbageCollector()
 	return nil
 }
 
+// startDBGarbageCollector starts the garbage collector of stale NB objects owned by the network controllers,
+// if enabled.
+func (cm *networkControllerManager) startDBGarbageCollector() {
+	if config.OVNKubernetesFeature.DBGCInterval == 0 {
+		return
+	}
+	getOwners := func() []dbgc.ObjectOwner {
+		owners := []dbgc.ObjectOwner{}
+		if owner, ok := cm.defaultNetworkController.(dbgc.ObjectOwner); ok {
+			owners = append(owners, owner)
+		}
+		if cm.nadController != nil {
+			for _, nc := range cm.nadController.GetAllNetworkControllers() {
+				if owner, ok := nc.(dbgc.ObjectOwner); ok {
+					owners = append(owners, owner)
+				}
+			}
+		}
+		return owners
+	}
+	gc := dbgc.NewController(cm.nbClient, getOwners,
+		time.Duration(config.OVNKubernetesFeature.DBGCInterval)*time.Second, config.OVNKubernetesFeature.DBGCDryRun)
+	cm.wg.Add(1)
+	go func() {
+		defer cm.wg.Done()
+		gc.Run(cm.stopChan)
+	}()
+}
+
 // Stop gracefully stops all managed controllers
 func (cm *networkControllerManager) Stop() {
 	// stop metric recorders
@@ -168,6 +168,16 @@ func (ncm *nodeNetworkControllerManager) Start(ctx context.Context) (err error)
 	return err
 }
 
+// GatewayModeChanged returns a channel signalled when another gateway mode is selected for
+// the node, which ovnkube-node must be restarted to migrate to, or nil if the default node
+// network controller isn't running
+func (ncm *nodeNetworkControllerManager) GatewayModeChanged() <-chan struct{} {
+	if nc, ok := ncm.defaultNodeNetworkController.(*node.DefaultNodeNetworkController); ok {
+		return nc.GatewayModeChanged()
+	}
+	return nil
+}
+
 // Stop gracefully stops all managed controllers
 func (ncm *nodeNetworkControllerManager) Stop() {
 	// stop stale ovs ports cleanup
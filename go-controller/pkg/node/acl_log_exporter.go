@@ -0,0 +1,568 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// aclLogTailInterval is how often the ovn-controller log file is checked for new ACL logs
+	aclLogTailInterval = time.Second
+	// aclLogSyslogTag is the tag of the ACL logs exported to syslog
+	aclLogSyslogTag = "ovnkube-acl-log"
+	// aclLogSyslogPriority is the priority of the ACL logs exported to syslog: facility user,
+	// severity info
+	aclLogSyslogPriority = 1<<3 | 6
+	// aclLogMaxDatagramSize is the size of the largest ACL log received on the unix socket
+	aclLogMaxDatagramSize = 64 * 1024
+)
+
+// kinds of the objects the ACLs of the logs are built for
+const (
+	aclLogOwnerNetworkPolicy    = "NetworkPolicy"
+	aclLogOwnerDefaultDeny      = "NetworkPolicyDefaultDeny"
+	aclLogOwnerARPAllow         = "NetworkPolicyARPAllow"
+	aclLogOwnerEgressFirewall   = "EgressFirewall"
+	aclLogOwnerMulticast        = "Multicast"
+	aclLogOwnerClusterMulticast = "ClusterMulticast"
+)
+
+// aclLogEgressFirewallName is the name of the EgressFirewalls, the only one allowed in a namespace
+const aclLogEgressFirewallName = "default"
+
+// aclLogPod identifies a pod of an ACL log
+type aclLogPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// aclLogOwner identifies the object an ACL of a log was built for from the name of the ACL.
+// Rule is the index of the rule of a network policy or an egress firewall, Network is set for
+// the multicast ACLs of the secondary networks.
+type aclLogOwner struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Rule      *int   `json:"rule,omitempty"`
+	Network   string `json:"network,omitempty"`
+}
+
+// aclLogRecord is an ACL log of ovn-controller as exported by ovnkube-node
+type aclLogRecord struct {
+	Timestamp string       `json:"timestamp"`
+	Node      string       `json:"node"`
+	ACL       string       `json:"acl"`
+	Verdict   string       `json:"verdict"`
+	Severity  string       `json:"severity"`
+	Direction string       `json:"direction,omitempty"`
+	Protocol  string       `json:"protocol,omitempty"`
+	SrcIP     string       `json:"srcIP,omitempty"`
+	DstIP     string       `json:"dstIP,omitempty"`
+	SrcPort   int          `json:"srcPort,omitempty"`
+	DstPort   int          `json:"dstPort,omitempty"`
+	SrcPod    *aclLogPod   `json:"srcPod,omitempty"`
+	DstPod    *aclLogPod   `json:"dstPod,omitempty"`
+	Owner     *aclLogOwner `json:"owner,omitempty"`
+}
+
+// aclLogExporter reads the ACL logs of ovn-controller, from its log file or from a unix socket
+// ovn-controller sends them to as syslog messages, and exports them as JSON lines identifying
+// the pods and the objects the ACLs were built for. Only the pods of the node can be resolved
+// from their IPs since the node only watches its own pods.
+type aclLogExporter struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	source       string
+	target       string
+	tailInterval time.Duration
+
+	// podsByIP indexes the pods of the node by their IPs, it is kept up to date by the pod
+	// handler and guarded by podsLock
+	podsLock   sync.RWMutex
+	podsByIP   map[string]aclLogPod
+	podHandler *factory.Handler
+}
+
+func newACLLogExporter(nodeName string, watchFactory factory.NodeWatchFactory) *aclLogExporter {
+	return &aclLogExporter{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		source:       config.Logging.ACLLogExportSource,
+		target:       config.Logging.ACLLogExportTarget,
+		tailInterval: aclLogTailInterval,
+		podsByIP:     map[string]aclLogPod{},
+	}
+}
+
+// Run exports the ACL logs until stopChan is closed
+func (e *aclLogExporter) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
+	out, err := newACLLogWriter(e.target)
+	if err != nil {
+		return err
+	}
+	if err := e.watchPods(); err != nil {
+		out.Close()
+		return err
+	}
+	export := func(line string) {
+		record := e.parse(line)
+		if record == nil {
+			return
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			klog.Errorf("Unable to encode ACL log %q: %v", line, err)
+			return
+		}
+		if _, err := out.Write(append(data, '\n')); err != nil {
+			klog.Errorf("Unable to export ACL log to %s: %v", e.target, err)
+		}
+	}
+
+	var run func()
+	if path := strings.TrimPrefix(e.source, "unix:"); path != e.source {
+		conn, err := listenACLLogSocket(path)
+		if err != nil {
+			e.unwatchPods()
+			out.Close()
+			return err
+		}
+		run = func() { receiveACLLogs(conn, stopChan, export) }
+	} else {
+		run = func() { tailACLLogFile(e.source, e.tailInterval, stopChan, export) }
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer out.Close()
+		defer e.unwatchPods()
+		klog.Infof("Exporting the ACL logs of %s to %s", e.source, e.target)
+		run()
+	}()
+	return nil
+}
+
+// watchPods indexes the IPs of the pods of the node, host network pods and completed pods are
+// not indexed
+func (e *aclLogExporter) watchPods() error {
+	handler, err := e.watchFactory.AddPodHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			e.updatePodIPs(nil, obj.(*kapi.Pod))
+		},
+		UpdateFunc: func(old, newer interface{}) {
+			e.updatePodIPs(old.(*kapi.Pod), newer.(*kapi.Pod))
+		},
+		DeleteFunc: func(obj interface{}) {
+			e.updatePodIPs(obj.(*kapi.Pod), nil)
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to watch the pods to resolve the IPs of the ACL logs: %w", err)
+	}
+	e.podHandler = handler
+	return nil
+}
+
+func (e *aclLogExporter) unwatchPods() {
+	if e.podHandler != nil {
+		e.watchFactory.RemovePodHandler(e.podHandler)
+		e.podHandler = nil
+	}
+}
+
+// getIndexedPodIPs returns the IPs a pod is indexed by
+func getIndexedPodIPs(pod *kapi.Pod) []string {
+	if pod == nil || pod.Spec.HostNetwork || util.PodCompleted(pod) {
+		return nil
+	}
+	ips := make([]string, 0, len(pod.Status.PodIPs))
+	for _, podIP := range pod.Status.PodIPs {
+		if ip := utilnet.ParseIPSloppy(podIP.IP); ip != nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	return ips
+}
+
+// updatePodIPs replaces the IPs of the old version of a pod by the IPs of its new version in the
+// index, old is nil when the pod is added and newer is nil when it is deleted. The IPs of the old
+// version are only removed if no other pod was indexed by them since.
+func (e *aclLogExporter) updatePodIPs(old, newer *kapi.Pod) {
+	e.podsLock.Lock()
+	defer e.podsLock.Unlock()
+	if old != nil {
+		oldPod := aclLogPod{Namespace: old.Namespace, Name: old.Name}
+		for _, ip := range getIndexedPodIPs(old) {
+			if e.podsByIP[ip] == oldPod {
+				delete(e.podsByIP, ip)
+			}
+		}
+	}
+	if newer != nil {
+		for _, ip := range getIndexedPodIPs(newer) {
+			e.podsByIP[ip] = aclLogPod{Namespace: newer.Namespace, Name: newer.Name}
+		}
+	}
+}
+
+// getPodByIP returns the pod of the node with the IP of an ACL log, nil if there is none
+func (e *aclLogExporter) getPodByIP(ip string) *aclLogPod {
+	parsed := utilnet.ParseIPSloppy(ip)
+	if parsed == nil {
+		return nil
+	}
+	e.podsLock.RLock()
+	defer e.podsLock.RUnlock()
+	if pod, ok := e.podsByIP[parsed.String()]; ok {
+		return &pod
+	}
+	return nil
+}
+
+// parse returns the record of an ACL log of ovn-controller or nil if the line is not an ACL log.
+// ACL logs look like:
+// 2023-01-27T10:22:14.123Z|00005|acl_log(ovn_pinctrl0)|INFO|name="ns_policy_0", verdict=allow,
+// severity=info, direction=to-lport: tcp,vlan_tci=0x0000,...,nw_src=10.244.1.3,nw_dst=10.244.2.5,
+// ...,tp_src=46010,tp_dst=8080,tcp_flags=syn
+func (e *aclLogExporter) parse(line string) *aclLogRecord {
+	idx := strings.Index(line, "acl_log(")
+	if idx < 0 {
+		return nil
+	}
+	// skip the module and the level
+	fields := strings.SplitN(line[idx:], "|", 3)
+	if len(fields) != 3 {
+		return nil
+	}
+	header, flow, found := strings.Cut(strings.TrimSpace(fields[2]), ": ")
+	if !found {
+		return nil
+	}
+
+	record := &aclLogRecord{Node: e.nodeName}
+	record.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	if ts, _, found := strings.Cut(line[:idx], "|"); found {
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(ts)); err == nil {
+			record.Timestamp = t.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	for _, field := range strings.Split(header, ", ") {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "name":
+			record.ACL = strings.Trim(value, "\"")
+			if record.ACL == "<unnamed>" {
+				record.ACL = ""
+			}
+		case "verdict":
+			record.Verdict = value
+		case "severity":
+			record.Severity = value
+		case "direction":
+			record.Direction = value
+		}
+	}
+	for i, field := range strings.Split(flow, ",") {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			if i == 0 {
+				record.Protocol = key
+			}
+			continue
+		}
+		switch key {
+		case "nw_src", "ipv6_src":
+			record.SrcIP = value
+		case "nw_dst", "ipv6_dst":
+			record.DstIP = value
+		case "tp_src":
+			record.SrcPort, _ = strconv.Atoi(value)
+		case "tp_dst":
+			record.DstPort, _ = strconv.Atoi(value)
+		}
+	}
+
+	record.Owner = getACLLogOwner(record.ACL)
+	record.SrcPod = e.getPodByIP(record.SrcIP)
+	record.DstPod = e.getPodByIP(record.DstIP)
+	return record
+}
+
+// getACLLogOwner returns the object an ACL was built for from its name or nil if it can't be
+// told. The names of the ACLs are:
+// - <namespace>_<policy>_<rule> for network policies
+// - <namespace>_ingressDefaultDeny, <namespace>_egressDefaultDeny and <namespace>_ARPallowPolicy
+// for the default deny policies of the namespaces with network policies
+// - egressFirewall_<namespace>_<priority> for egress firewalls
+// - [<network>_]<namespace>_MulticastAllow<Egress|Ingress> for the multicast policies of the
+// namespaces and [<network>_]<clusterPortGroup|clusterRtrPortGroup>_Default<Allow|Deny>Multicast
+// <Egress|Ingress> for the cluster wide multicast policies
+// Names are truncated to 63 characters by the network controller, so long names may not be
+// resolved.
+func getACLLogOwner(aclName string) *aclLogOwner {
+	if aclName == "" {
+		return nil
+	}
+	parts := strings.Split(aclName, "_")
+	last := parts[len(parts)-1]
+	switch {
+	case strings.Contains(last, "Multicast") && len(parts) >= 2:
+		owner := &aclLogOwner{Kind: aclLogOwnerMulticast}
+		name := parts[len(parts)-2]
+		owner.Network = strings.Join(parts[:len(parts)-2], "_")
+		if name == types.ClusterPortGroupName || name == types.ClusterRtrPortGroupName {
+			owner.Kind = aclLogOwnerClusterMulticast
+		} else {
+			owner.Namespace = name
+		}
+		return owner
+	case len(parts) != 2 && len(parts) != 3:
+		return nil
+	case len(parts) == 2 && (last == types.IngressDefaultDenySuffix || last == types.EgressDefaultDenySuffix):
+		return &aclLogOwner{Kind: aclLogOwnerDefaultDeny, Namespace: parts[0]}
+	case len(parts) == 2 && last == types.ARPAllowPolicySuffix:
+		return &aclLogOwner{Kind: aclLogOwnerARPAllow, Namespace: parts[0]}
+	case len(parts) == 3 && parts[0] == types.EgressFirewallACLPrefix:
+		priority, err := strconv.Atoi(last)
+		if err != nil {
+			return nil
+		}
+		rule := types.EgressFirewallStartPriority - priority
+		return &aclLogOwner{Kind: aclLogOwnerEgressFirewall, Namespace: parts[1], Name: aclLogEgressFirewallName, Rule: &rule}
+	case len(parts) == 3:
+		rule, err := strconv.Atoi(last)
+		if err != nil {
+			return nil
+		}
+		return &aclLogOwner{Kind: aclLogOwnerNetworkPolicy, Namespace: parts[0], Name: parts[1], Rule: &rule}
+	}
+	return nil
+}
+
+// tailACLLogFile calls export for every line appended to the log file at path until stopChan is
+// closed. Lines logged before it started are skipped. The file is reopened from its start when
+// it is rotated and read again from its start when it is truncated.
+func tailACLLogFile(path string, interval time.Duration, stopChan <-chan struct{}, export func(string)) {
+	var file *os.File
+	var reader *bufio.Reader
+	var partial string
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+	seekEnd := true
+	for {
+		if file == nil {
+			var err error
+			file, err = os.Open(path)
+			if err == nil && seekEnd {
+				_, err = file.Seek(0, io.SeekEnd)
+			}
+			if err != nil {
+				klog.V(5).Infof("Unable to open ACL log file %s: %v", path, err)
+				if file != nil {
+					file.Close()
+					file = nil
+				}
+			} else {
+				reader = bufio.NewReader(file)
+				partial = ""
+				seekEnd = false
+			}
+		}
+
+		if file != nil {
+			line, err := reader.ReadString('\n')
+			if err == nil {
+				export(strings.TrimSuffix(partial+line, "\n"))
+				partial = ""
+				continue
+			}
+			partial += line
+			if err != io.EOF {
+				klog.Errorf("Unable to read ACL log file %s: %v", path, err)
+				file.Close()
+				file = nil
+			} else if rotated, truncated := aclLogFileChanged(path, file); rotated {
+				klog.V(5).Infof("ACL log file %s was rotated", path)
+				file.Close()
+				file = nil
+				continue
+			} else if truncated {
+				klog.V(5).Infof("ACL log file %s was truncated", path)
+				if _, err := file.Seek(0, io.SeekStart); err != nil {
+					file.Close()
+					file = nil
+				} else {
+					reader.Reset(file)
+					partial = ""
+				}
+				continue
+			}
+		}
+
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// aclLogFileChanged tells whether the log file at path is not the open file anymore or whether
+// the open file was truncated below the read offset
+func aclLogFileChanged(path string, file *os.File) (rotated, truncated bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		// keep reading the rotated file until a new one gets created
+		return false, false
+	}
+	openInfo, err := file.Stat()
+	if err != nil {
+		return true, false
+	}
+	if !os.SameFile(info, openInfo) {
+		return true, false
+	}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return true, false
+	}
+	return false, openInfo.Size() < offset
+}
+
+// listenACLLogSocket listens on the unix datagram socket at path ovn-controller sends its logs
+// to when started with --syslog-method=unix:<path>
+func listenACLLogSocket(path string) (*net.UnixConn, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale ACL log socket %s: %w", path, err)
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on ACL log socket %s: %w", path, err)
+	}
+	return conn, nil
+}
+
+// receiveACLLogs calls export for every log received on conn until stopChan is closed
+func receiveACLLogs(conn *net.UnixConn, stopChan <-chan struct{}, export func(string)) {
+	go func() {
+		<-stopChan
+		conn.Close()
+	}()
+	buf := make([]byte, aclLogMaxDatagramSize)
+	for {
+		n, _, err := conn.ReadFromUnix(buf)
+		if err != nil {
+			select {
+			case <-stopChan:
+				return
+			default:
+			}
+			klog.Errorf("Unable to receive ACL logs: %v", err)
+			return
+		}
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			if line != "" {
+				export(line)
+			}
+		}
+	}
+}
+
+// newACLLogWriter returns the writer of the exported ACL logs for target: a file path rotated
+// like the log file of ovnkube, syslog for the local syslog daemon or syslog:<udp|tcp>:<address>
+// for a remote one
+func newACLLogWriter(target string) (io.WriteCloser, error) {
+	if target == "syslog" {
+		return &aclLogSyslogWriter{network: "unixgram", address: "/dev/log", local: true}, nil
+	}
+	if remote := strings.TrimPrefix(target, "syslog:"); remote != target {
+		network, address, _ := strings.Cut(remote, ":")
+		if network != "udp" && network != "tcp" {
+			return nil, fmt.Errorf("invalid ACL log export target %s: unsupported syslog network %q", target, network)
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("invalid ACL log export target %s: %w", target, err)
+		}
+		return &aclLogSyslogWriter{network: network, address: address}, nil
+	}
+	return &lumberjack.Logger{
+		Filename:   target,
+		MaxSize:    config.Logging.LogFileMaxSize, // megabytes
+		MaxBackups: config.Logging.LogFileMaxBackups,
+		MaxAge:     config.Logging.LogFileMaxAge, // days
+		Compress:   true,
+	}, nil
+}
+
+// aclLogSyslogWriter sends each write as a syslog message, (re)connecting to the syslog daemon as
+// needed
+type aclLogSyslogWriter struct {
+	network string
+	address string
+	local   bool
+	conn    net.Conn
+}
+
+func (w *aclLogSyslogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	var err error
+	// retry once with a new connection in case the syslog daemon was restarted
+	for i := 0; i < 2; i++ {
+		if w.conn == nil {
+			if w.conn, err = net.Dial(w.network, w.address); err != nil {
+				w.conn = nil
+				continue
+			}
+		}
+		if _, err = w.conn.Write([]byte(w.format(msg))); err == nil {
+			return len(p), nil
+		}
+		w.conn.Close()
+		w.conn = nil
+	}
+	return 0, err
+}
+
+// format formats msg as the log/syslog package does: the local syslog daemon fills in the
+// hostname and the stream based transports need messages to be newline terminated
+func (w *aclLogSyslogWriter) format(msg string) string {
+	if w.local {
+		return fmt.Sprintf("<%d>%s %s[%d]: %s\n", aclLogSyslogPriority, time.Now().Format(time.Stamp),
+			aclLogSyslogTag, os.Getpid(), msg)
+	}
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("<%d>%s %s %s[%d]: %s\n", aclLogSyslogPriority, time.Now().Format(time.RFC3339),
+		hostname, aclLogSyslogTag, os.Getpid(), msg)
+}
+
+func (w *aclLogSyslogWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
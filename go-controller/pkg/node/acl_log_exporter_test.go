@@ -0,0 +1,252 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	aclLogAllowLine = `2023-01-27T10:22:14.123Z|00005|acl_log(ovn_pinctrl0)|INFO|name="default_allow-web_0", ` +
+		`verdict=allow, severity=info, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:01,` +
+		`dl_dst=0a:58:0a:f4:00:05,nw_src=10.244.1.3,nw_dst=10.244.0.5,nw_tos=0,nw_ecn=0,nw_ttl=63,` +
+		`tp_src=46010,tp_dst=8080,tcp_flags=syn`
+	aclLogDropLine = `2023-01-27T10:22:15.456Z|00006|acl_log(ovn_pinctrl0)|INFO|name="egressFirewall_default_9999", ` +
+		`verdict=drop, severity=alert, direction=from-lport: udp6,vlan_tci=0x0000,dl_src=0a:58:0a:f4:00:05,` +
+		`dl_dst=0a:58:0a:f4:00:01,ipv6_src=fd00:10:244::5,ipv6_dst=2001:db8::1,ipv6_label=0x00000,nw_tos=0,` +
+		`nw_ecn=0,nw_ttl=64,tp_src=53000,tp_dst=53`
+)
+
+func newACLLogTestPod(name, ip string, hostNetwork bool) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: nodeName, HostNetwork: hostNetwork},
+		Status:     v1.PodStatus{PodIPs: []v1.PodIP{{IP: ip}}},
+	}
+}
+
+func readACLLogRecords(path string) []aclLogRecord {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var records []aclLogRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var record aclLogRecord
+		Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+		records = append(records, record)
+	}
+	return records
+}
+
+func appendToFile(path, data string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	_, err = f.WriteString(data)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+var _ = Describe("ACL log exporter", func() {
+	var (
+		wf         *factory.WatchFactory
+		fakeClient *util.OVNNodeClientset
+		tmpDir     string
+		stopChan   chan struct{}
+		wg         *sync.WaitGroup
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fakeClient = &util.OVNNodeClientset{
+			KubeClient: fake.NewSimpleClientset(
+				&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+				newACLLogTestPod("web", "10.244.0.5", false),
+				newACLLogTestPod("dns", "fd00:10:244::5", false),
+				newACLLogTestPod("host", "10.244.1.3", true),
+			),
+		}
+		var err error
+		wf, err = factory.NewNodeWatchFactory(fakeClient, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		tmpDir, err = os.MkdirTemp("", "acl-log-exporter")
+		Expect(err).NotTo(HaveOccurred())
+		stopChan = make(chan struct{})
+		wg = &sync.WaitGroup{}
+	})
+
+	AfterEach(func() {
+		close(stopChan)
+		wg.Wait()
+		wf.Shutdown()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("identifies the objects and the local pods of the ACL logs", func() {
+		exporter := newACLLogExporter(nodeName, wf)
+		Expect(exporter.watchPods()).To(Succeed())
+		defer exporter.unwatchPods()
+		Expect(exporter.parse("2023-01-27T10:22:14.123Z|00004|binding|INFO|Claiming lport")).To(BeNil())
+
+		rule := 0
+		Expect(exporter.parse(aclLogAllowLine)).To(Equal(&aclLogRecord{
+			Timestamp: "2023-01-27T10:22:14.123Z",
+			Node:      nodeName,
+			ACL:       "default_allow-web_0",
+			Verdict:   "allow",
+			Severity:  "info",
+			Direction: "to-lport",
+			Protocol:  "tcp",
+			SrcIP:     "10.244.1.3",
+			DstIP:     "10.244.0.5",
+			SrcPort:   46010,
+			DstPort:   8080,
+			DstPod:    &aclLogPod{Namespace: "default", Name: "web"},
+			Owner:     &aclLogOwner{Kind: aclLogOwnerNetworkPolicy, Namespace: "default", Name: "allow-web", Rule: &rule},
+		}))
+
+		rule = 1
+		Expect(exporter.parse(aclLogDropLine)).To(Equal(&aclLogRecord{
+			Timestamp: "2023-01-27T10:22:15.456Z",
+			Node:      nodeName,
+			ACL:       "egressFirewall_default_9999",
+			Verdict:   "drop",
+			Severity:  "alert",
+			Direction: "from-lport",
+			Protocol:  "udp6",
+			SrcIP:     "fd00:10:244::5",
+			DstIP:     "2001:db8::1",
+			SrcPort:   53000,
+			DstPort:   53,
+			SrcPod:    &aclLogPod{Namespace: "default", Name: "dns"},
+			Owner:     &aclLogOwner{Kind: aclLogOwnerEgressFirewall, Namespace: "default", Name: "default", Rule: &rule},
+		}))
+	})
+
+	It("keeps the pods of the IPs up to date", func() {
+		exporter := newACLLogExporter(nodeName, wf)
+		Expect(exporter.watchPods()).To(Succeed())
+		defer exporter.unwatchPods()
+		Expect(exporter.getPodByIP("10.244.0.5")).To(Equal(&aclLogPod{Namespace: "default", Name: "web"}))
+
+		err := fakeClient.KubeClient.CoreV1().Pods("default").Delete(context.TODO(), "web", metav1.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() *aclLogPod { return exporter.getPodByIP("10.244.0.5") }).Should(BeNil())
+
+		_, err = fakeClient.KubeClient.CoreV1().Pods("default").Create(context.TODO(),
+			newACLLogTestPod("web2", "10.244.0.5", false), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() *aclLogPod { return exporter.getPodByIP("10.244.0.5") }).Should(
+			Equal(&aclLogPod{Namespace: "default", Name: "web2"}))
+		Expect(exporter.getPodByIP("10.244.1.3")).To(BeNil())
+	})
+
+	It("identifies the objects the ACLs were built for from their names", func() {
+		Expect(getACLLogOwner("")).To(BeNil())
+		Expect(getACLLogOwner("allow-from-node")).To(BeNil())
+		Expect(getACLLogOwner("ns1_policy_rule")).To(BeNil())
+		Expect(getACLLogOwner("ns1_ingressDefaultDeny")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerDefaultDeny, Namespace: "ns1"}))
+		Expect(getACLLogOwner("ns1_egressDefaultDeny")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerDefaultDeny, Namespace: "ns1"}))
+		Expect(getACLLogOwner("ns1_ARPallowPolicy")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerARPAllow, Namespace: "ns1"}))
+		Expect(getACLLogOwner("ns1_MulticastAllowIngress")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerMulticast, Namespace: "ns1"}))
+		Expect(getACLLogOwner("blue_ns1_MulticastAllowEgress")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerMulticast, Namespace: "ns1", Network: "blue"}))
+		Expect(getACLLogOwner("clusterPortGroup_DefaultDenyMulticastEgress")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerClusterMulticast}))
+		Expect(getACLLogOwner("blue_clusterRtrPortGroup_DefaultAllowMulticastIngress")).To(Equal(
+			&aclLogOwner{Kind: aclLogOwnerClusterMulticast, Network: "blue"}))
+	})
+
+	It("exports the ACL logs appended to the log file of ovn-controller across rotations", func() {
+		source := filepath.Join(tmpDir, "ovn-controller.log")
+		target := filepath.Join(tmpDir, "acl.log")
+		appendToFile(source, aclLogDropLine+"\n")
+
+		exporter := newACLLogExporter(nodeName, wf)
+		exporter.source = source
+		exporter.target = target
+		exporter.tailInterval = 10 * time.Millisecond
+		Expect(exporter.Run(stopChan, wg)).To(Succeed())
+
+		// logs written before the exporter started are skipped
+		Consistently(func() []aclLogRecord { return readACLLogRecords(target) }, "100ms").Should(BeEmpty())
+
+		appendToFile(source, "2023-01-27T10:22:14.100Z|00004|binding|INFO|Claiming lport\n"+aclLogAllowLine+"\n")
+		Eventually(func() []aclLogRecord { return readACLLogRecords(target) }).Should(HaveLen(1))
+
+		// rotated by moving the file away
+		Expect(os.Rename(source, source+".1")).To(Succeed())
+		appendToFile(source+".1", aclLogAllowLine+"\n")
+		appendToFile(source, aclLogDropLine+"\n")
+		Eventually(func() []string {
+			var acls []string
+			for _, record := range readACLLogRecords(target) {
+				acls = append(acls, record.ACL)
+			}
+			return acls
+		}).Should(Equal([]string{"default_allow-web_0", "default_allow-web_0", "egressFirewall_default_9999"}))
+
+		// rotated by truncating the file
+		Expect(os.Truncate(source, 0)).To(Succeed())
+		time.Sleep(50 * time.Millisecond)
+		appendToFile(source, aclLogAllowLine+"\n")
+		Eventually(func() []aclLogRecord { return readACLLogRecords(target) }).Should(HaveLen(4))
+	})
+
+	It("exports the ACL logs received on a unix socket", func() {
+		source := filepath.Join(tmpDir, "acl.sock")
+		target := filepath.Join(tmpDir, "acl.log")
+
+		exporter := newACLLogExporter(nodeName, wf)
+		exporter.source = "unix:" + source
+		exporter.target = target
+		Expect(exporter.Run(stopChan, wg)).To(Succeed())
+
+		conn, err := net.Dial("unixgram", source)
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		_, err = conn.Write([]byte("<14>ovs|00005|acl_log(ovn_pinctrl0)|INFO|name=\"default_allow-web_0\", " +
+			"verdict=allow, severity=info, direction=to-lport: tcp,nw_src=10.244.1.3,nw_dst=10.244.0.5,tp_src=46010,tp_dst=8080"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() []aclLogRecord { return readACLLogRecords(target) }).Should(HaveLen(1))
+		record := readACLLogRecords(target)[0]
+		Expect(record.ACL).To(Equal("default_allow-web_0"))
+		Expect(record.DstPod).To(Equal(&aclLogPod{Namespace: "default", Name: "web"}))
+		Expect(record.SrcPod).To(BeNil())
+	})
+
+	It("rejects invalid syslog targets", func() {
+		_, err := newACLLogWriter("syslog:unix:/dev/log")
+		Expect(err).To(HaveOccurred())
+		_, err = newACLLogWriter("syslog:udp:10.0.0.1")
+		Expect(err).To(HaveOccurred())
+		w, err := newACLLogWriter("syslog:udp:10.0.0.1:514")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w).To(Equal(&aclLogSyslogWriter{network: "udp", address: "10.0.0.1:514"}))
+	})
+})
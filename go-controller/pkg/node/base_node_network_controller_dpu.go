@@ -7,11 +7,18 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/Mellanox/sriovnet"
+	"github.com/vishvananda/netlink"
 	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
@@ -20,109 +27,347 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// dpuRepPortsSyncPeriod is the period of the resync of the VF representors of the pods, on top of
+// the link events
+const dpuRepPortsSyncPeriod = 30 * time.Second
+
+// dpuPodMaxRetries is the number of rate limited retries of the VF representor of a pod, after which
+// it is left to the link events and the periodic resync
+const dpuPodMaxRetries = 10
+
+// dpuRepPort is the VF representor port plugged into br-int for a pod
+type dpuRepPort struct {
+	name string
+	// index is the interface index of the representor, which changes when its VF is hot-plugged
+	index int
+	// podUID is the pod the representor was plugged for, a pod recreated with the same name has another
+	podUID ktypes.UID
+}
+
+// dpuPods tracks the pods bound to the DPU by key. The pod events, link events and resyncs queue the
+// pods, and a single worker plugs their VF representors. The lock only guards the maps.
+type dpuPods struct {
+	sync.Mutex
+	queue workqueue.RateLimitingInterface
+	// pending holds the pods waiting for their VF representor to be plugged
+	pending sets.String
+	// served holds the VF representor ports of the pods that got a VF
+	served map[string]*dpuRepPort
+}
+
+func newDPUPods() *dpuPods {
+	return &dpuPods{
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemFastSlowRateLimiter(1*time.Second, 5*time.Second, 5),
+			"dpu-pods",
+		),
+		pending: sets.NewString(),
+		served:  map[string]*dpuRepPort{},
+	}
+}
+
+func (pods *dpuPods) getServed(key string) *dpuRepPort {
+	pods.Lock()
+	defer pods.Unlock()
+	return pods.served[key]
+}
+
+// setServed records the VF representor plugged for the pod, or forgets the pod when there is none
+func (pods *dpuPods) setServed(key string, port *dpuRepPort) {
+	pods.Lock()
+	defer pods.Unlock()
+	pods.pending.Delete(key)
+	if port == nil {
+		delete(pods.served, key)
+		return
+	}
+	pods.served[key] = port
+}
+
+func (pods *dpuPods) setPending(key string) {
+	pods.Lock()
+	defer pods.Unlock()
+	pods.pending.Insert(key)
+}
+
 // watchPodsDPU watch updates for pod dpu annotations
 func (bnnc *BaseNodeNetworkController) watchPodsDPU() error {
-	var retryPods sync.Map
-	// servedPods tracks the pods that got a VF
-	var servedPods sync.Map
-
+	pods := newDPUPods()
 	clientSet := cni.NewClientSet(bnnc.client, corev1listers.NewPodLister(bnnc.watchFactory.LocalPodInformer().GetIndexer()))
 
-	// Support default network for now
-	nadName := types.DefaultNetworkName
-	netName := types.DefaultNetworkName
 	_, err := bnnc.watchFactory.AddPodHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pod := obj.(*kapi.Pod)
 			klog.Infof("Add for Pod: %s/%s", pod.ObjectMeta.GetNamespace(), pod.ObjectMeta.GetName())
-			if util.PodWantsHostNetwork(pod) || pod.Status.Phase == kapi.PodRunning {
-				return
-			}
-			if util.PodScheduled(pod) {
-				// Is this pod created on same node as the DPU
-				if bnnc.name != pod.Spec.NodeName {
-					return
-				}
-
-				vfRepName, err := bnnc.getVfRepName(pod)
-				if err != nil {
-					klog.Infof("Failed to get rep name, %s. retrying", err)
-					retryPods.Store(pod.UID, true)
-					return
-				}
-				isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
-				podInterfaceInfo, err := cni.PodAnnotation2PodInfo(pod.Annotations, nil, isOvnUpEnabled, string(pod.UID),
-					"", nadName, netName, config.Default.MTU)
-				if err != nil {
-					retryPods.Store(pod.UID, true)
-					return
-				}
-				err = bnnc.addRepPort(pod, vfRepName, podInterfaceInfo, clientSet)
-				if err != nil {
-					klog.Infof("Failed to add rep port, %s. retrying", err)
-					retryPods.Store(pod.UID, true)
-				} else {
-					servedPods.Store(pod.UID, true)
-				}
-			} else {
-				// Handle unscheduled pods later in UpdateFunc
-				retryPods.Store(pod.UID, true)
-				return
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				pods.queue.Add(key)
 			}
 		},
 		UpdateFunc: func(old, newer interface{}) {
 			pod := newer.(*kapi.Pod)
 			klog.Infof("Update for Pod: %s/%s", pod.ObjectMeta.GetNamespace(), pod.ObjectMeta.GetName())
-			if util.PodWantsHostNetwork(pod) || pod.Status.Phase == kapi.PodRunning {
-				retryPods.Delete(pod.UID)
-				return
-			}
-			_, retry := retryPods.Load(pod.UID)
-			if util.PodScheduled(pod) && retry {
-				if bnnc.name != pod.Spec.NodeName {
-					retryPods.Delete(pod.UID)
-					return
-				}
-				vfRepName, err := bnnc.getVfRepName(pod)
-				if err != nil {
-					klog.Infof("Failed to get rep name, %s. retrying", err)
-					return
-				}
-				isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
-				podInterfaceInfo, err := cni.PodAnnotation2PodInfo(pod.Annotations, nil, isOvnUpEnabled, string(pod.UID),
-					"", nadName, netName, config.Default.MTU)
-				if err != nil {
-					return
-				}
-				err = bnnc.addRepPort(pod, vfRepName, podInterfaceInfo, clientSet)
-				if err != nil {
-					klog.Infof("Failed to add rep port, %s. retrying", err)
-				} else {
-					servedPods.Store(pod.UID, true)
-					retryPods.Delete(pod.UID)
-				}
+			if key, err := cache.MetaNamespaceKeyFunc(newer); err == nil {
+				pods.queue.Add(key)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
-			pod := obj.(*kapi.Pod)
-			klog.Infof("Delete for Pod: %s/%s", pod.ObjectMeta.GetNamespace(), pod.ObjectMeta.GetName())
-			if _, ok := servedPods.Load(pod.UID); !ok {
-				return
-			}
-			servedPods.Delete(pod.UID)
-			retryPods.Delete(pod.UID)
-			vfRepName, err := bnnc.getVfRepName(pod)
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 			if err != nil {
-				klog.Errorf("Failed to get VF Representor Name from Pod: %s. Representor port may have been deleted.", err)
+				utilruntime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
 				return
 			}
-			err = bnnc.delRepPort(vfRepName)
-			if err != nil {
-				klog.Errorf("Failed to delete VF representor %s. %s", vfRepName, err)
-			}
+			klog.Infof("Delete for Pod: %s", key)
+			pods.queue.Add(key)
 		},
 	}, nil)
-	return err
+	if err != nil {
+		return err
+	}
+
+	bnnc.wg.Add(1)
+	go func() {
+		defer bnnc.wg.Done()
+		wait.Until(func() {
+			for bnnc.processNextPodDPU(pods, clientSet) {
+			}
+		}, time.Second, bnnc.stopChan)
+	}()
+	bnnc.watchRepPortsDPU(pods)
+	return nil
+}
+
+func (bnnc *BaseNodeNetworkController) processNextPodDPU(pods *dpuPods, getter cni.PodInfoGetter) bool {
+	key, quit := pods.queue.Get()
+	if quit {
+		return false
+	}
+	defer pods.queue.Done(key)
+
+	err := bnnc.syncPodDPU(pods, key.(string), getter)
+	if err == nil {
+		pods.queue.Forget(key)
+		return true
+	}
+
+	klog.Infof("Failed to add rep port of pod %v, %v. retrying", key, err)
+	if pods.queue.NumRequeues(key) < dpuPodMaxRetries {
+		pods.queue.AddRateLimited(key)
+		return true
+	}
+
+	pods.queue.Forget(key)
+	return true
+}
+
+// watchRepPortsDPU queues the pods on the link events of their VF representors, to follow their renames
+// and hot-plugs, and resyncs them periodically in case of missed events
+func (bnnc *BaseNodeNetworkController) watchRepPortsDPU(pods *dpuPods) {
+	linkSubscribeOptions := netlink.LinkSubscribeOptions{
+		ErrorCallback: func(err error) {
+			klog.Errorf("Failed during LinkSubscribe callback: %v", err)
+		},
+	}
+	subscribe := func() (bool, chan netlink.LinkUpdate) {
+		linkChan := make(chan netlink.LinkUpdate)
+		if err := netlink.LinkSubscribeWithOptions(linkChan, bnnc.stopChan, linkSubscribeOptions); err != nil {
+			klog.Errorf("Error during netlink subscribe for VF representors: %v", err)
+			return false, nil
+		}
+		return true, linkChan
+	}
+
+	bnnc.wg.Add(1)
+	go func() {
+		defer bnnc.wg.Done()
+
+		syncTimer := time.NewTicker(dpuRepPortsSyncPeriod)
+		defer syncTimer.Stop()
+
+		subscribed, linkChan := subscribe()
+		for {
+			select {
+			case linkUpdate, ok := <-linkChan:
+				if !ok {
+					// link events may have been missed until the subscription is back
+					subscribed, linkChan = subscribe()
+					resyncPodsDPU(pods)
+					continue
+				}
+				onRepLinkUpdateDPU(pods, linkUpdate.Link)
+			case <-syncTimer.C:
+				if !subscribed {
+					subscribed, linkChan = subscribe()
+				}
+				resyncPodsDPU(pods)
+			case <-bnnc.stopChan:
+				pods.queue.ShutDown()
+				return
+			}
+		}
+	}()
+}
+
+// resyncPodsDPU queues all the pods served by the DPU or waiting for their VF representor
+func resyncPodsDPU(pods *dpuPods) {
+	pods.Lock()
+	defer pods.Unlock()
+	for key := range pods.served {
+		pods.queue.Add(key)
+	}
+	for key := range pods.pending {
+		pods.queue.Add(key)
+	}
+}
+
+// onRepLinkUpdateDPU queues the pod whose VF representor the link is, or was. The link of a VF
+// representor plugged for none of the pods is a new or renamed one, the pods waiting for their
+// representor are retried with rate limiting, so that the link changes of a failing pod don't make
+// it spin. The other links are ignored.
+func onRepLinkUpdateDPU(pods *dpuPods, link netlink.Link) {
+	attrs := link.Attrs()
+	if attrs == nil {
+		return
+	}
+	pods.Lock()
+	for key, port := range pods.served {
+		if port.name == attrs.Name || port.index == attrs.Index {
+			pods.queue.Add(key)
+			pods.Unlock()
+			return
+		}
+	}
+	pending := pods.pending.List()
+	pods.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	if flavour, err := util.GetSriovnetOps().GetRepresentorPortFlavour(attrs.Name); err != nil ||
+		flavour != sriovnet.PORT_FLAVOUR_PCI_VF {
+		return
+	}
+	for _, key := range pending {
+		pods.queue.AddRateLimited(key)
+	}
+}
+
+// syncPodDPU plugs the VF representor of a pod bound to the DPU, plugs it again when it was renamed or
+// hot-plugged, and unplugs it when the pod is gone. The running pods had their representor plugged
+// before a restart, they are only tracked.
+func (bnnc *BaseNodeNetworkController) syncPodDPU(pods *dpuPods, key string, getter cni.PodInfoGetter) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.Errorf("Invalid pod key %s: %v", key, err)
+		return nil
+	}
+	pod, err := bnnc.watchFactory.GetPod(namespace, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		pod = nil
+	}
+
+	port := pods.getServed(key)
+	if port != nil && (pod == nil || pod.UID != port.podUID) {
+		// the pod is gone, or was recreated with the same name
+		pods.setServed(key, nil)
+		if err := bnnc.delRepPort(port.name); err != nil {
+			klog.Errorf("Failed to delete VF representor %s. %s", port.name, err)
+		}
+		port = nil
+	}
+	if pod == nil || util.PodWantsHostNetwork(pod) || !util.PodScheduled(pod) || bnnc.name != pod.Spec.NodeName {
+		// the unscheduled pods are queued again once scheduled
+		pods.setServed(key, nil)
+		return nil
+	}
+	running := pod.Status.Phase == kapi.PodRunning
+
+	dpuCD, err := util.UnmarshalPodDPUConnDetails(pod.Annotations, types.DefaultNetworkName)
+	if err != nil {
+		// the pod is queued again once the CNI on the host sets the annotation
+		klog.V(5).Infof("Failed to get dpu annotation for pod %s/%s, %s", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	if !podBoundToDPU(dpuCD) {
+		klog.V(5).Infof("Pod %s/%s is bound to DPU %q", pod.Namespace, pod.Name, dpuCD.DPUName)
+		pods.setServed(key, nil)
+		return nil
+	}
+
+	current, err := bnnc.getRepPort(pod)
+	if port == nil && running {
+		if err != nil {
+			klog.Warningf("Failed to get the VF representor of running pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			return nil
+		}
+		pods.setServed(key, current)
+		return nil
+	}
+	if err != nil {
+		if port != nil {
+			klog.Warningf("VF representor %s of pod %s/%s is gone, waiting for it to be plugged back: %v",
+				port.name, pod.Namespace, pod.Name, err)
+		}
+		pods.setPending(key)
+		return err
+	}
+	if port != nil {
+		if current.name == port.name && current.index == port.index {
+			return nil
+		}
+		klog.Infof("VF representor of pod %s/%s changed from %s to %s, plugging it again",
+			pod.Namespace, pod.Name, port.name, current.name)
+		if err := bnnc.delRepPort(port.name); err != nil {
+			klog.Warningf("Failed to delete VF representor %s. %s", port.name, err)
+		}
+	}
+	if err := bnnc.plugRepPort(pod, current, getter); err != nil {
+		if port != nil {
+			// no representor has this index, so that the next sync plugs it again
+			current.index = -1
+			pods.setServed(key, current)
+		}
+		pods.setPending(key)
+		return err
+	}
+	pods.setServed(key, current)
+	return nil
+}
+
+// podBoundToDPU returns true if the VF of a pod is owned by the DPU ovnkube-node runs on. The pods
+// without a DPU name come from hosts with a single DPU and are served by the primary DPU.
+func podBoundToDPU(dpuCD *util.DPUConnectionDetails) bool {
+	if dpuCD.DPUName == "" {
+		return config.OvnKubeNode.IsPrimaryDPU()
+	}
+	return dpuCD.DPUName == config.OvnKubeNode.DPUName
+}
+
+// getRepPort returns the representor port of the VF assigned to the pod
+func (bnnc *BaseNodeNetworkController) getRepPort(pod *kapi.Pod) (*dpuRepPort, error) {
+	vfRepName, err := bnnc.getVfRepName(pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rep name: %v", err)
+	}
+	link, err := util.GetNetLinkOps().LinkByName(vfRepName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link device for interface %s: %v", vfRepName, err)
+	}
+	return &dpuRepPort{name: vfRepName, index: link.Attrs().Index, podUID: pod.UID}, nil
+}
+
+// plugRepPort adds the representor port of the VF of a pod to the ovs bridge
+func (bnnc *BaseNodeNetworkController) plugRepPort(pod *kapi.Pod, port *dpuRepPort, getter cni.PodInfoGetter) error {
+	isOvnUpEnabled := atomic.LoadInt32(&bnnc.atomicOvnUpEnabled) > 0
+	// Support default network for now
+	podInterfaceInfo, err := cni.PodAnnotation2PodInfo(pod.Annotations, nil, isOvnUpEnabled, string(pod.UID),
+		"", types.DefaultNetworkName, types.DefaultNetworkName, config.Default.MTU)
+	if err != nil {
+		return err
+	}
+	return bnnc.addRepPort(pod, port.name, podInterfaceInfo, getter)
 }
 
 // getVfRepName returns the VF's representor of the VF assigned to the pod
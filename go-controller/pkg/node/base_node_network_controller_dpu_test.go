@@ -3,13 +3,17 @@ package node
 import (
 	"fmt"
 
+	"github.com/Mellanox/sriovnet"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	factorymocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory/mocks"
 	kubemocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -421,4 +425,179 @@ var _ = Describe("Node DPU tests", func() {
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
 		})
 	})
+
+	Context("syncPodDPU", func() {
+		var pods *dpuPods
+		var key string
+		var vfLink *linkMock.Link
+
+		BeforeEach(func() {
+			Expect(config.PrepareTestConfig()).To(Succeed())
+			config.OvnKubeNode.Mode = types.NodeModeDPU
+			config.OvnKubeNode.DPUDevices = []config.DPUDevice{{Name: "dpu0"}, {Name: "dpu1"}}
+			config.OvnKubeNode.DPUName = "dpu1"
+			dnnc.name = "node1"
+			pods = newDPUPods()
+			key = pod.Namespace + "/" + pod.Name
+			vfLink = &linkMock.Link{}
+			pod.Spec.NodeName = "node1"
+			factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(&pod, nil)
+		})
+
+		AfterEach(func() {
+			pods.queue.ShutDown()
+		})
+
+		It("Ignores the pods bound to another DPU of the host", func() {
+			pod.Annotations = map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"default":{"pfId":"0","vfId":"9","sandboxId":"a8d09931","dpuName":"dpu0"}}`,
+			}
+			Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+			Expect(pods.pending).To(BeEmpty())
+			Expect(pods.served).To(BeEmpty())
+		})
+
+		It("Ignores the pods without a DPU name on a secondary DPU", func() {
+			pod.Annotations = map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"default":{"pfId":"0","vfId":"9","sandboxId":"a8d09931"}}`,
+			}
+			Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+			Expect(pods.pending).To(BeEmpty())
+			Expect(pods.served).To(BeEmpty())
+		})
+
+		It("Waits for the dpu.connection-details annotation of the pods", func() {
+			Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+			Expect(pods.pending).To(BeEmpty())
+			Expect(pods.served).To(BeEmpty())
+		})
+
+		It("Retries the pods whose VF representor is not there yet", func() {
+			pod.Annotations = map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"default":{"pfId":"1","vfId":"9","sandboxId":"a8d09931","dpuName":"dpu1"}}`,
+			}
+			sriovnetOpsMock.On("GetVfRepresentorDPU", "1", "9").Return("", fmt.Errorf("no representor"))
+
+			Expect(dnnc.syncPodDPU(pods, key, nil)).NotTo(Succeed())
+			Expect(pods.pending.Has(key)).To(BeTrue())
+			Expect(pods.served).To(BeEmpty())
+		})
+
+		It("Tracks the VF representor of the running pods bound to the DPU", func() {
+			pod.Annotations = map[string]string{
+				util.DPUConnectionDetailsAnnot: `{"default":{"pfId":"1","vfId":"9","sandboxId":"a8d09931","dpuName":"dpu1"}}`,
+			}
+			pod.Status.Phase = v1.PodRunning
+			vfLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "pf1vf9", Index: 12})
+			sriovnetOpsMock.On("GetVfRepresentorDPU", "1", "9").Return("pf1vf9", nil)
+			netlinkOpsMock.On("LinkByName", "pf1vf9").Return(vfLink, nil)
+
+			Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+			Expect(pods.pending).To(BeEmpty())
+			Expect(pods.served).To(HaveKeyWithValue(key, &dpuRepPort{name: "pf1vf9", index: 12, podUID: pod.UID}))
+			// the representor is already plugged
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+		})
+
+		Context("with a served pod", func() {
+			BeforeEach(func() {
+				config.OvnKubeNode.DPUDevices = nil
+				config.OvnKubeNode.DPUName = ""
+				pod.Annotations = map[string]string{
+					util.DPUConnectionDetailsAnnot: `{"pfId":"0","vfId":"9","sandboxId":"a8d09931"}`,
+				}
+				pod.Status.Phase = v1.PodRunning
+				pods.served[key] = &dpuRepPort{name: "pf0vf9", index: 12, podUID: pod.UID}
+			})
+
+			It("Leaves the VF representors that did not change", func() {
+				vfLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "pf0vf9", Index: 12})
+				sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("pf0vf9", nil)
+				netlinkOpsMock.On("LinkByName", "pf0vf9").Return(vfLink, nil)
+
+				Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+				Expect(pods.served[key]).To(Equal(&dpuRepPort{name: "pf0vf9", index: 12, podUID: pod.UID}))
+				Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			})
+
+			It("Waits for a VF representor that is gone to be plugged back", func() {
+				sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("", fmt.Errorf("no representor"))
+
+				Expect(dnnc.syncPodDPU(pods, key, nil)).NotTo(Succeed())
+				Expect(pods.served[key]).To(Equal(&dpuRepPort{name: "pf0vf9", index: 12, podUID: pod.UID}))
+				Expect(pods.pending.Has(key)).To(BeTrue())
+				Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			})
+
+			It("Unplugs a renamed VF representor and plugs it again with its new name", func() {
+				vfLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "eth5", Index: 14})
+				sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("eth5", nil)
+				netlinkOpsMock.On("LinkByName", "eth5").Return(vfLink, nil)
+				// the old representor is gone
+				netlinkOpsMock.On("LinkByName", "pf0vf9").Return(nil, fmt.Errorf("link not found"))
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: genOVSDelPortCmd("pf0vf9"),
+				})
+
+				// the pod has no network annotation, the new representor is plugged at the next sync
+				Expect(dnnc.syncPodDPU(pods, key, nil)).NotTo(Succeed())
+				Expect(pods.served[key]).To(Equal(&dpuRepPort{name: "eth5", index: -1, podUID: pod.UID}))
+				Expect(pods.pending.Has(key)).To(BeTrue())
+				Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			})
+
+			It("Unplugs the VF representor of a deleted pod", func() {
+				factoryMock = factorymocks.NodeWatchFactory{}
+				factoryMock.On("GetPod", pod.Namespace, pod.Name).Return(nil,
+					apierrors.NewNotFound(v1.Resource("pods"), pod.Name))
+				netlinkOpsMock.On("LinkByName", "pf0vf9").Return(vfLink, nil)
+				netlinkOpsMock.On("LinkSetDown", vfLink).Return(nil)
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: genOVSDelPortCmd("pf0vf9"),
+				})
+
+				Expect(dnnc.syncPodDPU(pods, key, nil)).To(Succeed())
+				Expect(pods.served).To(BeEmpty())
+				Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			})
+		})
+	})
+
+	Context("onRepLinkUpdateDPU", func() {
+		var pods *dpuPods
+
+		BeforeEach(func() {
+			pods = newDPUPods()
+			pods.served["foo-ns/served-pod"] = &dpuRepPort{name: "pf0vf9", index: 12}
+			pods.pending.Insert("foo-ns/pending-pod")
+		})
+
+		AfterEach(func() {
+			pods.queue.ShutDown()
+		})
+
+		It("Queues the pod whose VF representor was renamed", func() {
+			onRepLinkUpdateDPU(pods, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth5", Index: 12}})
+			Expect(pods.queue.Len()).To(Equal(1))
+			item, _ := pods.queue.Get()
+			Expect(item).To(Equal("foo-ns/served-pod"))
+		})
+
+		It("Retries the pending pods on the events of the other VF representors", func() {
+			sriovnetOpsMock.On("GetRepresentorPortFlavour", "pf0vf3").Return(sriovnet.PortFlavour(sriovnet.PORT_FLAVOUR_PCI_VF), nil)
+			onRepLinkUpdateDPU(pods, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "pf0vf3", Index: 20}})
+			Expect(pods.queue.NumRequeues("foo-ns/pending-pod")).To(Equal(1))
+			Expect(pods.queue.NumRequeues("foo-ns/served-pod")).To(Equal(0))
+		})
+
+		It("Ignores the events of the links that are not VF representors", func() {
+			sriovnetOpsMock.On("GetRepresentorPortFlavour", "pf0hpf").Return(sriovnet.PortFlavour(sriovnet.PORT_FLAVOUR_PCI_PF), nil)
+			sriovnetOpsMock.On("GetRepresentorPortFlavour", "eth0").Return(sriovnet.PortFlavour(sriovnet.PORT_FLAVOUR_UNKNOWN),
+				fmt.Errorf("not a representor"))
+			onRepLinkUpdateDPU(pods, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "pf0hpf", Index: 20}})
+			onRepLinkUpdateDPU(pods, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 2}})
+			Expect(pods.queue.Len()).To(Equal(0))
+			Expect(pods.queue.NumRequeues("foo-ns/pending-pod")).To(Equal(0))
+		})
+	})
 })
@@ -90,6 +90,12 @@ type DefaultNodeNetworkController struct {
 	BaseNodeNetworkController
 
 	gateway Gateway
+	// gateway mode of the cluster, which the node's "k8s.ovn.org/gateway-mode" annotation overrides
+	clusterGatewayMode config.GatewayMode
+	// gateway mode the node runs in, set once on start
+	gatewayMode config.GatewayMode
+	// signalled when another gateway mode is selected for the node
+	gatewayModeChanged chan struct{}
 
 	// Node healthcheck server for cloud load balancers
 	healthzServer *proxierHealthUpdater
@@ -110,6 +116,8 @@ func newDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, sto
 			stopChan:                        stopChan,
 			wg:                              wg,
 		},
+		clusterGatewayMode: config.Gateway.Mode,
+		gatewayModeChanged: make(chan struct{}, 1),
 	}
 }
 
@@ -352,7 +360,7 @@ func isOVNControllerReady() (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("could not get connection status: %w", err)
 	}
-	klog.Infof("Node connection status = %s", ret)
+	klog.V(5).Infof("Node connection status = %s", ret)
 	if ret != "connected" {
 		return false, nil
 	}
@@ -514,6 +522,22 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to parse kubernetes node IP address. %v", err)
 	}
 
+	// Each DPU of a host with several publishes its chassis ID before registering its chassis, so that
+	// the master keeps the chassis of all of them and binds the pods to the chassis of their DPU
+	if config.OvnKubeNode.Mode == types.NodeModeDPU && len(config.OvnKubeNode.DPUDevices) > 0 {
+		chassisID, err := util.GetNodeChassisID()
+		if err != nil {
+			return err
+		}
+		dpuAnnotator := kube.NewNodeAnnotator(nc.Kube, node.Name)
+		if err := util.SetNodeDPUChassisID(dpuAnnotator, config.OvnKubeNode.DPUName, chassisID); err != nil {
+			return err
+		}
+		if err := dpuAnnotator.Run(); err != nil {
+			return fmt.Errorf("failed to set the chassis ID of DPU %s on node %s: %v", config.OvnKubeNode.DPUName, nc.name, err)
+		}
+	}
+
 	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
 		for _, auth := range []config.OvnAuthConfig{config.OvnNorth, config.OvnSouth} {
 			if err := auth.SetDBAuth(); err != nil {
@@ -545,6 +569,22 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	}
 	klog.Infof("Node %s ready for ovn initialization with subnet %s", nc.name, util.JoinIPNets(subnets, ","))
 
+	// A secondary DPU of the host only plugs the VF representors of the pods bound to it, the
+	// management port and the gateway of the node are on the primary DPU
+	if config.OvnKubeNode.Mode == types.NodeModeDPU && !config.OvnKubeNode.IsPrimaryDPU() {
+		if err := level.Set(strconv.Itoa(config.GetLogLevel())); err != nil {
+			klog.Errorf("Reset of initial klog \"loglevel\" failed, err: %v", err)
+		}
+		if nc.healthzServer != nil {
+			nc.healthzServer.Start(nc.stopChan, nc.wg)
+		}
+		if err := nc.watchPodsDPU(); err != nil {
+			return err
+		}
+		klog.Infof("Default node network controller initialized on secondary DPU %s", config.OvnKubeNode.DPUName)
+		return nil
+	}
+
 	// Create CNI Server
 	isOvnUpEnabled := atomic.LoadInt32(&nc.atomicOvnUpEnabled) > 0
 	if config.OvnKubeNode.Mode != types.NodeModeDPU {
@@ -594,6 +634,9 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 			return err
 		}
 	} else {
+		if err := nc.migrateGatewayMode(node, mgmtPortConfig); err != nil {
+			return err
+		}
 		// Initialize gateway for OVS internal port or representor management port
 		if err := nc.initGateway(subnets, nodeAnnotator, waiter, mgmtPortConfig, nodeAddr); err != nil {
 			return err
@@ -624,6 +667,10 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 			return fmt.Errorf("failed to get initial topology version: %w", err)
 		}
 		klog.Infof("Current control-plane topology version is %d", initialTopoVersion)
+		if initialTopoVersion >= types.OvnPodQoSTopoVersion {
+			// the masters limit the bandwidth of the pods with OVN QoS rules
+			cni.EnableOVNPodQoS()
+		}
 
 		bridgeName := ""
 		if config.OvnKubeNode.Mode == types.NodeModeFull {
@@ -681,6 +728,10 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 				}
 			}
 
+			if initialTopoVersion < types.OvnPodQoSTopoVersion {
+				cni.EnableOVNPodQoS()
+			}
+
 			// ensure CNI support for port binding built into OVN, as masters have been upgraded
 			if initialTopoVersion < types.OvnPortBindingTopoVersion && !isOvnUpEnabled && !config.OvnKubeNode.DisableOVNIfaceIdVer {
 				isOvnUpEnabled, err := util.GetOVNIfUpCheckMode()
@@ -727,7 +778,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		}
 	}
 
-	if err := level.Set(strconv.Itoa(config.Logging.Level)); err != nil {
+	if err := level.Set(strconv.Itoa(config.GetLogLevel())); err != nil {
 		klog.Errorf("Reset of initial klog \"loglevel\" failed, err: %v", err)
 	}
 
@@ -740,6 +791,9 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		}
 	}
 
+	// report the external connectivity of the node in its conditions
+	nc.startExternalConnectivityChecker(mgmtPorts)
+
 	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
 		util.SetARPTimeout()
 		err := nc.WatchNamespaces()
@@ -754,6 +808,26 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to watch endpointSlices: %w", err)
 		}
+		if err := nc.watchGatewayMode(); err != nil {
+			return fmt.Errorf("failed to watch the gateway mode: %w", err)
+		}
+		if config.BGP.Enabled {
+			routeAdvertiser := newRouteAdvertiser(nc.name, nc.watchFactory.(*factory.WatchFactory))
+			if err := routeAdvertiser.Run(nc.stopChan, nc.wg); err != nil {
+				return fmt.Errorf("failed to start advertising routes with BGP: %w", err)
+			}
+		}
+		if config.Logging.ACLLogExportTarget != "" {
+			aclLogExporter := newACLLogExporter(nc.name, nc.watchFactory)
+			if err := aclLogExporter.Run(nc.stopChan, nc.wg); err != nil {
+				return fmt.Errorf("failed to start exporting ACL logs: %w", err)
+			}
+		}
+		if config.OVNKubernetesFeature.EnableEgressInterface {
+			if err := nc.startEgressInterfaceController(); err != nil {
+				return fmt.Errorf("failed to start the EgressInterface controller: %w", err)
+			}
+		}
 	}
 
 	if nc.healthzServer != nil {
@@ -765,10 +839,17 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 			return err
 		}
 	} else {
+		// CNI STATUS reports the node as not ready when the conditions waited for at startup are not
+		// met anymore
+		cniServer.SetReadyFunc(waiter.Ready)
 		// start the cni server
 		if err := cniServer.Start(cni.ServerRunDir); err != nil {
 			return err
 		}
+		// in unprivileged mode the pod interfaces are configured, and checked, by the CNI shim
+		if !config.UnprivilegedMode {
+			cniServer.StartPodInterfaceChecker(nc.recorder, nc.stopChan, nc.wg)
+		}
 
 		// Write CNI config file if it doesn't already exist
 		if err := config.WriteCNIConfig(); err != nil {
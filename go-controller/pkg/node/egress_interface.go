@@ -0,0 +1,315 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressinterfacev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/vishvananda/netlink"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// egressInterfaceResyncInterval is how often the gateway bridges of the EgressInterfaces are
+// reconciled, to pick up the interfaces that appear on the node and the patch ports created
+// by ovn-controller
+const egressInterfaceResyncInterval = 30 * time.Second
+
+// egressInterfaceController sets up an OVS gateway bridge for each EgressInterface whose
+// interface exists on the node, publishes it in the node's egress interfaces annotation for
+// ovnkube-master to connect it to the gateway router, and manages its flows once ovn-controller
+// has plugged it to br-int.
+type egressInterfaceController struct {
+	nodeName        string
+	watchFactory    *factory.WatchFactory
+	kube            kube.Interface
+	openflowManager *openflowManager
+	nodePortWatcher *nodePortWatcher
+	syncCh          chan struct{}
+
+	// gateway bridges set up so far, by EgressInterface name
+	bridges map[string]*bridgeConfiguration
+	// interface of the bridges set up so far, by EgressInterface name
+	interfaces map[string]string
+	// gateway configurations last published, by EgressInterface name
+	gatewayConfigs map[string]*util.EgressInterfaceGatewayConfig
+	// EgressInterfaces whose bridge flows are managed by the openflow manager
+	flowsReady sets.Set[string]
+}
+
+func newEgressInterfaceController(nodeName string, watchFactory *factory.WatchFactory, kube kube.Interface,
+	ofm *openflowManager, npw *nodePortWatcher) *egressInterfaceController {
+	return &egressInterfaceController{
+		nodeName:        nodeName,
+		watchFactory:    watchFactory,
+		kube:            kube,
+		openflowManager: ofm,
+		nodePortWatcher: npw,
+		syncCh:          make(chan struct{}, 1),
+		bridges:         map[string]*bridgeConfiguration{},
+		interfaces:      map[string]string{},
+		gatewayConfigs:  map[string]*util.EgressInterfaceGatewayConfig{},
+		flowsReady:      sets.New[string](),
+	}
+}
+
+// requestSync schedules a reconciliation of the EgressInterfaces, requests are coalesced
+func (eic *egressInterfaceController) requestSync() {
+	select {
+	case eic.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run watches the EgressInterfaces and keeps their gateway bridges in sync until stopChan is closed
+func (eic *egressInterfaceController) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
+	if _, err := eic.watchFactory.AddEgressInterfaceHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { eic.requestSync() },
+		UpdateFunc: func(old, new interface{}) { eic.requestSync() },
+		DeleteFunc: func(obj interface{}) { eic.requestSync() },
+	}, nil); err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(egressInterfaceResyncInterval)
+		defer ticker.Stop()
+		eic.requestSync()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-eic.syncCh:
+			case <-ticker.C:
+			}
+			if err := eic.sync(); err != nil {
+				klog.Errorf("Failed to sync the EgressInterfaces of node %s: %v", eic.nodeName, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// sync sets up the gateway bridges of the EgressInterfaces, annotates the node with them and
+// tears down the bridges of the deleted EgressInterfaces. An EgressInterface whose bridge fails
+// to be checked, e.g. because no default gateway is reachable through it yet, keeps its bridge
+// and the gateway configuration it had, and is retried on the next sync.
+func (eic *egressInterfaceController) sync() error {
+	egressInterfaces, err := eic.watchFactory.GetEgressInterfaces()
+	if err != nil {
+		return fmt.Errorf("failed to list EgressInterfaces: %w", err)
+	}
+
+	var errs []error
+	gatewayConfigs := map[string]*util.EgressInterfaceGatewayConfig{}
+	failed := sets.New[string]()
+	for _, egressInterface := range egressInterfaces {
+		bridge, nextHops, err := eic.ensureBridge(egressInterface)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("EgressInterface %s: %w", egressInterface.Name, err))
+			failed.Insert(egressInterface.Name)
+			if eic.gatewayConfigs[egressInterface.Name] != nil {
+				gatewayConfigs[egressInterface.Name] = eic.gatewayConfigs[egressInterface.Name]
+			}
+			continue
+		}
+		if bridge == nil {
+			continue
+		}
+		gatewayConfigs[egressInterface.Name] = &util.EgressInterfaceGatewayConfig{
+			InterfaceID: bridge.interfaceID,
+			MACAddress:  bridge.macAddress,
+			IPAddresses: bridge.ips,
+			NextHops:    nextHops,
+		}
+	}
+
+	for name := range eic.bridges {
+		if _, ok := gatewayConfigs[name]; ok || failed.Has(name) {
+			continue
+		}
+		if err := eic.deleteBridge(name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove the gateway bridge of EgressInterface %s: %w", name, err))
+			if eic.gatewayConfigs[name] != nil {
+				gatewayConfigs[name] = eic.gatewayConfigs[name]
+			}
+			continue
+		}
+		klog.Infof("Removed the gateway bridge of EgressInterface %s", name)
+	}
+
+	if err := eic.annotateNode(gatewayConfigs); err != nil {
+		return err
+	}
+	eic.gatewayConfigs = gatewayConfigs
+
+	// the flows can only be managed once the master has connected the bridge to the gateway
+	// router and ovn-controller has created the patch port
+	subnets, err := eic.getHostSubnets()
+	if err != nil {
+		return err
+	}
+	flowsChanged := false
+	for name := range gatewayConfigs {
+		if eic.flowsReady.Has(name) {
+			continue
+		}
+		bridge := eic.bridges[name]
+		if ready, err := gatewayReady(bridge.patchPort); err != nil || !ready {
+			klog.V(5).Infof("Gateway bridge %s of EgressInterface %s is not ready yet", bridge.bridgeName, name)
+			continue
+		}
+		if err := setBridgeOfPorts(bridge); err != nil {
+			return fmt.Errorf("failed to set the ofports of bridge %s: %w", bridge.bridgeName, err)
+		}
+		if err := eic.openflowManager.addEgressInterfaceBridge(name, bridge, subnets); err != nil {
+			return fmt.Errorf("failed to add the flows of bridge %s: %w", bridge.bridgeName, err)
+		}
+		if eic.nodePortWatcher != nil {
+			eic.nodePortWatcher.syncEgressInterfaceServiceFlows(name, bridge)
+		}
+		eic.flowsReady.Insert(name)
+		flowsChanged = true
+		klog.Infof("Gateway bridge %s of EgressInterface %s is ready", bridge.bridgeName, name)
+	}
+	if flowsChanged {
+		eic.openflowManager.requestFlowSync()
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteBridge tears down the gateway bridge set up for an EgressInterface
+func (eic *egressInterfaceController) deleteBridge(name string) error {
+	if err := eic.openflowManager.deleteEgressInterfaceBridge(name, eic.bridges[name]); err != nil {
+		return err
+	}
+	delete(eic.bridges, name)
+	delete(eic.interfaces, name)
+	delete(eic.gatewayConfigs, name)
+	eic.flowsReady.Delete(name)
+	return nil
+}
+
+// ensureBridge returns the gateway bridge of the EgressInterface and its next hops, setting
+// the bridge up if needed. A nil bridge is returned if the interface doesn't exist on the node.
+func (eic *egressInterfaceController) ensureBridge(egressInterface *egressinterfacev1.EgressInterface) (*bridgeConfiguration, []net.IP, error) {
+	if intf, ok := eic.interfaces[egressInterface.Name]; ok && intf != egressInterface.Spec.Interface {
+		// the interface changed, start over with the new one
+		if err := eic.deleteBridge(egressInterface.Name); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	bridge, ok := eic.bridges[egressInterface.Name]
+	if !ok {
+		if _, err := netlink.LinkByName(egressInterface.Spec.Interface); err != nil {
+			klog.V(5).Infof("Interface %s of EgressInterface %s not found on node %s",
+				egressInterface.Spec.Interface, egressInterface.Name, eic.nodeName)
+			return nil, nil, nil
+		}
+		var err error
+		bridge, err = bridgeForInterface(interfaceForEXGW(egressInterface.Spec.Interface), eic.nodeName,
+			types.PhysicalNetworkEgressInterfacePrefix+egressInterface.Name, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up the gateway bridge of interface %s: %w",
+				egressInterface.Spec.Interface, err)
+		}
+		eic.bridges[egressInterface.Name] = bridge
+		eic.interfaces[egressInterface.Name] = egressInterface.Spec.Interface
+		klog.Infof("Set up gateway bridge %s for EgressInterface %s", bridge.bridgeName, egressInterface.Name)
+	} else if _, err := bridge.updateInterfaceIPAddresses(); err != nil {
+		return nil, nil, fmt.Errorf("failed to get the addresses of bridge %s: %w", bridge.bridgeName, err)
+	}
+
+	// the egress traffic is routed to the default gateway reachable through the interface
+	var nextHops []net.IP
+	families := []int{}
+	if config.IPv4Mode {
+		families = append(families, netlink.FAMILY_V4)
+	}
+	if config.IPv6Mode {
+		families = append(families, netlink.FAMILY_V6)
+	}
+	for _, family := range families {
+		_, nextHop, err := getDefaultGatewayInterfaceByFamily(family, bridge.bridgeName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get the default gateway of bridge %s: %w", bridge.bridgeName, err)
+		}
+		if nextHop == nil {
+			return nil, nil, fmt.Errorf("no default gateway found through bridge %s", bridge.bridgeName)
+		}
+		nextHops = append(nextHops, nextHop)
+	}
+	return bridge, nextHops, nil
+}
+
+// annotateNode sets the egress interfaces annotation of the node if it changed
+func (eic *egressInterfaceController) annotateNode(gatewayConfigs map[string]*util.EgressInterfaceGatewayConfig) error {
+	node, err := eic.watchFactory.GetNode(eic.nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", eic.nodeName, err)
+	}
+	current, err := util.ParseNodeEgressInterfaces(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid egress interfaces annotation of node %s: %v", eic.nodeName, err)
+	}
+	if len(current) == 0 && len(gatewayConfigs) == 0 {
+		return nil
+	}
+	// compare the encoded configurations, parsed addresses don't always have the same length
+	if err == nil {
+		currentJSON, _ := json.Marshal(current)
+		desiredJSON, _ := json.Marshal(gatewayConfigs)
+		if bytes.Equal(currentJSON, desiredJSON) {
+			return nil
+		}
+	}
+	nodeAnnotator := kube.NewNodeAnnotator(eic.kube, eic.nodeName)
+	if err := util.SetNodeEgressInterfaces(nodeAnnotator, gatewayConfigs); err != nil {
+		return err
+	}
+	if err := nodeAnnotator.Run(); err != nil {
+		return fmt.Errorf("failed to set the egress interfaces annotation of node %s: %w", eic.nodeName, err)
+	}
+	return nil
+}
+
+// getHostSubnets returns the host subnets of the node
+func (eic *egressInterfaceController) getHostSubnets() ([]*net.IPNet, error) {
+	node, err := eic.watchFactory.GetNode(eic.nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", eic.nodeName, err)
+	}
+	subnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the host subnets of node %s: %w", eic.nodeName, err)
+	}
+	return subnets, nil
+}
+
+// startEgressInterfaceController starts managing the gateway bridges of the EgressInterfaces
+// along with the flows of the gateway
+func (nc *DefaultNodeNetworkController) startEgressInterfaceController() error {
+	gw, ok := nc.gateway.(*gateway)
+	if !ok || gw.openflowManager == nil {
+		return fmt.Errorf("gateway of node %s has no openflow manager", nc.name)
+	}
+	npw, _ := gw.nodePortWatcher.(*nodePortWatcher)
+	egressInterfaceController := newEgressInterfaceController(nc.name, nc.watchFactory.(*factory.WatchFactory),
+		nc.Kube, gw.openflowManager, npw)
+	return egressInterfaceController.Run(nc.stopChan, nc.wg)
+}
@@ -0,0 +1,276 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// externalConnectivityCheckInterval is how often the external connectivity of the node is checked
+const externalConnectivityCheckInterval = 30 * time.Second
+
+// reasons of the external connectivity condition of the node when a health check failed
+const (
+	ovsUnavailableReason               = "OVSUnavailable"
+	ovnControllerDisconnectedReason    = "OVNControllerDisconnected"
+	patchPortMissingReason             = "PatchPortMissing"
+	managementPortAddressMissingReason = "ManagementPortAddressMissing"
+	nextHopUnreachableReason           = "NextHopUnreachable"
+)
+
+// connectivityCheck is a health check of the external connectivity of the node, reason is the
+// reason reported in the node condition when it fails
+type connectivityCheck struct {
+	reason string
+	check  func() error
+}
+
+// externalConnectivityChecker periodically checks that the node can reach the external network
+// through its gateway and reports the result in the ExternalConnectivityUnavailable condition of
+// the node, which the EgressIP and egress service controllers consult to pick the egress nodes.
+type externalConnectivityChecker struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	kube         kube.Interface
+	checks       []connectivityCheck
+}
+
+// newExternalConnectivityChecker returns a checker of the OVS and ovn-controller connectivity,
+// of the addresses of the management ports, of the presence of the patch port of the gateway
+// bridge (if any) and of the reachability of the next hops of the gateway
+func newExternalConnectivityChecker(nodeName string, watchFactory factory.NodeWatchFactory, kube kube.Interface,
+	mgmtPorts []managementPortEntry, patchPort string) *externalConnectivityChecker {
+	ecc := &externalConnectivityChecker{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		kube:         kube,
+	}
+	// OVS and ovn-controller run on the DPU in DPU host mode
+	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		ecc.checks = append(ecc.checks,
+			connectivityCheck{reason: ovsUnavailableReason, check: checkOVSConnectivity},
+			connectivityCheck{reason: ovnControllerDisconnectedReason, check: checkOVNControllerConnectivity},
+		)
+	}
+	if patchPort != "" {
+		ecc.checks = append(ecc.checks, connectivityCheck{
+			reason: patchPortMissingReason,
+			check:  func() error { return checkPatchPort(patchPort) },
+		})
+	}
+	ecc.checks = append(ecc.checks,
+		connectivityCheck{
+			reason: managementPortAddressMissingReason,
+			check:  func() error { return checkManagementPortAddresses(mgmtPorts) },
+		},
+		connectivityCheck{reason: nextHopUnreachableReason, check: ecc.checkNextHops},
+	)
+	return ecc
+}
+
+// Run checks the external connectivity of the node periodically until stopChan is closed
+func (ecc *externalConnectivityChecker) Run(stopChan <-chan struct{}) {
+	wait.Until(ecc.checkConnectivity, externalConnectivityCheckInterval, stopChan)
+}
+
+// checkConnectivity runs all the health checks and updates the condition of the node, the
+// reason of the condition is the reason of the first failed check
+func (ecc *externalConnectivityChecker) checkConnectivity() {
+	reason := util.NodeExternalConnectivityAvailableReason
+	var failures []string
+	for _, c := range ecc.checks {
+		if err := c.check(); err != nil {
+			if len(failures) == 0 {
+				reason = c.reason
+			}
+			failures = append(failures, err.Error())
+		}
+	}
+	message := "External connectivity health checks passed"
+	if len(failures) > 0 {
+		message = strings.Join(failures, "; ")
+		klog.Warningf("External connectivity of node %s is unavailable: %s", ecc.nodeName, message)
+	}
+	if err := ecc.setCondition(len(failures) > 0, reason, message); err != nil {
+		klog.Errorf("Failed to set the %s condition of node %s: %v",
+			util.NodeExternalConnectivityUnavailable, ecc.nodeName, err)
+	}
+}
+
+// setCondition sets the external connectivity condition of the node if it changed
+func (ecc *externalConnectivityChecker) setCondition(unavailable bool, reason, message string) error {
+	status := corev1.ConditionFalse
+	if unavailable {
+		status = corev1.ConditionTrue
+	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		node, err := ecc.watchFactory.GetNode(ecc.nodeName)
+		if err != nil {
+			return err
+		}
+		condition := util.GetNodeCondition(node, util.NodeExternalConnectivityUnavailable)
+		if condition != nil && condition.Status == status && condition.Reason == reason && condition.Message == message {
+			return nil
+		}
+
+		// Informer cache should not be mutated, so get a copy of the object
+		cnode := node.DeepCopy()
+		now := metav1.Now()
+		newCondition := corev1.NodeCondition{
+			Type:               util.NodeExternalConnectivityUnavailable,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastHeartbeatTime:  now,
+			LastTransitionTime: now,
+		}
+		if condition == nil {
+			cnode.Status.Conditions = append(cnode.Status.Conditions, newCondition)
+		} else {
+			if condition.Status == status {
+				newCondition.LastTransitionTime = condition.LastTransitionTime
+			}
+			*util.GetNodeCondition(cnode, util.NodeExternalConnectivityUnavailable) = newCondition
+		}
+		if condition == nil || condition.Status != status {
+			klog.Infof("Setting the %s condition of node %s to %s: %s",
+				util.NodeExternalConnectivityUnavailable, ecc.nodeName, status, reason)
+		}
+		return ecc.kube.UpdateNodeStatus(cnode)
+	})
+}
+
+// checkNextHops checks that the next hops of the gateway, from the gateway configuration or the
+// default routes, are reachable
+func (ecc *externalConnectivityChecker) checkNextHops() error {
+	node, err := ecc.watchFactory.GetNode(ecc.nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", ecc.nodeName, err)
+	}
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return fmt.Errorf("failed to get the gateway configuration of node %s: %w", ecc.nodeName, err)
+	}
+	if l3GatewayConfig.Mode == config.GatewayModeDisabled {
+		return nil
+	}
+	for _, nextHop := range l3GatewayConfig.NextHops {
+		if err := checkNextHopReachable(nextHop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNextHopReachable checks that there is a route to the next hop and that the kernel didn't
+// fail to resolve it. The neighbor of a next hop that isn't used by the host may not be resolved
+// at all, it is then considered reachable.
+func checkNextHopReachable(nextHop net.IP) error {
+	routes, err := netlink.RouteGet(nextHop)
+	if err != nil || len(routes) == 0 {
+		return fmt.Errorf("no route to next hop %s: %v", nextHop, err)
+	}
+	family := netlink.FAMILY_V4
+	if utilnet.IsIPv6(nextHop) {
+		family = netlink.FAMILY_V6
+	}
+	neighs, err := util.GetNetLinkOps().NeighList(routes[0].LinkIndex, family)
+	if err != nil {
+		return fmt.Errorf("failed to list the neighbors of next hop %s: %w", nextHop, err)
+	}
+	for _, neigh := range neighs {
+		if neigh.IP.Equal(nextHop) && neigh.State&(netlink.NUD_FAILED|netlink.NUD_INCOMPLETE) != 0 {
+			return fmt.Errorf("next hop %s is unreachable", nextHop)
+		}
+	}
+	return nil
+}
+
+// checkOVSConnectivity checks that ovs-vswitchd answers and that br-int exists
+func checkOVSConnectivity() error {
+	if _, stderr, err := util.RunOVSVsctl("br-exists", "br-int"); err != nil {
+		return fmt.Errorf("failed to find bridge br-int, stderr: %q: %v", stderr, err)
+	}
+	return nil
+}
+
+// checkOVNControllerConnectivity checks that ovn-controller is connected to the southbound database
+func checkOVNControllerConnectivity() error {
+	stdout, stderr, err := util.RunOVNControllerAppCtl("connection-status")
+	if err != nil {
+		return fmt.Errorf("failed to get the connection status of ovn-controller, stderr: %q: %v", stderr, err)
+	}
+	if status := strings.TrimSpace(stdout); status != "connected" {
+		return fmt.Errorf("ovn-controller is %s from the southbound database", status)
+	}
+	return nil
+}
+
+// checkPatchPort checks that the patch port between the gateway bridge and br-int exists
+func checkPatchPort(patchPort string) error {
+	ofport, stderr, err := util.GetOVSOfPort("--if-exists", "get", "interface", patchPort, "ofport")
+	if err != nil {
+		return fmt.Errorf("failed to get the ofport of patch port %s, stderr: %q: %v", patchPort, stderr, err)
+	}
+	if ofport == "" || ofport == "-1" {
+		return fmt.Errorf("patch port %s not found", patchPort)
+	}
+	return nil
+}
+
+// checkManagementPortAddresses checks that the management ports that are assigned IP addresses
+// have them
+func checkManagementPortAddresses(mgmtPorts []managementPortEntry) error {
+	for _, mgmtPort := range mgmtPorts {
+		if !mgmtPort.port.HasIpAddr() || mgmtPort.config == nil {
+			continue
+		}
+		link, err := util.GetNetLinkOps().LinkByName(mgmtPort.config.ifName)
+		if err != nil {
+			return fmt.Errorf("failed to get management port %s: %w", mgmtPort.config.ifName, err)
+		}
+		for _, cfg := range []*managementPortIPFamilyConfig{mgmtPort.config.ipv4, mgmtPort.config.ipv6} {
+			if cfg == nil || cfg.ifAddr == nil {
+				continue
+			}
+			exists, err := util.LinkAddrExist(link, cfg.ifAddr)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("management port %s is missing address %s", mgmtPort.config.ifName, cfg.ifAddr)
+			}
+		}
+	}
+	return nil
+}
+
+// startExternalConnectivityChecker starts reporting the external connectivity of the node in
+// its ExternalConnectivityUnavailable condition
+func (nc *DefaultNodeNetworkController) startExternalConnectivityChecker(mgmtPorts []managementPortEntry) {
+	var patchPort string
+	if gw, ok := nc.gateway.(*gateway); ok && gw.openflowManager != nil {
+		patchPort = gw.openflowManager.defaultBridge.patchPort
+	}
+	checker := newExternalConnectivityChecker(nc.name, nc.watchFactory, nc.Kube, mgmtPorts, patchPort)
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		checker.Run(nc.stopChan)
+	}()
+}
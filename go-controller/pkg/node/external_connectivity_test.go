@@ -0,0 +1,100 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Node external connectivity checker", func() {
+	var (
+		fakeClient   *fake.Clientset
+		watchFactory *factory.WatchFactory
+		checker      *externalConnectivityChecker
+		nextHopErr   error
+	)
+
+	getCondition := func() *v1.NodeCondition {
+		node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return util.GetNodeCondition(node, util.NodeExternalConnectivityUnavailable)
+	}
+
+	// waitForCachedCondition waits for the informer cache to catch up with the condition of the
+	// node, which the checker compares the new condition with
+	waitForCachedCondition := func(status v1.ConditionStatus) {
+		Eventually(func() v1.ConditionStatus {
+			node, err := watchFactory.GetNode(nodeName)
+			Expect(err).NotTo(HaveOccurred())
+			if condition := util.GetNodeCondition(node, util.NodeExternalConnectivityUnavailable); condition != nil {
+				return condition.Status
+			}
+			return ""
+		}).Should(Equal(status))
+	}
+
+	BeforeEach(func() {
+		node := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+			},
+		}
+		fakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{*node}})
+		var err error
+		watchFactory, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFactory.Start()).To(Succeed())
+
+		nextHopErr = nil
+		checker = &externalConnectivityChecker{
+			nodeName:     nodeName,
+			watchFactory: watchFactory,
+			kube:         &kube.Kube{KClient: fakeClient},
+			checks: []connectivityCheck{
+				{reason: ovsUnavailableReason, check: func() error { return nil }},
+				{reason: nextHopUnreachableReason, check: func() error { return nextHopErr }},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		watchFactory.Shutdown()
+	})
+
+	It("reports the external connectivity of the node in its conditions", func() {
+		checker.checkConnectivity()
+		condition := getCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(util.NodeExternalConnectivityAvailableReason))
+		waitForCachedCondition(v1.ConditionFalse)
+
+		nextHopErr = fmt.Errorf("next hop 172.18.0.1 is unreachable")
+		checker.checkConnectivity()
+		condition = getCondition()
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(nextHopUnreachableReason))
+		Expect(condition.Message).To(Equal("next hop 172.18.0.1 is unreachable"))
+		waitForCachedCondition(v1.ConditionTrue)
+
+		node, err := watchFactory.GetNode(nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(util.IsNodeExternalConnectivityAvailable(node)).To(BeFalse())
+		// the other conditions of the node are kept
+		Expect(util.GetNodeCondition(node, v1.NodeReady)).NotTo(BeNil())
+
+		nextHopErr = nil
+		checker.checkConnectivity()
+		Expect(getCondition().Status).To(Equal(v1.ConditionFalse))
+	})
+})
@@ -43,6 +43,9 @@ type gateway struct {
 	nodeIPManager   *addressManager
 	initFunc        func() error
 	readyFunc       func() (bool, error)
+	// unregisterReloadHandlers unregister the config reload handlers registered by initFunc
+	// once the gateway is stopped
+	unregisterReloadHandlers []func()
 
 	watchFactory *factory.WatchFactory // used for retry
 	stopChan     <-chan struct{}
@@ -209,7 +212,18 @@ func (g *gateway) Init(wf factory.NodeWatchFactory, stopChan <-chan struct{}, wg
 	g.wg = wg
 
 	var err error
-	if err = g.initFunc(); err != nil {
+	err = g.initFunc()
+	if len(g.unregisterReloadHandlers) > 0 {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			<-g.stopChan
+			for _, unregister := range g.unregisterReloadHandlers {
+				unregister()
+			}
+		}()
+	}
+	if err != nil {
 		return err
 	}
 	servicesRetryFramework := g.newRetryFrameworkNode(factory.ServiceForGatewayType)
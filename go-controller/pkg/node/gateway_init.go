@@ -5,6 +5,8 @@ import (
 	"net"
 	"strings"
 
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
@@ -93,6 +95,42 @@ func bridgedGatewayNodeSetup(nodeName, bridgeName, bridgeInterface, physicalNetw
 	return ifaceID, macAddress, nil
 }
 
+// removeBridgeMappings removes the mappings of physical networks to the given bridge from
+// ovn-bridge-mappings, leaving the mappings of the other bridges alone
+func removeBridgeMappings(bridgeName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:ovn-bridge-mappings")
+	if err != nil {
+		return fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+	}
+	var mappings []string
+	found := false
+	for _, bridgeMapping := range strings.Split(stdout, ",") {
+		if m := strings.Split(bridgeMapping, ":"); len(m) == 2 && m[1] == bridgeName {
+			found = true
+			continue
+		}
+		if bridgeMapping != "" {
+			mappings = append(mappings, bridgeMapping)
+		}
+	}
+	if !found {
+		return nil
+	}
+	if len(mappings) == 0 {
+		_, stderr, err = util.RunOVSVsctl("--if-exists", "remove", "Open_vSwitch", ".", "external_ids",
+			"ovn-bridge-mappings")
+	} else {
+		_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
+			fmt.Sprintf("external_ids:ovn-bridge-mappings=%s", strings.Join(mappings, ",")))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove the ovn-bridge-mappings of ovs bridge %s, stderr:%s (%v)",
+			bridgeName, stderr, err)
+	}
+	return nil
+}
+
 // getNetworkInterfaceIPAddresses returns the IP addresses for the network interface 'iface'.
 func getNetworkInterfaceIPAddresses(iface string) ([]*net.IPNet, error) {
 	allIPs, err := util.GetNetworkInterfaceIPs(iface)
@@ -275,6 +313,106 @@ func configureSvcRouteViaInterface(iface string, gwIPs []net.IP) error {
 	return nil
 }
 
+// getGatewayMode returns the gateway mode selected for the node, either through its
+// "k8s.ovn.org/gateway-mode" annotation or the cluster gateway mode.
+func (nc *DefaultNodeNetworkController) getGatewayMode(node *kapi.Node) (config.GatewayMode, error) {
+	if nc.clusterGatewayMode == config.GatewayModeDisabled {
+		return nc.clusterGatewayMode, nil
+	}
+	mode, err := util.ParseNodeGatewayMode(node)
+	if err != nil {
+		if util.IsAnnotationNotSetError(err) {
+			return nc.clusterGatewayMode, nil
+		}
+		return "", err
+	}
+	return mode, nil
+}
+
+// migrateGatewayMode sets the gateway mode the node runs in and, if the node ran in another
+// gateway mode before as published in its l3 gateway config, removes the host configuration
+// that only that mode uses. The configuration of the new mode is set up by initGateway, and
+// the routes and policies on the OVN routers are migrated by the master once the node
+// publishes its new l3 gateway config. config.Gateway.Mode is only set here, on start and
+// before the gateway and the other users of it are started.
+func (nc *DefaultNodeNetworkController) migrateGatewayMode(node *kapi.Node, cfg *managementPortConfig) error {
+	mode, err := nc.getGatewayMode(node)
+	if err != nil {
+		return err
+	}
+	nc.gatewayMode = mode
+	config.Gateway.Mode = mode
+
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil || l3GatewayConfig.Mode == mode {
+		return nil
+	}
+	klog.Infof("Migrating node %s from %q to %q gateway mode", nc.name, l3GatewayConfig.Mode, mode)
+	if l3GatewayConfig.Mode == config.GatewayModeLocal {
+		for _, family := range []*managementPortIPFamilyConfig{cfg.ipv4, cfg.ipv6} {
+			if family == nil {
+				continue
+			}
+			cidr := &net.IPNet{IP: family.ifAddr.IP.Mask(family.ifAddr.Mask), Mask: family.ifAddr.Mask}
+			if err := cleanupLocalGatewayNATRules(cfg.ifName, cidr); err != nil {
+				return fmt.Errorf("failed to remove local gateway NAT rules for %s: %v", cfg.ifName, err)
+			}
+		}
+	}
+	// Both ways, the connections committed by the flows of the previous mode would send the
+	// replies of new connections reusing their tuples the way that mode did: to the gateway
+	// router in shared gateway mode, to the host in local gateway mode.
+	for _, zone := range []int{config.Default.ConntrackZone, HostMasqCTZone, HostNodePortCTZone} {
+		_, stderr, err := util.RunOVSAppctl("dpctl/flush-conntrack", fmt.Sprintf("zone=%d", zone))
+		if err != nil {
+			return fmt.Errorf("failed to flush conntrack zone %d, stderr: %q, error: %v", zone, stderr, err)
+		}
+	}
+	return nil
+}
+
+// watchGatewayMode signals GatewayModeChanged when another gateway mode is selected for the
+// node. The node isn't migrated in place: ovnkube-node stops, and migrateGatewayMode and
+// initGateway move the node to that mode when it starts again.
+func (nc *DefaultNodeNetworkController) watchGatewayMode() error {
+	if nc.clusterGatewayMode == config.GatewayModeDisabled {
+		return nil
+	}
+	_, err := nc.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			node, ok := new.(*kapi.Node)
+			if !ok || node.Name != nc.name {
+				return
+			}
+			nc.checkGatewayMode(node)
+		},
+	})
+	return err
+}
+
+func (nc *DefaultNodeNetworkController) checkGatewayMode(node *kapi.Node) {
+	mode, err := nc.getGatewayMode(node)
+	if err != nil {
+		klog.Errorf("Unable to get the gateway mode of node %s: %v", nc.name, err)
+		return
+	}
+	if mode == nc.gatewayMode {
+		return
+	}
+	select {
+	case nc.gatewayModeChanged <- struct{}{}:
+		klog.Infof("Gateway mode of node %s changed from %q to %q, stopping to migrate",
+			nc.name, nc.gatewayMode, mode)
+	default:
+	}
+}
+
+// GatewayModeChanged returns a channel signalled when another gateway mode is selected for
+// the node. ovnkube-node must then be restarted to migrate the node to that mode.
+func (nc *DefaultNodeNetworkController) GatewayModeChanged() <-chan struct{} {
+	return nc.gatewayModeChanged
+}
+
 func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAnnotator kube.Annotator,
 	waiter *startupWaiter, managementPortConfig *managementPortConfig, kubeNodeIP net.IP) error {
 	klog.Info("Initializing Gateway Functionality")
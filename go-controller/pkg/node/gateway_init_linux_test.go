@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -1620,4 +1621,100 @@ var _ = Describe("Gateway unit tests", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("migrateGatewayMode", func() {
+		const l3GatewayConfig = `{"default":{"mode":"local","interface-id":"breth0_node1","mac-address":"f2:20:a0:3c:26:4c",` +
+			`"ip-addresses":["169.254.33.2/24"],"next-hops":["169.254.33.1"]}}`
+		var nc *DefaultNodeNetworkController
+		var mgmtPortConfig *managementPortConfig
+
+		BeforeEach(func() {
+			config.Gateway.Mode = config.GatewayModeShared
+			nc = newDefaultNodeNetworkController(&CommonNodeNetworkControllerInfo{name: "node1"}, nil, nil)
+			mgmtPortConfig = &managementPortConfig{
+				ifName: types.K8sMgmtIntfName,
+				ipv4:   &managementPortIPFamilyConfig{ifAddr: ovntest.MustParseIPNet("10.1.1.2/24")},
+			}
+		})
+
+		It("selects the gateway mode requested for the node", func() {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:        "node1",
+				Annotations: map[string]string{"k8s.ovn.org/gateway-mode": "local"},
+			}}
+			Expect(nc.migrateGatewayMode(node, mgmtPortConfig)).To(Succeed())
+			Expect(config.Gateway.Mode).To(Equal(config.GatewayModeLocal))
+
+			node.Annotations["k8s.ovn.org/gateway-mode"] = "bogus"
+			Expect(nc.migrateGatewayMode(node, mgmtPortConfig)).NotTo(Succeed())
+		})
+
+		It("removes the local gateway NAT rules when moving to shared gateway mode", func() {
+			fexec := ovntest.NewFakeExec()
+			Expect(util.SetExec(fexec)).To(Succeed())
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64000",
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64001",
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64003",
+			})
+			iptV4, _ := util.SetFakeIPTablesHelpers()
+			cidr := ovntest.MustParseIPNet("10.1.1.0/24")
+			Expect(initLocalGatewayNATRules(types.K8sMgmtIntfName, cidr)).To(Succeed())
+			rule := getLocalGatewayNATRules(types.K8sMgmtIntfName, cidr)[0]
+			exists, err := iptV4.Exists(rule.table, rule.chain, rule.args...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+				Annotations: map[string]string{
+					"k8s.ovn.org/l3-gateway-config": l3GatewayConfig,
+					"k8s.ovn.org/node-chassis-id":   "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+				},
+			}}
+			Expect(nc.migrateGatewayMode(node, mgmtPortConfig)).To(Succeed())
+			Expect(config.Gateway.Mode).To(Equal(config.GatewayModeShared))
+			for _, rule := range getLocalGatewayNATRules(types.K8sMgmtIntfName, cidr) {
+				exists, err := iptV4.Exists(rule.table, rule.chain, rule.args...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			}
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		})
+
+		It("flushes the conntrack zones of the gateway bridge when moving to local gateway mode", func() {
+			fexec := ovntest.NewFakeExec()
+			Expect(util.SetExec(fexec)).To(Succeed())
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64000",
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64001",
+				"ovs-appctl --timeout=15 dpctl/flush-conntrack zone=64003",
+			})
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+				Annotations: map[string]string{
+					"k8s.ovn.org/gateway-mode":      "local",
+					"k8s.ovn.org/l3-gateway-config": strings.Replace(l3GatewayConfig, `"local"`, `"shared"`, 1),
+					"k8s.ovn.org/node-chassis-id":   "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+				},
+			}}
+			Expect(nc.migrateGatewayMode(node, mgmtPortConfig)).To(Succeed())
+			Expect(config.Gateway.Mode).To(Equal(config.GatewayModeLocal))
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		})
+
+		It("signals when another gateway mode is selected for the node", func() {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			Expect(nc.migrateGatewayMode(node, mgmtPortConfig)).To(Succeed())
+			nc.checkGatewayMode(node)
+			Consistently(nc.GatewayModeChanged()).ShouldNot(Receive())
+
+			node.Annotations = map[string]string{"k8s.ovn.org/gateway-mode": "local"}
+			nc.checkGatewayMode(node)
+			nc.checkGatewayMode(node)
+			Expect(nc.GatewayModeChanged()).To(Receive())
+			// the running gateway mode is left alone until the node restarts
+			Expect(config.Gateway.Mode).To(Equal(config.GatewayModeShared))
+		})
+	})
 })
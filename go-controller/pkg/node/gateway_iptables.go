@@ -488,22 +488,41 @@ func getLocalGatewayNATRules(ifname string, cidr *net.IPNet) []iptRule {
 			},
 			protocol: protocol,
 		},
-		{
-			table: "nat",
-			chain: "POSTROUTING",
-			args: []string{
-				"-s", cidr.String(),
-				"-j", "MASQUERADE",
-			},
-			protocol: protocol,
+		getLocalGatewayPodMasqueradeRule(cidr),
+	}
+}
+
+// getLocalGatewayPodMasqueradeRule returns the rule masquerading pod traffic leaving the node
+func getLocalGatewayPodMasqueradeRule(cidr *net.IPNet) iptRule {
+	return iptRule{
+		table: "nat",
+		chain: "POSTROUTING",
+		args: []string{
+			"-s", cidr.String(),
+			"-j", "MASQUERADE",
 		},
+		protocol: getIPTablesProtocol(cidr.IP.String()),
 	}
 }
 
 // initLocalGatewayNATRules sets up iptables rules for interfaces
 func initLocalGatewayNATRules(ifname string, cidr *net.IPNet) error {
+	rules := getLocalGatewayNATRules(ifname, cidr)
+	if config.BGP.DisableSNAT {
+		// pod traffic leaves the node with the pod IP as the pod subnets are advertised with BGP
+		podMasqueradeRule := getLocalGatewayPodMasqueradeRule(cidr)
+		if err := delIptRules([]iptRule{podMasqueradeRule}); err != nil {
+			return err
+		}
+		// the pod masquerade rule is the last of the local gateway NAT rules
+		rules = rules[:len(rules)-1]
+	}
 	// Append and not insert as these rules should be evaluated last
-	return appendIptRules(getLocalGatewayNATRules(ifname, cidr))
+	return appendIptRules(rules)
+}
+
+func cleanupLocalGatewayNATRules(ifname string, cidr *net.IPNet) error {
+	return delIptRules(getLocalGatewayNATRules(ifname, cidr))
 }
 
 func addChaintoTable(ipt util.IPTablesHelper, tableName, chain string) {
@@ -116,6 +116,16 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 			gw.openflowManager.requestFlowSync()
 		}
 
+		// resync flows when cluster subnets are added or removed
+		gw.unregisterReloadHandlers = append(gw.unregisterReloadHandlers,
+			config.OnReload(config.ReloadableClusterSubnets, func() {
+				klog.Info("Cluster subnets changed, re-syncing bridge flows")
+				if err := gw.openflowManager.updateBridgeFlowCache(hostSubnets, gw.nodeIPManager.ListAddresses()); err != nil {
+					klog.Errorf("Failed to re-generate gateway flows after cluster subnets change: %v", err)
+				}
+				gw.openflowManager.requestFlowSync()
+			}))
+
 		if config.Gateway.NodeportEnable {
 			if config.OvnKubeNode.Mode == types.NodeModeFull {
 				// (TODO): Internal Traffic Policy is not supported in DPU mode
@@ -119,6 +119,15 @@ func (npw *nodePortWatcher) updateGatewayIPs(addressManager *addressManager) {
 	npw.gatewayIPv6 = gatewayIPv6
 }
 
+// serviceBridge holds the ports and the addresses of a gateway bridge that the service flows are built from
+type serviceBridge struct {
+	name        string
+	ofportPhys  string
+	ofportPatch string
+	gatewayIPv4 string
+	gatewayIPv6 string
+}
+
 // updateServiceFlowCache handles managing breth0 gateway flows for ingress traffic towards kubernetes services
 // (nodeport, external, ingress). By default incoming traffic into the node is steered directly into OVN (case3 below).
 //
@@ -132,18 +141,93 @@ func (npw *nodePortWatcher) updateGatewayIPs(addressManager *addressManager) {
 //
 // NOTE: If LGW mode, the default flow will take care of sending traffic to host irrespective of service flow type.
 //
+// The same flows are managed on the gateway bridges of the EgressInterfaces, so that services are also reachable
+// through them.
+//
 // `add` parameter indicates if the flows should exist or be removed from the cache
 // `hasLocalHostNetworkEp` indicates if at least one host networked endpoint exists for this service which is local to this node.
 func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, hasLocalHostNetworkEp bool) error {
 	npw.gatewayIPLock.Lock()
 	defer npw.gatewayIPLock.Unlock()
+
+	bridge := serviceBridge{
+		name:        npw.gwBridge,
+		ofportPhys:  npw.ofportPhys,
+		ofportPatch: npw.ofportPatch,
+		gatewayIPv4: npw.gatewayIPv4,
+		gatewayIPv6: npw.gatewayIPv6,
+	}
+	flows, err := npw.serviceFlows(bridge, service, add, hasLocalHostNetworkEp)
+	for key, keyFlows := range flows {
+		if keyFlows == nil {
+			npw.ofm.deleteFlowsByKey(key)
+		} else {
+			npw.ofm.updateFlowCacheEntry(key, keyFlows)
+		}
+	}
+
+	for name, eifBridge := range npw.ofm.getEgressInterfaceBridges() {
+		if err := npw.updateEgressInterfaceServiceFlowCache(name, eifBridge, service, add, hasLocalHostNetworkEp); err != nil {
+			klog.Errorf("Failed to update the flows of service %s/%s on the bridge %s of EgressInterface %s: %v",
+				service.Namespace, service.Name, eifBridge.bridgeName, name, err)
+		}
+	}
+	return err
+}
+
+// updateEgressInterfaceServiceFlowCache manages the flows of a service on the gateway bridge of an EgressInterface
+func (npw *nodePortWatcher) updateEgressInterfaceServiceFlowCache(name string, eifBridge *bridgeConfiguration, service *kapi.Service,
+	add, hasLocalHostNetworkEp bool) error {
+	if !add {
+		// the keys are unique across services, flows to delete are removed from all the bridges
+		flows, err := npw.serviceFlows(serviceBridge{name: eifBridge.bridgeName}, service, false, false)
+		for key := range flows {
+			npw.ofm.deleteEgressInterfaceFlowsByKey(key)
+		}
+		return err
+	}
+	gatewayIPv4, gatewayIPv6 := getGatewayFamilyAddrs(eifBridge.ips)
+	bridge := serviceBridge{
+		name:        eifBridge.bridgeName,
+		ofportPhys:  eifBridge.ofPortPhys,
+		ofportPatch: eifBridge.ofPortPatch,
+		gatewayIPv4: gatewayIPv4,
+		gatewayIPv6: gatewayIPv6,
+	}
+	flows, err := npw.serviceFlows(bridge, service, true, hasLocalHostNetworkEp)
+	for key, keyFlows := range flows {
+		npw.ofm.updateEgressInterfaceFlowCacheEntry(name, key, keyFlows)
+	}
+	return err
+}
+
+// syncEgressInterfaceServiceFlows adds the flows of all the services to the gateway bridge of an EgressInterface
+func (npw *nodePortWatcher) syncEgressInterfaceServiceFlows(name string, eifBridge *bridgeConfiguration) {
+	// the service info lock is taken before the gateway IP lock, as in the service handlers
+	npw.serviceInfoLock.Lock()
+	defer npw.serviceInfoLock.Unlock()
+	npw.gatewayIPLock.Lock()
+	defer npw.gatewayIPLock.Unlock()
+	for _, svcConfig := range npw.serviceInfo {
+		if err := npw.updateEgressInterfaceServiceFlowCache(name, eifBridge, svcConfig.service, true,
+			svcConfig.hasLocalHostNetworkEp); err != nil {
+			klog.Errorf("Failed to add the flows of service %s/%s on the bridge %s of EgressInterface %s: %v",
+				svcConfig.service.Namespace, svcConfig.service.Name, eifBridge.bridgeName, name, err)
+		}
+	}
+}
+
+// serviceFlows returns the flows of the service on the bridge by flow cache key, a nil value means the flows of
+// the key must be deleted (see updateServiceFlowCache for details)
+func (npw *nodePortWatcher) serviceFlows(bridge serviceBridge, service *kapi.Service, add, hasLocalHostNetworkEp bool) (map[string][]string, error) {
 	var cookie, key string
 	var err error
 	var errors []error
+	flows := map[string][]string{}
 
 	isServiceTypeETPLocal := util.ServiceExternalTrafficPolicyLocal(service)
 
-	actions := fmt.Sprintf("output:%s", npw.ofportPatch)
+	actions := fmt.Sprintf("output:%s", bridge.ofportPatch)
 
 	// cookie is only used for debugging purpose. so it is not fatal error if cookie is failed to be generated.
 	for _, svcPort := range service.Spec.Ports {
@@ -166,7 +250,7 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 				key = strings.Join([]string{"NodePort", service.Namespace, service.Name, flowProtocol, fmt.Sprintf("%d", svcPort.NodePort)}, "_")
 				// Delete if needed and skip to next protocol
 				if !add {
-					npw.ofm.deleteFlowsByKey(key)
+					flows[key] = nil
 					continue
 				}
 				// This allows external traffic ingress when the svc's ExternalTrafficPolicy is
@@ -176,17 +260,18 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 				if isServiceTypeETPLocal && hasLocalHostNetworkEp {
 					// case1 (see function description for details)
 					var nodeportFlows []string
-					klog.V(5).Infof("Adding flows on breth0 for Nodeport Service %s in Namespace: %s since ExternalTrafficPolicy=local", service.Name, service.Namespace)
+					klog.V(5).Infof("Adding flows on %s for Nodeport Service %s in Namespace: %s since ExternalTrafficPolicy=local",
+						bridge.name, service.Name, service.Namespace)
 					// table 0, This rule matches on all traffic with dst port == NodePort, DNAT's the nodePort to the svc targetPort
 					// If ipv6 make sure to choose the ipv6 node address for rule
 					if strings.Contains(flowProtocol, "6") {
 						nodeportFlows = append(nodeportFlows,
 							fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_dst=%d, actions=ct(commit,zone=%d,nat(dst=[%s]:%s),table=6)",
-								cookie, npw.ofportPhys, flowProtocol, svcPort.NodePort, HostNodePortCTZone, npw.gatewayIPv6, svcPort.TargetPort.String()))
+								cookie, bridge.ofportPhys, flowProtocol, svcPort.NodePort, HostNodePortCTZone, bridge.gatewayIPv6, svcPort.TargetPort.String()))
 					} else {
 						nodeportFlows = append(nodeportFlows,
 							fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_dst=%d, actions=ct(commit,zone=%d,nat(dst=%s:%s),table=6)",
-								cookie, npw.ofportPhys, flowProtocol, svcPort.NodePort, HostNodePortCTZone, npw.gatewayIPv4, svcPort.TargetPort.String()))
+								cookie, bridge.ofportPhys, flowProtocol, svcPort.NodePort, HostNodePortCTZone, bridge.gatewayIPv4, svcPort.TargetPort.String()))
 					}
 					nodeportFlows = append(nodeportFlows,
 						// table 6, Sends the packet to the host. Note that the constant etp svc cookie is used since this flow would be
@@ -199,19 +284,19 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 						// table 7, Sends the packet back out eth0 to the external client. Note that the constant etp svc
 						// cookie is used since this would be same for all such services.
 						fmt.Sprintf("cookie=%s, priority=110, table=7, "+
-							"actions=output:%s", etpSvcOpenFlowCookie, npw.ofportPhys))
-					npw.ofm.updateFlowCacheEntry(key, nodeportFlows)
+							"actions=output:%s", etpSvcOpenFlowCookie, bridge.ofportPhys))
+					flows[key] = nodeportFlows
 				} else if config.Gateway.Mode == config.GatewayModeShared {
 					// case2 (see function description for details)
-					npw.ofm.updateFlowCacheEntry(key, []string{
+					flows[key] = []string{
 						// table=0, matches on service traffic towards nodePort and sends it to OVN pipeline
 						fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_dst=%d, "+
 							"actions=%s",
-							cookie, npw.ofportPhys, flowProtocol, svcPort.NodePort, actions),
+							cookie, bridge.ofportPhys, flowProtocol, svcPort.NodePort, actions),
 						// table=0, matches on return traffic from service nodePort and sends it out to primary node interface (br-ex)
 						fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_src=%d, "+
 							"actions=output:%s",
-							cookie, npw.ofportPatch, flowProtocol, svcPort.NodePort, npw.ofportPhys)})
+							cookie, bridge.ofportPatch, flowProtocol, svcPort.NodePort, bridge.ofportPhys)}
 				}
 			}
 		}
@@ -221,19 +306,19 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 		// NodePort/Ingress access in the OVS bridge will only ever come from outside of the host
 		for _, ing := range service.Status.LoadBalancer.Ingress {
 			if len(ing.IP) > 0 {
-				if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions, utilnet.ParseIPSloppy(ing.IP).String(), "Ingress"); err != nil {
+				if err = npw.createLbAndExternalSvcFlows(bridge, flows, service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions, utilnet.ParseIPSloppy(ing.IP).String(), "Ingress"); err != nil {
 					errors = append(errors, err)
 				}
 			}
 		}
 		// flows for externalIPs
 		for _, externalIP := range service.Spec.ExternalIPs {
-			if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions, utilnet.ParseIPSloppy(externalIP).String(), "External"); err != nil {
+			if err = npw.createLbAndExternalSvcFlows(bridge, flows, service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions, utilnet.ParseIPSloppy(externalIP).String(), "External"); err != nil {
 				errors = append(errors, err)
 			}
 		}
 	}
-	return apierrors.NewAggregate(errors)
+	return flows, apierrors.NewAggregate(errors)
 
 }
 
@@ -250,13 +335,15 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 //
 // NOTE: If LGW mode, the default flow will take care of sending traffic to host irrespective of service flow type.
 //
+// `bridge` is the gateway bridge the flows are built for
+// `flows` is where the flows are returned by flow cache key, nil flows for the flows to delete
 // `add` parameter indicates if the flows should exist or be removed from the cache
 // `hasLocalHostNetworkEp` indicates if at least one host networked endpoint exists for this service which is local to this node.
 // `protocol` is TCP/UDP/SCTP as set in the svc.Port
 // `actions`: "send to patchport"
 // `externalIPOrLBIngressIP` is either externalIP.IP or LB.status.ingress.IP
 // `ipType` is either "External" or "Ingress"
-func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, svcPort *kapi.ServicePort, add bool, hasLocalHostNetworkEp bool, protocol string, actions string, externalIPOrLBIngressIP string, ipType string) error {
+func (npw *nodePortWatcher) createLbAndExternalSvcFlows(bridge serviceBridge, flows map[string][]string, service *kapi.Service, svcPort *kapi.ServicePort, add bool, hasLocalHostNetworkEp bool, protocol string, actions string, externalIPOrLBIngressIP string, ipType string) error {
 	if net.ParseIP(externalIPOrLBIngressIP) == nil {
 		return fmt.Errorf("failed to parse %s IP: %q", ipType, externalIPOrLBIngressIP)
 	}
@@ -277,11 +364,11 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 	key := strings.Join([]string{ipType, service.Namespace, service.Name, externalIPOrLBIngressIP, fmt.Sprintf("%d", svcPort.Port)}, "_")
 	// Delete if needed and skip to next protocol
 	if !add {
-		npw.ofm.deleteFlowsByKey(key)
+		flows[key] = nil
 		return nil
 	}
 	// add the ARP bypass flow regardless of service type or gateway modes since its applicable in all scenarios.
-	arpFlow := npw.generateArpBypassFlow(protocol, externalIPOrLBIngressIP, cookie)
+	arpFlow := npw.generateArpBypassFlow(bridge, protocol, externalIPOrLBIngressIP, cookie)
 	externalIPFlows := []string{arpFlow}
 	// This allows external traffic ingress when the svc's ExternalTrafficPolicy is
 	// set to Local, and the backend pod is HostNetworked. We need to add
@@ -292,17 +379,18 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 	isServiceTypeETPLocal := util.ServiceExternalTrafficPolicyLocal(service)
 	if isServiceTypeETPLocal && hasLocalHostNetworkEp {
 		// case1 (see function description for details)
-		klog.V(5).Infof("Adding flows on breth0 for %s Service %s in Namespace: %s since ExternalTrafficPolicy=local", ipType, service.Name, service.Namespace)
+		klog.V(5).Infof("Adding flows on %s for %s Service %s in Namespace: %s since ExternalTrafficPolicy=local",
+			bridge.name, ipType, service.Name, service.Namespace)
 		// table 0, This rule matches on all traffic with dst ip == LoadbalancerIP / externalIP, DNAT's the nodePort to the svc targetPort
 		// If ipv6 make sure to choose the ipv6 node address for rule
 		if strings.Contains(flowProtocol, "6") {
 			externalIPFlows = append(externalIPFlows,
 				fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, tp_dst=%d, actions=ct(commit,zone=%d,nat(dst=[%s]:%s),table=6)",
-					cookie, npw.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, HostNodePortCTZone, npw.gatewayIPv6, svcPort.TargetPort.String()))
+					cookie, bridge.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, HostNodePortCTZone, bridge.gatewayIPv6, svcPort.TargetPort.String()))
 		} else {
 			externalIPFlows = append(externalIPFlows,
 				fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, tp_dst=%d, actions=ct(commit,zone=%d,nat(dst=%s:%s),table=6)",
-					cookie, npw.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, HostNodePortCTZone, npw.gatewayIPv4, svcPort.TargetPort.String()))
+					cookie, bridge.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, HostNodePortCTZone, bridge.gatewayIPv4, svcPort.TargetPort.String()))
 		}
 		externalIPFlows = append(externalIPFlows,
 			// table 6, Sends the packet to Host. Note that the constant etp svc cookie is used since this flow would be
@@ -315,27 +403,27 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 			// table 7, Sends the reply packet back out eth0 to the external client. Note that the constant etp svc
 			// cookie is used since this would be same for all such services.
 			fmt.Sprintf("cookie=%s, priority=110, table=7, actions=output:%s",
-				etpSvcOpenFlowCookie, npw.ofportPhys))
+				etpSvcOpenFlowCookie, bridge.ofportPhys))
 	} else if config.Gateway.Mode == config.GatewayModeShared {
 		// case2 (see function description for details)
 		externalIPFlows = append(externalIPFlows,
 			// table=0, matches on service traffic towards externalIP or LB ingress and sends it to OVN pipeline
 			fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, tp_dst=%d, "+
 				"actions=%s",
-				cookie, npw.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, actions),
+				cookie, bridge.ofportPhys, flowProtocol, nwDst, externalIPOrLBIngressIP, svcPort.Port, actions),
 			// table=0, matches on return traffic from service externalIP or LB ingress and sends it out to primary node interface (br-ex)
 			fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, tp_src=%d, "+
 				"actions=output:%s",
-				cookie, npw.ofportPatch, flowProtocol, nwSrc, externalIPOrLBIngressIP, svcPort.Port, npw.ofportPhys))
+				cookie, bridge.ofportPatch, flowProtocol, nwSrc, externalIPOrLBIngressIP, svcPort.Port, bridge.ofportPhys))
 	}
-	npw.ofm.updateFlowCacheEntry(key, externalIPFlows)
+	flows[key] = externalIPFlows
 
 	return nil
 }
 
 // generate ARP/NS bypass flow which will send the ARP/NS request everywhere *but* to OVN
 // OpenFlow will not do hairpin switching, so we can safely add the origin port to the list of ports, too
-func (npw *nodePortWatcher) generateArpBypassFlow(protocol string, ipAddr string, cookie string) string {
+func (npw *nodePortWatcher) generateArpBypassFlow(bridge serviceBridge, protocol string, ipAddr string, cookie string) string {
 	addrResDst := "arp_tpa"
 	addrResProto := "arp, arp_op=1"
 	if utilnet.IsIPv6String(ipAddr) {
@@ -345,7 +433,7 @@ func (npw *nodePortWatcher) generateArpBypassFlow(protocol string, ipAddr string
 
 	var arpFlow string
 	var arpPortsFiltered []string
-	arpPorts, err := util.GetOpenFlowPorts(npw.gwBridge, false)
+	arpPorts, err := util.GetOpenFlowPorts(bridge.name, false)
 	if err != nil {
 		// in the odd case that getting all ports from the bridge should not work,
 		// simply output to LOCAL (this should work well in the vast majority of cases, anyway)
@@ -353,7 +441,7 @@ func (npw *nodePortWatcher) generateArpBypassFlow(protocol string, ipAddr string
 			err)
 		arpFlow = fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, "+
 			"actions=output:%s",
-			cookie, npw.ofportPhys, addrResProto, addrResDst, ipAddr, ovsLocalPort)
+			cookie, bridge.ofportPhys, addrResProto, addrResDst, ipAddr, ovsLocalPort)
 	} else {
 		// cover the case where breth0 has more than 3 ports, e.g. if an admin adds a 4th port
 		// and the ExternalIP would be on that port
@@ -361,14 +449,14 @@ func (npw *nodePortWatcher) generateArpBypassFlow(protocol string, ipAddr string
 		// Filtering ofPortPhys is for consistency / readability only, OpenFlow will not send
 		// out the in_port normally (see man 7 ovs-actions)
 		for _, port := range arpPorts {
-			if port == npw.ofportPatch || port == npw.ofportPhys {
+			if port == bridge.ofportPatch || port == bridge.ofportPhys {
 				continue
 			}
 			arpPortsFiltered = append(arpPortsFiltered, port)
 		}
 		arpFlow = fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, %s=%s, "+
 			"actions=output:%s",
-			cookie, npw.ofportPhys, addrResProto, addrResDst, ipAddr, strings.Join(arpPortsFiltered, ","))
+			cookie, bridge.ofportPhys, addrResProto, addrResDst, ipAddr, strings.Join(arpPortsFiltered, ","))
 	}
 
 	return arpFlow
@@ -1082,13 +1170,14 @@ func (npwipt *nodePortWatcherIptables) SyncServices(services []interface{}) erro
 func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, subnets []*net.IPNet, extraIPs []net.IP) (*openflowManager, error) {
 	// add health check function to check default OpenFlow flows are on the shared gateway bridge
 	ofm := &openflowManager{
-		defaultBridge:         gwBridge,
-		externalGatewayBridge: exGWBridge,
-		flowCache:             make(map[string][]string),
-		flowMutex:             sync.Mutex{},
-		exGWFlowCache:         make(map[string][]string),
-		exGWFlowMutex:         sync.Mutex{},
-		flowChan:              make(chan struct{}, 1),
+		defaultBridge:          gwBridge,
+		externalGatewayBridge:  exGWBridge,
+		flowCache:              make(map[string][]string),
+		flowMutex:              sync.Mutex{},
+		exGWFlowCache:          make(map[string][]string),
+		exGWFlowMutex:          sync.Mutex{},
+		egressInterfaceBridges: make(map[string]*egressInterfaceBridge),
+		flowChan:               make(chan struct{}, 1),
 	}
 
 	if err := ofm.updateBridgeFlowCache(subnets, extraIPs); err != nil {
@@ -1128,6 +1217,16 @@ func (ofm *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs
 		}
 		ofm.updateExBridgeFlowCacheEntry("DEFAULT", exGWBridgeDftFlows)
 	}
+
+	ofm.egressInterfaceMutex.Lock()
+	defer ofm.egressInterfaceMutex.Unlock()
+	for _, eib := range ofm.egressInterfaceBridges {
+		eibDftFlows, err := commonFlows(subnets, eib.bridge)
+		if err != nil {
+			return err
+		}
+		eib.flowCache["DEFAULT"] = eibDftFlows
+	}
 	return nil
 }
 
@@ -1514,7 +1613,7 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 	// there is a chance that the pod traffic will reach the egress node before it configures the SNAT flows.
 	// Drop pod traffic that is not SNATed, excluding local pods(required for ICNIv2)
 	if config.OVNKubernetesFeature.EnableEgressIP {
-		for _, clusterEntry := range config.Default.ClusterSubnets {
+		for _, clusterEntry := range config.GetClusterSubnets() {
 			cidr := clusterEntry.CIDR
 			ipPrefix := "ip"
 			if utilnet.IsIPv6CIDR(cidr) {
@@ -1543,7 +1642,7 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 
 	if config.Gateway.DisableSNATMultipleGWs {
 		// table 1, traffic to pod subnet go directly to OVN
-		for _, clusterEntry := range config.Default.ClusterSubnets {
+		for _, clusterEntry := range config.GetClusterSubnets() {
 			cidr := clusterEntry.CIDR
 			var ipPrefix string
 			if utilnet.IsIPv6CIDR(cidr) {
@@ -1769,6 +1868,16 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 			gw.openflowManager.requestFlowSync()
 		}
 
+		// resync flows when cluster subnets are added or removed
+		gw.unregisterReloadHandlers = append(gw.unregisterReloadHandlers,
+			config.OnReload(config.ReloadableClusterSubnets, func() {
+				klog.Info("Cluster subnets changed, re-syncing bridge flows")
+				if err := gw.openflowManager.updateBridgeFlowCache(subnets, gw.nodeIPManager.ListAddresses()); err != nil {
+					klog.Errorf("Failed to re-generate gateway flows after cluster subnets change: %v", err)
+				}
+				gw.openflowManager.requestFlowSync()
+			}))
+
 		if config.Gateway.NodeportEnable {
 			if config.OvnKubeNode.Mode == types.NodeModeFull {
 				// (TODO): Internal Traffic Policy is not supported in DPU mode
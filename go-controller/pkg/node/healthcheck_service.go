@@ -194,7 +194,7 @@ func hasLocalHostNetworkEndpoints(epSlices []*discovery.EndpointSlice, nodeAddre
 
 // isHostEndpoint determines if the given endpoint ip belongs to a host networked pod
 func isHostEndpoint(endpointIP string) bool {
-	for _, clusterNet := range config.Default.ClusterSubnets {
+	for _, clusterNet := range config.GetClusterSubnets() {
 		if clusterNet.CIDR.Contains(net.ParseIP(endpointIP)) {
 			return false
 		}
@@ -157,6 +157,6 @@ func managementPortReady() (bool, error) {
 	if !strings.Contains(stdout, "actions=output:"+ofport) {
 		return false, nil
 	}
-	klog.Infof("Management port %s is ready", k8sMgmtIntfName)
+	klog.V(5).Infof("Management port %s is ready", k8sMgmtIntfName)
 	return true, nil
 }
@@ -39,18 +39,13 @@ type managementPortConfig struct {
 	ipv6 *managementPortIPFamilyConfig
 }
 
-func newManagementPortIPFamilyConfig(hostSubnet *net.IPNet, isIPv6 bool) (*managementPortIPFamilyConfig, error) {
-	var err error
-
-	cfg := &managementPortIPFamilyConfig{
-		ifAddr: util.GetNodeManagementIfAddr(hostSubnet),
-		gwIP:   util.GetNodeGatewayIfAddr(hostSubnet).IP,
-	}
-
-	// capture all the subnets for which we need to add routes through management port
-	for _, subnet := range config.Default.ClusterSubnets {
+// managementPortRoutedSubnets returns the subnets of the given IP family that are routed
+// through the management port
+func managementPortRoutedSubnets(isIPv6 bool) ([]*net.IPNet, error) {
+	var subnets []*net.IPNet
+	for _, subnet := range config.GetClusterSubnets() {
 		if utilnet.IsIPv6CIDR(subnet.CIDR) == isIPv6 {
-			cfg.allSubnets = append(cfg.allSubnets, subnet.CIDR)
+			subnets = append(subnets, subnet.CIDR)
 		}
 	}
 	// add the .3 masqueradeIP to add the route via mp0 for ETP=local case
@@ -60,13 +55,28 @@ func newManagementPortIPFamilyConfig(hostSubnet *net.IPNet, isIPv6 bool) (*manag
 		if err != nil {
 			return nil, err
 		}
-		cfg.allSubnets = append(cfg.allSubnets, masqueradeSubnet)
+		subnets = append(subnets, masqueradeSubnet)
 	} else {
 		_, masqueradeSubnet, err := net.ParseCIDR(types.V4HostETPLocalMasqueradeIP + "/32")
 		if err != nil {
 			return nil, err
 		}
-		cfg.allSubnets = append(cfg.allSubnets, masqueradeSubnet)
+		subnets = append(subnets, masqueradeSubnet)
+	}
+	return subnets, nil
+}
+
+func newManagementPortIPFamilyConfig(hostSubnet *net.IPNet, isIPv6 bool) (*managementPortIPFamilyConfig, error) {
+	var err error
+
+	cfg := &managementPortIPFamilyConfig{
+		ifAddr: util.GetNodeManagementIfAddr(hostSubnet),
+		gwIP:   util.GetNodeGatewayIfAddr(hostSubnet).IP,
+	}
+
+	// capture all the subnets for which we need to add routes through management port
+	if cfg.allSubnets, err = managementPortRoutedSubnets(isIPv6); err != nil {
+		return nil, err
 	}
 
 	if utilnet.IsIPv6CIDR(cfg.ifAddr) {
@@ -472,11 +482,51 @@ func DelMgtPortIptRules() {
 	_ = ipt6.DeleteChain("nat", iptableMgmPortChain)
 }
 
+// updateManagementPortClusterSubnets updates the subnets routed through the management port
+// after cluster subnets were added or removed, and deletes the routes of the removed ones.
+// The routes of the added ones are set up with the rest of the management port config.
+func updateManagementPortClusterSubnets(cfg *managementPortConfig) error {
+	for _, familyCfg := range []*managementPortIPFamilyConfig{cfg.ipv4, cfg.ipv6} {
+		if familyCfg == nil {
+			continue
+		}
+		allSubnets, err := managementPortRoutedSubnets(utilnet.IsIPv6CIDR(familyCfg.ifAddr))
+		if err != nil {
+			return err
+		}
+		var removedSubnets []*net.IPNet
+		for _, subnet := range familyCfg.allSubnets {
+			removed := true
+			for _, current := range allSubnets {
+				if current.String() == subnet.String() {
+					removed = false
+					break
+				}
+			}
+			if removed {
+				removedSubnets = append(removedSubnets, subnet)
+			}
+		}
+		if len(removedSubnets) > 0 {
+			klog.Infof("Deleting routes of removed cluster subnets %v from the management port", removedSubnets)
+			if err := util.LinkRoutesDel(cfg.link, removedSubnets); err != nil {
+				return err
+			}
+		}
+		familyCfg.allSubnets = allSubnets
+	}
+	return nil
+}
+
 // checks to make sure that following configurations are present on the k8s node
 // 1. route entries to cluster CIDR and service CIDR through management port
 // 2. ARP entry for the node subnet's gateway ip
 // 3. IPtables chain and rule for SNATing packets entering the logical topology
 func checkManagementPortHealth(cfg *managementPortConfig) {
+	// cluster subnets may have been added or removed since the last check
+	if err := updateManagementPortClusterSubnets(cfg); err != nil {
+		klog.Errorf("Failed to update the management port cluster subnets: %v", err)
+	}
 	warnings, err := setupManagementPortConfig(cfg)
 	for _, warning := range warnings {
 		klog.Warningf(warning)
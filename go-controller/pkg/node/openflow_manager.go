@@ -1,7 +1,11 @@
 package node
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,10 +23,24 @@ type openflowManager struct {
 	flowMutex     sync.Mutex
 	exGWFlowCache map[string][]string
 	exGWFlowMutex sync.Mutex
+	// flows last applied to each bridge, by match; nil until all the flows of the bridge
+	// are replaced, which is done again whenever the bridge is found to have drifted
+	appliedFlows     map[string]string
+	exGWAppliedFlows map[string]string
+	// gateway bridges of the EgressInterfaces, by EgressInterface name
+	egressInterfaceBridges map[string]*egressInterfaceBridge
+	egressInterfaceMutex   sync.Mutex
 	// channel to indicate we need to update flows immediately
 	flowChan chan struct{}
 }
 
+// egressInterfaceBridge is the gateway bridge set up for an EgressInterface, with its own flows
+type egressInterfaceBridge struct {
+	bridge       *bridgeConfiguration
+	flowCache    map[string][]string
+	appliedFlows map[string]string
+}
+
 func (c *openflowManager) updateFlowCacheEntry(key string, flows []string) {
 	c.flowMutex.Lock()
 	defer c.flowMutex.Unlock()
@@ -41,6 +59,78 @@ func (c *openflowManager) updateExBridgeFlowCacheEntry(key string, flows []strin
 	c.exGWFlowCache[key] = flows
 }
 
+// addEgressInterfaceBridge starts managing the flows of the gateway bridge of an EgressInterface,
+// replacing the bridge previously added for it if any
+func (c *openflowManager) addEgressInterfaceBridge(name string, bridge *bridgeConfiguration, subnets []*net.IPNet) error {
+	flows, err := commonFlows(subnets, bridge)
+	if err != nil {
+		return err
+	}
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	c.egressInterfaceBridges[name] = &egressInterfaceBridge{
+		bridge: bridge,
+		flowCache: map[string][]string{
+			"NORMAL":  {fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)},
+			"DEFAULT": flows,
+		},
+	}
+	return nil
+}
+
+// deleteEgressInterfaceBridge stops managing the flows of the gateway bridge of an EgressInterface
+// and tears the bridge down: a bridge created for the interface of the EgressInterface is deleted
+// along with the NIC enslaved to it, whose addresses and routes are moved back to it, while an
+// existing bridge is left switching normally. Either way the bridge is unmapped from the physical
+// network of the EgressInterface.
+func (c *openflowManager) deleteEgressInterfaceBridge(name string, bridge *bridgeConfiguration) error {
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	delete(c.egressInterfaceBridges, name)
+
+	if bridge.bridgeName == util.GetBridgeName(bridge.uplinkName) {
+		if err := util.BridgeToNic(bridge.bridgeName); err != nil {
+			return fmt.Errorf("failed to delete bridge %s and restore interface %s: %v",
+				bridge.bridgeName, bridge.uplinkName, err)
+		}
+	} else {
+		_, stderr, err := util.ReplaceOFFlows(bridge.bridgeName,
+			[]string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
+		if err != nil {
+			return fmt.Errorf("failed to reset the flows of bridge %s, stderr: %s: %v", bridge.bridgeName, stderr, err)
+		}
+	}
+	return removeBridgeMappings(bridge.bridgeName)
+}
+
+// getEgressInterfaceBridges returns the gateway bridges of the EgressInterfaces by EgressInterface name
+func (c *openflowManager) getEgressInterfaceBridges() map[string]*bridgeConfiguration {
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	bridges := make(map[string]*bridgeConfiguration, len(c.egressInterfaceBridges))
+	for name, eib := range c.egressInterfaceBridges {
+		bridges[name] = eib.bridge
+	}
+	return bridges
+}
+
+func (c *openflowManager) updateEgressInterfaceFlowCacheEntry(name, key string, flows []string) {
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	if eib, ok := c.egressInterfaceBridges[name]; ok {
+		eib.flowCache[key] = flows
+	}
+}
+
+// deleteEgressInterfaceFlowsByKey deletes the flows of the key from the gateway bridges of all the EgressInterfaces
+func (c *openflowManager) deleteEgressInterfaceFlowsByKey(key string) {
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	for _, eib := range c.egressInterfaceBridges {
+		delete(eib.flowCache, key)
+	}
+}
+
 func (c *openflowManager) requestFlowSync() {
 	select {
 	case c.flowChan <- struct{}{}:
@@ -50,6 +140,7 @@ func (c *openflowManager) requestFlowSync() {
 	}
 }
 
+// syncFlows pushes the flows that changed since the last sync to the bridges
 func (c *openflowManager) syncFlows() {
 	// protect gwBridge config from being updated by gw.nodeIPManager
 	c.defaultBridge.Lock()
@@ -58,30 +149,195 @@ func (c *openflowManager) syncFlows() {
 	c.flowMutex.Lock()
 	defer c.flowMutex.Unlock()
 
-	flows := []string{}
-	for _, entry := range c.flowCache {
-		flows = append(flows, entry...)
+	c.appliedFlows = syncBridgeFlows(c.defaultBridge.bridgeName, c.flowCache, c.appliedFlows)
+
+	if c.externalGatewayBridge != nil {
+		c.exGWFlowMutex.Lock()
+		defer c.exGWFlowMutex.Unlock()
+
+		c.exGWAppliedFlows = syncBridgeFlows(c.externalGatewayBridge.bridgeName, c.exGWFlowCache, c.exGWAppliedFlows)
 	}
 
-	_, stderr, err := util.ReplaceOFFlows(c.defaultBridge.bridgeName, flows)
-	if err != nil {
-		klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.flowCache)
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	for _, eib := range c.egressInterfaceBridges {
+		eib.appliedFlows = syncBridgeFlows(eib.bridge.bridgeName, eib.flowCache, eib.appliedFlows)
 	}
+}
+
+// verifyFlows checks that the bridges still have the flows last applied to them. The flows
+// of a bridge that drifted are all replaced on the next sync.
+func (c *openflowManager) verifyFlows() {
+	c.flowMutex.Lock()
+	if !bridgeFlowsVerified(c.defaultBridge.bridgeName, c.appliedFlows) {
+		c.appliedFlows = nil
+	}
+	c.flowMutex.Unlock()
 
 	if c.externalGatewayBridge != nil {
 		c.exGWFlowMutex.Lock()
-		defer c.exGWFlowMutex.Unlock()
+		if !bridgeFlowsVerified(c.externalGatewayBridge.bridgeName, c.exGWAppliedFlows) {
+			c.exGWAppliedFlows = nil
+		}
+		c.exGWFlowMutex.Unlock()
+	}
 
-		flows := []string{}
-		for _, entry := range c.exGWFlowCache {
-			flows = append(flows, entry...)
+	c.egressInterfaceMutex.Lock()
+	for _, eib := range c.egressInterfaceBridges {
+		if !bridgeFlowsVerified(eib.bridge.bridgeName, eib.appliedFlows) {
+			eib.appliedFlows = nil
 		}
+	}
+	c.egressInterfaceMutex.Unlock()
+}
 
-		_, stderr, err := util.ReplaceOFFlows(c.externalGatewayBridge.bridgeName, flows)
-		if err != nil {
-			klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.exGWFlowCache)
+// flowMatch returns the part of a flow that identifies it on the bridge: the table, priority
+// and match fields, without the actions and the flow_mod fields that don't take part in the
+// match. The flows of the flow cache are indexed by it, so the table and priority come first
+// and default to 0 and 32768, and the match fields are sorted. It is the flow argument to
+// delete the flow with a delete_strict flow_mod, so the table, which defaults to all the
+// tables for deletions, is always set.
+func flowMatch(flow string) string {
+	if i := strings.Index(flow, "actions="); i >= 0 {
+		flow = flow[:i]
+	}
+	table := "table=0"
+	priority := "priority=32768"
+	var fields []string
+	for _, field := range splitFlowFields(flow) {
+		name, _, _ := strings.Cut(field, "=")
+		switch name {
+		case "cookie", "duration", "n_packets", "n_bytes", "idle_age", "hard_age", "idle_timeout",
+			"hard_timeout", "importance", "send_flow_rem", "check_overlap", "reset_counts":
+			continue
+		case "table":
+			table = field
+			continue
+		case "priority":
+			priority = field
+			continue
 		}
+		fields = append(fields, field)
 	}
+	sort.Strings(fields)
+	return strings.Join(append([]string{table, priority}, fields...), ",")
+}
+
+// splitFlowFields splits the fields of a flow separated by commas or spaces, leaving the ones
+// nested in parentheses untouched
+func splitFlowFields(flow string) []string {
+	var fields []string
+	depth, start := 0, 0
+	for i, c := range flow + "," {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',', ' ', '\t', '\n':
+			if depth == 0 {
+				if field := flow[start:i]; field != "" {
+					fields = append(fields, field)
+				}
+				start = i + 1
+			}
+		}
+	}
+	return fields
+}
+
+// flowsByMatch indexes the flows of a flow cache by match. Like when the flows are added to
+// the bridge, the last flow of a match wins.
+func flowsByMatch(flowCache map[string][]string) map[string]string {
+	keys := make([]string, 0, len(flowCache))
+	for key := range flowCache {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	flows := map[string]string{}
+	for _, key := range keys {
+		for _, flow := range flowCache[key] {
+			flows[flowMatch(flow)] = flow
+		}
+	}
+	return flows
+}
+
+// syncBridgeFlows pushes the flows of flowCache to the bridge and returns the flows applied
+// to it by match, nil on failure. If applied is nil, all the flows of the bridge are replaced;
+// otherwise only the flows that differ from applied are added or deleted, in a single bundle.
+func syncBridgeFlows(bridgeName string, flowCache map[string][]string, applied map[string]string) map[string]string {
+	desired := flowsByMatch(flowCache)
+	if applied == nil {
+		return replaceBridgeFlows(bridgeName, desired)
+	}
+
+	var flowMods []string
+	for match, flow := range desired {
+		if applied[match] != flow {
+			// a flow that has the match of an existing flow replaces it
+			flowMods = append(flowMods, "add "+flow)
+		}
+	}
+	for match := range applied {
+		if _, ok := desired[match]; !ok {
+			flowMods = append(flowMods, "delete_strict "+match)
+		}
+	}
+	if len(flowMods) == 0 {
+		return applied
+	}
+	sort.Strings(flowMods)
+
+	klog.V(5).Infof("Updating %d flows of bridge %s", len(flowMods), bridgeName)
+	_, stderr, err := util.ModifyOFFlows(bridgeName, flowMods)
+	if err != nil {
+		klog.Errorf("Failed to update flows of bridge %s, replacing them all, error: %v, stderr, %s, flow mods: %s",
+			bridgeName, err, stderr, flowMods)
+		return replaceBridgeFlows(bridgeName, desired)
+	}
+	return desired
+}
+
+func replaceBridgeFlows(bridgeName string, desired map[string]string) map[string]string {
+	flows := make([]string, 0, len(desired))
+	for _, flow := range desired {
+		flows = append(flows, flow)
+	}
+	sort.Strings(flows)
+
+	_, stderr, err := util.ReplaceOFFlows(bridgeName, flows)
+	if err != nil {
+		klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, flows)
+		return nil
+	}
+	return desired
+}
+
+// bridgeFlowsVerified returns false if the flows of the bridge don't match the flows last
+// applied to it, i.e. the flows of the bridge were changed behind our back. The flows are
+// compared by ovs-ofctl, so that a flow whose actions were changed is caught as well as a
+// flow that was added or deleted, whatever the form OVS prints them in.
+func bridgeFlowsVerified(bridgeName string, applied map[string]string) bool {
+	if applied == nil {
+		return false
+	}
+	flows := make([]string, 0, len(applied))
+	for _, flow := range applied {
+		flows = append(flows, flow)
+	}
+	sort.Strings(flows)
+	diff, err := util.DiffOFFlows(bridgeName, flows)
+	if err != nil {
+		klog.Errorf("Failed to verify the flows of bridge %s: %v", bridgeName, err)
+		return false
+	}
+	if len(diff) > 0 {
+		klog.Warningf("Bridge %s has drifted from the flows last applied to it, replacing them all: %s",
+			bridgeName, strings.Join(diff, "; "))
+		return false
+	}
+	return true
 }
 
 // checkDefaultOpenFlow checks for the existence of default OpenFlow rules and
@@ -109,6 +365,12 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 						continue
 					}
 				}
+				if err := c.checkEgressInterfacePorts(); err != nil {
+					klog.Errorf("Checkports failed %v", err)
+					continue
+				}
+				// the flows of the bridges are only replaced if they drifted
+				c.verifyFlows()
 				c.syncFlows()
 			case <-c.flowChan:
 				c.syncFlows()
@@ -120,6 +382,18 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 	}()
 }
 
+func (c *openflowManager) checkEgressInterfacePorts() error {
+	c.egressInterfaceMutex.Lock()
+	defer c.egressInterfaceMutex.Unlock()
+	for _, eib := range c.egressInterfaceBridges {
+		if err := checkPorts(eib.bridge.patchPort, eib.bridge.ofPortPatch,
+			eib.bridge.uplinkName, eib.bridge.ofPortPhys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func checkPorts(patchIntf, ofPortPatch, physIntf, ofPortPhys string) error {
 	// it could be that the ovn-controller recreated the patch between the host OVS bridge and
 	// the integration bridge, as a result the ofport number changed for that patch interface
@@ -0,0 +1,239 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gateway OpenFlow manager", func() {
+	var (
+		fexec *ovntest.FakeExec
+		ofm   *openflowManager
+	)
+
+	BeforeEach(func() {
+		config.PrepareTestConfig()
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		ofm = &openflowManager{
+			defaultBridge: &bridgeConfiguration{bridgeName: "breth0"},
+			flowCache: map[string][]string{
+				"DEFAULT": {
+					"cookie=0xdeff105, priority=500, in_port=1, ip, actions=output:2",
+					"cookie=0xdeff105, priority=0, table=1, actions=drop",
+				},
+			},
+			flowMutex: sync.Mutex{},
+			flowChan:  make(chan struct{}, 1),
+		}
+	})
+
+	It("identifies flows by table, priority and match", func() {
+		Expect(flowMatch("cookie=0xdeff105, priority=500, in_port=1, ip, idle_timeout=10, actions=output:2")).
+			To(Equal("table=0,priority=500,in_port=1,ip"))
+		Expect(flowMatch("cookie=0xdeff105, table=1, priority=0, actions=drop")).
+			To(Equal("table=1,priority=0"))
+		// the match fields are sorted, so the order they are written in doesn't matter
+		Expect(flowMatch("cookie=0xdeff105, table=1, ip, in_port=1, actions=output:2")).
+			To(Equal(flowMatch("cookie=0xdeff105, in_port=1, table=1, ip actions=output:2")))
+	})
+
+	It("replaces the flows of the bridge on the first sync only", func() {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.appliedFlows).To(HaveLen(2))
+
+		// nothing to do when the flows didn't change
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+
+		// only the changed flows are pushed afterwards
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle add-flows breth0 -",
+		})
+		ofm.updateFlowCacheEntry("NodePort_namespace1_service1_tcp_31111",
+			[]string{"cookie=0x1, priority=110, in_port=1, tcp, tp_dst=31111, actions=output:2"})
+		ofm.deleteFlowsByKey("DEFAULT")
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.appliedFlows).To(Equal(map[string]string{
+			"table=0,priority=110,in_port=1,tcp,tp_dst=31111": "cookie=0x1, priority=110, in_port=1, tcp, tp_dst=31111, actions=output:2",
+		}))
+	})
+
+	It("replaces the flows of the bridge again when they drifted", func() {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+			Output: "+cookie=0xdeff105, table=1, priority=0 actions=drop\n",
+			Err:    fmt.Errorf("exit status 2"),
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.syncFlows()
+		ofm.verifyFlows()
+		ofm.syncFlows()
+		ofm.verifyFlows()
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("replaces the flows of the bridge when the actions of a flow were changed", func() {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+			Output: "-cookie=0xdeff105, priority=500,ip,in_port=1 actions=output:3\n" +
+				"+cookie=0xdeff105, priority=500,ip,in_port=1 actions=output:2\n",
+			Err: fmt.Errorf("exit status 2"),
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.syncFlows()
+		ofm.verifyFlows()
+		Expect(ofm.appliedFlows).To(BeNil())
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("keeps the flows of the bridge that OVS prints in another form", func() {
+		// flows as the gateway writes them, which a dump prints with set_field, ipv6 and
+		// icmp_type instead
+		ofm.flowCache["DEFAULT"] = []string{
+			"cookie=0xdeff105, priority=100, table=1, ip6, ct_state=+trk+est, ct_mark=0x2, actions=output:LOCAL",
+			"cookie=0xdeff105, table=2, actions=mod_dl_dst=0a:58:0a:f4:00:01,output:2",
+			"cookie=0xdeff105, priority=14, table=1,icmp6,icmpv6_type=136 actions=FLOOD",
+		}
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+			"ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+		})
+		ofm.syncFlows()
+		ofm.verifyFlows()
+		Expect(ofm.appliedFlows).To(HaveLen(3))
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+
+		// differences are reported in the form of an OpenFlow 1.3 dump
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+			Output: "-cookie=0xdeff105, table=2, priority=32768 actions=set_field:0a:58:0a:f4:00:02->eth_dst,output:2\n" +
+				"+cookie=0xdeff105, table=2, priority=32768 actions=set_field:0a:58:0a:f4:00:01->eth_dst,output:2\n" +
+				"-cookie=0xdeff105, table=1, priority=14,icmp6,icmp_type=135 actions=FLOOD\n",
+			Err: fmt.Errorf("exit status 2"),
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.verifyFlows()
+		Expect(ofm.appliedFlows).To(BeNil())
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("replaces the flows of the bridge when they can't be verified", func() {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-ofctl -O OpenFlow13 diff-flows breth0 -",
+			Stderr: "ovs-ofctl: breth0 is not a bridge or a socket",
+			Err:    fmt.Errorf("exit status 1"),
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.syncFlows()
+		ofm.verifyFlows()
+		Expect(ofm.appliedFlows).To(BeNil())
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("replaces the flows of the bridge when the update fails", func() {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-ofctl -O OpenFlow13 --bundle add-flows breth0 -",
+			Err: fmt.Errorf("bundle failed"),
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+		})
+		ofm.syncFlows()
+		ofm.deleteFlowsByKey("DEFAULT")
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.appliedFlows).To(BeEmpty())
+	})
+
+	It("manages the flows of the EgressInterface bridges separately", func() {
+		ofm.egressInterfaceBridges = map[string]*egressInterfaceBridge{
+			"secondary": {
+				bridge: &bridgeConfiguration{bridgeName: "breth1"},
+				flowCache: map[string][]string{
+					"NORMAL": {"table=0,priority=0,actions=NORMAL"},
+				},
+			},
+		}
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth1 -",
+		})
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+
+		// service flows are added to and deleted from the EgressInterface bridges only
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle add-flows breth1 -",
+		})
+		ofm.updateEgressInterfaceFlowCacheEntry("secondary", "NodePort_namespace1_service1_tcp_31111",
+			[]string{"cookie=0x1, priority=110, in_port=1, tcp, tp_dst=31111, actions=output:2"})
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.egressInterfaceBridges["secondary"].appliedFlows).To(HaveLen(2))
+
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle add-flows breth1 -",
+		})
+		ofm.deleteEgressInterfaceFlowsByKey("NodePort_namespace1_service1_tcp_31111")
+		ofm.syncFlows()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.egressInterfaceBridges["secondary"].appliedFlows).To(HaveLen(1))
+
+		// an existing bridge is left switching normally once the EgressInterface is gone, and is
+		// no longer mapped to its physical network
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth1 -",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: "physnet:breth0,eifphysnet-secondary:breth1",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-bridge-mappings=physnet:breth0",
+		})
+		Expect(ofm.deleteEgressInterfaceBridge("secondary", ofm.egressInterfaceBridges["secondary"].bridge)).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		Expect(ofm.getEgressInterfaceBridges()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,400 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// bgpRouteMap tags the network statements owned by ovnkube-node in the FRR configuration,
+	// so that networks configured by the administrator are left alone
+	bgpRouteMap = "ovn-kubernetes"
+	// routeAdvertiserResyncInterval is how often the advertised routes are reconciled with FRR
+	// to recover from FRR restarts and manual changes
+	routeAdvertiserResyncInterval = time.Minute
+)
+
+// frrConfigurer pushes the advertised routes to FRR
+type frrConfigurer interface {
+	// advertise makes FRR advertise exactly the given prefixes
+	advertise(prefixes sets.Set[string]) error
+}
+
+// routeAdvertiser advertises with BGP, through a local FRR instance, the host subnets of the
+// node, the EgressIPs assigned to the node and the external IPs of services with
+// externalTrafficPolicy=Local that have ready endpoints on the node. Routes are withdrawn when
+// the subnets, the EgressIP assignments or the endpoints move away from the node.
+type routeAdvertiser struct {
+	nodeName     string
+	watchFactory *factory.WatchFactory
+	frr          frrConfigurer
+	syncCh       chan struct{}
+}
+
+func newRouteAdvertiser(nodeName string, watchFactory *factory.WatchFactory) *routeAdvertiser {
+	var frr frrConfigurer
+	if config.BGP.ConfigMode == config.BGPConfigModeFile {
+		frr = &frrFileConfigurer{path: config.BGP.ConfigFile}
+	} else {
+		frr = &frrVtyshConfigurer{}
+	}
+	return &routeAdvertiser{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		frr:          frr,
+		syncCh:       make(chan struct{}, 1),
+	}
+}
+
+// requestSync schedules a reconciliation of the advertised routes, requests are coalesced
+func (ra *routeAdvertiser) requestSync() {
+	select {
+	case ra.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run watches the sources of the advertised routes and keeps FRR in sync until stopChan is closed
+func (ra *routeAdvertiser) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { ra.requestSync() },
+		UpdateFunc: func(old, new interface{}) { ra.requestSync() },
+		DeleteFunc: func(obj interface{}) { ra.requestSync() },
+	}
+	if _, err := ra.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode, ok := old.(*kapi.Node)
+			if !ok {
+				return
+			}
+			newNode, ok := new.(*kapi.Node)
+			if !ok || newNode.Name != ra.nodeName {
+				return
+			}
+			if nodeHostSubnetsChanged(oldNode, newNode) {
+				ra.requestSync()
+			}
+		},
+	}); err != nil {
+		return err
+	}
+	if _, err := ra.watchFactory.AddServiceHandler(handler, nil); err != nil {
+		return err
+	}
+	if _, err := ra.watchFactory.AddEndpointSliceHandler(handler, nil); err != nil {
+		return err
+	}
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		if _, err := ra.watchFactory.AddEgressIPHandler(handler, nil); err != nil {
+			return err
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(routeAdvertiserResyncInterval)
+		defer ticker.Stop()
+		var advertised sets.Set[string]
+		ra.requestSync()
+		for {
+			resync := false
+			select {
+			case <-stopChan:
+				return
+			case <-ra.syncCh:
+			case <-ticker.C:
+				resync = true
+			}
+			prefixes, err := ra.getPrefixes()
+			if err != nil {
+				klog.Errorf("Unable to get the routes to advertise with BGP for node %s: %v", ra.nodeName, err)
+				continue
+			}
+			if !resync && advertised != nil && advertised.Equal(prefixes) {
+				continue
+			}
+			if err := ra.frr.advertise(prefixes); err != nil {
+				klog.Errorf("Unable to advertise routes %v with BGP for node %s: %v",
+					sets.List(prefixes), ra.nodeName, err)
+				advertised = nil
+				continue
+			}
+			klog.V(5).Infof("Advertising routes %v with BGP for node %s", sets.List(prefixes), ra.nodeName)
+			advertised = prefixes
+		}
+	}()
+	return nil
+}
+
+// getPrefixes returns the prefixes the node must advertise
+func (ra *routeAdvertiser) getPrefixes() (sets.Set[string], error) {
+	prefixes := sets.New[string]()
+
+	node, err := ra.watchFactory.GetNode(ra.nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", ra.nodeName, err)
+	}
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		return nil, fmt.Errorf("failed to parse the host subnets of node %s: %w", ra.nodeName, err)
+	}
+	for _, hostSubnet := range hostSubnets {
+		prefixes.Insert(hostSubnet.String())
+	}
+
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		egressIPs, err := ra.watchFactory.GetEgressIPs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list EgressIPs: %w", err)
+		}
+		for _, egressIP := range egressIPs {
+			for _, status := range egressIP.Status.Items {
+				if status.Node != ra.nodeName {
+					continue
+				}
+				if ip := utilnet.ParseIPSloppy(status.EgressIP); ip != nil {
+					prefixes.Insert(ip.String() + util.GetIPFullMask(ip.String()))
+				}
+			}
+		}
+	}
+
+	services, err := ra.watchFactory.GetServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	for _, service := range services {
+		if !util.ServiceExternalTrafficPolicyLocal(service) {
+			continue
+		}
+		vips := util.GetExternalAndLBIPs(service)
+		if len(vips) == 0 {
+			continue
+		}
+		epSlices, err := ra.watchFactory.GetEndpointSlices(service.Namespace, service.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the endpointslices of service %s/%s: %w",
+				service.Namespace, service.Name, err)
+		}
+		if !hasLocalReadyEndpoints(epSlices, ra.nodeName) {
+			continue
+		}
+		for _, vip := range vips {
+			prefixes.Insert(vip + util.GetIPFullMask(vip))
+		}
+	}
+	return prefixes, nil
+}
+
+// hasLocalReadyEndpoints returns true if there is at least one ready endpoint on the given node
+func hasLocalReadyEndpoints(epSlices []*discovery.EndpointSlice, nodeName string) bool {
+	for _, epSlice := range epSlices {
+		for _, endpoint := range epSlice.Endpoints {
+			if endpoint.NodeName != nil && *endpoint.NodeName == nodeName && util.IsEndpointReady(endpoint) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodeHostSubnetsChanged returns true if the host subnet annotation of the node changed
+func nodeHostSubnetsChanged(oldNode, newNode *kapi.Node) bool {
+	oldSubnets, _ := util.ParseNodeHostSubnetAnnotation(oldNode, types.DefaultNetworkName)
+	newSubnets, _ := util.ParseNodeHostSubnetAnnotation(newNode, types.DefaultNetworkName)
+	return !reflect.DeepEqual(oldSubnets, newSubnets)
+}
+
+// splitPrefixesByFamily returns the sorted IPv4 and IPv6 prefixes
+func splitPrefixesByFamily(prefixes sets.Set[string]) ([]string, []string) {
+	var v4Prefixes, v6Prefixes []string
+	for _, prefix := range sets.List(prefixes) {
+		if utilnet.IsIPv6CIDRString(prefix) {
+			v6Prefixes = append(v6Prefixes, prefix)
+		} else {
+			v4Prefixes = append(v4Prefixes, prefix)
+		}
+	}
+	return v4Prefixes, v6Prefixes
+}
+
+// frrVtyshConfigurer adds and removes the network statements of the advertised prefixes
+// to the BGP router of a running FRR instance through vtysh
+type frrVtyshConfigurer struct{}
+
+func (f *frrVtyshConfigurer) advertise(prefixes sets.Set[string]) error {
+	stdout, stderr, err := util.RunVtysh("-c", "show running-config")
+	if err != nil {
+		return fmt.Errorf("failed to get the FRR running config, stderr: %q: %w", stderr, err)
+	}
+	current := parseFRRNetworks(stdout, config.BGP.ASN)
+
+	v4Add, v6Add := splitPrefixesByFamily(prefixes.Difference(current))
+	v4Del, v6Del := splitPrefixesByFamily(current.Difference(prefixes))
+	if len(v4Add)+len(v6Add)+len(v4Del)+len(v6Del) == 0 {
+		return nil
+	}
+
+	commands := []string{
+		"configure terminal",
+		fmt.Sprintf("route-map %s permit 10", bgpRouteMap),
+		"exit",
+		fmt.Sprintf("router bgp %d", config.BGP.ASN),
+		// the advertised prefixes have no route in the host routing table, which FRR
+		// otherwise requires to advertise them
+		"no bgp network import-check",
+	}
+	for _, family := range []struct {
+		name     string
+		add, del []string
+	}{
+		{"ipv4", v4Add, v4Del},
+		{"ipv6", v6Add, v6Del},
+	} {
+		if len(family.add)+len(family.del) == 0 {
+			continue
+		}
+		commands = append(commands, fmt.Sprintf("address-family %s unicast", family.name))
+		for _, prefix := range family.add {
+			commands = append(commands, fmt.Sprintf("network %s route-map %s", prefix, bgpRouteMap))
+		}
+		for _, prefix := range family.del {
+			commands = append(commands, fmt.Sprintf("no network %s route-map %s", prefix, bgpRouteMap))
+		}
+		commands = append(commands, "exit-address-family")
+	}
+
+	args := make([]string, 0, 2*len(commands))
+	for _, command := range commands {
+		args = append(args, "-c", command)
+	}
+	if _, stderr, err := util.RunVtysh(args...); err != nil {
+		return fmt.Errorf("failed to configure the FRR BGP router, stderr: %q: %w", stderr, err)
+	}
+	return nil
+}
+
+// parseFRRNetworks returns the prefixes of the network statements tagged with the
+// ovn-kubernetes route-map in the given BGP router of an FRR running config
+func parseFRRNetworks(runningConfig string, asn uint) sets.Set[string] {
+	prefixes := sets.New[string]()
+	router := fmt.Sprintf("router bgp %d", asn)
+	inRouter := false
+	for _, line := range strings.Split(runningConfig, "\n") {
+		if !strings.HasPrefix(line, " ") {
+			inRouter = strings.TrimSpace(line) == router
+			continue
+		}
+		if !inRouter {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 4 && fields[0] == "network" && fields[2] == "route-map" && fields[3] == bgpRouteMap {
+			if _, ipNet, err := net.ParseCIDR(fields[1]); err == nil {
+				prefixes.Insert(ipNet.String())
+			}
+		}
+	}
+	return prefixes
+}
+
+// frrFileConfigurer renders the whole FRR configuration, peering with the configured neighbors,
+// into a file that FRR reloads on change
+type frrFileConfigurer struct {
+	path string
+}
+
+func (f *frrFileConfigurer) advertise(prefixes sets.Set[string]) error {
+	newBytes := renderFRRConfig(prefixes)
+	if existingBytes, err := os.ReadFile(f.path); err == nil && bytes.Equal(newBytes, existingBytes) {
+		return nil
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "ovnkube-frr-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(newBytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// renderFRRConfig renders the FRR configuration advertising the given prefixes
+func renderFRRConfig(prefixes sets.Set[string]) []byte {
+	neighborASN := config.BGP.NeighborASN
+	if neighborASN == 0 {
+		neighborASN = config.BGP.ASN
+	}
+	neighbors := append([]string{}, config.BGP.NeighborAddresses...)
+	sort.Strings(neighbors)
+	v4Prefixes, v6Prefixes := splitPrefixesByFamily(prefixes)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "! Rendered by ovnkube-node, do not edit\n")
+	fmt.Fprintf(&b, "frr defaults traditional\n")
+	fmt.Fprintf(&b, "!\n")
+	fmt.Fprintf(&b, "route-map %s permit 10\n", bgpRouteMap)
+	fmt.Fprintf(&b, "exit\n")
+	fmt.Fprintf(&b, "!\n")
+	fmt.Fprintf(&b, "router bgp %d\n", config.BGP.ASN)
+	fmt.Fprintf(&b, " no bgp ebgp-requires-policy\n")
+	fmt.Fprintf(&b, " no bgp default ipv4-unicast\n")
+	fmt.Fprintf(&b, " no bgp network import-check\n")
+	for _, neighbor := range neighbors {
+		fmt.Fprintf(&b, " neighbor %s remote-as %d\n", neighbor, neighborASN)
+	}
+	for _, family := range []struct {
+		name     string
+		ipv6     bool
+		prefixes []string
+	}{
+		{"ipv4", false, v4Prefixes},
+		{"ipv6", true, v6Prefixes},
+	} {
+		fmt.Fprintf(&b, " !\n")
+		fmt.Fprintf(&b, " address-family %s unicast\n", family.name)
+		for _, prefix := range family.prefixes {
+			fmt.Fprintf(&b, "  network %s route-map %s\n", prefix, bgpRouteMap)
+		}
+		for _, neighbor := range neighbors {
+			if utilnet.IsIPv6String(neighbor) == family.ipv6 {
+				fmt.Fprintf(&b, "  neighbor %s activate\n", neighbor)
+			}
+		}
+		fmt.Fprintf(&b, " exit-address-family\n")
+	}
+	fmt.Fprintf(&b, "exit\n")
+	fmt.Fprintf(&b, "!\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,177 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	utilpointer "k8s.io/utils/pointer"
+)
+
+func newETPLocalService(name, vip string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Type:                  v1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyTypeLocal,
+			ExternalIPs:           []string{vip},
+		},
+	}
+}
+
+func newServiceEndpointSlice(service, endpointNode string) *discovery.EndpointSlice {
+	return &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service + "ab23",
+			Namespace: "default",
+			Labels:    map[string]string{discovery.LabelServiceName: service},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses:  []string{"10.244.0.5"},
+				Conditions: discovery.EndpointConditions{Ready: utilpointer.Bool(true)},
+				NodeName:   utilpointer.String(endpointNode),
+			},
+		},
+	}
+}
+
+var _ = Describe("BGP route advertiser", func() {
+	var fexec *ovntest.FakeExec
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.BGP.Enabled = true
+		config.BGP.ASN = 64512
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	It("advertises the host subnets, the assigned EgressIPs and the ETP=local service VIPs with local endpoints", func() {
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        nodeName,
+			Annotations: map[string]string{"k8s.ovn.org/node-subnets": `{"default":"10.244.0.0/24"}`},
+		}}
+		egressIP := &egressipv1.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "egressip"},
+			Status: egressipv1.EgressIPStatus{Items: []egressipv1.EgressIPStatusItem{
+				{Node: nodeName, EgressIP: "192.168.126.101"},
+				{Node: "other-node", EgressIP: "192.168.126.102"},
+			}},
+		}
+		fakeClient := &util.OVNNodeClientset{
+			KubeClient: fake.NewSimpleClientset(node,
+				newETPLocalService("local", "1.1.1.1"), newServiceEndpointSlice("local", nodeName),
+				newETPLocalService("remote", "1.1.1.2"), newServiceEndpointSlice("remote", "other-node")),
+			EgressIPClient: egressipfake.NewSimpleClientset(egressIP),
+		}
+		wf, err := factory.NewNodeWatchFactory(fakeClient, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		prefixes, err := newRouteAdvertiser(nodeName, wf).getPrefixes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sets.List(prefixes)).To(Equal([]string{"1.1.1.1/32", "10.244.0.0/24", "192.168.126.101/32"}))
+	})
+
+	It("adds and withdraws only the networks owned by ovnkube-node through vtysh", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "vtysh -c show running-config",
+			Output: `frr version 8.4
+router bgp 64512
+ address-family ipv4 unicast
+  network 10.0.0.0/8
+  network 10.244.0.0/24 route-map ovn-kubernetes
+  network 192.168.126.101/32 route-map ovn-kubernetes
+ exit-address-family
+exit
+`,
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "vtysh -c configure terminal -c route-map ovn-kubernetes permit 10 -c exit -c router bgp 64512 " +
+				"-c no bgp network import-check -c address-family ipv4 unicast -c network 1.1.1.1/32 route-map ovn-kubernetes " +
+				"-c no network 192.168.126.101/32 route-map ovn-kubernetes -c exit-address-family",
+		})
+
+		frr := &frrVtyshConfigurer{}
+		Expect(frr.advertise(sets.New("10.244.0.0/24", "1.1.1.1/32"))).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("renders an FRR configuration advertising the prefixes without requiring a route to them", func() {
+		config.BGP.NeighborAddresses = []string{"172.18.0.1"}
+		Expect(string(renderFRRConfig(sets.New("10.244.0.0/24", "1.1.1.1/32")))).To(Equal(`! Rendered by ovnkube-node, do not edit
+frr defaults traditional
+!
+route-map ovn-kubernetes permit 10
+exit
+!
+router bgp 64512
+ no bgp ebgp-requires-policy
+ no bgp default ipv4-unicast
+ no bgp network import-check
+ neighbor 172.18.0.1 remote-as 64512
+ !
+ address-family ipv4 unicast
+  network 1.1.1.1/32 route-map ovn-kubernetes
+  network 10.244.0.0/24 route-map ovn-kubernetes
+  neighbor 172.18.0.1 activate
+ exit-address-family
+ !
+ address-family ipv6 unicast
+ exit-address-family
+exit
+!
+`))
+	})
+
+	It("renders the FRR configuration file only when the advertised routes change", func() {
+		config.BGP.NeighborAddresses = []string{"172.18.0.1", "fc00:f853:ccd:e793::1"}
+		dir, err := os.MkdirTemp("", "frr")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		frr := &frrFileConfigurer{path: filepath.Join(dir, "frr.conf")}
+
+		Expect(frr.advertise(sets.New("10.244.0.0/24", "fd00:10:244:1::/64"))).To(Succeed())
+		rendered, err := os.ReadFile(frr.path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(ContainSubstring(" neighbor 172.18.0.1 remote-as 64512\n"))
+		Expect(string(rendered)).To(ContainSubstring(" address-family ipv4 unicast\n" +
+			"  network 10.244.0.0/24 route-map ovn-kubernetes\n" +
+			"  neighbor 172.18.0.1 activate\n" +
+			" exit-address-family\n"))
+		Expect(string(rendered)).To(ContainSubstring(" address-family ipv6 unicast\n" +
+			"  network fd00:10:244:1::/64 route-map ovn-kubernetes\n" +
+			"  neighbor fc00:f853:ccd:e793::1 activate\n" +
+			" exit-address-family\n"))
+
+		info, err := os.Stat(frr.path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(frr.advertise(sets.New("fd00:10:244:1::/64", "10.244.0.0/24"))).To(Succeed())
+		unchanged, err := os.Stat(frr.path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.SameFile(info, unchanged)).To(BeTrue())
+
+		Expect(frr.advertise(sets.New("10.244.0.0/24"))).To(Succeed())
+		rendered, err = os.ReadFile(frr.path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).NotTo(ContainSubstring("fd00:10:244:1::/64"))
+	})
+})
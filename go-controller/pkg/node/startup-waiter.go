@@ -59,3 +59,13 @@ func (w *startupWaiter) Wait() error {
 	}
 	return nil
 }
+
+// Ready returns whether all the conditions waited for are met right now, without waiting
+func (w *startupWaiter) Ready() (bool, error) {
+	for _, t := range w.tasks {
+		if ready, err := t.waitFn(); err != nil || !ready {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/klog/v2"
 
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	mcastpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -32,7 +33,8 @@ func hasResourceAnUpdateFunc(objType reflect.Type) bool {
 		factory.EgressFwNodeType,
 		factory.CloudPrivateIPConfigType,
 		factory.LocalPodSelectorType,
-		factory.NamespaceType:
+		factory.NamespaceType,
+		factory.MulticastPolicyType:
 		return true
 	}
 	return false
@@ -98,6 +100,17 @@ func (h *baseNetworkControllerEventHandler) areResourcesEqual(objType reflect.Ty
 		}
 		return reflect.DeepEqual(oldEgressFirewall.Spec, newEgressFirewall.Spec), nil
 
+	case factory.MulticastPolicyType:
+		oldMcastPolicy, ok := obj1.(*mcastpolicyapi.MulticastPolicy)
+		if !ok {
+			return false, fmt.Errorf("could not cast obj1 of type %T to *mcastpolicyapi.MulticastPolicy", obj1)
+		}
+		newMcastPolicy, ok := obj2.(*mcastpolicyapi.MulticastPolicy)
+		if !ok {
+			return false, fmt.Errorf("could not cast obj2 of type %T to *mcastpolicyapi.MulticastPolicy", obj2)
+		}
+		return reflect.DeepEqual(oldMcastPolicy.Spec, newMcastPolicy.Spec), nil
+
 	case factory.EgressIPType,
 		factory.EgressIPNamespaceType,
 		factory.EgressNodeType,
@@ -165,6 +178,9 @@ func (h *baseNetworkControllerEventHandler) getResourceFromInformerCache(objType
 	case factory.EgressFirewallType:
 		obj, err = watchFactory.GetEgressFirewall(namespace, name)
 
+	case factory.MulticastPolicyType:
+		obj, err = watchFactory.GetMulticastPolicy(namespace, name)
+
 	case factory.EgressIPType:
 		obj, err = watchFactory.GetEgressIP(name)
 
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,13 +20,16 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
 	ovnretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -111,6 +115,16 @@ type BaseNetworkController struct {
 // configuration for secondary network controller
 type BaseSecondaryNetworkController struct {
 	BaseNetworkController
+
+	// retry framework for namespaces, only used with multicast support
+	retryNamespaces *ovnretry.RetryFramework
+	// namespace events factory handler
+	namespaceHandler *factory.Handler
+
+	// namespaces whose multicast port group and ACLs have been created,
+	// guarded by multicastNamespacesLock
+	multicastNamespaces     sets.Set[string]
+	multicastNamespacesLock sync.Mutex
 }
 
 // NewCommonNetworkControllerInfo creates CommonNetworkControllerInfo shared by controllers
@@ -341,9 +355,8 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 		return err
 	}
 
-	// multicast is only supported in default network for now
 	if bnc.multicastSupport {
-		err = libovsdbops.AddPortsToPortGroup(bnc.nbClient, types.ClusterRtrPortGroupName, logicalSwitchPort.UUID)
+		err = libovsdbops.AddPortsToPortGroup(bnc.nbClient, bnc.getClusterRtrPortGroupName(), logicalSwitchPort.UUID)
 		if err != nil {
 			klog.Errorf(err.Error())
 			return err
@@ -660,3 +673,127 @@ func (bnc *BaseNetworkController) recordNodeErrorEvent(node *kapi.Node, nodeErr
 func (bnc *BaseNetworkController) doesNetworkRequireIPAM() bool {
 	return !((bnc.TopologyType() == types.Layer2Topology || bnc.TopologyType() == types.LocalnetTopology) && len(bnc.Subnets()) == 0)
 }
+
+// allocatesPodAnnotation returns true if the network controller allocates the pod
+// IPs and MAC and writes the pod annotation itself. For layer2 secondary networks the
+// pod annotation is allocated by cluster manager and only consumed here.
+func (bnc *BaseNetworkController) allocatesPodAnnotation() bool {
+	return !(bnc.IsSecondary() && bnc.TopologyType() == types.Layer2Topology)
+}
+
+// GetControllerName returns the name of the controller, that is used to identify db objects owned by the controller.
+func (bnc *BaseNetworkController) GetControllerName() string {
+	return bnc.controllerName
+}
+
+// IsStaleObject returns true if the kubernetes object the given db object was created for doesn't exist anymore.
+// Objects of ObjectIDsTypes that are only created by the default network controller are stale for
+// the other controllers, the default network controller overrides this method to check them.
+func (bnc *BaseNetworkController) IsStaleObject(dbIDs *libovsdbops.DbObjectIDs) (bool, error) {
+	var err error
+	switch idsType := dbIDs.GetIDsType(); {
+	case idsType.IsSameType(libovsdbops.AddressSetNetworkPolicy):
+		// deprecated, address sets of this type are replaced on startup
+		return true, nil
+	case idsType.IsSameType(libovsdbops.AddressSetPodSelector), idsType.IsSameType(libovsdbops.AddressSetEgressIP),
+		idsType.IsSameType(libovsdbops.AddressSetEgressService), idsType.IsSameType(libovsdbops.AddressSetEgressFirewallDNS),
+		idsType.IsSameType(libovsdbops.ACLNetpolDefault):
+		return bnc.IsSecondary(), nil
+	case idsType.IsSameType(libovsdbops.AddressSetNamespace), idsType.IsSameType(libovsdbops.AddressSetEgressQoS):
+		// ObjectNameKey is namespace
+		_, err = bnc.watchFactory.GetNamespace(dbIDs.GetObjectID(libovsdbops.ObjectNameKey))
+	case idsType.IsSameType(libovsdbops.AddressSetHybridNodeRoute):
+		// ObjectNameKey is node name
+		_, err = bnc.watchFactory.GetNode(dbIDs.GetObjectID(libovsdbops.ObjectNameKey))
+	default:
+		return false, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// podIPQuarantineName returns the name of the quarantine list of the pod IPs of the network
+func (bnc *BaseNetworkController) podIPQuarantineName() string {
+	return "pod-ips." + bnc.GetNetworkName()
+}
+
+// startPodIPQuarantine makes the logical switch manager quarantine the IPs of the deleted
+// pods if pod-ip-quarantine-period is set, and releases them once their quarantine expires.
+// It must be called before the logical switches are added.
+func (bnc *BaseNetworkController) startPodIPQuarantine() error {
+	if config.Default.PodIPQuarantinePeriod <= 0 || !bnc.allocatesPodAnnotation() {
+		return nil
+	}
+	q := quarantine.NewList(bnc.podIPQuarantineName(),
+		time.Duration(config.Default.PodIPQuarantinePeriod)*time.Second,
+		quarantine.NewConfiguredStore(bnc.client), nil)
+	bnc.lsManager.SetQuarantine(q, config.Default.ReleaseQuarantineWhenExhausted)
+	if err := bnc.lsManager.RestoreQuarantine(); err != nil {
+		return fmt.Errorf("failed to restore the quarantined pod IPs of network %s: %w", bnc.GetNetworkName(), err)
+	}
+	bnc.wg.Add(1)
+	go func() {
+		defer bnc.wg.Done()
+		bnc.lsManager.RunQuarantine(bnc.stopChan)
+	}()
+	return nil
+}
+
+// purgePodIPQuarantine drops the quarantined pod IPs of the network from the quarantine store
+// once the network is gone
+func (bnc *BaseNetworkController) purgePodIPQuarantine() error {
+	if !bnc.allocatesPodAnnotation() {
+		return nil
+	}
+	return quarantine.NewList(bnc.podIPQuarantineName(), 0, quarantine.NewConfiguredStore(bnc.client), nil).Purge()
+}
+
+// registerNetworkMetrics registers the per-network metrics of this network controller
+func (bnc *BaseNetworkController) registerNetworkMetrics() {
+	funcs := metrics.NetworkMetricsFuncs{
+		LogicalSwitchPorts: func() float64 {
+			return float64(bnc.logicalPortCache.count())
+		},
+		AllocatedIPs: func() float64 {
+			allocated, _ := bnc.lsManager.GetIPUsage()
+			return float64(allocated)
+		},
+		AvailableIPs: func() float64 {
+			_, available := bnc.lsManager.GetIPUsage()
+			return float64(available)
+		},
+	}
+	if bnc.IsSecondary() {
+		funcs.NADs = func() float64 {
+			return float64(len(bnc.GetNADs()))
+		}
+	}
+	// layer2 and localnet networks have a single switch for all the nodes
+	if !bnc.IsSecondary() || bnc.TopologyType() == types.Layer3Topology {
+		funcs.NodeIPUsage = func() map[string]metrics.NodeIPUsage {
+			nodeIPUsage := map[string]metrics.NodeIPUsage{}
+			for switchName, usage := range bnc.lsManager.GetIPUsageBySwitch() {
+				// node switches are named after the node, scoped by network
+				nodeName := strings.TrimPrefix(switchName, bnc.GetPrefix())
+				nodeIPUsage[nodeName] = metrics.NodeIPUsage{
+					Allocated: float64(usage.Allocated),
+					Available: float64(usage.Available),
+				}
+			}
+			return nodeIPUsage
+		}
+	}
+	if config.Default.PodIPQuarantinePeriod > 0 && bnc.allocatesPodAnnotation() {
+		funcs.QuarantinedIPs = func() float64 {
+			return float64(bnc.lsManager.QuarantinedIPs())
+		}
+	}
+	metrics.RegisterNetworkMetrics(bnc.GetNetworkName(), funcs)
+}
+
+// unregisterNetworkMetrics unregisters the per-network metrics of this network controller
+func (bnc *BaseNetworkController) unregisterNetworkMetrics() {
+	metrics.UnregisterNetworkMetrics(bnc.GetNetworkName())
+}
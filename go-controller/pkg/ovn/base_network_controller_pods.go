@@ -318,7 +318,7 @@ func (bnc *BaseNetworkController) findPodWithIPAddresses(needleIPs []net.IP) (*k
 }
 
 func (bnc *BaseNetworkController) releasePodIPs(pInfo *lpInfo) error {
-	if err := bnc.lsManager.ReleaseIPs(pInfo.logicalSwitch, pInfo.ips); err != nil {
+	if err := bnc.lsManager.QuarantineIPs(pInfo.logicalSwitch, pInfo.ips); err != nil {
 		if !errors.Is(err, logicalswitchmanager.SwitchNotFound) {
 			return fmt.Errorf("cannot release IPs of port %s on switch %s: %w", pInfo.name, pInfo.logicalSwitch, err)
 		}
@@ -428,7 +428,7 @@ func (bnc *BaseNetworkController) addRoutesGatewayIP(pod *kapi.Pod, network *nad
 		}
 		var gatewayIP net.IP
 		if otherDefaultRoute {
-			for _, clusterSubnet := range config.Default.ClusterSubnets {
+			for _, clusterSubnet := range config.GetClusterSubnets() {
 				if isIPv6 == utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 					podAnnotation.Routes = append(podAnnotation.Routes, util.PodRoute{
 						Dest:    clusterSubnet.CIDR,
@@ -484,6 +484,25 @@ func (bnc *BaseNetworkController) getExpectedSwitchName(pod *kapi.Pod) (string,
 	return switchName, nil
 }
 
+// podRequestedChassis returns the chassis the logical switch port of the pod is bound to: the chassis
+// of the DPU owning the VF of the pod when its node has several DPUs, else the node of the pod
+func (bnc *BaseNetworkController) podRequestedChassis(pod *kapi.Pod, nadName string) (string, error) {
+	dpuCD, err := util.UnmarshalPodDPUConnDetails(pod.Annotations, nadName)
+	if err != nil || dpuCD.DPUName == "" {
+		return pod.Spec.NodeName, nil
+	}
+	node, err := bnc.watchFactory.GetNode(pod.Spec.NodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %s of pod %s/%s: %v", pod.Spec.NodeName, pod.Namespace, pod.Name, err)
+	}
+	chassisID, ok := util.ParseNodeDPUChassisIDs(node)[dpuCD.DPUName]
+	if !ok {
+		return "", fmt.Errorf("node %s has no chassis ID for DPU %s of pod %s/%s yet", node.Name, dpuCD.DPUName,
+			pod.Namespace, pod.Name)
+	}
+	return chassisID, nil
+}
+
 func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *kapi.Pod, nadName string,
 	network *nadapi.NetworkSelectionElement) (ops []ovsdb.Operation,
 	lsp *nbdb.LogicalSwitchPort, podAnnotation *util.PodAnnotation, newlyCreatedPort bool, err error) {
@@ -574,7 +593,19 @@ func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *kapi.Pod, nadName
 	// chassis if ovnkube-node isn't running correctly and hasn't cleared
 	// out iface-id for an old instance of this pod, and the pod got
 	// rescheduled.
-	lsp.Options["requested-chassis"] = pod.Spec.NodeName
+	lsp.Options["requested-chassis"], err = bnc.podRequestedChassis(pod, nadName)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	// Keep the multicast options of an existing port, they are set with the
+	// multicast policy of the namespace of the pod
+	if lspExist {
+		for _, option := range []string{mcastFloodOption, mcastFloodReportsOption} {
+			if value, ok := existingLSP.Options[option]; ok {
+				lsp.Options[option] = value
+			}
+		}
+	}
 
 	podAnnotation, err = util.UnmarshalPodAnnotation(pod.Annotations, nadName)
 
@@ -597,6 +628,12 @@ func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *kapi.Pod, nadName
 		}
 	}()
 
+	if err != nil && !bnc.allocatesPodAnnotation() {
+		// the pod annotation is allocated by cluster manager; retry once it is set
+		return nil, nil, nil, false, fmt.Errorf("waiting for cluster manager to allocate the pod annotation of pod %s: %w",
+			podDesc, err)
+	}
+
 	if err == nil {
 		podMac = podAnnotation.MAC
 		podIfAddrs = podAnnotation.IPs
@@ -605,7 +642,10 @@ func (bnc *BaseNetworkController) addLogicalPortToNetwork(pod *kapi.Pod, nadName
 		// IP/MAC from the annotation.
 		lsp.DynamicAddresses = nil
 
-		if bnc.doesNetworkRequireIPAM() {
+		if !bnc.allocatesPodAnnotation() {
+			// IPs are reserved by cluster manager
+			needsIP = false
+		} else if bnc.doesNetworkRequireIPAM() {
 			// ensure we have reserved the IPs in the annotation
 			if err = bnc.lsManager.AllocateIPs(switchName, podIfAddrs); err != nil && err != ipallocator.ErrAllocated {
 				return nil, nil, nil, false, fmt.Errorf("unable to ensure IPs allocated for already annotated pod: %s, IPs: %s, error: %v",
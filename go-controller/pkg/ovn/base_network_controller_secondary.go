@@ -47,6 +47,10 @@ func (bsnc *BaseSecondaryNetworkController) AddSecondaryNetworkResourceCommon(ob
 		}
 		return bsnc.ensurePodForSecondaryNetwork(pod, true)
 
+	case factory.NamespaceType:
+		// the multicast policy of a namespace is created with its first pod
+		return nil
+
 	default:
 		return fmt.Errorf("object type %s not supported", objType)
 	}
@@ -65,6 +69,9 @@ func (bsnc *BaseSecondaryNetworkController) UpdateSecondaryNetworkResourceCommon
 
 		return bsnc.ensurePodForSecondaryNetwork(newPod, inRetryCache || util.PodScheduled(oldPod) != util.PodScheduled(newPod))
 
+	case factory.NamespaceType:
+		return nil
+
 	default:
 		return fmt.Errorf("object type %s not supported", objType)
 	}
@@ -85,6 +92,13 @@ func (bsnc *BaseSecondaryNetworkController) DeleteSecondaryNetworkResourceCommon
 		}
 		return bsnc.removePodForSecondaryNetwork(pod, portInfoMap)
 
+	case factory.NamespaceType:
+		ns, ok := obj.(*kapi.Namespace)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *kapi.Namespace", obj)
+		}
+		return bsnc.deleteNamespaceMulticastPolicyForSecondaryNetwork(ns.Name)
+
 	default:
 		return fmt.Errorf("object type %s not supported", objType)
 	}
@@ -181,6 +195,13 @@ func (bsnc *BaseSecondaryNetworkController) addLogicalPortToNetworkForNAD(pod *k
 		return fmt.Errorf("UUID is empty from LSP: %+v", *lsp)
 	}
 
+	if bsnc.multicastSupport {
+		if err = bsnc.podAddAllowMulticastPolicyForSecondaryNetwork(pod.Namespace, lsp.UUID); err != nil {
+			return fmt.Errorf("failed to add pod %s/%s to the multicast policy of network %s: %v",
+				pod.Namespace, pod.Name, bsnc.GetNetworkName(), err)
+		}
+	}
+
 	_ = bsnc.logicalPortCache.add(pod, switchName, nadName, lsp.UUID, podAnnotation.MAC, podAnnotation.IPs)
 
 	if newlyCreated {
@@ -225,7 +246,7 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *ka
 			continue
 		}
 
-		if len(pInfo.ips) == 0 {
+		if len(pInfo.ips) == 0 || !bsnc.allocatesPodAnnotation() {
 			continue
 		}
 
@@ -242,6 +263,20 @@ func (bsnc *BaseSecondaryNetworkController) removePodForSecondaryNetwork(pod *ka
 	return nil
 }
 
+// WatchNamespaces starts the watching of the Namespace resource and calls back
+// the appropriate handler logic
+func (bsnc *BaseSecondaryNetworkController) WatchNamespaces() error {
+	if bsnc.namespaceHandler != nil {
+		return nil
+	}
+
+	handler, err := bsnc.retryNamespaces.WatchResource()
+	if err == nil {
+		bsnc.namespaceHandler = handler
+	}
+	return err
+}
+
 func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []interface{}) error {
 	// get the list of logical switch ports (equivalent to pods). Reserve all existing Pod IPs to
 	// avoid subsequent new Pods getting the same duplicate Pod IP.
@@ -267,7 +302,7 @@ func (bsnc *BaseSecondaryNetworkController) syncPodsForSecondaryNetwork(pods []i
 				}
 				continue
 			}
-			if bsnc.doesNetworkRequireIPAM() {
+			if bsnc.doesNetworkRequireIPAM() && bsnc.allocatesPodAnnotation() {
 				expectedLogicalPortName, err := bsnc.allocatePodIPs(pod, annotations, nadName)
 				if err != nil {
 					return err
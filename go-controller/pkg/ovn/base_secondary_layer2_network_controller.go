@@ -107,6 +107,9 @@ func (h *secondaryLayer2NetworkControllerEventHandler) SyncFunc(objs []interface
 		case factory.PodType:
 			syncFunc = h.oc.syncPodsForSecondaryNetwork
 
+		case factory.NamespaceType:
+			syncFunc = h.oc.syncNamespacesForSecondaryNetwork
+
 		default:
 			return fmt.Errorf("no sync function for object type %s", h.objType)
 		}
@@ -131,6 +134,9 @@ type BaseSecondaryLayer2NetworkController struct {
 
 func (oc *BaseSecondaryLayer2NetworkController) initRetryFramework() {
 	oc.retryPods = oc.newRetryFramework(factory.PodType)
+	if oc.multicastSupport {
+		oc.retryNamespaces = oc.newRetryFramework(factory.NamespaceType)
+	}
 }
 
 // newRetryFramework builds and returns a retry framework for the input resource type;
@@ -147,6 +153,7 @@ func (oc *BaseSecondaryLayer2NetworkController) newRetryFramework(
 		HasUpdateFunc:          hasResourceAnUpdateFunc(objectType),
 		NeedsUpdateDuringRetry: needsUpdateDuringRetry(objectType),
 		ObjType:                objectType,
+		NetworkName:            oc.GetNetworkName(),
 		EventHandler:           eventHandler,
 	}
 	return retry.NewRetryFramework(
@@ -162,10 +169,15 @@ func (oc *BaseSecondaryLayer2NetworkController) Stop() {
 	klog.Infof("Stop secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
 	close(oc.stopChan)
 	oc.wg.Wait()
+	oc.unregisterNetworkMetrics()
 
 	if oc.podHandler != nil {
 		oc.watchFactory.RemovePodHandler(oc.podHandler)
 	}
+
+	if oc.namespaceHandler != nil {
+		oc.watchFactory.RemoveNamespaceHandler(oc.namespaceHandler)
+	}
 }
 
 // cleanup cleans up logical entities for the given network, called from net-attach-def routine
@@ -182,11 +194,20 @@ func (oc *BaseSecondaryLayer2NetworkController) cleanup(topotype, netName string
 		return fmt.Errorf("failed to get ops for deleting switches of network %s: %v", netName, err)
 	}
 
+	// and the multicast port groups
+	ops, err = deleteSecondaryNetworkMulticastPolicyOps(oc.nbClient, ops, netName)
+	if err != nil {
+		return fmt.Errorf("failed to get ops for deleting port groups of network %s: %v", netName, err)
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
-		return fmt.Errorf("failed to deleting switches of network %s: %v", netName, err)
+		return fmt.Errorf("failed to deleting switches/port groups of network %s: %v", netName, err)
 	}
 
+	if err = oc.purgePodIPQuarantine(); err != nil {
+		return fmt.Errorf("failed to purge the quarantined pod IPs of network %s: %v", netName, err)
+	}
 	return nil
 }
 
@@ -194,6 +215,12 @@ func (oc *BaseSecondaryLayer2NetworkController) Run() error {
 	klog.Infof("Starting all the Watchers for network %s ...", oc.GetNetworkName())
 	start := time.Now()
 
+	if oc.multicastSupport {
+		if err := oc.WatchNamespaces(); err != nil {
+			return err
+		}
+	}
+
 	if err := oc.WatchPods(); err != nil {
 		return err
 	}
@@ -228,6 +255,22 @@ func (oc *BaseSecondaryLayer2NetworkController) InitializeLogicalSwitch(switchNa
 		}
 	}
 
+	// If supported, enable IGMP/MLD snooping and querier on the switch. There
+	// is no router on the network, so the querier sends proxy queries
+	// (RFC 4541) from an address derived from the network name: 0.0.0.0 for
+	// IGMP and a link local address for MLD.
+	if oc.multicastSupport {
+		if logicalSwitch.OtherConfig == nil {
+			logicalSwitch.OtherConfig = map[string]string{}
+		}
+		querierMAC := util.NetworkNameToHWAddr(oc.GetNetworkName())
+		logicalSwitch.OtherConfig["mcast_snoop"] = "true"
+		logicalSwitch.OtherConfig["mcast_querier"] = "true"
+		logicalSwitch.OtherConfig["mcast_eth_src"] = querierMAC.String()
+		logicalSwitch.OtherConfig["mcast_ip4_src"] = net.IPv4zero.String()
+		logicalSwitch.OtherConfig["mcast_ip6_src"] = util.HWAddrToIPv6LLA(querierMAC).String()
+	}
+
 	err := libovsdbops.CreateOrUpdateLogicalSwitch(oc.nbClient, &logicalSwitch, &logicalSwitch.OtherConfig, &logicalSwitch.ExternalIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logical switch %+v: %v", logicalSwitch, err)
@@ -0,0 +1,200 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// getClusterSubnetCIDRs returns the CIDRs of the current cluster subnets
+func getClusterSubnetCIDRs() []*net.IPNet {
+	entries := config.GetClusterSubnets()
+	clusterSubnets := make([]*net.IPNet, 0, len(entries))
+	for _, clusterSubnet := range entries {
+		clusterSubnets = append(clusterSubnets, clusterSubnet.CIDR)
+	}
+	return clusterSubnets
+}
+
+// syncClusterSubnets updates the logical network configuration derived from the cluster
+// subnets after they were changed at runtime: the gateway routers' routes and SNATs, the
+// egress IP no-reroute policies and the egress firewall ACLs.
+// Pods keep their routes to the cluster subnets until they are recreated.
+func (oc *DefaultNetworkController) syncClusterSubnets() {
+	select {
+	case <-oc.stopChan:
+		return
+	default:
+	}
+
+	clusterSubnets := getClusterSubnetCIDRs()
+	removedSubnets := []*net.IPNet{}
+	for _, previous := range oc.syncedClusterSubnets {
+		removed := true
+		for _, subnet := range clusterSubnets {
+			if subnet.String() == previous.String() {
+				removed = false
+				break
+			}
+		}
+		if removed {
+			removedSubnets = append(removedSubnets, previous)
+		}
+	}
+	klog.Infof("Cluster subnets changed from %v to %v, updating the network configuration",
+		oc.syncedClusterSubnets, clusterSubnets)
+	oc.syncedClusterSubnets = clusterSubnets
+
+	if err := oc.deleteClusterSubnetsPolicies(removedSubnets); err != nil {
+		klog.Errorf("Failed to delete no-reroute policies of removed cluster subnets %v: %v", removedSubnets, err)
+	}
+	if err := InitClusterEgressPolicies(oc.nbClient, oc.addressSetFactory, oc.controllerName); err != nil {
+		klog.Errorf("Failed to update no-reroute policies of cluster subnets %v: %v", clusterSubnets, err)
+	}
+
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		klog.Errorf("Failed to get nodes to update their gateways for cluster subnets %v: %v", clusterSubnets, err)
+	}
+	for _, node := range nodes {
+		if err := oc.deleteClusterSubnetsGatewayEntries(node.Name, removedSubnets); err != nil {
+			klog.Errorf("Failed to delete gateway entries of removed cluster subnets %v on node %s: %v",
+				removedSubnets, node.Name, err)
+		}
+		// the gateway sync adds the routes and SNATs of the new cluster subnets
+		oc.gatewaysFailed.Store(node.Name, true)
+		if err := oc.retryNodes.AddRetryObjWithAddNoBackoff(node); err != nil {
+			klog.Errorf("Failed to retry gateway sync of node %s: %v", node.Name, err)
+		}
+	}
+	oc.retryNodes.RequestRetryObjs()
+
+	if config.OVNKubernetesFeature.EnableEgressFirewall {
+		if err := oc.resyncEgressFirewalls(); err != nil {
+			klog.Errorf("Failed to update egress firewalls for cluster subnets %v: %v", clusterSubnets, err)
+		}
+	}
+}
+
+// deleteClusterSubnetsPolicies deletes the no-reroute policies of the given cluster subnets
+// from the cluster router
+func (oc *DefaultNetworkController) deleteClusterSubnetsPolicies(subnets []*net.IPNet) error {
+	if len(subnets) == 0 {
+		return nil
+	}
+	p := func(item *nbdb.LogicalRouterPolicy) bool {
+		if item.Priority != types.DefaultNoRereoutePriority {
+			return false
+		}
+		for _, subnet := range subnets {
+			ipPrefix := "ip4"
+			if utilnet.IsIPv6CIDR(subnet) {
+				ipPrefix = "ip6"
+			}
+			if strings.HasPrefix(item.Match, fmt.Sprintf("%s.src == %s ", ipPrefix, subnet)) {
+				return true
+			}
+		}
+		return false
+	}
+	return libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, types.OVNClusterRouter, p)
+}
+
+// deleteClusterSubnetsGatewayEntries deletes the routes and SNATs of the given cluster subnets
+// from the gateway router of a node
+func (oc *DefaultNetworkController) deleteClusterSubnetsGatewayEntries(nodeName string, subnets []*net.IPNet) error {
+	if len(subnets) == 0 {
+		return nil
+	}
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+		for _, subnet := range subnets {
+			if item.IPPrefix == subnet.String() {
+				return true
+			}
+		}
+		return false
+	}
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(oc.nbClient, gatewayRouter, p); err != nil {
+		return err
+	}
+	nats := make([]*nbdb.NAT, 0, len(subnets))
+	for _, subnet := range subnets {
+		nats = append(nats, libovsdbops.BuildSNAT(nil, subnet, "", nil))
+	}
+	logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+	return libovsdbops.DeleteNATs(oc.nbClient, &logicalRouter, nats...)
+}
+
+// resyncEgressFirewalls updates the matches of the egress firewall ACLs in place, since the rules
+// that match cluster subnets depend on them. All ACLs are updated in a single transaction.
+func (oc *DefaultNetworkController) resyncEgressFirewalls() error {
+	var ops []libovsdb.Operation
+	var errs []error
+	// keep the egress firewalls locked until their ACLs are updated
+	locked := []*egressFirewall{}
+	defer func() {
+		for _, ef := range locked {
+			ef.Unlock()
+		}
+	}()
+	oc.egressFirewalls.Range(func(_, value interface{}) bool {
+		ef := value.(*egressFirewall)
+		ef.Lock()
+		locked = append(locked, ef)
+		var err error
+		ops, err = oc.updateEgressFirewallACLsOps(ops, ef)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update the ACLs of egress firewall %s/%s: %w", ef.namespace, ef.name, err))
+		}
+		return true
+	})
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		errs = append(errs, fmt.Errorf("failed to transact egress firewall ACLs: %w", err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateEgressFirewallACLsOps returns the ops to update the ACLs of the rules of ef for the
+// current cluster subnets. ef must be locked.
+func (oc *DefaultNetworkController) updateEgressFirewallACLsOps(ops []libovsdb.Operation, ef *egressFirewall) ([]libovsdb.Operation, error) {
+	as, err := oc.addressSetFactory.EnsureAddressSet(getNamespaceAddrSetDbIDs(ef.namespace, oc.controllerName))
+	if err != nil {
+		return ops, err
+	}
+	ipv4HashedAS, ipv6HashedAS := as.GetASHashNames()
+	aclLoggingLevels := oc.GetNamespaceACLLogging(ef.namespace)
+	acls := []*nbdb.ACL{}
+	for _, rule := range ef.egressRules {
+		if rule.to.cidrSelector != "" {
+			_, ipNet, err := net.ParseCIDR(rule.to.cidrSelector)
+			if err != nil {
+				return ops, err
+			}
+			rule.to.clusterSubnetIntersection = intersectsClusterSubnets(ipNet)
+		}
+		match, err := oc.getEgressFirewallRuleMatch(ef.namespace, rule, ipv4HashedAS, ipv6HashedAS)
+		if err != nil {
+			return ops, err
+		}
+		if match == "" {
+			// rules without destination have no ACL
+			continue
+		}
+		acls = append(acls, buildEgressFirewallACL(types.EgressFirewallStartPriority-rule.id, match,
+			getEgressFirewallRuleAction(rule), ef.namespace, aclLoggingLevels))
+	}
+	// the ACLs are already in the cluster port group, only their match is updated
+	return libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, ops, acls...)
+}
@@ -0,0 +1,292 @@
+package dbgc
+
+import (
+	"fmt"
+	"time"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// deleteBatchSize is the maximum number of objects deleted in a single transaction
+const deleteBatchSize = 100
+
+// ObjectOwner is a controller that owns NB objects identified by libovsdbops.DbObjectIDs,
+// e.g. the default or a secondary network controller.
+type ObjectOwner interface {
+	// GetControllerName returns the owner controller name set in the ExternalIDs of the owned objects.
+	GetControllerName() string
+	// IsStaleObject returns true if the kubernetes object that the db object was created for doesn't exist anymore.
+	// It should return false for ObjectIDsTypes the owner doesn't know how to verify.
+	IsStaleObject(dbIDs *libovsdbops.DbObjectIDs) (bool, error)
+}
+
+// Controller periodically deletes NB objects left behind by their owners, e.g. because of a deleted network,
+// a sync that failed half-way or objects created by an older version.
+// Every run lists the objects of every registered ObjectIDsType and asks the owning controller whether the
+// referenced kubernetes object still exists. Objects owned by a controller that doesn't exist anymore are stale.
+// To avoid racing with the owners, an object is only deleted when it was found stale in two consecutive runs.
+//
+// Address sets are deleted. ACLs are removed from the port groups and logical switches that reference them,
+// the db server then garbage collects them as they are not referenced anymore.
+type Controller struct {
+	nbClient libovsdbclient.Client
+	// getOwners returns all running object owners
+	getOwners func() []ObjectOwner
+	interval  time.Duration
+	// dryRun only reports stale objects without deleting them
+	dryRun bool
+
+	// staleUUIDs stores the UUIDs of the objects found stale by the previous run, by object type
+	staleUUIDs map[string]sets.Set[string]
+}
+
+// dbObject is the part of an NB object the garbage collector works with
+type dbObject struct {
+	uuid        string
+	name        string
+	externalIDs map[string]string
+}
+
+// dbTable lists and deletes the objects of an NB table
+type dbTable struct {
+	name     string
+	idsTypes []*libovsdbops.ObjectIDsType
+	// list returns the objects of the table that were created with the given predicate
+	list func(p func(externalIDs map[string]string) bool) ([]dbObject, error)
+	// delete returns the ops to delete the objects with the given UUIDs
+	delete func(uuids []string) ([]libovsdb.Operation, error)
+}
+
+// NewController creates a garbage collector for objects owned by the controllers returned by getOwners.
+func NewController(nbClient libovsdbclient.Client, getOwners func() []ObjectOwner, interval time.Duration,
+	dryRun bool) *Controller {
+	return &Controller{
+		nbClient:   nbClient,
+		getOwners:  getOwners,
+		interval:   interval,
+		dryRun:     dryRun,
+		staleUUIDs: map[string]sets.Set[string]{},
+	}
+}
+
+// Run runs the garbage collector every interval until stopCh is closed.
+// The first run only happens after the first interval to let the owners sync their objects.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	klog.Infof("Starting NB garbage collector, interval %v, dry run %v", c.interval, c.dryRun)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.RunOnce(); err != nil {
+				klog.Errorf("NB garbage collection failed: %v", err)
+			}
+		case <-stopCh:
+			klog.Infof("Stopping NB garbage collector")
+			return
+		}
+	}
+}
+
+// RunOnce finds the stale objects and deletes the ones that were already found stale by the previous run.
+func (c *Controller) RunOnce() error {
+	startTime := time.Now()
+	klog.V(4).Infof("Starting NB garbage collection")
+	defer func() {
+		klog.V(4).Infof("Finished NB garbage collection: %v", time.Since(startTime))
+	}()
+
+	owners := map[string]ObjectOwner{}
+	for _, owner := range c.getOwners() {
+		owners[owner.GetControllerName()] = owner
+	}
+
+	staleUUIDs := map[string]sets.Set[string]{}
+	var errs []error
+	for _, table := range c.getTables() {
+		for _, idsType := range table.idsTypes {
+			objectType := table.name + ":" + idsType.GetOwnerType()
+			prevStale := c.staleUUIDs[objectType]
+			staleObjs, err := c.findStaleObjects(table, idsType, owners)
+			if err != nil {
+				// keep the previous candidates of this type, they will be confirmed by the next run
+				errs = append(errs, fmt.Errorf("failed to find stale %s objects: %w", objectType, err))
+				staleUUIDs[objectType] = prevStale
+				continue
+			}
+			metrics.RecordDBGCStaleObjects(objectType, len(staleObjs))
+
+			typeStale := sets.New[string]()
+			staleUUIDs[objectType] = typeStale
+			confirmed := []string{}
+			for _, obj := range staleObjs {
+				if !prevStale.Has(obj.uuid) {
+					typeStale.Insert(obj.uuid)
+					continue
+				}
+				if c.dryRun {
+					klog.Infof("NB garbage collector dry run: stale %s %s, external IDs %v", table.name, obj.name, obj.externalIDs)
+					// keep reporting them on every run
+					typeStale.Insert(obj.uuid)
+					continue
+				}
+				confirmed = append(confirmed, obj.uuid)
+			}
+			if len(confirmed) == 0 {
+				continue
+			}
+			deleted, err := c.deleteObjects(table, confirmed)
+			metrics.RecordDBGCCollectedObjects(objectType, deleted)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete stale %s objects: %w", objectType, err))
+				typeStale.Insert(confirmed[deleted:]...)
+			}
+			if deleted > 0 {
+				klog.Infof("NB garbage collector deleted %d stale %s objects", deleted, objectType)
+			}
+		}
+	}
+	c.staleUUIDs = staleUUIDs
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// getTables returns the NB tables handled by the garbage collector
+func (c *Controller) getTables() []dbTable {
+	return []dbTable{
+		{
+			name:     "AddressSet",
+			idsTypes: libovsdbops.GetAddressSetObjectIDsTypes(),
+			list: func(p func(externalIDs map[string]string) bool) ([]dbObject, error) {
+				addrSets, err := libovsdbops.FindAddressSetsWithPredicate(c.nbClient,
+					func(item *nbdb.AddressSet) bool { return p(item.ExternalIDs) })
+				if err != nil {
+					return nil, err
+				}
+				objs := make([]dbObject, 0, len(addrSets))
+				for _, as := range addrSets {
+					objs = append(objs, dbObject{uuid: as.UUID, name: as.Name, externalIDs: as.ExternalIDs})
+				}
+				return objs, nil
+			},
+			delete: func(uuids []string) ([]libovsdb.Operation, error) {
+				addrSets := make([]*nbdb.AddressSet, 0, len(uuids))
+				for _, uuid := range uuids {
+					addrSets = append(addrSets, &nbdb.AddressSet{UUID: uuid})
+				}
+				return libovsdbops.DeleteAddressSetsOps(c.nbClient, nil, addrSets...)
+			},
+		},
+		{
+			name:     "ACL",
+			idsTypes: libovsdbops.GetACLObjectIDsTypes(),
+			list: func(p func(externalIDs map[string]string) bool) ([]dbObject, error) {
+				acls, err := libovsdbops.FindACLsWithPredicate(c.nbClient,
+					func(item *nbdb.ACL) bool { return p(item.ExternalIDs) })
+				if err != nil {
+					return nil, err
+				}
+				objs := make([]dbObject, 0, len(acls))
+				for _, acl := range acls {
+					objs = append(objs, dbObject{uuid: acl.UUID, name: libovsdbops.GetACLName(acl), externalIDs: acl.ExternalIDs})
+				}
+				return objs, nil
+			},
+			delete: c.removeACLsOps,
+		},
+	}
+}
+
+// removeACLsOps returns the ops to remove the ACLs with the given UUIDs from all the port groups
+// and logical switches that reference them.
+func (c *Controller) removeACLsOps(uuids []string) ([]libovsdb.Operation, error) {
+	aclUUIDs := sets.New[string](uuids...)
+	acls := make([]*nbdb.ACL, 0, len(uuids))
+	for _, uuid := range uuids {
+		acls = append(acls, &nbdb.ACL{UUID: uuid})
+	}
+	pgs, err := libovsdbops.FindPortGroupsWithPredicate(c.nbClient, func(item *nbdb.PortGroup) bool {
+		return aclUUIDs.HasAny(item.ACLs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var ops []libovsdb.Operation
+	for _, pg := range pgs {
+		ops, err = libovsdbops.DeleteACLsFromPortGroupOps(c.nbClient, ops, pg.Name, acls...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return libovsdbops.RemoveACLsFromLogicalSwitchesWithPredicateOps(c.nbClient, ops, func(item *nbdb.LogicalSwitch) bool {
+		return aclUUIDs.HasAny(item.ACLs...)
+	}, acls...)
+}
+
+// findStaleObjects returns the objects of the given table and type that are owned by an unknown controller,
+// or that their owner reports as stale.
+func (c *Controller) findStaleObjects(table dbTable, idsType *libovsdbops.ObjectIDsType, owners map[string]ObjectOwner) ([]dbObject, error) {
+	objs, err := table.list(func(externalIDs map[string]string) bool {
+		return externalIDs[libovsdbops.OwnerTypeKey.String()] == idsType.GetOwnerType() &&
+			externalIDs[libovsdbops.OwnerControllerKey.String()] != ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	stale := []dbObject{}
+	for _, obj := range objs {
+		dbIDs, err := libovsdbops.NewDbObjectIDsFromExternalIDs(idsType, obj.externalIDs)
+		if err != nil {
+			klog.Warningf("Skipping %s %s with unexpected external IDs: %v", table.name, obj.name, err)
+			continue
+		}
+		owner, ok := owners[dbIDs.GetOwnerController()]
+		if !ok {
+			stale = append(stale, obj)
+			continue
+		}
+		isStale, err := owner.IsStaleObject(dbIDs)
+		if err != nil {
+			return nil, err
+		}
+		if isStale {
+			stale = append(stale, obj)
+		}
+	}
+	return stale, nil
+}
+
+// deleteObjects deletes the objects with the given UUIDs in batches of deleteBatchSize, and returns
+// the number of deleted objects. Objects are deleted in order, so that on error uuids[deleted:]
+// are the objects that were not deleted.
+func (c *Controller) deleteObjects(table dbTable, uuids []string) (int, error) {
+	deleted := 0
+	for deleted < len(uuids) {
+		end := deleted + deleteBatchSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		ops, err := table.delete(uuids[deleted:end])
+		if err != nil {
+			return deleted, err
+		}
+		if _, err = libovsdbops.TransactAndCheck(c.nbClient, ops); err != nil {
+			return deleted, err
+		}
+		deleted = end
+	}
+	return deleted, nil
+}
@@ -0,0 +1,222 @@
+package dbgc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+const (
+	controllerName        = "default-network-controller"
+	deletedControllerName = "deleted-network-controller"
+)
+
+// fakeOwner reports namespace address sets as stale when their namespace is not in namespaces,
+// and fails to verify egress IP address sets when egressIPErr is set
+type fakeOwner struct {
+	name        string
+	namespaces  map[string]bool
+	egressIPErr error
+}
+
+func (f *fakeOwner) GetControllerName() string {
+	return f.name
+}
+
+func (f *fakeOwner) IsStaleObject(dbIDs *libovsdbops.DbObjectIDs) (bool, error) {
+	if dbIDs.GetIDsType().IsSameType(libovsdbops.AddressSetEgressIP) {
+		return false, f.egressIPErr
+	}
+	if !dbIDs.GetIDsType().IsSameType(libovsdbops.AddressSetNamespace) {
+		return false, nil
+	}
+	return !f.namespaces[dbIDs.GetObjectID(libovsdbops.ObjectNameKey)], nil
+}
+
+func namespaceAddressSet(controller, namespace string) *nbdb.AddressSet {
+	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetNamespace, controller, map[libovsdbops.ExternalIDKey]string{
+		libovsdbops.ObjectNameKey:         namespace,
+		libovsdbops.AddressSetIPFamilyKey: "ipv4",
+	})
+	return &nbdb.AddressSet{
+		UUID:        controller + "-" + namespace + "-UUID",
+		Name:        controller + "-" + namespace,
+		ExternalIDs: dbIDs.GetExternalIDs(),
+	}
+}
+
+func egressIPAddressSet(controller string) *nbdb.AddressSet {
+	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetEgressIP, controller, map[libovsdbops.ExternalIDKey]string{
+		libovsdbops.ObjectNameKey:         "egressip-served-pods",
+		libovsdbops.AddressSetIPFamilyKey: "ipv4",
+	})
+	return &nbdb.AddressSet{
+		UUID:        controller + "-egressip-UUID",
+		Name:        controller + "-egressip",
+		ExternalIDs: dbIDs.GetExternalIDs(),
+	}
+}
+
+func netpolDefaultACL(controller string) *nbdb.ACL {
+	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolDefault, controller, map[libovsdbops.ExternalIDKey]string{
+		libovsdbops.ObjectNameKey:      "allow-hairpinning",
+		libovsdbops.PolicyDirectionKey: "Ingress",
+	})
+	acl := libovsdbops.BuildACL("", nbdb.ACLDirectionToLport, 1001, "ip4.src == 169.254.169.5", nbdb.ACLActionAllowRelated,
+		"", "", false, dbIDs.GetExternalIDs(), nil)
+	acl.UUID = controller + "-acl-UUID"
+	return acl
+}
+
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		desc       string
+		dryRun     bool
+		initialDb  []libovsdbtest.TestData
+		expectedDb []libovsdbtest.TestData
+	}{
+		{
+			desc: "address sets of existing objects are kept",
+			initialDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns1"),
+				egressIPAddressSet(controllerName),
+			},
+			expectedDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns1"),
+				egressIPAddressSet(controllerName),
+			},
+		},
+		{
+			desc: "address sets of deleted objects and unknown controllers are deleted",
+			initialDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns1"),
+				namespaceAddressSet(controllerName, "ns2"),
+				namespaceAddressSet(deletedControllerName, "ns1"),
+				egressIPAddressSet(controllerName),
+				egressIPAddressSet(deletedControllerName),
+			},
+			expectedDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns1"),
+				egressIPAddressSet(controllerName),
+			},
+		},
+		{
+			desc:   "dry run doesn't delete anything",
+			dryRun: true,
+			initialDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns2"),
+				egressIPAddressSet(deletedControllerName),
+			},
+			expectedDb: []libovsdbtest.TestData{
+				namespaceAddressSet(controllerName, "ns2"),
+				egressIPAddressSet(deletedControllerName),
+			},
+		},
+		{
+			desc: "ACLs of unknown controllers are removed from port groups and switches",
+			initialDb: []libovsdbtest.TestData{
+				netpolDefaultACL(controllerName),
+				netpolDefaultACL(deletedControllerName),
+				&nbdb.PortGroup{
+					UUID: "pg-UUID",
+					Name: "pg",
+					ACLs: []string{controllerName + "-acl-UUID", deletedControllerName + "-acl-UUID"},
+				},
+				&nbdb.LogicalSwitch{
+					UUID: "switch-UUID",
+					Name: "switch",
+					ACLs: []string{deletedControllerName + "-acl-UUID"},
+				},
+			},
+			expectedDb: []libovsdbtest.TestData{
+				netpolDefaultACL(controllerName),
+				// stale acl will be de-referenced, but the test server doesn't garbage collect it
+				netpolDefaultACL(deletedControllerName),
+				&nbdb.PortGroup{
+					UUID: "pg-UUID",
+					Name: "pg",
+					ACLs: []string{controllerName + "-acl-UUID"},
+				},
+				&nbdb.LogicalSwitch{
+					UUID: "switch-UUID",
+					Name: "switch",
+				},
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.desc), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: tt.initialDb}, nil)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			t.Cleanup(cleanup.Cleanup)
+
+			owner := &fakeOwner{name: controllerName, namespaces: map[string]bool{"ns1": true}}
+			gc := NewController(nbClient, func() []ObjectOwner { return []ObjectOwner{owner} }, 0, tt.dryRun)
+
+			// stale objects are only deleted by the second run
+			g.Expect(gc.RunOnce()).To(gomega.Succeed())
+			g.Expect(nbClient).To(libovsdbtest.HaveData(tt.initialDb))
+			g.Expect(gc.RunOnce()).To(gomega.Succeed())
+			g.Expect(nbClient).To(libovsdbtest.HaveData(tt.expectedDb))
+		})
+	}
+}
+
+func TestRunOnceObjectRecovered(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	initialDb := []libovsdbtest.TestData{
+		namespaceAddressSet(controllerName, "ns1"),
+	}
+	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: initialDb}, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	t.Cleanup(cleanup.Cleanup)
+
+	owner := &fakeOwner{name: controllerName, namespaces: map[string]bool{}}
+	gc := NewController(nbClient, func() []ObjectOwner { return []ObjectOwner{owner} }, 0, false)
+
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	// the namespace shows up before the object was confirmed stale
+	owner.namespaces["ns1"] = true
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	owner.namespaces["ns1"] = false
+	// a single run is not enough to confirm the object is stale again
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	g.Expect(nbClient).To(libovsdbtest.HaveData(initialDb))
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	g.Expect(nbClient).To(libovsdbtest.HaveData([]libovsdbtest.TestData{}))
+}
+
+func TestRunOnceKeepsOnlyFailedTypeCandidates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	initialDb := []libovsdbtest.TestData{
+		namespaceAddressSet(controllerName, "ns1"),
+		egressIPAddressSet(controllerName),
+	}
+	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: initialDb}, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	t.Cleanup(cleanup.Cleanup)
+
+	owner := &fakeOwner{name: controllerName, namespaces: map[string]bool{}}
+	gc := NewController(nbClient, func() []ObjectOwner { return []ObjectOwner{owner} }, 0, false)
+
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	// the namespace shows up while egress IP address sets can't be verified
+	owner.namespaces["ns1"] = true
+	owner.egressIPErr = fmt.Errorf("failed")
+	g.Expect(gc.RunOnce()).NotTo(gomega.Succeed())
+	// the namespace address set was found live, the failed type must not carry it over
+	owner.namespaces["ns1"] = false
+	owner.egressIPErr = nil
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	g.Expect(nbClient).To(libovsdbtest.HaveData(initialDb))
+	g.Expect(gc.RunOnce()).To(gomega.Succeed())
+	g.Expect(nbClient).To(libovsdbtest.HaveData([]libovsdbtest.TestData{
+		egressIPAddressSet(controllerName),
+	}))
+}
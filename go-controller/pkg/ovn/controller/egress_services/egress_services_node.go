@@ -321,7 +321,7 @@ func (c *Controller) nodeStateFor(name string) (*nodeState, error) {
 	v4NodeAddr, v6NodeAddr := util.GetNodeInternalAddrs(node)
 
 	return &nodeState{name: name, mgmtIPs: mgmtIPs, v4MgmtIP: v4IP, v6MgmtIP: v6IP, v4InternalNodeIP: v4NodeAddr, v6InternalNodeIP: v6NodeAddr,
-		healthClient: healthcheck.NewEgressIPHealthClient(name), allocations: map[string]*svcState{}, labels: node.Labels,
+		healthClient: healthcheck.NewEgressIPHealthClient(name, nil), allocations: map[string]*svcState{}, labels: node.Labels,
 		reachable: true, draining: false}, nil
 }
 
@@ -340,11 +340,11 @@ func (c *Controller) cachedNodesFor(selector labels.Selector) (sets.Set[string],
 	return names, states
 }
 
-// Returns if the given node is in "Ready" state.
+// Returns if the given node is in "Ready" state and didn't report that it lost its external connectivity.
 func nodeIsReady(n *corev1.Node) bool {
 	for _, condition := range n.Status.Conditions {
 		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
-			return true
+			return util.IsNodeExternalConnectivityAvailable(n)
 		}
 	}
 	return false
@@ -12,6 +12,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -94,9 +95,10 @@ func newNodeTracker(nodeInformer coreinformers.NodeInformer) (*nodeTracker, erro
 			}
 
 			// updateNode needs to be called only when hostSubnet annotation has changed or
-			// if L3Gateway annotation's ip addresses have changed or the name of the node (very rare)
+			// if L3Gateway or EgressInterfaces annotation's ip addresses have changed or the name of the node (very rare)
 			// has changed. No need to trigger update for any other field change.
-			if util.NodeSubnetAnnotationChanged(oldObj, newObj) || util.NodeL3GatewayAnnotationChanged(oldObj, newObj) || oldObj.Name != newObj.Name {
+			if util.NodeSubnetAnnotationChanged(oldObj, newObj) || util.NodeL3GatewayAnnotationChanged(oldObj, newObj) ||
+				util.NodeEgressInterfacesAnnotationChanged(oldObj, newObj) || oldObj.Name != newObj.Name {
 				nt.updateNode(newObj)
 			}
 		},
@@ -199,6 +201,16 @@ func (nt *nodeTracker) updateNode(node *v1.Node) {
 			for _, ip := range gwConf.IPAddresses {
 				ips = append(ips, ip.IP.String())
 			}
+			// services are also reachable through the gateway bridges of the EgressInterfaces
+			egressInterfaces, err := util.ParseNodeEgressInterfaces(node)
+			if err != nil && !util.IsAnnotationNotSetError(err) {
+				klog.Warningf("Node %s has invalid EgressInterfaces config: %v", node.Name, err)
+			}
+			for _, name := range sets.List(sets.KeySet(egressInterfaces)) {
+				for _, ip := range egressInterfaces[name].IPAddresses {
+					ips = append(ips, ip.IP.String())
+				}
+			}
 		}
 	}
 
@@ -3,6 +3,7 @@ package ovn
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"time"
@@ -10,8 +11,10 @@ import (
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressinterfacelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/listers/egressinterface/v1"
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	egressqoslisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/listers/egressqos/v1"
+	mcastpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -68,6 +71,17 @@ type DefaultNetworkController struct {
 	egressQoSNodeSynced cache.InformerSynced
 	egressQoSNodeQueue  workqueue.RateLimitingInterface
 
+	// EgressInterface
+	egressInterfaceLister          egressinterfacelisters.EgressInterfaceLister
+	egressInterfaceSynced          cache.InformerSynced
+	egressInterfaceNamespaceLister corev1listers.NamespaceLister
+	egressInterfaceNamespaceSynced cache.InformerSynced
+	egressInterfacePodLister       corev1listers.PodLister
+	egressInterfacePodSynced       cache.InformerSynced
+	egressInterfaceNodeLister      corev1listers.NodeLister
+	egressInterfaceNodeSynced      cache.InformerSynced
+	egressInterfaceNodeQueue       workqueue.RateLimitingInterface
+
 	// network policies map, key should be retrieved with getPolicyKey(policy *knet.NetworkPolicy).
 	// network policies that failed to be created will also be added here, and can be retried or cleaned up later.
 	// network policy is only deleted from this map after successful cleanup.
@@ -105,14 +119,15 @@ type DefaultNetworkController struct {
 	// Is ACL logging enabled while configuring meters?
 	aclLoggingEnabled bool
 
-	joinSwIPManager *lsm.JoinSwitchIPManager
-
 	// retry framework for network policies
 	retryNetworkPolicies *retry.RetryFramework
 
 	// retry framework for egress firewall
 	retryEgressFirewalls *retry.RetryFramework
 
+	// retry framework for multicast policies
+	retryMulticastPolicies *retry.RetryFramework
+
 	// retry framework for egress IP
 	retryEgressIPs *retry.RetryFramework
 	// retry framework for egress IP Namespaces
@@ -132,6 +147,8 @@ type DefaultNetworkController struct {
 	addNodeFailed               sync.Map
 	nodeClusterRouterPortFailed sync.Map
 	hybridOverlayFailed         sync.Map
+	// gateway mode each node's gateway was last synced in, used to migrate a node between gateway modes
+	gatewayModes sync.Map
 
 	// retry framework for Cloud private IP config
 	retryCloudPrivateIPConfig *retry.RetryFramework
@@ -142,6 +159,13 @@ type DefaultNetworkController struct {
 	// variable to determine if all pods present on the node during startup have been processed
 	// updated atomically
 	allInitialPodsProcessed uint32
+
+	// syncedClusterSubnets are the cluster subnets the network was last configured for,
+	// used to clean up after cluster subnets are removed at runtime
+	syncedClusterSubnets []*net.IPNet
+
+	// unregisterReloadHandlers unregister the config reload handlers of the controller on Stop
+	unregisterReloadHandlers []func()
 }
 
 // NewDefaultNetworkController creates a new OVN controller for creating logical network
@@ -197,13 +221,17 @@ func newDefaultNetworkControllerCommon(cnci *CommonNetworkControllerInfo,
 			nbClient:                          cnci.nbClient,
 			watchFactory:                      cnci.watchFactory,
 			egressIPTotalTimeout:              config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout,
-			reachabilityCheckInterval:         egressIPReachabilityCheckInterval,
+			reachabilityCheckInterval:         time.Duration(config.OVNKubernetesFeature.EgressIPReachabilityCheckInterval) * time.Second,
+			reachabilityCheckJitter:           float64(config.OVNKubernetesFeature.EgressIPReachabilityCheckJitter) / 100,
+			reachabilityFailureThreshold:      config.OVNKubernetesFeature.EgressIPReachabilityFailureThreshold,
+			egressNodeWatchFailures:           make(chan string, egressNodeWatchFailuresSize),
 			egressIPNodeHealthCheckPort:       config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
+			assignmentFailures:                make(map[string]map[string]string),
+			rebalanceInterval:                 time.Duration(config.OVNKubernetesFeature.EgressIPRebalanceInterval) * time.Second,
 		},
 		loadbalancerClusterCache: make(map[kapi.Protocol]string),
 		loadBalancerGroupUUID:    "",
 		aclLoggingEnabled:        true,
-		joinSwIPManager:          nil,
 		svcController:            svcController,
 		svcFactory:               svcFactory,
 		egressSvcController:      egressSvcController,
@@ -220,6 +248,7 @@ func (oc *DefaultNetworkController) initRetryFramework() {
 	oc.retryNetworkPolicies = oc.newRetryFrameworkWithParameters(factory.PolicyType, nil, nil)
 	oc.retryNodes = oc.newRetryFrameworkWithParameters(factory.NodeType, nil, nil)
 	oc.retryEgressFirewalls = oc.newRetryFrameworkWithParameters(factory.EgressFirewallType, nil, nil)
+	oc.retryMulticastPolicies = oc.newRetryFrameworkWithParameters(factory.MulticastPolicyType, nil, nil)
 	oc.retryEgressIPs = oc.newRetryFrameworkWithParameters(factory.EgressIPType, nil, nil)
 	oc.retryEgressIPNamespaces = oc.newRetryFrameworkWithParameters(factory.EgressIPNamespaceType, nil, nil)
 	oc.retryEgressIPPods = oc.newRetryFrameworkWithParameters(factory.EgressIPPodType, nil, nil)
@@ -258,6 +287,7 @@ func (oc *DefaultNetworkController) newRetryFrameworkWithParameters(
 		HasUpdateFunc:          hasResourceAnUpdateFunc(objectType),
 		NeedsUpdateDuringRetry: needsUpdateDuringRetry(objectType),
 		ObjType:                objectType,
+		NetworkName:            oc.GetNetworkName(),
 		EventHandler:           eventHandler,
 	}
 	r := retry.NewRetryFramework(
@@ -288,17 +318,57 @@ func (oc *DefaultNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("cleaning up stale pod selector address sets failed: %w", err)
 	}
 
+	if err = oc.startPodIPQuarantine(); err != nil {
+		return err
+	}
 	if err = oc.Init(); err != nil {
 		return err
 	}
+	oc.registerNetworkMetrics()
 
 	return oc.Run(ctx)
 }
 
 // Stop gracefully stops the controller
 func (oc *DefaultNetworkController) Stop() {
+	for _, unregister := range oc.unregisterReloadHandlers {
+		unregister()
+	}
 	close(oc.stopChan)
 	oc.wg.Wait()
+	oc.unregisterNetworkMetrics()
+}
+
+// IsStaleObject returns true if the kubernetes object the given db object was created for doesn't exist anymore,
+// or if the controller doesn't use the given db object.
+func (oc *DefaultNetworkController) IsStaleObject(dbIDs *libovsdbops.DbObjectIDs) (bool, error) {
+	name := dbIDs.GetObjectID(libovsdbops.ObjectNameKey)
+	switch idsType := dbIDs.GetIDsType(); {
+	case idsType.IsSameType(libovsdbops.AddressSetPodSelector):
+		// ObjectNameKey is the pod selector key, the address set exists as long as a network policy uses it
+		_, found := oc.podSelectorAddressSets.Load(name)
+		return !found, nil
+	case idsType.IsSameType(libovsdbops.AddressSetEgressIP):
+		// cluster-wide address sets
+		return name != string(NodeIPAddrSetName) && name != string(EgressIPServedPodsAddrSetName), nil
+	case idsType.IsSameType(libovsdbops.AddressSetEgressService):
+		// cluster-wide address set
+		return name != egresssvc.EgressServiceServedPodsAddrSetName, nil
+	case idsType.IsSameType(libovsdbops.AddressSetEgressFirewallDNS):
+		// ObjectNameKey is the dns name, the address set exists as long as an egress firewall uses it
+		if !config.OVNKubernetesFeature.EnableEgressFirewall {
+			return true, nil
+		}
+		if oc.egressFirewallDNS == nil {
+			// egress firewall is not started yet
+			return false, nil
+		}
+		return !oc.egressFirewallDNS.hasDNSName(name), nil
+	case idsType.IsSameType(libovsdbops.ACLNetpolDefault):
+		return name != allowHairpinningACLID, nil
+	default:
+		return oc.BaseNetworkController.IsStaleObject(dbIDs)
+	}
 }
 
 // Init runs a subnet IPAM and a controller that watches arrival/departure
@@ -328,8 +398,21 @@ func (oc *DefaultNetworkController) Init() error {
 		klog.Warningf("ACL logging support enabled, however acl-logging meter could not be created: %v. "+
 			"Disabling ACL logging support", err)
 		oc.aclLoggingEnabled = false
+	} else {
+		// the rate limit may be changed in the config file while running
+		unregister := config.OnReload(config.ReloadableACLLoggingRateLimit, func() {
+			if err := oc.createACLLoggingMeter(); err != nil {
+				klog.Errorf("Failed to update acl-logging meter rate to %d: %v", config.GetACLLoggingRateLimit(), err)
+			}
+		})
+		oc.unregisterReloadHandlers = append(oc.unregisterReloadHandlers, unregister)
 	}
 
+	// cluster subnets may be added and removed while running
+	oc.syncedClusterSubnets = getClusterSubnetCIDRs()
+	oc.unregisterReloadHandlers = append(oc.unregisterReloadHandlers,
+		config.OnReload(config.ReloadableClusterSubnets, oc.syncClusterSubnets))
+
 	// FIXME: When https://github.com/ovn-org/libovsdb/issues/235 is fixed,
 	// use IsTableSupported(nbdb.LoadBalancerGroup).
 	if _, _, err := util.RunOVNNbctl("--columns=_uuid", "list", "Load_Balancer_Group"); err != nil {
@@ -346,11 +429,7 @@ func (oc *DefaultNetworkController) Init() error {
 		oc.loadBalancerGroupUUID = loadBalancerGroup.UUID
 	}
 
-	nodeNames := []string{}
-	for _, node := range existingNodes.Items {
-		nodeNames = append(nodeNames, node.Name)
-	}
-	if err := oc.SetupMaster(nodeNames); err != nil {
+	if err := oc.SetupMaster(); err != nil {
 		klog.Errorf("Failed to setup master (%v)", err)
 		return err
 	}
@@ -402,6 +481,13 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 		return err
 	}
 
+	// WatchMulticastPolicy depends on WatchPods and WatchNamespaces
+	if oc.multicastSupport && config.OVNKubernetesFeature.EnableMulticastPolicy {
+		if err := WithSyncDurationMetric("multicast policy", oc.WatchMulticastPolicy); err != nil {
+			return err
+		}
+	}
+
 	if config.OVNKubernetesFeature.EnableEgressIP {
 		// This is probably the best starting order for all egress IP handlers.
 		// WatchEgressIPNamespaces and WatchEgressIPPods only use the informer
@@ -470,6 +556,22 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 		}()
 	}
 
+	if config.OVNKubernetesFeature.EnableEgressInterface {
+		err := oc.initEgressInterfaceController(
+			oc.watchFactory.EgressInterfaceInformer(),
+			oc.watchFactory.NamespaceCoreInformer(),
+			oc.watchFactory.PodCoreInformer(),
+			oc.watchFactory.NodeCoreInformer())
+		if err != nil {
+			return err
+		}
+		oc.wg.Add(1)
+		go func() {
+			defer oc.wg.Done()
+			oc.runEgressInterfaceController(1, oc.stopChan)
+		}()
+	}
+
 	oc.wg.Add(1)
 	go func() {
 		defer oc.wg.Done()
@@ -727,6 +829,10 @@ func (h *defaultNetworkControllerEventHandler) AddResource(obj interface{}, from
 		}
 		return err
 
+	case factory.MulticastPolicyType:
+		mcastPolicy := obj.(*mcastpolicyapi.MulticastPolicy)
+		return h.oc.reconcileMulticastPolicy(mcastPolicy)
+
 	case factory.EgressIPType:
 		eIP := obj.(*egressipv1.EgressIP)
 		return h.oc.reconcileEgressIP(nil, eIP)
@@ -814,11 +920,13 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 		_, failed := h.oc.nodeClusterRouterPortFailed.Load(newNode.Name)
 		clusterRtrSync := failed || nodeChassisChanged(oldNode, newNode) || nodeSubnetChanged(oldNode, newNode)
 		_, failed = h.oc.mgmtPortFailed.Load(newNode.Name)
-		mgmtSync := failed || macAddressChanged(oldNode, newNode) || nodeSubnetChanged(oldNode, newNode)
+		mgmtSync := failed || macAddressChanged(oldNode, newNode) || nodeSubnetChanged(oldNode, newNode) ||
+			nodeGatewayModeChanged(oldNode, newNode)
 		_, failed = h.oc.gatewaysFailed.Load(newNode.Name)
 		gwSync := (failed || gatewayChanged(oldNode, newNode) ||
 			nodeSubnetChanged(oldNode, newNode) || hostAddressesChanged(oldNode, newNode) ||
-			nodeGatewayMTUSupportChanged(oldNode, newNode))
+			nodeGatewayMTUSupportChanged(oldNode, newNode) || nodeGatewayRouterLRPAddrsChanged(oldNode, newNode) ||
+			util.NodeEgressInterfacesAnnotationChanged(oldNode, newNode))
 		_, hoSync := h.oc.hybridOverlayFailed.Load(newNode.Name)
 
 		return h.oc.addUpdateNodeEvent(newNode, &nodeSyncs{nodeSync, clusterRtrSync, mgmtSync, gwSync, hoSync})
@@ -938,6 +1046,10 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 	case factory.NamespaceType:
 		oldNs, newNs := oldObj.(*kapi.Namespace), newObj.(*kapi.Namespace)
 		return h.oc.updateNamespace(oldNs, newNs)
+
+	case factory.MulticastPolicyType:
+		newMcastPolicy := newObj.(*mcastpolicyapi.MulticastPolicy)
+		return h.oc.reconcileMulticastPolicy(newMcastPolicy)
 	}
 	return fmt.Errorf("no update function for object type %s", h.objType)
 }
@@ -998,6 +1110,10 @@ func (h *defaultNetworkControllerEventHandler) DeleteResource(obj, cachedObj int
 		metrics.DecrementEgressFirewallCount()
 		return nil
 
+	case factory.MulticastPolicyType:
+		mcastPolicy := obj.(*mcastpolicyapi.MulticastPolicy)
+		return h.oc.reconcileMulticastPolicy(mcastPolicy)
+
 	case factory.EgressIPType:
 		eIP := obj.(*egressipv1.EgressIP)
 		return h.oc.reconcileEgressIP(eIP, nil)
@@ -1070,6 +1186,9 @@ func (h *defaultNetworkControllerEventHandler) SyncFunc(objs []interface{}) erro
 		case factory.EgressFirewallType:
 			syncFunc = h.oc.syncEgressFirewall
 
+		case factory.MulticastPolicyType:
+			syncFunc = nil
+
 		case factory.EgressIPNamespaceType:
 			syncFunc = h.oc.syncEgressIPs
 
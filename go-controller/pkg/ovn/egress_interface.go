@@ -0,0 +1,686 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressinterfaceapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1"
+	egressinterfaceinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/informers/externalversions/egressinterface/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	maxEgressInterfaceRetries = 10
+	// egressInterfaceExternalIDKey is the external ID holding the name of the EgressInterface
+	// that a gateway router policy or SNAT belongs to
+	egressInterfaceExternalIDKey = "EgressInterface"
+	// egressInterfaceNodeExternalIDKey is the external ID holding the name of the node of the
+	// gateway router that a policy belongs to
+	egressInterfaceNodeExternalIDKey = "node"
+)
+
+// The EgressInterface controller steers the egress traffic of the pods of the namespaces
+// selected by an EgressInterface out of the gateway bridge that ovnkube-node sets up for it,
+// instead of the default gateway bridge. The bridges are connected to the gateway routers by
+// syncGatewayLogicalNetwork from the egress interfaces annotation of the nodes. For each node,
+// the controller reroutes on the gateway router the traffic of the local pods of the selected
+// namespaces to the next hop of the bridge, and SNATs it to the IP of the bridge. It is only
+// effective in shared gateway mode, in local gateway mode the egress traffic of the pods leaves
+// through the host routing table.
+
+func (oc *DefaultNetworkController) initEgressInterfaceController(
+	eifInformer egressinterfaceinformer.EgressInterfaceInformer,
+	namespaceInformer v1coreinformers.NamespaceInformer,
+	podInformer v1coreinformers.PodInformer,
+	nodeInformer v1coreinformers.NodeInformer) error {
+	klog.Info("Setting up event handlers for EgressInterface")
+	oc.egressInterfaceLister = eifInformer.Lister()
+	oc.egressInterfaceSynced = eifInformer.Informer().HasSynced
+	oc.egressInterfaceNamespaceLister = namespaceInformer.Lister()
+	oc.egressInterfaceNamespaceSynced = namespaceInformer.Informer().HasSynced
+	oc.egressInterfacePodLister = podInformer.Lister()
+	oc.egressInterfacePodSynced = podInformer.Informer().HasSynced
+	oc.egressInterfaceNodeLister = nodeInformer.Lister()
+	oc.egressInterfaceNodeSynced = nodeInformer.Informer().HasSynced
+	// the gateway router of each node is reconciled as a whole, all the events are
+	// queued as the nodes they affect
+	oc.egressInterfaceNodeQueue = workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemFastSlowRateLimiter(1*time.Second, 5*time.Second, 5),
+		"egressinterfacenodes",
+	)
+
+	_, err := eifInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { oc.queueAllEgressInterfaceNodes() },
+		UpdateFunc: oc.onEgressInterfaceUpdate,
+		DeleteFunc: func(obj interface{}) { oc.queueAllEgressInterfaceNodes() },
+	}))
+	if err != nil {
+		return fmt.Errorf("could not add Event Handler for eifInformer during egressInterfaceController initialization, %w", err)
+	}
+
+	_, err = namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { oc.queueAllEgressInterfaceNodes() },
+		UpdateFunc: oc.onEgressInterfaceNamespaceUpdate,
+		DeleteFunc: func(obj interface{}) { oc.queueAllEgressInterfaceNodes() },
+	})
+	if err != nil {
+		return fmt.Errorf("could not add Event Handler for namespaceInformer during egressInterfaceController initialization, %w", err)
+	}
+
+	_, err = podInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    oc.onEgressInterfacePodAdd,
+		UpdateFunc: oc.onEgressInterfacePodUpdate,
+		DeleteFunc: oc.onEgressInterfacePodDelete,
+	}))
+	if err != nil {
+		return fmt.Errorf("could not add Event Handler for podInformer during egressInterfaceController initialization, %w", err)
+	}
+
+	_, err = nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    oc.onEgressInterfaceNodeAdd,
+		UpdateFunc: oc.onEgressInterfaceNodeUpdate,
+		DeleteFunc: func(obj interface{}) {}, // the gateway router is deleted with the node
+	})
+	if err != nil {
+		return fmt.Errorf("could not add Event Handler for nodeInformer during egressInterfaceController initialization, %w", err)
+	}
+	return nil
+}
+
+func (oc *DefaultNetworkController) runEgressInterfaceController(threadiness int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+
+	klog.Infof("Starting EgressInterface Controller")
+
+	if !cache.WaitForNamedCacheSync("egressinterfaces", stopCh, oc.egressInterfaceSynced, oc.egressInterfaceNamespaceSynced,
+		oc.egressInterfacePodSynced, oc.egressInterfaceNodeSynced) {
+		utilruntime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		klog.Infof("Synchronization failed")
+		return
+	}
+
+	klog.Infof("Repairing EgressInterfaces")
+	err := oc.repairEgressInterfaces()
+	if err != nil {
+		klog.Errorf("Failed to delete stale EgressInterface entries: %v", err)
+	}
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < threadiness; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait.Until(func() {
+				oc.runEgressInterfaceNodeWorker(wg)
+			}, time.Second, stopCh)
+		}()
+	}
+
+	// wait until we're told to stop
+	<-stopCh
+
+	klog.Infof("Shutting down EgressInterface controller")
+	oc.egressInterfaceNodeQueue.ShutDown()
+
+	wg.Wait()
+}
+
+// queueAllEgressInterfaceNodes queues all the nodes for processing.
+func (oc *DefaultNetworkController) queueAllEgressInterfaceNodes() {
+	nodes, err := oc.egressInterfaceNodeLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't list nodes: %v", err))
+		return
+	}
+	for _, node := range nodes {
+		oc.egressInterfaceNodeQueue.Add(node.Name)
+	}
+}
+
+// onEgressInterfaceUpdate queues all the nodes for processing when the EgressInterface spec changes.
+func (oc *DefaultNetworkController) onEgressInterfaceUpdate(oldObj, newObj interface{}) {
+	oldEIF := oldObj.(*egressinterfaceapi.EgressInterface)
+	newEIF := newObj.(*egressinterfaceapi.EgressInterface)
+
+	if oldEIF.ResourceVersion == newEIF.ResourceVersion ||
+		!newEIF.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	oc.queueAllEgressInterfaceNodes()
+}
+
+// onEgressInterfaceNamespaceUpdate queues all the nodes for processing when the labels of the
+// namespace change, as the namespace may be selected by different EgressInterfaces.
+func (oc *DefaultNetworkController) onEgressInterfaceNamespaceUpdate(oldObj, newObj interface{}) {
+	oldNamespace := oldObj.(*kapi.Namespace)
+	newNamespace := newObj.(*kapi.Namespace)
+
+	if labels.Equals(oldNamespace.Labels, newNamespace.Labels) {
+		return
+	}
+	oc.queueAllEgressInterfaceNodes()
+}
+
+// onEgressInterfacePodAdd queues the node of the pod for processing.
+func (oc *DefaultNetworkController) onEgressInterfacePodAdd(obj interface{}) {
+	pod := obj.(*kapi.Pod)
+	if pod.Spec.NodeName == "" || util.PodWantsHostNetwork(pod) {
+		return
+	}
+	oc.egressInterfaceNodeQueue.Add(pod.Spec.NodeName)
+}
+
+// onEgressInterfacePodUpdate queues the node of the pod for processing when the pod IPs
+// or the pod state change.
+func (oc *DefaultNetworkController) onEgressInterfacePodUpdate(oldObj, newObj interface{}) {
+	oldPod := oldObj.(*kapi.Pod)
+	newPod := newObj.(*kapi.Pod)
+
+	if oldPod.ResourceVersion == newPod.ResourceVersion ||
+		newPod.Spec.NodeName == "" || util.PodWantsHostNetwork(newPod) {
+		return
+	}
+
+	oldPodIPs, _ := util.GetPodIPsOfNetwork(oldPod, oc.NetInfo)
+	newPodIPs, _ := util.GetPodIPsOfNetwork(newPod, oc.NetInfo)
+	if oldPod.Spec.NodeName == newPod.Spec.NodeName &&
+		util.PodCompleted(oldPod) == util.PodCompleted(newPod) &&
+		len(oldPodIPs) == len(newPodIPs) {
+		return
+	}
+	oc.egressInterfaceNodeQueue.Add(newPod.Spec.NodeName)
+}
+
+// onEgressInterfacePodDelete queues the node of the pod for processing.
+func (oc *DefaultNetworkController) onEgressInterfacePodDelete(obj interface{}) {
+	pod, ok := obj.(*kapi.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %#v", obj))
+			return
+		}
+		pod, ok = tombstone.Obj.(*kapi.Pod)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a Pod: %#v", obj))
+			return
+		}
+	}
+	if pod.Spec.NodeName == "" || util.PodWantsHostNetwork(pod) {
+		return
+	}
+	oc.egressInterfaceNodeQueue.Add(pod.Spec.NodeName)
+}
+
+// onEgressInterfaceNodeAdd queues the node for processing.
+func (oc *DefaultNetworkController) onEgressInterfaceNodeAdd(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+	oc.egressInterfaceNodeQueue.Add(key)
+}
+
+// onEgressInterfaceNodeUpdate queues the node for processing when its gateway changes.
+func (oc *DefaultNetworkController) onEgressInterfaceNodeUpdate(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*kapi.Node)
+	newNode := newObj.(*kapi.Node)
+
+	if !util.NodeEgressInterfacesAnnotationChanged(oldNode, newNode) && !gatewayChanged(oldNode, newNode) &&
+		!nodeSubnetChanged(oldNode, newNode) {
+		return
+	}
+	oc.egressInterfaceNodeQueue.Add(newNode.Name)
+}
+
+func (oc *DefaultNetworkController) runEgressInterfaceNodeWorker(wg *sync.WaitGroup) {
+	for oc.processNextEgressInterfaceNodeWorkItem(wg) {
+	}
+}
+
+func (oc *DefaultNetworkController) processNextEgressInterfaceNodeWorkItem(wg *sync.WaitGroup) bool {
+	wg.Add(1)
+	defer wg.Done()
+
+	key, quit := oc.egressInterfaceNodeQueue.Get()
+	if quit {
+		return false
+	}
+
+	defer oc.egressInterfaceNodeQueue.Done(key)
+
+	err := oc.syncEgressInterfaceNode(key.(string))
+	if err == nil {
+		oc.egressInterfaceNodeQueue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with : %v", key, err))
+
+	if oc.egressInterfaceNodeQueue.NumRequeues(key) < maxEgressInterfaceRetries {
+		oc.egressInterfaceNodeQueue.AddRateLimited(key)
+		return true
+	}
+
+	oc.egressInterfaceNodeQueue.Forget(key)
+	return true
+}
+
+// This takes care of syncing stale data which we might have in OVN if
+// there's no ovnkube-master running for a while.
+// It deletes the policies and SNATs of the EgressInterfaces from the gateway
+// routers of the nodes that don't exist anymore, the gateway routers of the
+// existing nodes are reconciled as they are added to the queue.
+func (oc *DefaultNetworkController) repairEgressInterfaces() error {
+	startTime := time.Now()
+	klog.V(4).Infof("Starting repairing loop for egressinterface")
+	defer func() {
+		klog.V(4).Infof("Finished repairing loop for egressinterface: %v", time.Since(startTime))
+	}()
+
+	nodes, err := oc.egressInterfaceNodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	existingNodes := sets.New[string]()
+	for _, node := range nodes {
+		existingNodes.Insert(node.Name)
+	}
+
+	staleNodes := sets.New[string]()
+	p := func(item *nbdb.LogicalRouterPolicy) bool {
+		if _, ok := item.ExternalIDs[egressInterfaceExternalIDKey]; !ok {
+			return false
+		}
+		nodeName := item.ExternalIDs[egressInterfaceNodeExternalIDKey]
+		return !existingNodes.Has(nodeName)
+	}
+	policies, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		staleNodes.Insert(policy.ExternalIDs[egressInterfaceNodeExternalIDKey])
+	}
+	for _, nodeName := range sets.List(staleNodes) {
+		if err := oc.deleteEgressInterfaceObjects(nodeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEgressInterfaceObjects deletes all the policies and SNATs of the EgressInterfaces from
+// the gateway router of the node.
+func (oc *DefaultNetworkController) deleteEgressInterfaceObjects(nodeName string) error {
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, gatewayRouter, func(item *nbdb.LogicalRouterPolicy) bool {
+		_, ok := item.ExternalIDs[egressInterfaceExternalIDKey]
+		return ok
+	})
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("failed to delete the EgressInterface policies of router %s: %v", gatewayRouter, err)
+	}
+	ops, err := libovsdbops.DeleteNATsWithPredicateOps(oc.nbClient, nil, func(item *nbdb.NAT) bool {
+		_, ok := item.ExternalIDs[egressInterfaceExternalIDKey]
+		return ok && item.ExternalIDs[egressInterfaceNodeExternalIDKey] == nodeName
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the EgressInterface SNATs of router %s: %v", gatewayRouter, err)
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to delete the EgressInterface SNATs of router %s: %v", gatewayRouter, err)
+	}
+	return nil
+}
+
+// syncEgressInterfaceNode reconciles the policies and the SNATs of the EgressInterfaces on
+// the gateway router of the node.
+func (oc *DefaultNetworkController) syncEgressInterfaceNode(nodeName string) error {
+	startTime := time.Now()
+	klog.V(5).Infof("Processing sync for EgressInterface node %s", nodeName)
+
+	defer func() {
+		klog.V(4).Infof("Finished syncing EgressInterface node %s: %v", nodeName, time.Since(startTime))
+	}()
+
+	node, err := oc.egressInterfaceNodeLister.Get(nodeName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if node == nil || util.NoHostSubnet(node) {
+		return oc.deleteEgressInterfaceObjects(nodeName)
+	}
+
+	policies, nats, err := oc.getEgressInterfaceNodeObjects(node)
+	if err != nil {
+		return err
+	}
+
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	logicalRouter := &nbdb.LogicalRouter{Name: gatewayRouter}
+	if _, err := libovsdbops.GetLogicalRouter(oc.nbClient, logicalRouter); err != nil {
+		if errors.Is(err, libovsdbclient.ErrNotFound) && len(policies) == 0 && len(nats) == 0 {
+			return nil
+		}
+		// the gateway router may not be created yet, retry
+		return fmt.Errorf("unable to get gateway router %s: %v", gatewayRouter, err)
+	}
+
+	// delete the stale policies, the policies are identified by their EgressInterface
+	// and their match, which holds the address sets and the host subnet
+	desiredPolicies := sets.New[string]()
+	for _, policy := range policies {
+		desiredPolicies.Insert(policy.ExternalIDs[egressInterfaceExternalIDKey] + "/" + policy.Match)
+	}
+	err = libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, gatewayRouter, func(item *nbdb.LogicalRouterPolicy) bool {
+		name, ok := item.ExternalIDs[egressInterfaceExternalIDKey]
+		return ok && item.ExternalIDs[egressInterfaceNodeExternalIDKey] == nodeName &&
+			!desiredPolicies.Has(name+"/"+item.Match)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the stale EgressInterface policies of router %s: %v", gatewayRouter, err)
+	}
+	for _, policy := range policies {
+		policy := policy
+		p := func(item *nbdb.LogicalRouterPolicy) bool {
+			return item.Priority == policy.Priority && item.Match == policy.Match &&
+				item.ExternalIDs[egressInterfaceExternalIDKey] == policy.ExternalIDs[egressInterfaceExternalIDKey] &&
+				item.ExternalIDs[egressInterfaceNodeExternalIDKey] == nodeName
+		}
+		if err := libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(oc.nbClient, gatewayRouter, policy, p,
+			&policy.Nexthops, &policy.Action); err != nil {
+			return fmt.Errorf("failed to create EgressInterface policy %+v on router %s: %v", policy, gatewayRouter, err)
+		}
+	}
+
+	// delete the stale SNATs, a pod IP is SNATed to a single EgressInterface IP
+	desiredNATs := map[string]string{}
+	for _, nat := range nats {
+		desiredNATs[nat.LogicalIP] = nat.ExternalIP
+	}
+	routerNATs, err := libovsdbops.GetRouterNATs(oc.nbClient, logicalRouter)
+	if err != nil {
+		return fmt.Errorf("unable to get the NATs of router %s: %v", gatewayRouter, err)
+	}
+	staleNATs := []*nbdb.NAT{}
+	for _, nat := range routerNATs {
+		if _, ok := nat.ExternalIDs[egressInterfaceExternalIDKey]; !ok {
+			continue
+		}
+		if externalIP, ok := desiredNATs[nat.LogicalIP]; !ok || externalIP != nat.ExternalIP {
+			staleNATs = append(staleNATs, nat)
+		}
+	}
+	if len(staleNATs) > 0 {
+		if err := libovsdbops.DeleteNATs(oc.nbClient, logicalRouter, staleNATs...); err != nil {
+			return fmt.Errorf("failed to delete the stale EgressInterface SNATs of router %s: %v", gatewayRouter, err)
+		}
+	}
+	if len(nats) > 0 {
+		if err := libovsdbops.CreateOrUpdateNATs(oc.nbClient, logicalRouter, nats...); err != nil {
+			return fmt.Errorf("failed to create the EgressInterface SNATs of router %s: %v", gatewayRouter, err)
+		}
+	}
+	return nil
+}
+
+// getEgressInterfaceNodeObjects returns the policies and the SNATs of the EgressInterfaces
+// that must exist on the gateway router of the node.
+func (oc *DefaultNetworkController) getEgressInterfaceNodeObjects(node *kapi.Node) ([]*nbdb.LogicalRouterPolicy, []*nbdb.NAT, error) {
+	policies := []*nbdb.LogicalRouterPolicy{}
+	nats := []*nbdb.NAT{}
+
+	if nodeGatewayMode(node) != config.GatewayModeShared {
+		return policies, nats, nil
+	}
+	gatewayConfigs, err := util.ParseNodeEgressInterfaces(node)
+	if err != nil {
+		if util.IsAnnotationNotSetError(err) {
+			return policies, nats, nil
+		}
+		return nil, nil, err
+	}
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	egressInterfaces, err := oc.egressInterfaceLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	// sort the EgressInterfaces so that a namespace selected by several EgressInterfaces
+	// consistently uses the first one
+	sort.Slice(egressInterfaces, func(i, j int) bool {
+		return egressInterfaces[i].Name < egressInterfaces[j].Name
+	})
+
+	selectedNamespaces := sets.New[string]()
+	for _, egressInterface := range egressInterfaces {
+		gatewayConfig, ok := gatewayConfigs[egressInterface.Name]
+		if !ok {
+			// the interface is not set up on this node
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&egressInterface.Spec.NamespaceSelector)
+		if err != nil {
+			klog.Errorf("Invalid namespace selector of EgressInterface %s: %v", egressInterface.Name, err)
+			continue
+		}
+		namespaces, err := oc.egressInterfaceNamespaceLister.List(selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		namespaceNames := []string{}
+		for _, namespace := range namespaces {
+			if selectedNamespaces.Has(namespace.Name) {
+				klog.Warningf("Namespace %s is selected by several EgressInterfaces, ignoring EgressInterface %s for it",
+					namespace.Name, egressInterface.Name)
+				continue
+			}
+			selectedNamespaces.Insert(namespace.Name)
+			namespaceNames = append(namespaceNames, namespace.Name)
+		}
+		if len(namespaceNames) == 0 {
+			continue
+		}
+		sort.Strings(namespaceNames)
+
+		for _, nextHop := range gatewayConfig.NextHops {
+			isIPv6 := utilnet.IsIPv6(nextHop)
+			egressIP, err := util.MatchFirstIPNetFamily(isIPv6, gatewayConfig.IPAddresses)
+			if err != nil {
+				klog.Warningf("No IP address of the family of next hop %s for EgressInterface %s on node %s",
+					nextHop, egressInterface.Name, node.Name)
+				continue
+			}
+			hostSubnet, err := util.MatchFirstIPNetFamily(isIPv6, hostSubnets)
+			if err != nil {
+				continue
+			}
+
+			policy, err := oc.buildEgressInterfacePolicy(egressInterface.Name, node.Name, namespaceNames, hostSubnet, nextHop)
+			if err != nil {
+				return nil, nil, err
+			}
+			policies = append(policies, policy)
+
+			for _, namespaceName := range namespaceNames {
+				podNATs, err := oc.buildEgressInterfacePodSNATs(egressInterface.Name, node.Name, namespaceName, isIPv6, egressIP.IP)
+				if err != nil {
+					return nil, nil, err
+				}
+				nats = append(nats, podNATs...)
+			}
+		}
+	}
+	return policies, nats, nil
+}
+
+// buildEgressInterfacePolicy returns the policy that reroutes the traffic of the pods of the
+// namespaces running on the node to the next hop of the EgressInterface.
+func (oc *DefaultNetworkController) buildEgressInterfacePolicy(name, nodeName string, namespaceNames []string,
+	hostSubnet *net.IPNet, nextHop net.IP) (*nbdb.LogicalRouterPolicy, error) {
+	ipPrefix := "ip4"
+	if utilnet.IsIPv6(nextHop) {
+		ipPrefix = "ip6"
+	}
+	addressSets := []string{}
+	for _, namespaceName := range namespaceNames {
+		addrSet, err := oc.addressSetFactory.EnsureAddressSet(getNamespaceAddrSetDbIDs(namespaceName, oc.controllerName))
+		if err != nil {
+			return nil, fmt.Errorf("cannot ensure that addressSet for namespace %s exists %v", namespaceName, err)
+		}
+		hashedIPv4, hashedIPv6 := addrSet.GetASHashNames()
+		if utilnet.IsIPv6(nextHop) {
+			addressSets = append(addressSets, "$"+hashedIPv6)
+		} else {
+			addressSets = append(addressSets, "$"+hashedIPv4)
+		}
+	}
+	// the namespace address sets hold the pods of all the nodes, only the local pods
+	// leave the cluster through the gateway router of the node
+	match := fmt.Sprintf("%s.src == {%s} && %s.src == %s", ipPrefix, strings.Join(addressSets, ", "),
+		ipPrefix, hostSubnet.String())
+	return &nbdb.LogicalRouterPolicy{
+		Priority: types.EgressInterfaceReroutePriority,
+		Match:    match,
+		Action:   nbdb.LogicalRouterPolicyActionReroute,
+		Nexthops: []string{nextHop.String()},
+		ExternalIDs: map[string]string{
+			egressInterfaceExternalIDKey:     name,
+			egressInterfaceNodeExternalIDKey: nodeName,
+		},
+	}, nil
+}
+
+// buildEgressInterfacePodSNATs returns the SNATs to the EgressInterface IP of the pods of
+// the namespace running on the node. The SNATs are per pod so that they take precedence
+// over the SNAT of the cluster subnets to the node IP.
+func (oc *DefaultNetworkController) buildEgressInterfacePodSNATs(name, nodeName, namespaceName string, isIPv6 bool,
+	egressIP net.IP) ([]*nbdb.NAT, error) {
+	pods, err := oc.egressInterfacePodLister.Pods(namespaceName).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nats := []*nbdb.NAT{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		podIPs, err := util.GetPodIPsOfNetwork(pod, oc.NetInfo)
+		if errors.Is(err, util.ErrNoPodIPFound) {
+			continue // reprocess it when it is updated with an IP
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, podIP := range podIPs {
+			if utilnet.IsIPv6(podIP) != isIPv6 {
+				continue
+			}
+			mask := util.GetIPFullMask(podIP.String())
+			_, podIPNet, err := net.ParseCIDR(podIP.String() + mask)
+			if err != nil {
+				return nil, err
+			}
+			nats = append(nats, libovsdbops.BuildSNAT(&egressIP, podIPNet, "", map[string]string{
+				egressInterfaceExternalIDKey:     name,
+				egressInterfaceNodeExternalIDKey: nodeName,
+			}))
+		}
+	}
+	return nats, nil
+}
+
+// egressInterfaceSwitchPrefix returns the prefix of the names of the external switch and
+// ports of the EgressInterface
+func egressInterfaceSwitchPrefix(name string) string {
+	return types.EgressInterfaceSwitchPrefix + name + "_"
+}
+
+// syncEgressInterfaceGateways connects the gateway bridges that ovnkube-node set up for the
+// EgressInterfaces to the gateway router of the node, and removes the stale ones.
+func (oc *DefaultNetworkController) syncEgressInterfaceGateways(node *kapi.Node) error {
+	gatewayConfigs := map[string]*util.EgressInterfaceGatewayConfig{}
+	if config.OVNKubernetesFeature.EnableEgressInterface {
+		var err error
+		gatewayConfigs, err = util.ParseNodeEgressInterfaces(node)
+		if err != nil && !util.IsAnnotationNotSetError(err) {
+			return err
+		}
+	}
+
+	gatewayRouter := types.GWRouterPrefix + node.Name
+	for name, gatewayConfig := range gatewayConfigs {
+		if err := oc.addExternalSwitch(egressInterfaceSwitchPrefix(name),
+			gatewayConfig.InterfaceID,
+			node.Name,
+			gatewayRouter,
+			gatewayConfig.MACAddress.String(),
+			types.PhysicalNetworkEgressInterfacePrefix+name,
+			gatewayConfig.IPAddresses,
+			nil); err != nil {
+			return err
+		}
+	}
+
+	return oc.deleteEgressInterfaceGateways(node.Name, sets.KeySet(gatewayConfigs))
+}
+
+// deleteEgressInterfaceGateways removes from the gateway router of the node the external
+// switches of the EgressInterfaces that are not kept.
+func (oc *DefaultNetworkController) deleteEgressInterfaceGateways(nodeName string, keep sets.Set[string]) error {
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	switchSuffix := "_" + types.ExternalSwitchPrefix + nodeName
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(oc.nbClient, func(item *nbdb.LogicalSwitch) bool {
+		return strings.HasPrefix(item.Name, types.EgressInterfaceSwitchPrefix) && strings.HasSuffix(item.Name, switchSuffix)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find the EgressInterface switches of node %s: %v", nodeName, err)
+	}
+	for _, sw := range switches {
+		name := strings.TrimSuffix(strings.TrimPrefix(sw.Name, types.EgressInterfaceSwitchPrefix), switchSuffix)
+		if keep.Has(name) {
+			continue
+		}
+		logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+		logicalRouterPort := nbdb.LogicalRouterPort{
+			Name: egressInterfaceSwitchPrefix(name) + types.GWRouterToExtSwitchPrefix + gatewayRouter,
+		}
+		err = libovsdbops.DeleteLogicalRouterPorts(oc.nbClient, &logicalRouter, &logicalRouterPort)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete port %s on router %s: %v", logicalRouterPort.Name, gatewayRouter, err)
+		}
+		if err := libovsdbops.DeleteLogicalSwitch(oc.nbClient, sw.Name); err != nil {
+			return fmt.Errorf("failed to delete external switch %s: %v", sw.Name, err)
+		}
+		klog.Infof("Removed the external switch %s of EgressInterface %s", sw.Name, name)
+	}
+	return nil
+}
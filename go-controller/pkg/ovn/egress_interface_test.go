@@ -0,0 +1,307 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressinterfaceapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/urfave/cli/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+func newEgressInterfaceObject(name, intf string, namespaceLabels map[string]string) *egressinterfaceapi.EgressInterface {
+	return &egressinterfaceapi.EgressInterface{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: egressinterfaceapi.EgressInterfaceSpec{
+			Interface: intf,
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: namespaceLabels,
+			},
+		},
+	}
+}
+
+func newEgressInterfaceNode(name, hostSubnet string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				"k8s.ovn.org/node-subnets": fmt.Sprintf(`{"default":"%s"}`, hostSubnet),
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("OVN EgressInterface Operations", func() {
+	var (
+		app     *cli.App
+		fakeOVN *FakeOVN
+	)
+
+	const (
+		node1Name  string = "node1"
+		node2Name  string = "node2"
+		staleNode  string = "node3"
+		eifName    string = "secondary"
+		eifIP      string = "172.19.0.5"
+		eifNextHop string = "172.19.0.1"
+		podIP      string = "10.128.1.3"
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableEgressInterface = true
+		config.Gateway.Mode = config.GatewayModeShared
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+
+		fakeOVN = NewFakeOVN()
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOVN.shutdown()
+	})
+
+	ginkgo.It("reroutes and SNATs the pods of the selected namespaces on the nodes with the interface", func() {
+		app.Action = func(ctx *cli.Context) error {
+			staleNAT := &nbdb.NAT{
+				UUID:        "stale-nat-UUID",
+				Type:        nbdb.NATTypeSNAT,
+				ExternalIP:  "172.19.0.6",
+				LogicalIP:   "10.128.3.3",
+				Options:     map[string]string{"stateless": "false"},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: staleNode},
+			}
+			stalePolicy := &nbdb.LogicalRouterPolicy{
+				UUID:        "stale-policy-UUID",
+				Priority:    types.EgressInterfaceReroutePriority,
+				Match:       "ip4.src == {$a1} && ip4.src == 10.128.3.0/24",
+				Action:      nbdb.LogicalRouterPolicyActionReroute,
+				Nexthops:    []string{eifNextHop},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: staleNode},
+			}
+			node1GR := &nbdb.LogicalRouter{
+				UUID: types.GWRouterPrefix + node1Name + "-UUID",
+				Name: types.GWRouterPrefix + node1Name,
+			}
+			node2GR := &nbdb.LogicalRouter{
+				UUID: types.GWRouterPrefix + node2Name + "-UUID",
+				Name: types.GWRouterPrefix + node2Name,
+			}
+			staleGR := &nbdb.LogicalRouter{
+				UUID:     types.GWRouterPrefix + staleNode + "-UUID",
+				Name:     types.GWRouterPrefix + staleNode,
+				Nat:      []string{staleNAT.UUID},
+				Policies: []string{stalePolicy.UUID},
+			}
+
+			// only node1 has the interface of the EgressInterface
+			node1 := newEgressInterfaceNode(node1Name, "10.128.1.0/24")
+			node1.Annotations["k8s.ovn.org/egress-interfaces"] = fmt.Sprintf(
+				`{"%s":{"interface-id":"breth1_%s","mac-address":"0a:58:0a:01:01:01","ip-addresses":["%s/24"],"next-hops":["%s"]}}`,
+				eifName, node1Name, eifIP, eifNextHop)
+			node2 := newEgressInterfaceNode(node2Name, "10.128.2.0/24")
+
+			selected := *newNamespaceWithLabels("selected", map[string]string{"egress": "secondary"})
+			notSelected := *newNamespace("not-selected")
+
+			fakeOVN.startWithDBSetup(
+				libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						staleNAT,
+						stalePolicy,
+						node1GR,
+						node2GR,
+						staleGR,
+					},
+				},
+				&v1.NodeList{
+					Items: []v1.Node{*node1, *node2},
+				},
+				&v1.NamespaceList{
+					Items: []v1.Namespace{selected, notSelected},
+				},
+				&v1.PodList{
+					Items: []v1.Pod{
+						*newPod(selected.Name, "pod1", node1Name, podIP),
+						*newPod(selected.Name, "pod2", node2Name, "10.128.2.3"),
+						*newPod(notSelected.Name, "pod3", node1Name, "10.128.1.4"),
+					},
+				},
+				&egressinterfaceapi.EgressInterfaceList{
+					Items: []egressinterfaceapi.EgressInterface{
+						*newEgressInterfaceObject(eifName, "eth1", map[string]string{"egress": "secondary"}),
+					},
+				},
+			)
+
+			fakeOVN.InitAndRunEgressInterfaceController()
+
+			asv4, _ := getNsAddrSetHashNames(selected.Name)
+			policy := &nbdb.LogicalRouterPolicy{
+				UUID:        "policy-UUID",
+				Priority:    types.EgressInterfaceReroutePriority,
+				Match:       fmt.Sprintf("ip4.src == {$%s} && ip4.src == 10.128.1.0/24", asv4),
+				Action:      nbdb.LogicalRouterPolicyActionReroute,
+				Nexthops:    []string{eifNextHop},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: node1Name},
+			}
+			nat := &nbdb.NAT{
+				UUID:        "nat-UUID",
+				Type:        nbdb.NATTypeSNAT,
+				ExternalIP:  eifIP,
+				LogicalIP:   podIP,
+				Options:     map[string]string{"stateless": "false"},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: node1Name},
+			}
+			node1GR.Policies = []string{policy.UUID}
+			node1GR.Nat = []string{nat.UUID}
+			staleGR.Policies = []string{}
+			staleGR.Nat = []string{}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{
+				policy,
+				nat,
+				node1GR,
+				node2GR,
+				staleGR,
+			}))
+
+			// the SNATs follow the pods
+			pod4 := newPod(selected.Name, "pod4", node1Name, "10.128.1.5")
+			_, err := fakeOVN.fakeClient.KubeClient.CoreV1().Pods(selected.Name).Create(context.TODO(), pod4, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			nat2 := nat.DeepCopy()
+			nat2.UUID = "nat2-UUID"
+			nat2.LogicalIP = "10.128.1.5"
+			node1GR.Nat = []string{nat.UUID, nat2.UUID}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{
+				policy,
+				nat,
+				nat2,
+				node1GR,
+				node2GR,
+				staleGR,
+			}))
+
+			// the namespace isn't selected anymore
+			selected.Labels = map[string]string{"name": selected.Name}
+			_, err = fakeOVN.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), &selected, metav1.UpdateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			node1GR.Policies = []string{}
+			node1GR.Nat = []string{}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{
+				node1GR,
+				node2GR,
+				staleGR,
+			}))
+
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("removes the policies and SNATs when the EgressInterface is deleted", func() {
+		app.Action = func(ctx *cli.Context) error {
+			node1GR := &nbdb.LogicalRouter{
+				UUID: types.GWRouterPrefix + node1Name + "-UUID",
+				Name: types.GWRouterPrefix + node1Name,
+			}
+			node1 := newEgressInterfaceNode(node1Name, "10.128.1.0/24")
+			node1.Annotations["k8s.ovn.org/egress-interfaces"] = fmt.Sprintf(
+				`{"%s":{"interface-id":"breth1_%s","mac-address":"0a:58:0a:01:01:01","ip-addresses":["%s/24"],"next-hops":["%s"]}}`,
+				eifName, node1Name, eifIP, eifNextHop)
+			selected := *newNamespaceWithLabels("selected", map[string]string{"egress": "secondary"})
+
+			fakeOVN.startWithDBSetup(
+				libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						node1GR,
+					},
+				},
+				&v1.NodeList{
+					Items: []v1.Node{*node1},
+				},
+				&v1.NamespaceList{
+					Items: []v1.Namespace{selected},
+				},
+				&v1.PodList{
+					Items: []v1.Pod{
+						*newPod(selected.Name, "pod1", node1Name, podIP),
+					},
+				},
+				&egressinterfaceapi.EgressInterfaceList{
+					Items: []egressinterfaceapi.EgressInterface{
+						*newEgressInterfaceObject(eifName, "eth1", map[string]string{"egress": "secondary"}),
+					},
+				},
+			)
+
+			fakeOVN.InitAndRunEgressInterfaceController()
+
+			asv4, _ := getNsAddrSetHashNames(selected.Name)
+			policy := &nbdb.LogicalRouterPolicy{
+				UUID:        "policy-UUID",
+				Priority:    types.EgressInterfaceReroutePriority,
+				Match:       fmt.Sprintf("ip4.src == {$%s} && ip4.src == 10.128.1.0/24", asv4),
+				Action:      nbdb.LogicalRouterPolicyActionReroute,
+				Nexthops:    []string{eifNextHop},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: node1Name},
+			}
+			nat := &nbdb.NAT{
+				UUID:        "nat-UUID",
+				Type:        nbdb.NATTypeSNAT,
+				ExternalIP:  eifIP,
+				LogicalIP:   podIP,
+				Options:     map[string]string{"stateless": "false"},
+				ExternalIDs: map[string]string{egressInterfaceExternalIDKey: eifName, egressInterfaceNodeExternalIDKey: node1Name},
+			}
+			node1GR.Policies = []string{policy.UUID}
+			node1GR.Nat = []string{nat.UUID}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{
+				policy,
+				nat,
+				node1GR,
+			}))
+
+			err := fakeOVN.fakeClient.EgressInterfaceClient.K8sV1().EgressInterfaces().Delete(context.TODO(), eifName, metav1.DeleteOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			node1GR.Policies = []string{}
+			node1GR.Nat = []string{}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{
+				node1GR,
+			}))
+
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
+
+func (o *FakeOVN) InitAndRunEgressInterfaceController() {
+	klog.Warningf("#### [%p] INIT EgressInterface", o)
+	o.controller.initEgressInterfaceController(o.watcher.EgressInterfaceInformer(), o.watcher.NamespaceCoreInformer(),
+		o.watcher.PodCoreInformer(), o.watcher.NodeCoreInformer())
+	o.egressIFWg.Add(1)
+	go func() {
+		defer o.egressIFWg.Done()
+		o.controller.runEgressInterfaceController(1, o.stopChan)
+	}()
+}
@@ -86,14 +86,7 @@ func (oc *DefaultNetworkController) newEgressFirewallRule(rawEgressFirewallRule
 			return nil, err
 		}
 		efr.to.cidrSelector = rawEgressFirewallRule.To.CIDRSelector
-		intersect := false
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
-			if clusterSubnet.CIDR.Contains(ipNet.IP) || ipNet.Contains(clusterSubnet.CIDR.IP) {
-				intersect = true
-				break
-			}
-		}
-		efr.to.clusterSubnetIntersection = intersect
+		efr.to.clusterSubnetIntersection = intersectsClusterSubnets(ipNet)
 	} else {
 		efr.to.nodeSelector = rawEgressFirewallRule.To.NodeSelector
 		efr.to.nodeAddrs = sets.New[string]()
@@ -119,6 +112,16 @@ func (oc *DefaultNetworkController) newEgressFirewallRule(rawEgressFirewallRule
 	return efr, nil
 }
 
+// intersectsClusterSubnets returns true if ipNet intersects with one of the cluster subnets
+func intersectsClusterSubnets(ipNet *net.IPNet) bool {
+	for _, clusterSubnet := range config.GetClusterSubnets() {
+		if clusterSubnet.CIDR.Contains(ipNet.IP) || ipNet.Contains(clusterSubnet.CIDR.IP) {
+			return true
+		}
+	}
+	return false
+}
+
 // This function is used to sync egress firewall setup. Egress firewall implementation had many versions,
 // the latest one makes no difference for gateway modes, and creates ACLs on types.ClusterPortGroupName.
 // The following cleanups are needed from the previous versions:
@@ -345,43 +348,11 @@ func (oc *DefaultNetworkController) addEgressFirewallRules(ef *egressFirewall, h
 				continue
 			}
 		}
-		var action string
-		var matchTargets []matchTarget
-		if rule.access == egressfirewallapi.EgressFirewallRuleAllow {
-			action = nbdb.ACLActionAllow
-		} else {
-			action = nbdb.ACLActionDrop
-		}
-		if len(rule.to.nodeAddrs) > 0 {
-			for addr := range rule.to.nodeAddrs {
-				if utilnet.IsIPv6String(addr) {
-					matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, false})
-				} else {
-					matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, false})
-				}
-			}
-		} else if rule.to.cidrSelector != "" {
-			if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
-				matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			} else {
-				matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			}
-		} else if len(rule.to.dnsName) > 0 {
-			// rule based on DNS NAME
-			dnsNameAddressSets, err := oc.egressFirewallDNS.Add(ef.namespace, rule.to.dnsName)
-			if err != nil {
-				return fmt.Errorf("error with EgressFirewallDNS - %v", err)
-			}
-			dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
-			if dnsNameIPv4ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
-			}
-			if dnsNameIPv6ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
-			}
+		match, err := oc.getEgressFirewallRuleMatch(ef.namespace, rule, hashedAddressSetNameIPv4, hashedAddressSetNameIPv6)
+		if err != nil {
+			return err
 		}
-
-		if len(matchTargets) == 0 {
+		if match == "" {
 			klog.Warningf("Egress Firewall rule: %#v has no destination...ignoring", *rule)
 			// ensure the ACL is removed from OVN
 			if err := oc.deleteEgressFirewallRule(buildEgressFwAclName(ef.namespace, efStartPriority-rule.id)); err != nil {
@@ -390,8 +361,7 @@ func (oc *DefaultNetworkController) addEgressFirewallRules(ef *egressFirewall, h
 			continue
 		}
 
-		match := generateMatch(hashedAddressSetNameIPv4, hashedAddressSetNameIPv6, matchTargets, rule.ports)
-		err := oc.createEgressFirewallRules(efStartPriority-rule.id, match, action, ef.namespace, aclLogging)
+		err = oc.createEgressFirewallRules(efStartPriority-rule.id, match, getEgressFirewallRuleAction(rule), ef.namespace, aclLogging)
 		if err != nil {
 			return err
 		}
@@ -399,26 +369,56 @@ func (oc *DefaultNetworkController) addEgressFirewallRules(ef *egressFirewall, h
 	return nil
 }
 
+func getEgressFirewallRuleAction(rule *egressFirewallRule) string {
+	if rule.access == egressfirewallapi.EgressFirewallRuleAllow {
+		return nbdb.ACLActionAllow
+	}
+	return nbdb.ACLActionDrop
+}
+
+// getEgressFirewallRuleMatch returns the match of the ACL of the given rule, or an empty string if the rule
+// has no destination
+func (oc *DefaultNetworkController) getEgressFirewallRuleMatch(namespace string, rule *egressFirewallRule, hashedAddressSetNameIPv4,
+	hashedAddressSetNameIPv6 string) (string, error) {
+	var matchTargets []matchTarget
+	if len(rule.to.nodeAddrs) > 0 {
+		for addr := range rule.to.nodeAddrs {
+			if utilnet.IsIPv6String(addr) {
+				matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, false})
+			} else {
+				matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, false})
+			}
+		}
+	} else if rule.to.cidrSelector != "" {
+		if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
+			matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		} else {
+			matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		}
+	} else if len(rule.to.dnsName) > 0 {
+		// rule based on DNS NAME
+		dnsNameAddressSets, err := oc.egressFirewallDNS.Add(namespace, rule.to.dnsName)
+		if err != nil {
+			return "", fmt.Errorf("error with EgressFirewallDNS - %v", err)
+		}
+		dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
+		if dnsNameIPv4ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
+		}
+		if dnsNameIPv6ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
+		}
+	}
+	if len(matchTargets) == 0 {
+		return "", nil
+	}
+	return generateMatch(hashedAddressSetNameIPv4, hashedAddressSetNameIPv6, matchTargets, rule.ports), nil
+}
+
 // createEgressFirewallRules uses the previously generated elements and creates the
 // acls for all node switches
 func (oc *DefaultNetworkController) createEgressFirewallRules(priority int, match, action, externalID string, aclLogging *ACLLoggingLevels) error {
-	// a name is needed for logging purposes - the name must be unique, so make it
-	// egressFirewall_<namespace name>_<priority>
-	aclName := buildEgressFwAclName(externalID, priority)
-
-	egressFirewallACL := BuildACL(
-		aclName,
-		priority,
-		match,
-		action,
-		aclLogging,
-		// since egressFirewall has direction to-lport, set type to ingress
-		lportIngress,
-		map[string]string{
-			egressFirewallACLExtIdKey:    externalID,
-			egressFirewallACLPriorityKey: fmt.Sprintf("%d", priority),
-		},
-	)
+	egressFirewallACL := buildEgressFirewallACL(priority, match, action, externalID, aclLogging)
 	ops, err := libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, nil, egressFirewallACL)
 	if err != nil {
 		return fmt.Errorf("failed to create egressFirewall ACL %v: %v", egressFirewallACL, err)
@@ -439,6 +439,26 @@ func (oc *DefaultNetworkController) createEgressFirewallRules(priority int, matc
 	return nil
 }
 
+func buildEgressFirewallACL(priority int, match, action, externalID string, aclLogging *ACLLoggingLevels) *nbdb.ACL {
+	// a name is needed for logging purposes - the name must be unique, so make it
+	// egressFirewall_<namespace name>_<priority>
+	aclName := buildEgressFwAclName(externalID, priority)
+
+	return BuildACL(
+		aclName,
+		priority,
+		match,
+		action,
+		aclLogging,
+		// since egressFirewall has direction to-lport, set type to ingress
+		lportIngress,
+		map[string]string{
+			egressFirewallACLExtIdKey:    externalID,
+			egressFirewallACLPriorityKey: fmt.Sprintf("%d", priority),
+		},
+	)
+}
+
 func (oc *DefaultNetworkController) deleteEgressFirewallRule(name string) error {
 	// Find ACLs for a given egressFirewall
 	pACL := func(item *nbdb.ACL) bool {
@@ -657,7 +677,7 @@ func egressGetL4Match(ports []egressfirewallapi.EgressFirewallPort) string {
 
 func getV4ClusterSubnetsExclusion() string {
 	var exclusions []string
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.GetClusterSubnets() {
 		if utilnet.IsIPv4CIDR(clusterSubnet.CIDR) {
 			exclusions = append(exclusions, fmt.Sprintf("%s.dst != %s", "ip4", clusterSubnet.CIDR))
 		}
@@ -667,7 +687,7 @@ func getV4ClusterSubnetsExclusion() string {
 
 func getV6ClusterSubnetsExclusion() string {
 	var exclusions []string
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.GetClusterSubnets() {
 		if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 			exclusions = append(exclusions, fmt.Sprintf("%s.dst != %s", "ip6", clusterSubnet.CIDR))
 		}
@@ -712,7 +732,7 @@ func (oc *DefaultNetworkController) updateACLLoggingForEgressFirewall(egressFire
 }
 
 func buildEgressFwAclName(namespace string, priority int) string {
-	return fmt.Sprintf("egressFirewall_%s_%d", namespace, priority)
+	return fmt.Sprintf("%s_%s_%d", types.EgressFirewallACLPrefix, namespace, priority)
 }
 
 func getNodeInternalAddrsToString(node *kapi.Node) []string {
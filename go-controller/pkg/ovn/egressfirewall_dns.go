@@ -128,6 +128,14 @@ func (e *EgressDNS) Delete(namespace string) error {
 	return nil
 }
 
+// hasDNSName returns true if an egress firewall uses the given dns name
+func (e *EgressDNS) hasDNSName(dnsName string) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	_, ok := e.dnsEntries[dnsName]
+	return ok
+}
+
 func (e *EgressDNS) Update(dns string) (bool, error) {
 	return e.dns.Update(dns)
 }
@@ -146,7 +154,7 @@ func (e *EgressDNS) updateEntryForName(dnsName string) error {
 	ipsNoClusterSubnet := []net.IP{}
 	for _, ip := range ips {
 		fromClusterSubnet := false
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.GetClusterSubnets() {
 			if clusterSubnet.CIDR.Contains(ip) {
 				fromClusterSubnet = true
 				break
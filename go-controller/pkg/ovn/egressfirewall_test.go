@@ -1243,6 +1243,84 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 				err := app.Run([]string{app.Name})
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
+			ginkgo.It(fmt.Sprintf("updates the subnet exclusion of the ACLs in place when cluster subnets change, gateway mode %s", gwMode), func() {
+				config.Gateway.Mode = gwMode
+				app.Action = func(ctx *cli.Context) error {
+					clusterSubnetStr := "10.128.0.0/14"
+					_, clusterSubnet, _ := net.ParseCIDR(clusterSubnetStr)
+					config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: clusterSubnet}}
+
+					namespace1 := *newNamespace("namespace1")
+					egressFirewall := newEgressFirewallObject("default", namespace1.Name, []egressfirewallapi.EgressFirewallRule{
+						{
+							Type: "Deny",
+							To: egressfirewallapi.EgressFirewallDestination{
+								CIDRSelector: "0.0.0.0/0",
+							},
+						},
+					})
+					fakeOVN.startWithDBSetup(dbSetup,
+						&egressfirewallapi.EgressFirewallList{
+							Items: []egressfirewallapi.EgressFirewall{
+								*egressFirewall,
+							},
+						},
+						&v1.NamespaceList{
+							Items: []v1.Namespace{
+								namespace1,
+							},
+						})
+
+					err := fakeOVN.controller.WatchNamespaces()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					fakeOVN.controller.WatchEgressFirewall()
+
+					asHash, _ := getNsAddrSetHashNames(namespace1.Name)
+					acl := libovsdbops.BuildACL(
+						buildEgressFwAclName("namespace1", t.EgressFirewallStartPriority),
+						nbdb.ACLDirectionToLport,
+						t.EgressFirewallStartPriority,
+						"(ip4.dst == 0.0.0.0/0 && ip4.dst != "+clusterSubnetStr+") && ip4.src == $"+asHash,
+						nbdb.ACLActionDrop,
+						t.OvnACLLoggingMeter,
+						"",
+						false,
+						map[string]string{
+							egressFirewallACLExtIdKey:    "namespace1",
+							egressFirewallACLPriorityKey: fmt.Sprintf("%d", t.EgressFirewallStartPriority),
+						},
+						nil,
+					)
+					acl.UUID = "acl-UUID"
+					clusterPortGroup.ACLs = []string{acl.UUID}
+					expectedDatabaseState := append(initialData, acl)
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+					getACLUUID := func() string {
+						acls, err := libovsdbops.FindACLsWithPredicate(fakeOVN.nbClient, func(item *nbdb.ACL) bool {
+							return item.ExternalIDs[egressFirewallACLExtIdKey] == "namespace1"
+						})
+						gomega.Expect(err).NotTo(gomega.HaveOccurred())
+						gomega.Expect(acls).To(gomega.HaveLen(1))
+						return acls[0].UUID
+					}
+					aclUUID := getACLUUID()
+
+					newClusterSubnetStr := "10.0.0.0/16"
+					_, newClusterSubnet, _ := net.ParseCIDR(newClusterSubnetStr)
+					config.Default.ClusterSubnets = append(config.Default.ClusterSubnets, config.CIDRNetworkEntry{CIDR: newClusterSubnet})
+					err = fakeOVN.controller.resyncEgressFirewalls()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					// the same ACL is updated
+					acl.Match = "(ip4.dst == 0.0.0.0/0 && ip4.dst != " + clusterSubnetStr + "&&ip4.dst != " + newClusterSubnetStr +
+						") && ip4.src == $" + asHash
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+					gomega.Expect(getACLUUID()).To(gomega.Equal(aclUUID))
+					return nil
+				}
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
 			ginkgo.It(fmt.Sprintf("correctly creates an egressfirewall for namespace name > 43 symbols, gateway mode %s", gwMode), func() {
 				app.Action = func(ctx *cli.Context) error {
 					// 52 characters namespace
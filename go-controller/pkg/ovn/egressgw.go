@@ -640,7 +640,7 @@ func addOrUpdatePodSNATOps(nbClient libovsdbclient.Client, nodeName string, extI
 // addHybridRoutePolicyForPod handles adding a higher priority allow policy to allow traffic to be routed normally
 // by ecmp routes
 func (oc *DefaultNetworkController) addHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	if config.Gateway.Mode == config.GatewayModeLocal {
+	if oc.getNodeGatewayMode(node) == config.GatewayModeLocal {
 		// Add podIP to the node's address_set.
 		asIndex := getHybridRouteAddrSetDbIDs(node, oc.controllerName)
 		as, err := oc.addressSetFactory.EnsureAddressSet(asIndex)
@@ -677,7 +677,7 @@ func (oc *DefaultNetworkController) addHybridRoutePolicyForPod(podIP net.IP, nod
 
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.GetClusterSubnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -714,7 +714,7 @@ func (oc *DefaultNetworkController) addHybridRoutePolicyForPod(podIP net.IP, nod
 // delHybridRoutePolicyForPod handles deleting a logical route policy that
 // forces pod egress traffic to be rerouted to a gateway router for local gateway mode.
 func (oc *DefaultNetworkController) delHybridRoutePolicyForPod(podIP net.IP, node string) error {
-	if config.Gateway.Mode == config.GatewayModeLocal {
+	if oc.getNodeGatewayMode(node) == config.GatewayModeLocal {
 		// Delete podIP from the node's address_set.
 		asIndex := getHybridRouteAddrSetDbIDs(node, oc.controllerName)
 		as, err := oc.addressSetFactory.EnsureAddressSet(asIndex)
@@ -748,7 +748,7 @@ func (oc *DefaultNetworkController) delHybridRoutePolicyForPod(podIP net.IP, nod
 		if deletePolicy {
 			var matchDst string
 			var clusterL3Prefix string
-			for _, clusterSubnet := range config.Default.ClusterSubnets {
+			for _, clusterSubnet := range config.GetClusterSubnets() {
 				if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 					clusterL3Prefix = "ip6"
 				} else {
@@ -782,6 +782,85 @@ func (oc *DefaultNetworkController) delHybridRoutePolicyForPod(podIP net.IP, nod
 	return nil
 }
 
+// syncNodeGatewayMode migrates the hybrid route policies of the pods on the node when the
+// node moved to another gateway mode since its gateway was last synced: the policies are
+// only used in local gateway mode.
+func (oc *DefaultNetworkController) syncNodeGatewayMode(node string, mode config.GatewayMode) error {
+	if prevMode, ok := oc.gatewayModes.Load(node); ok && prevMode.(config.GatewayMode) != mode {
+		klog.Infof("Node %s moved from %q to %q gateway mode, syncing its hybrid route policies", node, prevMode, mode)
+		var err error
+		if mode == config.GatewayModeLocal {
+			err = oc.addHybridRoutePoliciesForNode(node)
+		} else {
+			err = oc.delHybridRoutePoliciesForNode(node)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to sync hybrid route policies of node %s for %q gateway mode: %v", node, mode, err)
+		}
+	}
+	oc.gatewayModes.Store(node, mode)
+	return nil
+}
+
+// addHybridRoutePoliciesForNode adds the hybrid route policies of the pods on the node that
+// are served by external gateways, as found from the ECMP routes of the node's gateway router.
+func (oc *DefaultNetworkController) addHybridRoutePoliciesForNode(node string) error {
+	gatewayRouter := types.GWRouterPrefix + node
+	for podIP, routes := range oc.buildOVNECMPCache() {
+		for _, route := range routes {
+			if route.router != gatewayRouter {
+				continue
+			}
+			if err := oc.addHybridRoutePolicyForPod(net.ParseIP(podIP), node); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// delHybridRoutePoliciesForNode deletes the 501 hybrid-route-policies of the pods on the node
+// along with the node's address set.
+func (oc *DefaultNetworkController) delHybridRoutePoliciesForNode(node string) error {
+	inport := fmt.Sprintf(`inport == "%s%s"`, types.RouterToSwitchPrefix, node)
+	policyPred := func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.Priority == types.HybridOverlayReroutePriority && strings.Contains(item.Match, inport)
+	}
+	err := libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, types.OVNClusterRouter, policyPred)
+	if err != nil {
+		return fmt.Errorf("error deleting hybrid route policies of node %s on %s: %v", node, types.OVNClusterRouter, err)
+	}
+	if err := oc.addressSetFactory.DestroyAddressSet(getHybridRouteAddrSetDbIDs(node, oc.controllerName)); err != nil {
+		return fmt.Errorf("failed to remove hybrid route address set of node %s: %v", node, err)
+	}
+	return nil
+}
+
+// delSharedGatewayHybridRoutePolicies deletes the hybrid route policies of the nodes running in
+// shared gateway mode, all of them when no node runs in local gateway mode.
+func (oc *DefaultNetworkController) delSharedGatewayHybridRoutePolicies() error {
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+	var sharedNodes []string
+	for _, node := range nodes {
+		if nodeGatewayMode(node) == config.GatewayModeShared {
+			sharedNodes = append(sharedNodes, node.Name)
+		}
+	}
+	if len(sharedNodes) == len(nodes) && config.Gateway.Mode == config.GatewayModeShared {
+		return oc.delAllHybridRoutePolicies()
+	}
+	for _, node := range sharedNodes {
+		if err := oc.delHybridRoutePoliciesForNode(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // delAllHybridRoutePolicies deletes all the 501 hybrid-route-policies that
 // force pod egress traffic to be rerouted to a gateway router for local gateway mode.
 // Called when migrating to SGW from LGW.
@@ -885,16 +964,13 @@ func (oc *DefaultNetworkController) cleanExGwECMPRoutes() {
 	}()
 
 	// migration from LGW to SGW mode
-	// for shared gateway mode, these LRPs shouldn't exist, so delete them all
-	if config.Gateway.Mode == config.GatewayModeShared {
-		if err := oc.delAllHybridRoutePolicies(); err != nil {
-			klog.Errorf("Error while removing hybrid policies on moving to SGW mode, error: %v", err)
-		}
-	} else if config.Gateway.Mode == config.GatewayModeLocal {
-		// remove all legacy hybrid route policies
-		if err := oc.delAllLegacyHybridRoutePolicies(); err != nil {
-			klog.Errorf("Error while removing legacy hybrid policies, error: %v", err)
-		}
+	// for nodes in shared gateway mode, these LRPs shouldn't exist, so delete them
+	if err := oc.delSharedGatewayHybridRoutePolicies(); err != nil {
+		klog.Errorf("Error while removing hybrid policies on moving to SGW mode, error: %v", err)
+	}
+	// remove all legacy hybrid route policies
+	if err := oc.delAllLegacyHybridRoutePolicies(); err != nil {
+		klog.Errorf("Error while removing legacy hybrid policies, error: %v", err)
 	}
 
 	// Get all ECMP routes in OVN and build cache
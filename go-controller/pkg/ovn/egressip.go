@@ -33,10 +33,12 @@ import (
 	kapi "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -63,12 +65,28 @@ type egressIPDialer interface {
 var dialer egressIPDialer = &egressIPDial{}
 
 type healthcheckClientAllocator interface {
-	allocate(nodeName string) healthcheck.EgressIPHealthClient
+	allocate(nodeName string, onWatchFailure func(nodeName string)) healthcheck.EgressIPHealthClient
 }
 
+// egressNodeWatchFailuresSize is the number of egress nodes whose health watch
+// failed that can be queued for an immediate reachability check
+const egressNodeWatchFailuresSize = 100
+
 var hccAllocator healthcheckClientAllocator = &egressIPHealthcheckClientAllocator{}
 
 func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.EgressIP) (err error) {
+	return oc.reconcileEgressIPWithRebalance(old, new, false)
+}
+
+// rebalanceEgressIP moves the egress IPs of the EgressIP which are assigned to
+// a node while a preferred egress node could host them, see
+// hasPreferredEgressNode.
+func (oc *DefaultNetworkController) rebalanceEgressIP(eIP *egressipv1.EgressIP) error {
+	klog.V(5).Infof("Rebalancing EgressIP: %s", eIP.Name)
+	return oc.reconcileEgressIPWithRebalance(eIP, eIP, true)
+}
+
+func (oc *DefaultNetworkController) reconcileEgressIPWithRebalance(old, new *egressipv1.EgressIP, rebalance bool) (err error) {
 	// Lock the assignment, this is needed because this function can end up
 	// being called from WatchEgressNodes and WatchEgressIP, i.e: two different
 	// go-routines and we need to make sure the assignment is safe.
@@ -161,6 +179,22 @@ func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.Egres
 			delete(validStatus, status)
 		}
 	}
+	// When rebalancing, the egress IPs which have a preferred egress node are
+	// handled as invalid so that they are moved in the same way as the
+	// assignments of a node which can no longer host them.
+	if rebalance {
+		for status := range validStatus {
+			if oc.hasPreferredEgressNode(name, status, newEIP.Spec.PreferredNodes) {
+				klog.Infof("Moving egress IP: %s of EgressIP: %s from node: %s to a preferred egress node",
+					status.EgressIP, name, status.Node)
+				invalidStatus[status] = ""
+				delete(validStatus, status)
+			}
+		}
+	}
+	if new == nil {
+		oc.deleteEgressIPAssignmentFailures(name)
+	}
 
 	invalidStatusLen := len(invalidStatus)
 	if invalidStatusLen > 0 {
@@ -220,7 +254,7 @@ func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.Egres
 			}
 		}
 		if len(ipsToAssign) > 0 {
-			statusToAdd = oc.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			statusToAdd = oc.assignEgressIPs(name, ipsToAssign.UnsortedList(), newEIP.Spec.PreferredNodes)
 			statusToKeep = append(statusToKeep, statusToAdd...)
 		}
 		// Assign all statusToKeep, we need to warm up the podAssignment cache
@@ -235,9 +269,12 @@ func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.Egres
 		// avoid incorrect future assignments due to a de-synchronized cache.
 		oc.addAllocatorEgressIPAssignments(name, statusToKeep)
 		// Update the object only on an ADD/UPDATE. If we are processing a
-		// DELETE, new will be nil and we should not update the object.
-		if len(statusToAdd) > 0 || (len(statusToRemove) > 0 && new != nil) {
-			if err := oc.patchReplaceEgressIPStatus(name, statusToKeep); err != nil {
+		// DELETE, new will be nil and we should not update the object. The
+		// conditions can change without any assignment change, when an egress
+		// IP can't be assigned for another reason than before.
+		if new != nil && (len(statusToAdd) > 0 || len(statusToRemove) > 0 ||
+			!egressIPConditionsEqual(newEIP.Status.Conditions, oc.getEgressIPConditions(newEIP, statusToKeep))) {
+			if err := oc.patchReplaceEgressIPStatus(newEIP, statusToKeep); err != nil {
 				return err
 			}
 		}
@@ -280,7 +317,7 @@ func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.Egres
 		// processing the answer from the requests we make here, and update OVN
 		// accordingly when we know what the outcome is.
 		if len(ipsToAssign) > 0 {
-			statusToAdd = oc.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			statusToAdd = oc.assignEgressIPs(name, ipsToAssign.UnsortedList(), newEIP.Spec.PreferredNodes)
 			statusToKeep = append(statusToKeep, statusToAdd...)
 		}
 		// Same as above: Add all assignments which are to be kept to the
@@ -659,7 +696,7 @@ func (oc *DefaultNetworkController) reconcileCloudPrivateIPConfig(old, new *ocpc
 					updatedStatus = append(updatedStatus, status)
 				}
 			}
-			if err := oc.patchReplaceEgressIPStatus(egressIP.Name, updatedStatus); err != nil {
+			if err := oc.patchReplaceEgressIPStatus(egressIP, updatedStatus); err != nil {
 				return err
 			}
 		}
@@ -709,7 +746,7 @@ func (oc *DefaultNetworkController) reconcileCloudPrivateIPConfig(old, new *ocpc
 		}
 		if !hasStatus {
 			statusToKeep := append(egressIP.Status.Items, statusItem)
-			if err := oc.patchReplaceEgressIPStatus(egressIP.Name, statusToKeep); err != nil {
+			if err := oc.patchReplaceEgressIPStatus(egressIP, statusToKeep); err != nil {
 				return err
 			}
 		}
@@ -1324,10 +1361,12 @@ func (oc *DefaultNetworkController) deletePodEgressIPAssignments(name string, st
 	return nil
 }
 
+// isEgressNodeReady returns true if the node is ready and didn't report that it lost its
+// external connectivity
 func (oc *DefaultNetworkController) isEgressNodeReady(egressNode *kapi.Node) bool {
 	for _, condition := range egressNode.Status.Conditions {
 		if condition.Type == v1.NodeReady {
-			return condition.Status == v1.ConditionTrue
+			return condition.Status == v1.ConditionTrue && util.IsNodeExternalConnectivityAvailable(egressNode)
 		}
 	}
 	return false
@@ -1680,16 +1719,19 @@ type EgressIPPatchStatus struct {
 // important because processing egress IPs can take a while (when running on a
 // public cloud and in the worst case), hence we don't want to perform a full
 // object update which risks resetting the EgressIP object's fields to the state
-// they had when we started processing the change.
-func (oc *DefaultNetworkController) patchReplaceEgressIPStatus(name string, statusItems []egressipv1.EgressIPStatusItem) error {
-	klog.Infof("Patching status on EgressIP %s: %v", name, statusItems)
+// they had when we started processing the change. The conditions of the status
+// are computed from the provided assignments.
+func (oc *DefaultNetworkController) patchReplaceEgressIPStatus(eIP *egressipv1.EgressIP, statusItems []egressipv1.EgressIPStatusItem) error {
+	klog.Infof("Patching status on EgressIP %s: %v", eIP.Name, statusItems)
+	conditions := oc.getEgressIPConditions(eIP, statusItems)
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		t := []EgressIPPatchStatus{
 			{
 				Op:   "replace",
 				Path: "/status",
 				Value: egressipv1.EgressIPStatus{
-					Items: statusItems,
+					Items:      statusItems,
+					Conditions: conditions,
 				},
 			},
 		}
@@ -1697,10 +1739,139 @@ func (oc *DefaultNetworkController) patchReplaceEgressIPStatus(name string, stat
 		if err != nil {
 			return fmt.Errorf("error serializing status patch operation: %+v, err: %v", statusItems, err)
 		}
-		return oc.kube.PatchEgressIP(name, op)
+		return oc.kube.PatchEgressIP(eIP.Name, op)
 	})
 }
 
+// getEgressIPConditions returns the conditions of the EgressIP for the provided
+// assignments, given the reasons why its other egress IPs could not be assigned
+func (oc *DefaultNetworkController) getEgressIPConditions(eIP *egressipv1.EgressIP, statusItems []egressipv1.EgressIPStatusItem) []metav1.Condition {
+	oc.eIPC.allocator.Lock()
+	failures := make(map[string]string, len(oc.eIPC.assignmentFailures[eIP.Name]))
+	for egressIP, reason := range oc.eIPC.assignmentFailures[eIP.Name] {
+		failures[egressIP] = reason
+	}
+	oc.eIPC.allocator.Unlock()
+	return egressIPConditions(eIP, statusItems, failures)
+}
+
+// deleteEgressIPAssignmentFailures forgets why the egress IPs of the deleted
+// EgressIP could not be assigned
+func (oc *DefaultNetworkController) deleteEgressIPAssignmentFailures(name string) {
+	oc.eIPC.allocator.Lock()
+	defer oc.eIPC.allocator.Unlock()
+	delete(oc.eIPC.assignmentFailures, name)
+}
+
+// egressIPAssignmentFailureMessages describes the reasons why an egress IP
+// could not be assigned
+var egressIPAssignmentFailureMessages = map[string]string{
+	egressipv1.EgressIPReasonNoAssignableNode:     "no node is labeled with " + util.GetNodeEgressLabel(),
+	egressipv1.EgressIPReasonNoNodeHostsSubnet:    "no egress node hosts its subnet",
+	egressipv1.EgressIPReasonNodesUnreachable:     "the egress nodes hosting its subnet are not ready or not reachable",
+	egressipv1.EgressIPReasonNotEnoughNodes:       "all the egress nodes hosting its subnet already host another egress IP of the EgressIP",
+	egressipv1.EgressIPReasonCapacityExhausted:    "the egress IP capacity of the egress nodes hosting its subnet is exhausted",
+	egressipv1.EgressIPReasonAlreadyAllocated:     "it is already assigned to another EgressIP",
+	egressipv1.EgressIPReasonNodeIP:               "it is the IP address of a node",
+	egressipv1.EgressIPReasonAssignmentInProgress: "its assignment is in progress",
+}
+
+// egressIPConditions returns the conditions of the EgressIP with the provided
+// assignments. failures holds the reason why each egress IP of the spec which
+// isn't assigned could not be, the egress IPs without a reason are considered
+// being assigned.
+func egressIPConditions(eIP *egressipv1.EgressIP, statusItems []egressipv1.EgressIPStatusItem, failures map[string]string) []metav1.Condition {
+	assigned := sets.New[string]()
+	for _, statusItem := range statusItems {
+		assigned.Insert(statusItem.EgressIP)
+	}
+	var unassigned, unreachable, messages []string
+	reason := ""
+	for _, egressIP := range eIP.Spec.EgressIPs {
+		if ip := net.ParseIP(egressIP); ip != nil {
+			egressIP = ip.String()
+		}
+		if assigned.Has(egressIP) {
+			continue
+		}
+		failure, exists := failures[egressIP]
+		if !exists {
+			failure = egressipv1.EgressIPReasonAssignmentInProgress
+		}
+		if reason == "" {
+			reason = failure
+		}
+		if failure == egressipv1.EgressIPReasonNodesUnreachable {
+			unreachable = append(unreachable, egressIP)
+		}
+		unassigned = append(unassigned, egressIP)
+		messages = append(messages, fmt.Sprintf("egress IP %s is not assigned: %s", egressIP, egressIPAssignmentFailureMessages[failure]))
+	}
+
+	assignedCondition := metav1.Condition{
+		Type:    egressipv1.EgressIPAssigned,
+		Status:  metav1.ConditionTrue,
+		Reason:  egressipv1.EgressIPReasonAllAssigned,
+		Message: "All the egress IPs are assigned",
+	}
+	partiallyAssignedCondition := metav1.Condition{
+		Type:    egressipv1.EgressIPPartiallyAssigned,
+		Status:  metav1.ConditionFalse,
+		Reason:  egressipv1.EgressIPReasonAllAssigned,
+		Message: "All the egress IPs are assigned",
+	}
+	unreachableCondition := metav1.Condition{
+		Type:    egressipv1.EgressIPUnreachable,
+		Status:  metav1.ConditionFalse,
+		Reason:  egressipv1.EgressIPReasonNodesReachable,
+		Message: "The egress nodes which can host the egress IPs are reachable",
+	}
+	if len(unassigned) > 0 {
+		message := strings.Join(messages, "; ")
+		assignedCondition.Status = metav1.ConditionFalse
+		assignedCondition.Reason = reason
+		assignedCondition.Message = message
+		if len(unassigned) < len(eIP.Spec.EgressIPs) {
+			partiallyAssignedCondition.Status = metav1.ConditionTrue
+			partiallyAssignedCondition.Reason = egressipv1.EgressIPReasonSomeUnassigned
+			partiallyAssignedCondition.Message = message
+		} else {
+			partiallyAssignedCondition.Reason = egressipv1.EgressIPReasonNoneAssigned
+			partiallyAssignedCondition.Message = "None of the egress IPs are assigned"
+		}
+	}
+	if len(unreachable) > 0 {
+		unreachableCondition.Status = metav1.ConditionTrue
+		unreachableCondition.Reason = egressipv1.EgressIPReasonNodesUnreachable
+		unreachableCondition.Message = fmt.Sprintf("The egress nodes which can host egress IPs %s are not ready or not reachable",
+			strings.Join(unreachable, ", "))
+	}
+
+	conditions := make([]metav1.Condition, len(eIP.Status.Conditions))
+	copy(conditions, eIP.Status.Conditions)
+	for _, condition := range []metav1.Condition{assignedCondition, partiallyAssignedCondition, unreachableCondition} {
+		condition.ObservedGeneration = eIP.Generation
+		meta.SetStatusCondition(&conditions, condition)
+	}
+	return conditions
+}
+
+// egressIPConditionsEqual returns true if the conditions only differ by their
+// transition times
+func egressIPConditionsEqual(a, b []metav1.Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, condition := range a {
+		other := meta.FindStatusCondition(b, condition.Type)
+		if other == nil || other.Status != condition.Status || other.Reason != condition.Reason ||
+			other.Message != condition.Message || other.ObservedGeneration != condition.ObservedGeneration {
+			return false
+		}
+	}
+	return true
+}
+
 // assignEgressIPs is the main assignment algorithm for egress IPs to nodes.
 // Specifically we have a couple of hard constraints: a) the subnet of the node
 // must be able to host the egress IP b) the egress IP cannot be a node IP c)
@@ -1713,11 +1884,19 @@ func (oc *DefaultNetworkController) patchReplaceEgressIPStatus(name string, stat
 // ascending order following their existing amount of allocations, and trying to
 // assign the egress IP to the node with the lowest amount of allocations every
 // time, this does not guarantee complete balance, but mostly complete.
-func (oc *DefaultNetworkController) assignEgressIPs(name string, egressIPs []string) []egressipv1.EgressIPStatusItem {
+func (oc *DefaultNetworkController) assignEgressIPs(name string, egressIPs []string, preferredNodes []string) []egressipv1.EgressIPStatusItem {
 	oc.eIPC.allocator.Lock()
 	defer oc.eIPC.allocator.Unlock()
 	assignments := []egressipv1.EgressIPStatusItem{}
-	assignableNodes, existingAllocations := oc.getSortedEgressData()
+	failures, exists := oc.eIPC.assignmentFailures[name]
+	if !exists {
+		failures = map[string]string{}
+		oc.eIPC.assignmentFailures[name] = failures
+	}
+	for _, egressIP := range egressIPs {
+		delete(failures, net.ParseIP(egressIP).String())
+	}
+	assignableNodes, existingAllocations := oc.getSortedEgressData(preferredNodes)
 	if len(assignableNodes) == 0 {
 		eIPRef := kapi.ObjectReference{
 			Kind: "EgressIP",
@@ -1725,6 +1904,10 @@ func (oc *DefaultNetworkController) assignEgressIPs(name string, egressIPs []str
 		}
 		oc.recorder.Eventf(&eIPRef, kapi.EventTypeWarning, "NoMatchingNodeFound", "no assignable nodes for EgressIP: %s, please tag at least one node with label: %s", name, util.GetNodeEgressLabel())
 		klog.Errorf("No assignable nodes found for EgressIP: %s and requested IPs: %v", name, egressIPs)
+		for _, egressIP := range egressIPs {
+			eIPC := net.ParseIP(egressIP)
+			failures[eIPC.String()] = oc.getEgressIPAssignmentFailure(name, eIPC)
+		}
 		return assignments
 	}
 	klog.V(5).Infof("Current assignments are: %+v", existingAllocations)
@@ -1751,6 +1934,7 @@ func (oc *DefaultNetworkController) assignEgressIPs(name string, egressIPs []str
 				continue
 			} else {
 				klog.Errorf("IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node)
+				failures[eIPC.String()] = egressipv1.EgressIPReasonAlreadyAllocated
 				return assignments
 			}
 		}
@@ -1766,42 +1950,26 @@ func (oc *DefaultNetworkController) assignEgressIPs(name string, egressIPs []str
 				"Egress IP: %v for object EgressIP: %s is the IP address of node: %s, this is unsupported", eIPC, name, node.name,
 			)
 			klog.Errorf("Egress IP: %v is the IP address of node: %s", eIPC, node.name)
+			failures[eIPC.String()] = egressipv1.EgressIPReasonNodeIP
 			return assignments
 		}
+		assigned := false
 		for _, eNode := range assignableNodes {
 			klog.V(5).Infof("Attempting assignment on egress node: %+v", eNode)
-			if eNode.getAllocationCountForEgressIP(name) > 0 {
-				klog.V(5).Infof("Node: %s is already in use by another egress IP for this EgressIP: %s, trying another node", eNode.name, name)
+			if eNode.getEgressIPAssignmentFailure(name, eIPC) != "" {
 				continue
 			}
-			if eNode.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
-				if eNode.egressIPConfig.Capacity.IP-len(eNode.allocations) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", eNode.name)
-					continue
-				}
-			}
-			if eNode.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIPC) {
-				if eNode.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(eNode.allocations, false) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", eNode.name)
-					continue
-				}
-			}
-			if eNode.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIPC) {
-				if eNode.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(eNode.allocations, true) <= 0 {
-					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", eNode.name)
-					continue
-				}
-			}
-			if (eNode.egressIPConfig.V6.Net != nil && eNode.egressIPConfig.V6.Net.Contains(eIPC)) ||
-				(eNode.egressIPConfig.V4.Net != nil && eNode.egressIPConfig.V4.Net.Contains(eIPC)) {
-				assignments = append(assignments, egressipv1.EgressIPStatusItem{
-					Node:     eNode.name,
-					EgressIP: eIPC.String(),
-				})
-				klog.Infof("Successful assignment of egress IP: %s on node: %+v", egressIP, eNode)
-				eNode.allocations[eIPC.String()] = name
-				break
-			}
+			assignments = append(assignments, egressipv1.EgressIPStatusItem{
+				Node:     eNode.name,
+				EgressIP: eIPC.String(),
+			})
+			klog.Infof("Successful assignment of egress IP: %s on node: %+v", egressIP, eNode)
+			eNode.allocations[eIPC.String()] = name
+			assigned = true
+			break
+		}
+		if !assigned {
+			failures[eIPC.String()] = oc.getEgressIPAssignmentFailure(name, eIPC)
 		}
 	}
 	if len(assignments) == 0 {
@@ -1840,9 +2008,9 @@ type egressIPNodeStatus struct {
 	Name string
 }
 
-// getSortedEgressData returns a sorted slice of all egressNodes based on the
-// amount of allocations found in the cache
-func (oc *DefaultNetworkController) getSortedEgressData() ([]*egressNode, map[string]egressIPNodeStatus) {
+// getSortedEgressData returns a sorted slice of all egressNodes based on their
+// order in the preferred nodes and the amount of allocations found in the cache
+func (oc *DefaultNetworkController) getSortedEgressData(preferredNodes []string) ([]*egressNode, map[string]egressIPNodeStatus) {
 	assignableNodes := []*egressNode{}
 	allAllocations := make(map[string]egressIPNodeStatus)
 	for _, eNode := range oc.eIPC.allocator.cache {
@@ -1854,11 +2022,95 @@ func (oc *DefaultNetworkController) getSortedEgressData() ([]*egressNode, map[st
 		}
 	}
 	sort.Slice(assignableNodes, func(i, j int) bool {
+		iPreference := getEgressNodePreference(preferredNodes, assignableNodes[i].name)
+		jPreference := getEgressNodePreference(preferredNodes, assignableNodes[j].name)
+		if iPreference != jPreference {
+			return iPreference < jPreference
+		}
 		return len(assignableNodes[i].allocations) < len(assignableNodes[j].allocations)
 	})
 	return assignableNodes, allAllocations
 }
 
+// getEgressNodePreference returns the rank of the node in the preferred nodes,
+// the nodes which are not preferred all have the lowest preference
+func getEgressNodePreference(preferredNodes []string, nodeName string) int {
+	for i, preferredNode := range preferredNodes {
+		if preferredNode == nodeName {
+			return i
+		}
+	}
+	return len(preferredNodes)
+}
+
+// getEgressIPAssignmentFailure returns why the egress IP of the EgressIP could
+// not be assigned to any egress node. This must be called with a lock on the
+// allocator.
+func (oc *DefaultNetworkController) getEgressIPAssignmentFailure(name string, eIP net.IP) string {
+	hasEgressNode, hostingNodes, usableNodes := false, 0, 0
+	notEnoughNodes := true
+	for _, eNode := range oc.eIPC.allocator.cache {
+		if !eNode.isEgressAssignable {
+			continue
+		}
+		hasEgressNode = true
+		if !eNode.canHostEgressIPSubnet(eIP) {
+			continue
+		}
+		hostingNodes++
+		if !eNode.isReady || !eNode.isReachable {
+			continue
+		}
+		usableNodes++
+		if eNode.getAllocationCountForEgressIP(name) == 0 {
+			notEnoughNodes = false
+		}
+	}
+	switch {
+	case !hasEgressNode:
+		return egressipv1.EgressIPReasonNoAssignableNode
+	case hostingNodes == 0:
+		return egressipv1.EgressIPReasonNoNodeHostsSubnet
+	case usableNodes == 0:
+		return egressipv1.EgressIPReasonNodesUnreachable
+	case notEnoughNodes:
+		return egressipv1.EgressIPReasonNotEnoughNodes
+	default:
+		return egressipv1.EgressIPReasonCapacityExhausted
+	}
+}
+
+// hasPreferredEgressNode returns true if the assigned egress IP can be moved to
+// an egress node preferred over its current node: a node listed earlier in the
+// preferred nodes or, between equally preferred nodes, a node with at least two
+// allocations less so that moving the egress IP improves the balance.
+func (oc *DefaultNetworkController) hasPreferredEgressNode(name string, status egressipv1.EgressIPStatusItem, preferredNodes []string) bool {
+	oc.eIPC.allocator.Lock()
+	defer oc.eIPC.allocator.Unlock()
+	currentNode, exists := oc.eIPC.allocator.cache[status.Node]
+	if !exists {
+		return false
+	}
+	eIP := net.ParseIP(status.EgressIP)
+	currentPreference := getEgressNodePreference(preferredNodes, currentNode.name)
+	for _, eNode := range oc.eIPC.allocator.cache {
+		if eNode.name == currentNode.name || !eNode.isEgressAssignable || !eNode.isReady || !eNode.isReachable {
+			continue
+		}
+		if eNode.getEgressIPAssignmentFailure(name, eIP) != "" {
+			continue
+		}
+		preference := getEgressNodePreference(preferredNodes, eNode.name)
+		if preference < currentPreference ||
+			(preference == currentPreference && len(eNode.allocations)+1 < len(currentNode.allocations)) {
+			klog.V(5).Infof("Egress node: %s is preferred over node: %s for egress IP: %s of EgressIP: %s",
+				eNode.name, currentNode.name, status.EgressIP, name)
+			return true
+		}
+	}
+	return false
+}
+
 func (oc *DefaultNetworkController) setNodeEgressAssignable(nodeName string, isAssignable bool) {
 	oc.eIPC.allocator.Lock()
 	defer oc.eIPC.allocator.Unlock()
@@ -1947,6 +2199,12 @@ func (oc *DefaultNetworkController) addEgressNode(nodeName string) error {
 			if err := oc.reconcileEgressIP(nil, &egressIP); err != nil {
 				errors = append(errors, fmt.Errorf("synthetic update for EgressIP: %s failed, err: %v", egressIP.Name, err))
 			}
+		} else if egressIP.Spec.RebalancePolicy == egressipv1.RebalanceWhenNodeReturns {
+			// The returning node might be preferred over the nodes the
+			// egress IPs were moved to while it was gone.
+			if err := oc.rebalanceEgressIP(&egressIP); err != nil {
+				errors = append(errors, fmt.Errorf("rebalance of EgressIP: %s failed, err: %v", egressIP.Name, err))
+			}
 		}
 	}
 
@@ -2034,7 +2292,7 @@ func (oc *DefaultNetworkController) initEgressIPAllocator(node *kapi.Node) (err
 			egressIPConfig: parsedEgressIPConfig,
 			mgmtIPs:        mgmtIPs,
 			allocations:    make(map[string]string),
-			healthClient:   hccAllocator.allocate(node.Name),
+			healthClient:   hccAllocator.allocate(node.Name, oc.notifyEgressNodeWatchFailure),
 		}
 	}
 	return nil
@@ -2093,6 +2351,7 @@ func (oc *DefaultNetworkController) initClusterEgressPolicies(nodes []interface{
 	}
 
 	go oc.checkEgressNodesReachability()
+	go oc.rebalanceEgressIPsPeriodically()
 	return nil
 }
 
@@ -2141,6 +2400,8 @@ type egressNode struct {
 	isReachable        bool
 	isEgressAssignable bool
 	name               string
+	// number of consecutive failed reachability checks
+	reachabilityFailures int
 }
 
 func (e *egressNode) getAllocationCountForEgressIP(name string) (count int) {
@@ -2152,6 +2413,43 @@ func (e *egressNode) getAllocationCountForEgressIP(name string) (count int) {
 	return
 }
 
+// canHostEgressIPSubnet returns true if the egress IP is in a subnet of the node
+func (e *egressNode) canHostEgressIPSubnet(eIP net.IP) bool {
+	return (e.egressIPConfig.V6.Net != nil && e.egressIPConfig.V6.Net.Contains(eIP)) ||
+		(e.egressIPConfig.V4.Net != nil && e.egressIPConfig.V4.Net.Contains(eIP))
+}
+
+// getEgressIPAssignmentFailure returns why the egress IP of the EgressIP can't
+// be assigned to the node, or an empty string if it can
+func (e *egressNode) getEgressIPAssignmentFailure(name string, eIP net.IP) string {
+	if e.getAllocationCountForEgressIP(name) > 0 {
+		klog.V(5).Infof("Node: %s is already in use by another egress IP for this EgressIP: %s, trying another node", e.name, name)
+		return egressipv1.EgressIPReasonNotEnoughNodes
+	}
+	if e.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
+		if e.egressIPConfig.Capacity.IP-len(e.allocations) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", e.name)
+			return egressipv1.EgressIPReasonCapacityExhausted
+		}
+	}
+	if e.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIP) {
+		if e.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(e.allocations, false) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", e.name)
+			return egressipv1.EgressIPReasonCapacityExhausted
+		}
+	}
+	if e.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIP) {
+		if e.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(e.allocations, true) <= 0 {
+			klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", e.name)
+			return egressipv1.EgressIPReasonCapacityExhausted
+		}
+	}
+	if !e.canHostEgressIPSubnet(eIP) {
+		return egressipv1.EgressIPReasonNoNodeHostsSubnet
+	}
+	return ""
+}
+
 // podAssignmentState keeps track of which egressIP object is serving
 // the related pod.
 // NOTE: At a given time only one object will be configured. This is
@@ -2223,8 +2521,21 @@ type egressIPController struct {
 	egressIPTotalTimeout int
 	// reachability check interval
 	reachabilityCheckInterval time.Duration
+	// maximum factor of the reachability check interval randomly added to it
+	reachabilityCheckJitter float64
+	// number of consecutive failed reachability checks after which a node is unreachable
+	reachabilityFailureThreshold int
+	// egressNodeWatchFailures receives the names of the egress nodes whose
+	// health watch failed, to check their reachability immediately
+	egressNodeWatchFailures chan string
 	// EgressIP Node reachability gRPC port (0 means it should use dial instead)
 	egressIPNodeHealthCheckPort int
+	// assignmentFailures holds, per EgressIP name, the reason why each of its
+	// egress IPs could not be assigned the last time it was attempted. It is
+	// guarded by the allocator lock.
+	assignmentFailures map[string]map[string]string
+	// interval of the rebalancing of the EgressIPs with the Periodic rebalance policy
+	rebalanceInterval time.Duration
 }
 
 // addStandByEgressIPAssignment does the same setup that is done by addPodEgressIPAssignments but for
@@ -2273,7 +2584,7 @@ func (oc *DefaultNetworkController) addStandByEgressIPAssignment(podKey string,
 // (routing pod traffic to the egress node) and NAT objects on the egress node
 // (SNAT-ing to the egress IP).
 func (e *egressIPController) addPodEgressIPAssignment(egressIPName string, status egressipv1.EgressIPStatusItem, pod *kapi.Pod, podIPs []*net.IPNet) (err error) {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			if err != nil {
@@ -2306,7 +2617,7 @@ func (e *egressIPController) addPodEgressIPAssignment(egressIPName string, statu
 // deletePodEgressIPAssignment deletes the OVN programmed egress IP
 // configuration mentioned for addPodEgressIPAssignment.
 func (e *egressIPController) deletePodEgressIPAssignment(egressIPName string, status egressipv1.EgressIPStatusItem, pod *kapi.Pod, podIPs []*net.IPNet) (err error) {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			if err != nil {
@@ -2596,12 +2907,16 @@ func (oc *DefaultNetworkController) deletePodIPsFromAddressSet(addrSetIPs []net.
 // is important because egress IP is based upon routing traffic to these nodes,
 // and if they aren't reachable we shouldn't be using them for egress IP.
 func (oc *DefaultNetworkController) checkEgressNodesReachability() {
-	timer := time.NewTicker(oc.eIPC.reachabilityCheckInterval)
+	timer := time.NewTimer(oc.getReachabilityCheckInterval())
 	defer timer.Stop()
 	for {
 		select {
 		case <-timer.C:
 			checkEgressNodesReachabilityIterate(oc)
+			timer.Reset(oc.getReachabilityCheckInterval())
+		case nodeName := <-oc.eIPC.egressNodeWatchFailures:
+			klog.Infof("Health watch of node: %s failed, checking its reachability", nodeName)
+			checkEgressNodeReachability(oc, nodeName)
 		case <-oc.stopChan:
 			klog.V(5).Infof("Stop channel got triggered: will stop checkEgressNodesReachability")
 			return
@@ -2609,13 +2924,95 @@ func (oc *DefaultNetworkController) checkEgressNodesReachability() {
 	}
 }
 
+// rebalanceEgressIPsPeriodically rebalances the EgressIPs with the Periodic
+// rebalance policy every rebalance interval
+func (oc *DefaultNetworkController) rebalanceEgressIPsPeriodically() {
+	if oc.eIPC.rebalanceInterval <= 0 {
+		return
+	}
+	timer := time.NewTicker(oc.eIPC.rebalanceInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			oc.rebalanceEgressIPsWithPolicy(egressipv1.RebalancePeriodic)
+		case <-oc.stopChan:
+			klog.V(5).Infof("Stop channel got triggered: will stop rebalanceEgressIPsPeriodically")
+			return
+		}
+	}
+}
+
+// rebalanceEgressIPsWithPolicy rebalances the fully assigned EgressIPs which
+// have the rebalance policy
+func (oc *DefaultNetworkController) rebalanceEgressIPsWithPolicy(policy egressipv1.EgressIPRebalancePolicy) {
+	egressIPs, err := oc.kube.GetEgressIPs()
+	if err != nil {
+		klog.Errorf("Unable to list EgressIPs to rebalance them, err: %v", err)
+		return
+	}
+	for _, egressIP := range egressIPs.Items {
+		egressIP := egressIP
+		if egressIP.Spec.RebalancePolicy != policy || len(egressIP.Spec.EgressIPs) != len(egressIP.Status.Items) {
+			continue
+		}
+		if err := oc.rebalanceEgressIP(&egressIP); err != nil {
+			klog.Errorf("Unable to rebalance EgressIP: %s, err: %v", egressIP.Name, err)
+		}
+	}
+}
+
+// getReachabilityCheckInterval returns the reachability check interval with
+// its random jitter, so that the checks of the masters don't align
+func (oc *DefaultNetworkController) getReachabilityCheckInterval() time.Duration {
+	if oc.eIPC.reachabilityCheckJitter <= 0 {
+		return oc.eIPC.reachabilityCheckInterval
+	}
+	return wait.Jitter(oc.eIPC.reachabilityCheckInterval, oc.eIPC.reachabilityCheckJitter)
+}
+
+// notifyEgressNodeWatchFailure queues an immediate reachability check of the
+// egress node whose health watch failed. The check is skipped if the queue is
+// full, the next periodic check covers the node anyway.
+func (oc *DefaultNetworkController) notifyEgressNodeWatchFailure(nodeName string) {
+	select {
+	case oc.eIPC.egressNodeWatchFailures <- nodeName:
+	default:
+	}
+}
+
 func checkEgressNodesReachabilityIterate(oc *DefaultNetworkController) {
+	checkEgressNodesReachabilityOf(oc, nil)
+}
+
+// checkEgressNodeReachability checks the reachability of a single egress node
+func checkEgressNodeReachability(oc *DefaultNetworkController, nodeName string) {
+	checkEgressNodesReachabilityOf(oc, sets.New(nodeName))
+}
+
+// checkEgressNodesReachabilityOf checks the reachability of the egress nodes,
+// or of all the nodes if nodeNames is nil. A node is only declared unreachable
+// after the configured number of consecutive failed checks.
+func checkEgressNodesReachabilityOf(oc *DefaultNetworkController, nodeNames sets.Set[string]) {
 	reAddOrDelete := map[string]bool{}
 	oc.eIPC.allocator.Lock()
 	for _, eNode := range oc.eIPC.allocator.cache {
+		if nodeNames != nil && !nodeNames.Has(eNode.name) {
+			continue
+		}
 		if eNode.isEgressAssignable && eNode.isReady {
 			wasReachable := eNode.isReachable
 			isReachable := oc.isReachable(eNode.name, eNode.mgmtIPs, eNode.healthClient)
+			if isReachable {
+				eNode.reachabilityFailures = 0
+			} else {
+				eNode.reachabilityFailures++
+				if wasReachable && eNode.reachabilityFailures < oc.eIPC.reachabilityFailureThreshold {
+					klog.V(5).Infof("Node: %s failed %d reachability checks, tolerating it up to %d",
+						eNode.name, eNode.reachabilityFailures, oc.eIPC.reachabilityFailureThreshold)
+					isReachable = true
+				}
+			}
 			if wasReachable && !isReachable {
 				reAddOrDelete[eNode.name] = true
 			} else if !wasReachable && isReachable {
@@ -2725,8 +3122,8 @@ func (e *egressIPDial) dial(ip net.IP, timeout time.Duration) bool {
 
 type egressIPHealthcheckClientAllocator struct{}
 
-func (hccAlloc *egressIPHealthcheckClientAllocator) allocate(nodeName string) healthcheck.EgressIPHealthClient {
-	return healthcheck.NewEgressIPHealthClient(nodeName)
+func (hccAlloc *egressIPHealthcheckClientAllocator) allocate(nodeName string, onWatchFailure func(nodeName string)) healthcheck.EgressIPHealthClient {
+	return healthcheck.NewEgressIPHealthClient(nodeName, onWatchFailure)
 }
 
 func isReachableViaGRPC(mgmtIPs []net.IP, healthClient healthcheck.EgressIPHealthClient, healthCheckPort, totalTimeout int) bool {
@@ -2745,7 +3142,7 @@ func isReachableViaGRPC(mgmtIPs []net.IP, healthClient healthcheck.EgressIPHealt
 func getClusterSubnets() ([]*net.IPNet, []*net.IPNet) {
 	var v4ClusterSubnets = []*net.IPNet{}
 	var v6ClusterSubnets = []*net.IPNet{}
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.GetClusterSubnets() {
 		if !utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 			v4ClusterSubnets = append(v4ClusterSubnets, clusterSubnet.CIDR)
 		} else {
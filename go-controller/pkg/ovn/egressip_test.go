@@ -22,6 +22,7 @@ import (
 	"github.com/urfave/cli/v2"
 	kapi "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -68,7 +69,7 @@ func (fehc *fakeEgressIPHealthClient) Probe(dialCtx context.Context) bool {
 
 type fakeEgressIPHealthClientAllocator struct{}
 
-func (f *fakeEgressIPHealthClientAllocator) allocate(nodeName string) healthcheck.EgressIPHealthClient {
+func (f *fakeEgressIPHealthClientAllocator) allocate(nodeName string, onWatchFailure func(nodeName string)) healthcheck.EgressIPHealthClient {
 	return &fakeEgressIPHealthClient{}
 }
 
@@ -148,7 +149,7 @@ func setupNode(nodeName string, ipNets []string, mockAllocationIPs map[string]st
 			},
 		},
 		allocations:        mockAllcations,
-		healthClient:       hccAllocator.allocate(nodeName), // using fakeEgressIPHealthClientAllocator
+		healthClient:       hccAllocator.allocate(nodeName, nil), // using fakeEgressIPHealthClientAllocator
 		name:               nodeName,
 		isReady:            true,
 		isReachable:        true,
@@ -7767,6 +7768,41 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+
+		ginkgo.It("should only declare an egress node unreachable after the failure threshold is reached", func() {
+			app.Action = func(ctx *cli.Context) error {
+				fakeOvn.startWithDBSetup(clusterRouterDbSetup)
+
+				node1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				node1.mgmtIPs = []net.IP{net.ParseIP("10.128.0.2")}
+				fakeOvn.controller.eIPC.allocator.cache[node1.name] = &node1
+				fakeOvn.controller.eIPC.reachabilityFailureThreshold = 2
+
+				hcClient := node1.healthClient.(*fakeEgressIPHealthClient)
+				hcClient.Connected = true
+				hcClient.FakeProbeFailure = true
+
+				checkEgressNodeReachability(fakeOvn.controller, node1.name)
+				gomega.Expect(node1.reachabilityFailures).To(gomega.Equal(1))
+				gomega.Expect(node1.isReachable).To(gomega.BeTrue())
+
+				checkEgressNodeReachability(fakeOvn.controller, node1.name)
+				gomega.Expect(node1.reachabilityFailures).To(gomega.Equal(2))
+				gomega.Expect(node1.isReachable).To(gomega.BeFalse())
+
+				hcClient.FakeProbeFailure = false
+				checkEgressNodeReachability(fakeOvn.controller, node1.name)
+				gomega.Expect(node1.reachabilityFailures).To(gomega.Equal(0))
+				gomega.Expect(node1.isReachable).To(gomega.BeTrue())
+
+				// a failed health watch queues an immediate check of the node
+				fakeOvn.controller.notifyEgressNodeWatchFailure(node1.name)
+				gomega.Expect(fakeOvn.controller.eIPC.egressNodeWatchFailures).To(gomega.Receive(gomega.Equal(node1.name)))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 	})
 
 	ginkgo.Context("Dual-stack assignment", func() {
@@ -7789,7 +7825,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -7858,7 +7894,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -7889,7 +7925,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP1, egressIP2},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -7923,7 +7959,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP1, egressIP2, egressIP3},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -7958,7 +7994,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 					},
 				}
 
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1Name))
 				return nil
@@ -7987,7 +8023,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(0))
 
 				return nil
@@ -8019,7 +8055,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 					},
 				}
 
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(0))
 				return nil
 			}
@@ -8049,7 +8085,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 					},
 				}
 
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(0))
 				return nil
 			}
@@ -8077,7 +8113,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -8109,7 +8145,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 					},
 				}
 
-				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(0))
 				return nil
 			}
@@ -8144,6 +8180,107 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 		})
 	})
 
+	ginkgo.Context("Preferred nodes and conditions", func() {
+
+		ginkgo.It("should assign the egress IP to the preferred node, even if it has more assignments", func() {
+			app.Action = func(ctx *cli.Context) error {
+
+				fakeOvn.start()
+				egressIP := "192.168.126.101"
+
+				node1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{"192.168.126.102": "bogus1", "192.168.126.111": "bogus2"})
+				node2 := setupNode(node2Name, []string{"192.168.126.51/24"}, map[string]string{})
+
+				fakeOvn.controller.eIPC.allocator.cache[node1.name] = &node1
+				fakeOvn.controller.eIPC.allocator.cache[node2.name] = &node2
+
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs:      []string{egressIP},
+						PreferredNodes: []string{node1.name},
+					},
+				}
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1.name))
+
+				// the egress IP stays on the preferred node until it can no longer host it
+				gomega.Expect(fakeOvn.controller.hasPreferredEgressNode(eIP.Name, assignedStatuses[0], eIP.Spec.PreferredNodes)).To(gomega.BeFalse())
+				// without preference, the node with less assignments is preferred
+				gomega.Expect(fakeOvn.controller.hasPreferredEgressNode(eIP.Name, assignedStatuses[0], nil)).To(gomega.BeTrue())
+
+				conditions := fakeOvn.controller.getEgressIPConditions(&eIP, assignedStatuses)
+				gomega.Expect(meta.IsStatusConditionTrue(conditions, egressipv1.EgressIPAssigned)).To(gomega.BeTrue())
+				gomega.Expect(meta.IsStatusConditionFalse(conditions, egressipv1.EgressIPPartiallyAssigned)).To(gomega.BeTrue())
+				gomega.Expect(meta.IsStatusConditionFalse(conditions, egressipv1.EgressIPUnreachable)).To(gomega.BeTrue())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should report why the egress IPs are not assigned", func() {
+			app.Action = func(ctx *cli.Context) error {
+
+				fakeOvn.start()
+				egressIPs := []string{"192.168.126.101", "192.168.127.101", "192.168.128.101"}
+
+				node1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				node2 := setupNode(node2Name, []string{"192.168.127.51/24"}, map[string]string{})
+				node2.isReachable = false
+
+				fakeOvn.controller.eIPC.allocator.cache[node1.name] = &node1
+				fakeOvn.controller.eIPC.allocator.cache[node2.name] = &node2
+
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: egressIPs,
+					},
+				}
+				assignedStatuses := fakeOvn.controller.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs, eIP.Spec.PreferredNodes)
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1.name))
+				gomega.Expect(fakeOvn.controller.eIPC.assignmentFailures[eIP.Name]).To(gomega.Equal(map[string]string{
+					"192.168.127.101": egressipv1.EgressIPReasonNodesUnreachable,
+					"192.168.128.101": egressipv1.EgressIPReasonNoNodeHostsSubnet,
+				}))
+
+				conditions := fakeOvn.controller.getEgressIPConditions(&eIP, assignedStatuses)
+				assigned := meta.FindStatusCondition(conditions, egressipv1.EgressIPAssigned)
+				gomega.Expect(assigned.Status).To(gomega.Equal(metav1.ConditionFalse))
+				gomega.Expect(assigned.Reason).To(gomega.Equal(egressipv1.EgressIPReasonNodesUnreachable))
+				gomega.Expect(assigned.Message).To(gomega.Equal("egress IP 192.168.127.101 is not assigned: the egress nodes hosting its subnet are not ready or not reachable; " +
+					"egress IP 192.168.128.101 is not assigned: no egress node hosts its subnet"))
+				partiallyAssigned := meta.FindStatusCondition(conditions, egressipv1.EgressIPPartiallyAssigned)
+				gomega.Expect(partiallyAssigned.Status).To(gomega.Equal(metav1.ConditionTrue))
+				gomega.Expect(partiallyAssigned.Reason).To(gomega.Equal(egressipv1.EgressIPReasonSomeUnassigned))
+				unreachable := meta.FindStatusCondition(conditions, egressipv1.EgressIPUnreachable)
+				gomega.Expect(unreachable.Status).To(gomega.Equal(metav1.ConditionTrue))
+				gomega.Expect(unreachable.Message).To(gomega.ContainSubstring("192.168.127.101"))
+
+				// once the node is reachable again, the conditions only differ by their status
+				node2.isReachable = true
+				assignedStatuses = append(assignedStatuses, fakeOvn.controller.assignEgressIPs(eIP.Name, []string{"192.168.127.101"}, nil)...)
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
+				eIP.Status.Conditions = conditions
+				conditions = fakeOvn.controller.getEgressIPConditions(&eIP, assignedStatuses)
+				gomega.Expect(egressIPConditionsEqual(eIP.Status.Conditions, conditions)).To(gomega.BeFalse())
+				gomega.Expect(meta.IsStatusConditionFalse(conditions, egressipv1.EgressIPUnreachable)).To(gomega.BeTrue())
+				gomega.Expect(meta.FindStatusCondition(conditions, egressipv1.EgressIPAssigned).Reason).To(gomega.Equal(egressipv1.EgressIPReasonNoNodeHostsSubnet))
+
+				fakeOvn.controller.deleteEgressIPAssignmentFailures(eIP.Name)
+				gomega.Expect(fakeOvn.controller.eIPC.assignmentFailures).NotTo(gomega.HaveKey(eIP.Name))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("WatchEgressIP", func() {
 
 		ginkgo.It("should update status correctly for single-stack IPv4", func() {
@@ -74,6 +74,11 @@ func (oc *DefaultNetworkController) gatewayCleanup(nodeName string) error {
 		return fmt.Errorf("failed to delete external switch %s: %v", exGWexternalSwitch, err)
 	}
 
+	// Remove the external switches of the EgressInterfaces
+	if err := oc.deleteEgressInterfaceGateways(nodeName, nil); err != nil {
+		return err
+	}
+
 	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes. It will be a no-op for any other mode.
 	oc.delPbrAndNatRules(nodeName, nil)
 	return nil
@@ -219,6 +224,11 @@ func (oc *DefaultNetworkController) multiJoinSwitchGatewayCleanup(nodeName strin
 		return fmt.Errorf("failed to delete external switch %s: %v", extSwitchName, err)
 	}
 
+	// Remove the external switches of the EgressInterfaces
+	if err := oc.deleteEgressInterfaceGateways(nodeName, nil); err != nil {
+		return err
+	}
+
 	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes. It will be a no-op for any other mode.
 	oc.delPbrAndNatRules(nodeName, nil)
 	return nil
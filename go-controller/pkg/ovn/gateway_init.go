@@ -365,7 +365,7 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 			Nexthop:  gwLRPIP[0].String(),
 		}
 
-		if config.Gateway.Mode != config.GatewayModeLocal {
+		if l3GatewayConfig.Mode != config.GatewayModeLocal {
 			p := func(item *nbdb.LogicalRouterStaticRoute) bool {
 				return item.IPPrefix == lrsr.IPPrefix && libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
 			}
@@ -380,7 +380,7 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 			if err != nil {
 				return fmt.Errorf("error creating static route %+v in GR %s: %v", lrsr, types.OVNClusterRouter, err)
 			}
-		} else if config.Gateway.Mode == config.GatewayModeLocal {
+		} else {
 			// If migrating from shared to local gateway, let's remove the static routes towards
 			// join switch for the hostSubnet prefix
 			// Note syncManagementPort happens before gateway sync so only remove things pointing to join subnet
@@ -431,9 +431,10 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 	}
 	nats := make([]*nbdb.NAT, 0, len(clusterIPSubnet))
 	var nat *nbdb.NAT
-	if !config.Gateway.DisableSNATMultipleGWs {
+	if !config.Gateway.DisableSNATMultipleGWs && !config.BGP.DisableSNAT {
 		// Default SNAT rules. DisableSNATMultipleGWs=false in LGW (traffic egresses via mp0) always.
 		// We are not checking for gateway mode to be shared explicitly to reduce topology differences.
+		// No SNAT is needed when the pod subnets are advertised with BGP.
 		for _, entry := range clusterIPSubnet {
 			externalIP, err := util.MatchIPFamily(utilnet.IsIPv6CIDR(entry), externalIPs)
 			if err != nil {
@@ -448,7 +449,8 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 			return fmt.Errorf("failed to update SNAT rule for pod on router %s error: %v", gatewayRouter, err)
 		}
 	} else {
-		// ensure we do not have any leftover SNAT entries after an upgrade
+		// ensure we do not have any leftover SNAT entries after an upgrade or after
+		// the pod subnets started to be advertised with BGP
 		for _, logicalSubnet := range clusterIPSubnet {
 			nat = libovsdbops.BuildSNAT(nil, logicalSubnet, "", nil)
 			nats = append(nats, nat)
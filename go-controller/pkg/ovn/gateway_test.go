@@ -111,7 +111,7 @@ func generateGatewayInitExpectedNB(testData []libovsdb.TestData, expectedOVNClus
 		})
 
 	}
-	if config.Gateway.Mode == config.GatewayModeShared {
+	if l3GatewayConfig.Mode == config.GatewayModeShared {
 		for i, hostSubnet := range hostSubnets {
 			joinLRPIP, _ := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(hostSubnet), joinLRPIPs)
 			ocrStaticRouteNamedUUID := fmt.Sprintf("subnet-static-route-ovn-cluster-router-%v-UUID", i)
@@ -124,7 +124,7 @@ func generateGatewayInitExpectedNB(testData []libovsdb.TestData, expectedOVNClus
 			})
 		}
 	}
-	if config.Gateway.Mode == config.GatewayModeLocal && nodeMgmtPortIP != "" {
+	if l3GatewayConfig.Mode == config.GatewayModeLocal && nodeMgmtPortIP != "" {
 		for i, hostSubnet := range hostSubnets {
 			ocrStaticRouteNamedUUID := fmt.Sprintf("subnet-static-route-ovn-cluster-router-%v-UUID", i)
 			expectedOVNClusterRouter.StaticRoutes = append(expectedOVNClusterRouter.StaticRoutes, ocrStaticRouteNamedUUID)
@@ -392,7 +392,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -471,7 +471,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -542,7 +542,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -627,7 +627,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			joinLRPIPs := ovntest.MustParseIPNets("fd98::3/64")
 			defLRPIPs := ovntest.MustParseIPNets("fd98::1/64")
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -698,7 +698,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			defLRPIPs := ovntest.MustParseIPNets("fd98::1/64")
 			nodeName := "test-node"
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -772,7 +772,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16", "fd98::1/64")
 			nodeName := "test-node"
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -843,7 +843,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
 			nodeName := "test-node"
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -876,6 +876,78 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			gomega.Eventually(fakeOvn.sbClient).Should(libovsdbtest.HaveData(expectedSBDatabaseState))
 		})
 
+		ginkgo.It("does not SNAT the cluster subnets advertised with BGP", func() {
+			expectedOVNClusterRouter := &nbdb.LogicalRouter{
+				UUID: types.OVNClusterRouter + "-UUID",
+				Name: types.OVNClusterRouter,
+			}
+			expectedNodeSwitch := &nbdb.LogicalSwitch{
+				UUID: nodeName + "-UUID",
+				Name: nodeName,
+			}
+			expectedClusterLBGroup := &nbdb.LoadBalancerGroup{
+				UUID: types.ClusterLBGroupName + "-UUID",
+				Name: types.ClusterLBGroupName,
+			}
+			gr := types.GWRouterPrefix + nodeName
+			datapath := &sbdb.DatapathBinding{
+				UUID:        gr + "-UUID",
+				ExternalIDs: map[string]string{"logical-router": gr + "-UUID", "name": gr},
+			}
+			fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					&nbdb.LogicalSwitch{
+						UUID: types.OVNJoinSwitch + "-UUID",
+						Name: types.OVNJoinSwitch,
+					},
+					expectedOVNClusterRouter,
+					expectedNodeSwitch,
+					expectedClusterLBGroup,
+				},
+				SBData: []libovsdbtest.TestData{
+					datapath,
+				},
+			})
+
+			clusterIPSubnets := ovntest.MustParseIPNets("10.128.0.0/14")
+			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
+			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
+			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
+			nodeName := "test-node"
+			l3GatewayConfig := &util.L3GatewayConfig{
+				Mode:           config.GatewayModeShared,
+				ChassisID:      "SYSTEM-ID",
+				InterfaceID:    "INTERFACE-ID",
+				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses:    ovntest.MustParseIPNets("169.254.33.2/24"),
+				NextHops:       ovntest.MustParseIPs("169.254.33.1"),
+				NodePortEnable: true,
+			}
+			sctpSupport := false
+			config.BGP.DisableSNAT = true
+
+			var err error
+			fakeOvn.controller.defaultCOPPUUID, err = EnsureDefaultCOPP(fakeOvn.nbClient)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = fakeOvn.controller.gatewayInit(
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, sctpSupport, joinLRPIPs, defLRPIPs, true)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			testData := []libovsdb.TestData{}
+			skipSnat := true
+			// We don't set up the Allow from mgmt port ACL here
+			mgmtPortIP := ""
+			expectedDatabaseState := generateGatewayInitExpectedNB(testData, expectedOVNClusterRouter, expectedNodeSwitch,
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, joinLRPIPs, defLRPIPs, skipSnat, mgmtPortIP,
+				"1400")
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+			testData = []libovsdb.TestData{datapath}
+			expectedSBDatabaseState := generateGatewayInitExpectedSB(testData, nodeName)
+			gomega.Eventually(fakeOvn.sbClient).Should(libovsdbtest.HaveData(expectedSBDatabaseState))
+		})
+
 		ginkgo.It("ensures only a single static route per node for ovn_cluster_router", func() {
 			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
 			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
@@ -947,7 +1019,7 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
 			nodeName := "test-node"
 			l3GatewayConfig := &util.L3GatewayConfig{
-				Mode:           config.GatewayModeLocal,
+				Mode:           config.GatewayModeShared,
 				ChassisID:      "SYSTEM-ID",
 				InterfaceID:    "INTERFACE-ID",
 				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
@@ -979,6 +1051,85 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			expectedDatabaseState = append(expectedDatabaseState, ignoreRoute4)
 			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 		})
+
+		ginkgo.It("removes the route on ovn_cluster_router to join subnet of a node migrated to local gateway mode", func() {
+			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
+			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
+			badRouteName := "wrongRoute-UUID"
+			badRoute := &nbdb.LogicalRouterStaticRoute{
+				UUID:     badRouteName,
+				Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
+				IPPrefix: hostSubnets[0].String(),
+				Nexthop:  "100.64.0.5",
+			}
+			expectedOVNClusterRouter := &nbdb.LogicalRouter{
+				UUID:         types.OVNClusterRouter + "-UUID",
+				Name:         types.OVNClusterRouter,
+				StaticRoutes: []string{badRouteName},
+			}
+			expectedNodeSwitch := &nbdb.LogicalSwitch{
+				UUID: nodeName + "-UUID",
+				Name: nodeName,
+			}
+			expectedClusterLBGroup := &nbdb.LoadBalancerGroup{
+				UUID: types.ClusterLBGroupName + "-UUID",
+				Name: types.ClusterLBGroupName,
+			}
+			gr := types.GWRouterPrefix + nodeName
+			datapath := &sbdb.DatapathBinding{
+				UUID:        gr + "-UUID",
+				ExternalIDs: map[string]string{"logical-router": gr + "-UUID", "name": gr},
+			}
+			fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					&nbdb.LogicalSwitch{
+						UUID: types.OVNJoinSwitch + "-UUID",
+						Name: types.OVNJoinSwitch,
+					},
+					badRoute,
+					expectedOVNClusterRouter,
+					expectedNodeSwitch,
+					expectedClusterLBGroup,
+				},
+				SBData: []libovsdbtest.TestData{
+					datapath,
+				},
+			})
+			clusterIPSubnets := ovntest.MustParseIPNets("10.128.0.0/14")
+			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
+			nodeName := "test-node"
+			l3GatewayConfig := &util.L3GatewayConfig{
+				Mode:           config.GatewayModeLocal,
+				ChassisID:      "SYSTEM-ID",
+				InterfaceID:    "INTERFACE-ID",
+				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses:    ovntest.MustParseIPNets("169.254.33.2/24"),
+				NextHops:       ovntest.MustParseIPs("169.254.33.1"),
+				NodePortEnable: true,
+			}
+			sctpSupport := false
+			config.Gateway.DisableSNATMultipleGWs = true
+
+			var err error
+			fakeOvn.controller.defaultCOPPUUID, err = EnsureDefaultCOPP(fakeOvn.nbClient)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = fakeOvn.controller.gatewayInit(
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, sctpSupport, joinLRPIPs, defLRPIPs, true)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			testData := []libovsdb.TestData{}
+			skipSnat := true
+
+			// remove bad route from expected data
+			expectedOVNClusterRouter.StaticRoutes = []string{}
+			mgmtPortIP := ""
+			ginkgo.By("Gateway init should have removed the route of the shared gateway mode")
+			expectedDatabaseState := generateGatewayInitExpectedNB(testData, expectedOVNClusterRouter, expectedNodeSwitch,
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, joinLRPIPs, defLRPIPs, skipSnat, mgmtPortIP,
+				"1400")
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+		})
 	})
 
 	ginkgo.Context("Gateway Create Operations Local Gateway Mode", func() {
@@ -2,25 +2,38 @@ package healthcheck
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"golang.org/x/net/context"
 	"golang.org/x/net/proxy"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 	"net"
+	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
 	serviceEgressIPNode = "Service_Egress_IP"
+
+	// gracefulStopTimeout is how long the server waits for the Watch streams
+	// to end before closing them
+	gracefulStopTimeout = 2 * time.Second
 )
 
 // UnimplementedHealthServer must be embedded to have forward compatible implementations.
 type healthServer struct {
 	UnimplementedHealthServer
+	// shutdown is closed when the server is stopped, to end the Watch streams
+	shutdown chan struct{}
 }
 
 func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
@@ -34,6 +47,106 @@ func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCh
 	return &response, nil
 }
 
+// Watch sends the status of the service and keeps the stream open until the
+// client ends it or the server is stopped, after sending NOT_SERVING. The
+// client detects that the node stops serving as soon as the stream ends,
+// without waiting for its next probe.
+func (hs *healthServer) Watch(req *HealthCheckRequest, stream Health_WatchServer) error {
+	response := HealthCheckResponse{Status: HealthCheckResponse_SERVICE_UNKNOWN}
+	if req.GetService() == serviceEgressIPNode {
+		response.Status = HealthCheckResponse_SERVING
+	}
+	if err := stream.Send(&response); err != nil {
+		return err
+	}
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-hs.shutdown:
+		return stream.Send(&HealthCheckResponse{Status: HealthCheckResponse_NOT_SERVING})
+	}
+}
+
+// loadCertPool returns a pool with the CA certificates of the file
+func loadCertPool(caCertFile string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate %s: %w", caCertFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate %s", caCertFile)
+	}
+	return pool, nil
+}
+
+// serverCredentials returns the TLS credentials of the health server, or nil
+// to serve insecure connections. The EgressIP health check certificate takes
+// precedence over the northbound database one, and when the EgressIP health
+// check CA is set the clients must present a certificate it signed.
+func serverCredentials() (credentials.TransportCredentials, error) {
+	cfg := &config.OVNKubernetesFeature
+	if cfg.EgressIPHealthCheckCert == "" {
+		northCfg := &config.OvnNorth
+		if northCfg.Cert == "" || northCfg.PrivKey == "" {
+			return nil, nil
+		}
+		cert, err := tls.LoadX509KeyPair(northCfg.Cert, northCfg.PrivKey)
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewServerTLSFromCert(&cert), nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.EgressIPHealthCheckCert, cfg.EgressIPHealthCheckPrivKey)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.EgressIPHealthCheckCACert != "" {
+		pool, err := loadCertPool(cfg.EgressIPHealthCheckCACert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// clientCredentials returns the TLS credentials of the health client, or nil
+// to use insecure connections. The EgressIP health check CA and certificate
+// take precedence over the northbound database ones.
+func clientCredentials() (credentials.TransportCredentials, error) {
+	cfg := &config.OVNKubernetesFeature
+	if cfg.EgressIPHealthCheckCACert == "" {
+		northCfg := &config.OvnNorth
+		if northCfg.CACert == "" || northCfg.CertCommonName == "" {
+			return nil, nil
+		}
+		return credentials.NewClientTLSFromFile(northCfg.CACert, northCfg.CertCommonName)
+	}
+	pool, err := loadCertPool(cfg.EgressIPHealthCheckCACert)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		ServerName: cfg.EgressIPHealthCheckCertCommonName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.EgressIPHealthCheckCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.EgressIPHealthCheckCert, cfg.EgressIPHealthCheckPrivKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 // EgressIPHealthServer interface is the means for spawning a gRPC server for
 // the egress ip health check service.
 type EgressIPHealthServer interface {
@@ -66,24 +179,24 @@ func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	wg := &sync.WaitGroup{}
 
 	opts := []grpc.ServerOption{}
-	cfg := &config.OvnNorth
-	if cfg.Cert == "" || cfg.PrivKey == "" {
+	creds, err := serverCredentials()
+	if err != nil {
+		klog.Fatalf("Health checking TLS key failed: %v", err)
+	}
+	if creds == nil {
 		klog.Warning("Health checking using insecure connection")
 	} else {
 		// Enable TLS for all incoming connections.
-		cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.PrivKey)
-		if err != nil {
-			klog.Fatalf("Health checking TLS key failed: %v", err)
-		}
-		opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+		opts = append(opts, grpc.Creds(creds))
 	}
 	grpcServer := grpc.NewServer(opts...)
+	hs := &healthServer{shutdown: make(chan struct{})}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		RegisterHealthServer(grpcServer, &healthServer{})
+		RegisterHealthServer(grpcServer, hs)
 		klog.Infof("Starting Egress IP Health Server on %s:%d", ehs.nodeMgmtIP.String(), ehs.healthCheckPort)
 		if err := grpcServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			klog.Fatalf("Egress IP Health checking server failed: %v", err)
@@ -94,7 +207,18 @@ func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	<-stopCh
 
 	klog.Info("Shutting down Egress IP Health Server")
-	grpcServer.Stop()
+	// Let the Watch streams notify the clients that the node stops serving
+	close(hs.shutdown)
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(gracefulStopTimeout):
+		grpcServer.Stop()
+	}
 	wg.Wait()
 	klog.Info("Egress IP Health Server is shutdown")
 }
@@ -116,11 +240,19 @@ type egressIPHealthClient struct {
 	// connection just went down. With that, we do not declare node
 	// unreachable unless connection could not be re-established.
 	probeFailed bool
+	// onWatchFailure is called when the watch of the health of the node fails
+	onWatchFailure func(nodeName string)
+	// watchFailed is set to 1 once the watch of the current session failed
+	watchFailed int32
+	// cancelWatch ends the watch of the current session
+	cancelWatch context.CancelFunc
 }
 
-// NewEgressIPHealthClient allocates an Egress IP health client.
-func NewEgressIPHealthClient(nodeName string) EgressIPHealthClient {
-	return &egressIPHealthClient{nodeName: nodeName}
+// NewEgressIPHealthClient allocates an Egress IP health client. Once connected,
+// the client watches the health of the node and calls onWatchFailure, if not
+// nil, as soon as the node stops serving or the watch breaks.
+func NewEgressIPHealthClient(nodeName string, onWatchFailure func(nodeName string)) EgressIPHealthClient {
+	return &egressIPHealthClient{nodeName: nodeName, onWatchFailure: onWatchFailure}
 }
 
 // IsConnected returns whether client session is established or not.
@@ -140,17 +272,16 @@ func (ehc *egressIPHealthClient) Connect(dialCtx context.Context, mgmtIPs []net.
 			return proxy.Dial(ctx, "tcp", s)
 		}),
 	}
-	cfg := &config.OvnNorth
-	if cfg.CACert == "" || cfg.CertCommonName == "" {
+	creds, err := clientCredentials()
+	if err != nil {
+		klog.Errorf("Health checking TLS key failed: %v", err)
+		return false
+	}
+	if creds == nil {
 		klog.Warning("Health checking using insecure connection")
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
 		// Set up the credentials for the connection.
-		creds, err := credentials.NewClientTLSFromFile(cfg.CACert, cfg.CertCommonName)
-		if err != nil {
-			klog.Errorf("Health checking TLS key failed: %v", err)
-			return false
-		}
 		opts = append(opts, grpc.WithTransportCredentials(creds))
 	}
 	for _, nodeMgmtIP := range mgmtIPs {
@@ -168,11 +299,59 @@ func (ehc *egressIPHealthClient) Connect(dialCtx context.Context, mgmtIPs []net.
 	klog.Infof("Connected to %s (%s)", ehc.nodeName, nodeAddr)
 	ehc.nodeAddr = nodeAddr
 	ehc.conn = conn
+	ehc.startWatch()
 	return true
 }
 
+// startWatch watches the health of the node over the current session, to
+// detect that the node stops serving without waiting for the next probe
+func (ehc *egressIPHealthClient) startWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ehc.cancelWatch = cancel
+	atomic.StoreInt32(&ehc.watchFailed, 0)
+	conn, nodeName, nodeAddr := ehc.conn, ehc.nodeName, ehc.nodeAddr
+	go func() {
+		err := watchHealth(ctx, conn)
+		if ctx.Err() != nil {
+			// the session was closed
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			klog.V(5).Infof("Health watch is not supported by %s (%s), relying on probes", nodeName, nodeAddr)
+			return
+		}
+		klog.Warningf("Health watch of %s (%s) failed: %v", nodeName, nodeAddr, err)
+		atomic.StoreInt32(&ehc.watchFailed, 1)
+		if ehc.onWatchFailure != nil {
+			ehc.onWatchFailure(nodeName)
+		}
+	}()
+}
+
+// watchHealth watches the health of the egress ip service until it stops
+// serving or the stream breaks
+func watchHealth(ctx context.Context, conn *grpc.ClientConn) error {
+	stream, err := NewHealthClient(conn).Watch(ctx, &HealthCheckRequest{Service: serviceEgressIPNode})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if response.GetStatus() != HealthCheckResponse_SERVING {
+			return fmt.Errorf("service is %s", response.GetStatus())
+		}
+	}
+}
+
 // Disconnect stops gRPC session with the egress ip health check service.
 func (ehc *egressIPHealthClient) Disconnect() {
+	if ehc.cancelWatch != nil {
+		ehc.cancelWatch()
+		ehc.cancelWatch = nil
+	}
 	if ehc.conn != nil {
 		klog.Infof("Closing connection with %s (%s)", ehc.nodeName, ehc.nodeAddr)
 		ehc.conn.Close()
@@ -193,14 +372,21 @@ func (ehc *egressIPHealthClient) Probe(dialCtx context.Context) bool {
 		// check failed. What we will return here will depend on ehc.probeFailed. If this is the first failure,
 		// let's tolerate it to account for cases where session went down and we just need it re-established.
 		// Otherwise, declare it failed.
+		// A failed watch already reported that the session went down, so
+		// the failure is not tolerated then.
 		klog.V(5).Infof("Probe failed %s (%s): %s", ehc.nodeName, ehc.nodeAddr, err)
+		prevProbeFailed := ehc.probeFailed || atomic.LoadInt32(&ehc.watchFailed) == 1
 		ehc.Disconnect()
-		prevProbeFailed := ehc.probeFailed
 		ehc.probeFailed = true
 		return !prevProbeFailed
 	}
 
 	ehc.probeFailed = false
+	if atomic.LoadInt32(&ehc.watchFailed) == 1 {
+		// The node is serving again over the same session, watch it again
+		ehc.cancelWatch()
+		ehc.startWatch()
+	}
 	klog.V(5).Infof("Got response from %s (%s): %v", ehc.nodeName, ehc.nodeAddr, response.GetStatus())
 	return response.GetStatus() == HealthCheckResponse_SERVING
 }
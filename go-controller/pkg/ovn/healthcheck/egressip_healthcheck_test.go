@@ -0,0 +1,128 @@
+package healthcheck
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+const testServerName = "egressip-health"
+
+func noError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeCert signs a certificate with the CA, or self-signs it if ca is nil, and
+// writes it and its key in dir
+func writeCert(t *testing.T, dir, name string, template *x509.Certificate, ca *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	noError(t, err)
+	if ca == nil {
+		ca, caKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	noError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	noError(t, err)
+	noError(t, os.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	noError(t, os.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	return cert, key
+}
+
+// writeCerts writes a CA and a certificate it signed, valid both for the
+// server and the client
+func writeCerts(t *testing.T, dir string) {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := writeCert(t, dir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	writeCert(t, dir, "health", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: testServerName},
+		DNSNames:     []string{testServerName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+}
+
+func getFreePort(t *testing.T) int {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	noError(t, err)
+	defer lis.Close()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestEgressIPHealthCheckMutualTLS(t *testing.T) {
+	noError(t, config.PrepareTestConfig())
+	dir := t.TempDir()
+	writeCerts(t, dir)
+	config.OVNKubernetesFeature.EgressIPHealthCheckCert = filepath.Join(dir, "health.crt")
+	config.OVNKubernetesFeature.EgressIPHealthCheckPrivKey = filepath.Join(dir, "health.key")
+	config.OVNKubernetesFeature.EgressIPHealthCheckCACert = filepath.Join(dir, "ca.crt")
+	config.OVNKubernetesFeature.EgressIPHealthCheckCertCommonName = testServerName
+
+	mgmtIPs := []net.IP{net.ParseIP("127.0.0.1")}
+	port := getFreePort(t)
+	server, err := NewEgressIPHealthServer(mgmtIPs[0], port)
+	noError(t, err)
+	stopCh := make(chan struct{})
+	serverDone := make(chan struct{})
+	go func() {
+		server.Run(stopCh)
+		close(serverDone)
+	}()
+
+	watchFailures := make(chan string, 1)
+	client := NewEgressIPHealthClient("node1", func(nodeName string) { watchFailures <- nodeName })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if !client.Connect(ctx, mgmtIPs, port) {
+		t.Fatal("client with a certificate should connect")
+	}
+	assert.True(t, client.Probe(ctx))
+
+	// the server requires a client certificate
+	config.OVNKubernetesFeature.EgressIPHealthCheckCert = ""
+	config.OVNKubernetesFeature.EgressIPHealthCheckPrivKey = ""
+	noCertClient := NewEgressIPHealthClient("node1", nil)
+	noCertCtx, noCertCancel := context.WithTimeout(context.Background(), time.Second)
+	defer noCertCancel()
+	assert.False(t, noCertClient.Connect(noCertCtx, mgmtIPs, port), "client without a certificate should not connect")
+
+	// the watch reports that the node stops serving as soon as the server stops
+	close(stopCh)
+	select {
+	case nodeName := <-watchFailures:
+		assert.Equal(t, "node1", nodeName)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the health watch did not report the server stop")
+	}
+	<-serverDone
+	client.Disconnect()
+}
@@ -6,14 +6,13 @@ import (
 	"math/rand"
 	"net"
 	"reflect"
+	"strings"
 	"sync"
 
-	libovsdbclient "github.com/ovn-org/libovsdb/client"
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/ipallocator/allocator"
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -41,6 +40,11 @@ type LogicalSwitchManager struct {
 	// A RW mutex for LogicalSwitchManager which holds logicalSwitch information
 	sync.RWMutex
 	ipamFunc ipamFactoryFunc
+	// quarantine, if set, holds the IPs released by QuarantineIPs back from allocation
+	quarantine *quarantine.List
+	// releaseQuarantineWhenExhausted allows quarantined IPs to be allocated before
+	// their quarantine expired when the subnets of a switch are exhausted
+	releaseQuarantineWhenExhausted bool
 }
 
 // GetUUID returns the UUID for the given logical switch name if
@@ -122,6 +126,7 @@ func (manager *LogicalSwitchManager) AddSwitch(switchName, uuid string, hostSubn
 		noHostSubnet: len(hostSubnets) == 0,
 		uuid:         uuid,
 	}
+	manager.reserveQuarantinedIPs(switchName, ipams)
 
 	return nil
 }
@@ -168,6 +173,45 @@ func (manager *LogicalSwitchManager) GetSwitchSubnets(switchName string) []*net.
 	return nil
 }
 
+// GetIPUsage returns the number of IPs allocated and still available in the
+// host subnets of all the switches
+func (manager *LogicalSwitchManager) GetIPUsage() (allocated, available int) {
+	manager.RLock()
+	defer manager.RUnlock()
+	for _, lsi := range manager.cache {
+		for _, ipam := range lsi.ipams {
+			allocated += ipam.Used()
+			available += ipam.Free()
+		}
+	}
+	return allocated, available
+}
+
+// IPUsage is the number of IPs allocated and still available in the host subnets of a switch
+type IPUsage struct {
+	Allocated int
+	Available int
+}
+
+// GetIPUsageBySwitch returns the IP usage of each switch that has host subnets, by switch name
+func (manager *LogicalSwitchManager) GetIPUsageBySwitch() map[string]IPUsage {
+	manager.RLock()
+	defer manager.RUnlock()
+	usage := make(map[string]IPUsage, len(manager.cache))
+	for switchName, lsi := range manager.cache {
+		if len(lsi.ipams) == 0 {
+			continue
+		}
+		var switchUsage IPUsage
+		for _, ipam := range lsi.ipams {
+			switchUsage.Allocated += ipam.Used()
+			switchUsage.Available += ipam.Free()
+		}
+		usage[switchName] = switchUsage
+	}
+	return usage
+}
+
 // AllocateUntilFull used for unit testing only, allocates the rest of the switch subnet
 func (manager *LogicalSwitchManager) AllocateUntilFull(switchName string) error {
 	manager.RLock()
@@ -228,6 +272,11 @@ func (manager *LogicalSwitchManager) AllocateIPs(switchName string, ipnets []*ne
 					return err
 				}
 				if err = ipam.Allocate(ipnet.IP); err != nil {
+					// an IP that is claimed while quarantined is taken out of quarantine
+					if errors.Is(err, ipallocator.ErrAllocated) && manager.unquarantine(switchName, ipnet.IP) {
+						err = nil
+						break
+					}
 					return err
 				}
 				allocated[idx] = ipnet
@@ -275,7 +324,7 @@ func (manager *LogicalSwitchManager) AllocateNextIPs(switchName string) ([]*net.
 	}()
 
 	for idx, ipam := range lsi.ipams {
-		ip, err = ipam.AllocateNext()
+		ip, err = manager.allocateNext(switchName, ipam)
 		if err != nil {
 			return nil, err
 		}
@@ -371,242 +420,232 @@ func (manager *LogicalSwitchManager) ReleaseIPs(switchName string, ipnets []*net
 	return nil
 }
 
-// ConditionalIPRelease determines if any IP is available to be released from an IPAM conditionally if func is true.
-// It guarantees state of the allocator will not change while executing the predicate function
-// TODO(trozet): add unit testing for this function
-func (manager *LogicalSwitchManager) ConditionalIPRelease(switchName string, ipnets []*net.IPNet, predicate func() (bool, error)) (bool, error) {
+// QuarantineIPs releases the IPs of a deleted pod like ReleaseIPs, unless the manager has a
+// quarantine: the IPs are then kept allocated until their quarantine expires.
+func (manager *LogicalSwitchManager) QuarantineIPs(switchName string, ipnets []*net.IPNet) error {
+	if manager.quarantine == nil {
+		return manager.ReleaseIPs(switchName, ipnets)
+	}
 	manager.RLock()
 	defer manager.RUnlock()
 	if ipnets == nil || switchName == "" {
 		klog.V(5).Infof("Switch name is empty or ip slice to release is nil")
-		return false, nil
+		return nil
 	}
 	lsi, ok := manager.cache[switchName]
 	if !ok {
-		return false, nil
-	}
-	if len(lsi.ipams) == 0 {
-		return false, nil
+		return fmt.Errorf("unable to quarantine ips for switch %s: %w", switchName, SwitchNotFound)
 	}
 
-	// check if ipam has one of the ip addresses, and then execute the predicate function to determine
-	// if this IP should be released or not
 	for _, ipnet := range ipnets {
 		for _, ipam := range lsi.ipams {
 			cidr := ipam.CIDR()
 			if cidr.Contains(ipnet.IP) {
 				if ipam.Has(ipnet.IP) {
-					return predicate()
+					manager.quarantine.Add(quarantineKey(switchName, ipnet.IP))
 				}
+				break
 			}
 		}
 	}
-
-	return false, nil
-}
-
-// IP allocator manager for join switch's IPv4 and IPv6 subnets.
-type JoinSwitchIPManager struct {
-	lsm            *LogicalSwitchManager
-	nbClient       libovsdbclient.Client
-	lrpIPCache     map[string][]*net.IPNet
-	lrpIPCacheLock sync.Mutex
+	return nil
 }
 
-// NewJoinIPAMAllocator provides an ipam interface which can be used for join switch IPAM
-// allocations for the specified cidr using a contiguous allocation strategy.
-func NewJoinIPAMAllocator(cidr *net.IPNet) (ipam.Interface, error) {
-	subnetRange, err := ipam.NewAllocatorCIDRRange(cidr, func(max int, rangeSpec string) (allocator.Interface, error) {
-		return allocator.NewContiguousAllocationMap(max, rangeSpec), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	return subnetRange, nil
+// SetQuarantine makes QuarantineIPs hold the released IPs back in q until their quarantine
+// expires. If releaseWhenExhausted is set, quarantined IPs of a switch are allocated again,
+// oldest first, when no other IP of the switch is available.
+func (manager *LogicalSwitchManager) SetQuarantine(q *quarantine.List, releaseWhenExhausted bool) {
+	manager.Lock()
+	defer manager.Unlock()
+	manager.quarantine = q
+	manager.releaseQuarantineWhenExhausted = releaseWhenExhausted
 }
 
-// Initializes a new join switch logical switch manager.
-// This IPmanager guaranteed to always have both IPv4 and IPv6 regardless of dual-stack
-func NewJoinLogicalSwitchIPManager(nbClient libovsdbclient.Client, uuid string, existingNodeNames []string) (*JoinSwitchIPManager, error) {
-	j := JoinSwitchIPManager{
-		lsm: &LogicalSwitchManager{
-			cache:    make(map[string]logicalSwitchInfo),
-			ipamFunc: NewJoinIPAMAllocator,
-		},
-		nbClient:   nbClient,
-		lrpIPCache: make(map[string][]*net.IPNet),
+// RestoreQuarantine loads the quarantined IPs from the store of the quarantine. The IPs of
+// the switches that are known already are reserved right away, the others when their switch
+// is added.
+func (manager *LogicalSwitchManager) RestoreQuarantine() error {
+	if manager.quarantine == nil {
+		return nil
 	}
-	var joinSubnets []*net.IPNet
-	joinSubnetsConfig := []string{}
-	if config.IPv4Mode {
-		joinSubnetsConfig = append(joinSubnetsConfig, config.Gateway.V4JoinSubnet)
+	if _, err := manager.quarantine.Load(); err != nil {
+		return err
 	}
-	if config.IPv6Mode {
-		joinSubnetsConfig = append(joinSubnetsConfig, config.Gateway.V6JoinSubnet)
+	manager.RLock()
+	defer manager.RUnlock()
+	for switchName, lsi := range manager.cache {
+		manager.reserveQuarantinedIPs(switchName, lsi.ipams)
 	}
-	for _, joinSubnetString := range joinSubnetsConfig {
-		_, joinSubnet, err := net.ParseCIDR(joinSubnetString)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing join subnet string %s: %v", joinSubnetString, err)
-		}
-		joinSubnets = append(joinSubnets, joinSubnet)
+	return nil
+}
+
+// RunQuarantine releases the quarantined IPs whose quarantine expired until stopChan is closed
+func (manager *LogicalSwitchManager) RunQuarantine(stopChan <-chan struct{}) {
+	if manager.quarantine == nil {
+		return
 	}
-	err := j.lsm.AddSwitch(types.OVNJoinSwitch, uuid, joinSubnets)
-	if err != nil {
-		return nil, err
+	manager.quarantine.Run(stopChan, func(key string) {
+		manager.RLock()
+		defer manager.RUnlock()
+		manager.releaseQuarantined(key)
+	})
+}
+
+// PurgeQuarantine drops the quarantined IPs, from the store of the quarantine as well, once
+// the network is gone
+func (manager *LogicalSwitchManager) PurgeQuarantine() error {
+	if manager.quarantine == nil {
+		return nil
 	}
-	for _, nodeName := range existingNodeNames {
-		gwLRPIPs := j.getJoinLRPAddresses(nodeName)
-		if len(gwLRPIPs) > 0 {
-			klog.Infof("Initializing and reserving the join switch IP for node: %s to: %v", nodeName, gwLRPIPs)
-			if err := j.reserveJoinLRPIPs(nodeName, gwLRPIPs); err != nil {
-				return nil, fmt.Errorf("error initiliazing and reserving the join switch IP for node: %s, err: %v", nodeName, err)
-			}
-		}
+	return manager.quarantine.Purge()
+}
+
+// QuarantinedIPs returns the number of quarantined IPs
+func (manager *LogicalSwitchManager) QuarantinedIPs() int {
+	if manager.quarantine == nil {
+		return 0
 	}
-	return &j, nil
+	return manager.quarantine.Len()
 }
 
-func (jsIPManager *JoinSwitchIPManager) getJoinLRPCacheIPs(nodeName string) ([]*net.IPNet, bool) {
-	gwLRPIPs, ok := jsIPManager.lrpIPCache[nodeName]
-	return gwLRPIPs, ok
+func quarantineKey(switchName string, ip net.IP) string {
+	return switchName + " " + ip.String()
 }
 
-func sameIPs(a, b []*net.IPNet) bool {
-	if len(a) != len(b) {
-		return false
+func parseQuarantineKey(key string) (string, net.IP) {
+	i := strings.LastIndex(key, " ")
+	if i < 0 {
+		return "", nil
+	}
+	return key[:i], net.ParseIP(key[i+1:])
+}
+
+// reserveQuarantinedIPs keeps the quarantined IPs of the switch allocated in its ipams.
+// The caller must hold the manager lock.
+func (manager *LogicalSwitchManager) reserveQuarantinedIPs(switchName string, ipams []ipam.Interface) {
+	if manager.quarantine == nil {
+		return
 	}
-	for _, aip := range a {
-		found := false
-		for _, bip := range b {
-			if aip.String() == bip.String() {
-				found = true
+	for _, key := range manager.quarantine.Addrs() {
+		quarantinedSwitch, ip := parseQuarantineKey(key)
+		if quarantinedSwitch != switchName || ip == nil {
+			continue
+		}
+		for _, ipam := range ipams {
+			cidr := ipam.CIDR()
+			if cidr.Contains(ip) {
+				if err := ipam.Allocate(ip); err != nil && !errors.Is(err, ipallocator.ErrAllocated) {
+					klog.Warningf("Removing IP %s of switch %s from quarantine %s: %v", ip, switchName,
+						manager.quarantine.Name(), err)
+					manager.quarantine.Remove(key)
+				}
 				break
 			}
 		}
-		if !found {
-			return false
-		}
 	}
-	return true
 }
 
-func (jsIPManager *JoinSwitchIPManager) setJoinLRPCacheIPs(nodeName string, gwLRPIPs []*net.IPNet) error {
-	if oldIPs, ok := jsIPManager.lrpIPCache[nodeName]; ok && !sameIPs(oldIPs, gwLRPIPs) {
-		return fmt.Errorf("join switch IPs %v already cached", oldIPs)
+// releaseQuarantined releases a quarantined IP from the ipam of its switch, if the switch
+// still exists. The caller must hold the manager lock.
+func (manager *LogicalSwitchManager) releaseQuarantined(key string) {
+	switchName, ip := parseQuarantineKey(key)
+	lsi, ok := manager.cache[switchName]
+	if !ok || ip == nil {
+		return
+	}
+	for _, ipam := range lsi.ipams {
+		cidr := ipam.CIDR()
+		if cidr.Contains(ip) {
+			ipam.Release(ip)
+			return
+		}
 	}
-	jsIPManager.lrpIPCache[nodeName] = gwLRPIPs
-	return nil
-}
-
-func (jsIPManager *JoinSwitchIPManager) delJoinLRPCacheIPs(nodeName string) {
-	delete(jsIPManager.lrpIPCache, nodeName)
 }
 
-// reserveJoinLRPIPs tries to add the LRP IPs to the joinSwitchIPManager, then they will be stored in the cache;
-func (jsIPManager *JoinSwitchIPManager) reserveJoinLRPIPs(nodeName string, gwLRPIPs []*net.IPNet) error {
-	// reserve the given IP in the allocator
-	if err := jsIPManager.lsm.AllocateIPs(types.OVNJoinSwitch, gwLRPIPs); err != nil {
-		return err
+// unquarantine takes the IP of the switch out of quarantine, keeping it allocated, and
+// returns whether it was quarantined
+func (manager *LogicalSwitchManager) unquarantine(switchName string, ip net.IP) bool {
+	if manager.quarantine == nil {
+		return false
 	}
+	return manager.quarantine.Remove(quarantineKey(switchName, ip))
+}
 
-	// store the allocated IPs in the cache if possible
-	if err := jsIPManager.setJoinLRPCacheIPs(nodeName, gwLRPIPs); err != nil {
-		// if storing the IPs to the cache fails, release the IPs again and return the error
-		klog.Errorf("Failed to add node %s reserved IPs %v to the join switch IP cache: %s", nodeName, gwLRPIPs, err.Error())
-		if relErr := jsIPManager.lsm.ReleaseIPs(types.OVNJoinSwitch, gwLRPIPs); relErr != nil {
-			klog.Errorf("Failed to release logical router port IPs %v just reserved for node %s: %q",
-				util.JoinIPNetIPs(gwLRPIPs, " "), nodeName, relErr)
+// allocateNext allocates the next IP of an ipam of the switch. If the ipam is exhausted and
+// releaseQuarantineWhenExhausted is set, quarantined IPs of the ipam are released, oldest
+// first, until the allocation succeeds. The caller must hold the manager lock.
+func (manager *LogicalSwitchManager) allocateNext(switchName string, ipamInstance ipam.Interface) (net.IP, error) {
+	for {
+		ip, err := ipamInstance.AllocateNext()
+		if !errors.Is(err, ipam.ErrFull) || manager.quarantine == nil || !manager.releaseQuarantineWhenExhausted {
+			return ip, err
 		}
-		return err
+		cidr := ipamInstance.CIDR()
+		key, ok := manager.quarantine.PopOldest(func(key string) bool {
+			quarantinedSwitch, ip := parseQuarantineKey(key)
+			return quarantinedSwitch == switchName && ip != nil && cidr.Contains(ip)
+		})
+		if !ok {
+			return ip, err
+		}
+		manager.releaseQuarantined(key)
 	}
-
-	return nil
 }
 
-// ensureJoinLRPIPs tries to allocate the LRP IPs if it is not yet allocated, then they will be stored in the cache
-func (jsIPManager *JoinSwitchIPManager) EnsureJoinLRPIPs(nodeName string) (gwLRPIPs []*net.IPNet, err error) {
-	jsIPManager.lrpIPCacheLock.Lock()
-	defer jsIPManager.lrpIPCacheLock.Unlock()
-	// first check the IP cache, return if an entry already exists
-	gwLRPIPs, ok := jsIPManager.getJoinLRPCacheIPs(nodeName)
-	if ok {
-		return gwLRPIPs, nil
-	}
-	// second check the running DB
-	gwLRPIPs = jsIPManager.getJoinLRPAddresses(nodeName)
-	if len(gwLRPIPs) > 0 {
-		// Saving the hit in the cache
-		err = jsIPManager.reserveJoinLRPIPs(nodeName, gwLRPIPs)
-		if err != nil {
-			klog.Errorf("Failed to add reserve IPs to the join switch IP cache: %s", err.Error())
-			return nil, err
-		}
-		return gwLRPIPs, nil
+// ConditionalIPRelease determines if any IP is available to be released from an IPAM conditionally if func is true.
+// It guarantees state of the allocator will not change while executing the predicate function
+// TODO(trozet): add unit testing for this function
+func (manager *LogicalSwitchManager) ConditionalIPRelease(switchName string, ipnets []*net.IPNet, predicate func() (bool, error)) (bool, error) {
+	manager.RLock()
+	defer manager.RUnlock()
+	if ipnets == nil || switchName == "" {
+		klog.V(5).Infof("Switch name is empty or ip slice to release is nil")
+		return false, nil
 	}
-	gwLRPIPs, err = jsIPManager.lsm.AllocateNextIPs(types.OVNJoinSwitch)
-	if err != nil {
-		return nil, err
+	lsi, ok := manager.cache[switchName]
+	if !ok {
+		return false, nil
 	}
-
-	defer func() {
-		if err != nil {
-			if relErr := jsIPManager.lsm.ReleaseIPs(types.OVNJoinSwitch, gwLRPIPs); relErr != nil {
-				klog.Errorf("Failed to release logical router port IPs %v for node %s: %q",
-					util.JoinIPNetIPs(gwLRPIPs, " "), nodeName, relErr)
-			}
-		}
-	}()
-
-	if err = jsIPManager.setJoinLRPCacheIPs(nodeName, gwLRPIPs); err != nil {
-		klog.Errorf("Failed to add node %s reserved IPs %v to the join switch IP cache: %s", nodeName, gwLRPIPs, err.Error())
-		return nil, err
+	if len(lsi.ipams) == 0 {
+		return false, nil
 	}
 
-	return gwLRPIPs, nil
-}
-
-// getJoinLRPAddresses check if IPs of gateway logical router port are within the join switch IP range, and return them if true.
-func (jsIPManager *JoinSwitchIPManager) getJoinLRPAddresses(nodeName string) []*net.IPNet {
-	// try to get the IPs from the logical router port
-	gwLRPIPs := []*net.IPNet{}
-	gwLrpName := types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + nodeName
-	joinSubnets := jsIPManager.lsm.GetSwitchSubnets(types.OVNJoinSwitch)
-	ifAddrs, err := util.GetLRPAddrs(jsIPManager.nbClient, gwLrpName)
-	if err == nil {
-		for _, ifAddr := range ifAddrs {
-			for _, subnet := range joinSubnets {
-				if subnet.Contains(ifAddr.IP) {
-					gwLRPIPs = append(gwLRPIPs, &net.IPNet{IP: ifAddr.IP, Mask: subnet.Mask})
-					break
+	// check if ipam has one of the ip addresses, and then execute the predicate function to determine
+	// if this IP should be released or not
+	for _, ipnet := range ipnets {
+		for _, ipam := range lsi.ipams {
+			cidr := ipam.CIDR()
+			if cidr.Contains(ipnet.IP) {
+				if ipam.Has(ipnet.IP) {
+					return predicate()
 				}
 			}
 		}
 	}
 
-	if len(gwLRPIPs) != len(joinSubnets) {
-		var errStr string
-		if len(gwLRPIPs) == 0 {
-			errStr = "Failed to get IPs"
-		} else {
-			errStr = fmt.Sprintf("Invalid IPs %s (possibly not in the range of subnet %s)",
-				util.JoinIPNets(gwLRPIPs, " "), util.JoinIPNets(joinSubnets, " "))
-		}
-		klog.Warningf("%s for logical router port %s", errStr, gwLrpName)
-		return []*net.IPNet{}
+	return false, nil
+}
+
+// NewJoinIPAMAllocator provides an ipam interface which can be used for join switch IPAM
+// allocations for the specified cidr using a contiguous allocation strategy.
+func NewJoinIPAMAllocator(cidr *net.IPNet) (ipam.Interface, error) {
+	subnetRange, err := ipam.NewAllocatorCIDRRange(cidr, func(max int, rangeSpec string) (allocator.Interface, error) {
+		return allocator.NewContiguousAllocationMap(max, rangeSpec), nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return gwLRPIPs
+	return subnetRange, nil
 }
 
-func (jsIPManager *JoinSwitchIPManager) ReleaseJoinLRPIPs(nodeName string) (err error) {
-	jsIPManager.lrpIPCacheLock.Lock()
-	defer jsIPManager.lrpIPCacheLock.Unlock()
-	gwLRPIPs, ok := jsIPManager.getJoinLRPCacheIPs(nodeName)
-	if ok {
-		err = jsIPManager.lsm.ReleaseIPs(types.OVNJoinSwitch, gwLRPIPs)
-		jsIPManager.delJoinLRPCacheIPs(nodeName)
+// NewJoinSwitchManager initializes a new join switch logical switch manager,
+// only manage subnet for the join switch
+func NewJoinSwitchManager() *LogicalSwitchManager {
+	return &LogicalSwitchManager{
+		cache:    make(map[string]logicalSwitchInfo),
+		RWMutex:  sync.RWMutex{},
+		ipamFunc: NewJoinIPAMAllocator,
 	}
-	return err
 }
 
 // NewL2SwitchManager initializes a new layer2 logical switch manager,
@@ -2,12 +2,14 @@ package logicalswitchmanager
 
 import (
 	"net"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/quarantine"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 
 	"github.com/onsi/ginkgo"
@@ -315,6 +317,41 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("reports the IP usage of all switches", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				err = lsManager.AddSwitch("testNode1", "", ovntest.MustParseIPNets("10.1.1.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = lsManager.AddSwitch("testNode2", "", ovntest.MustParseIPNets("10.1.2.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				allocated, available := lsManager.GetIPUsage()
+				// the gateway and management port IPs are reserved on every switch
+				gomega.Expect(allocated).To(gomega.Equal(4))
+
+				ips, err := lsManager.AllocateNextIPs("testNode1")
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				newAllocated, newAvailable := lsManager.GetIPUsage()
+				gomega.Expect(newAllocated).To(gomega.Equal(allocated + 1))
+				gomega.Expect(newAvailable).To(gomega.Equal(available - 1))
+				gomega.Expect(lsManager.GetIPUsageBySwitch()).To(gomega.Equal(map[string]IPUsage{
+					"testNode1": {Allocated: 3, Available: available/2 - 1},
+					"testNode2": {Allocated: 2, Available: available / 2},
+				}))
+
+				err = lsManager.ReleaseIPs("testNode1", ips)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				lsManager.DeleteSwitch("testNode2")
+				newAllocated, newAvailable = lsManager.GetIPUsage()
+				gomega.Expect(newAllocated).To(gomega.Equal(allocated / 2))
+				gomega.Expect(newAvailable).To(gomega.Equal(available / 2))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("releases IPs for other host subnet nodes when any host subnets allocation fails", func() {
 			app.Action = func(ctx *cli.Context) error {
 				_, err := config.InitConfig(ctx, fexec, nil)
@@ -392,6 +429,55 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("quarantines the released IPs of deleted pods", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				switchName := "testNode1"
+				q := quarantine.NewList("pod-ips.test", time.Hour, nil, nil)
+				lsManager.SetQuarantine(q, false)
+				err = lsManager.AddSwitch(switchName, "", ovntest.MustParseIPNets("10.1.1.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				ips, err := lsManager.AllocateNextIPs(switchName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips[0].IP.String()).To(gomega.Equal("10.1.1.3"))
+				err = lsManager.QuarantineIPs(switchName, ips)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(q.Addrs()).To(gomega.Equal([]string{"testNode1 10.1.1.3"}))
+				gomega.Expect(lsManager.isAllocatedIP(switchName, "10.1.1.3")).To(gomega.BeTrue())
+
+				// quarantined IPs are reserved again when the switch is added back
+				lsManager.DeleteSwitch(switchName)
+				err = lsManager.AddSwitch(switchName, "", ovntest.MustParseIPNets("10.1.1.0/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(lsManager.isAllocatedIP(switchName, "10.1.1.3")).To(gomega.BeTrue())
+
+				// quarantined IPs are only allocated again when the switch is exhausted, if allowed
+				err = lsManager.AllocateUntilFull(switchName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = lsManager.AllocateNextIPs(switchName)
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				lsManager.SetQuarantine(q, true)
+				ips, err = lsManager.AllocateNextIPs(switchName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips[0].IP.String()).To(gomega.Equal("10.1.1.3"))
+				gomega.Expect(q.Len()).To(gomega.Equal(0))
+
+				// a quarantined IP claimed by a pod is taken out of quarantine
+				claimed := ovntest.MustParseIPNets("10.1.1.4/24")
+				err = lsManager.QuarantineIPs(switchName, claimed)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(q.Has("testNode1 10.1.1.4")).To(gomega.BeTrue())
+				err = lsManager.AllocateIPs(switchName, claimed)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(q.Len()).To(gomega.Equal(0))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 	})
 
 })
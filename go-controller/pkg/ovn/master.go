@@ -23,7 +23,6 @@ import (
 
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	houtil "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/util"
-	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -111,7 +110,7 @@ func (oc *DefaultNetworkController) upgradeOVNTopology(existingNodes *kapi.NodeL
 }
 
 // SetupMaster creates the central router and load-balancers for the network
-func (oc *DefaultNetworkController) SetupMaster(existingNodeNames []string) error {
+func (oc *DefaultNetworkController) SetupMaster() error {
 	// Create default Control Plane Protection (COPP) entry for routers
 	logicalRouter, err := oc.createOvnClusterRouter()
 	if err != nil {
@@ -185,18 +184,11 @@ func (oc *DefaultNetworkController) SetupMaster(existingNodeNames []string) erro
 		return fmt.Errorf("failed to create logical switch %+v: %v", logicalSwitch, err)
 	}
 
-	// Initialize the OVNJoinSwitch switch IP manager
-	// The OVNJoinSwitch will be allocated IP addresses in the range 100.64.0.0/16 or fd98::/64.
-	oc.joinSwIPManager, err = lsm.NewJoinLogicalSwitchIPManager(oc.nbClient, logicalSwitch.UUID, existingNodeNames)
+	// The logical router port "GwRouterToJoinSwitchPrefix + OVNClusterRouter" gets the first IPs in the join
+	// switch subnets, which are reserved by cluster manager
+	gwLRPIfAddrs, err := getClusterRouterJoinIPs()
 	if err != nil {
-		return err
-	}
-
-	// Allocate IPs for logical router port "GwRouterToJoinSwitchPrefix + OVNClusterRouter". This should always
-	// allocate the first IPs in the join switch subnets
-	gwLRPIfAddrs, err := oc.joinSwIPManager.EnsureJoinLRPIPs(types.OVNClusterRouter)
-	if err != nil {
-		return fmt.Errorf("failed to allocate join switch IP address connected to %s: %v", types.OVNClusterRouter, err)
+		return fmt.Errorf("failed to get join switch IP address connected to %s: %v", types.OVNClusterRouter, err)
 	}
 
 	// Connect the distributed router to OVNJoinSwitch.
@@ -252,6 +244,7 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 		}
 	}
 
+	gatewayMode := nodeGatewayMode(node)
 	var v4Subnet *net.IPNet
 	addresses := macAddress.String()
 	for _, hostSubnet := range hostSubnets {
@@ -265,7 +258,7 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 		if !utilnet.IsIPv6CIDR(hostSubnet) {
 			v4Subnet = hostSubnet
 		}
-		if config.Gateway.Mode == config.GatewayModeLocal {
+		if gatewayMode == config.GatewayModeLocal {
 			lrsr := nbdb.LogicalRouterStaticRoute{
 				Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
 				IPPrefix: hostSubnet.String(),
@@ -308,20 +301,35 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 	return nil
 }
 
+// getClusterRouterJoinIPs returns the IPs of the cluster router port to the join switch,
+// the first IPs of the join subnets
+func getClusterRouterJoinIPs() ([]*net.IPNet, error) {
+	joinSubnets, err := util.GetJoinSubnets()
+	if err != nil {
+		return nil, err
+	}
+	clusterRouterIPs := make([]*net.IPNet, 0, len(joinSubnets))
+	for _, joinSubnet := range joinSubnets {
+		clusterRouterIPs = append(clusterRouterIPs, util.GetNodeGatewayIfAddr(joinSubnet))
+	}
+	return clusterRouterIPs, nil
+}
+
 func (oc *DefaultNetworkController) syncGatewayLogicalNetwork(node *kapi.Node, l3GatewayConfig *util.L3GatewayConfig,
 	hostSubnets []*net.IPNet, hostAddrs sets.Set[string]) error {
 	var err error
-	var gwLRPIPs, clusterSubnets []*net.IPNet
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
-		clusterSubnets = append(clusterSubnets, clusterSubnet.CIDR)
-	}
+	var gwLRPIPs []*net.IPNet
+	clusterSubnets := getClusterSubnetCIDRs()
 
-	gwLRPIPs, err = oc.joinSwIPManager.EnsureJoinLRPIPs(node.Name)
+	gwLRPIPs, err = util.ParseNodeGatewayRouterLRPAddrs(node, types.DefaultNetworkName)
 	if err != nil {
-		return fmt.Errorf("failed to allocate join switch port IP address for node %s: %v", node.Name, err)
+		return fmt.Errorf("failed to get join switch port IP address for node %s: %v", node.Name, err)
 	}
 
-	drLRPIPs, _ := oc.joinSwIPManager.EnsureJoinLRPIPs(types.OVNClusterRouter)
+	drLRPIPs, err := getClusterRouterJoinIPs()
+	if err != nil {
+		return err
+	}
 
 	enableGatewayMTU := util.ParseNodeGatewayMTUSupport(node)
 
@@ -331,6 +339,10 @@ func (oc *DefaultNetworkController) syncGatewayLogicalNetwork(node *kapi.Node, l
 		return fmt.Errorf("failed to init shared interface gateway: %v", err)
 	}
 
+	if err := oc.syncEgressInterfaceGateways(node); err != nil {
+		return fmt.Errorf("failed to sync the EgressInterface gateways: %v", err)
+	}
+
 	for _, subnet := range hostSubnets {
 		hostIfAddr := util.GetNodeManagementIfAddr(subnet)
 		l3GatewayConfigIP, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6(hostIfAddr.IP), l3GatewayConfig.IPAddresses)
@@ -352,16 +364,15 @@ func (oc *DefaultNetworkController) syncGatewayLogicalNetwork(node *kapi.Node, l
 func (oc *DefaultNetworkController) ensureNodeLogicalNetwork(node *kapi.Node, hostSubnets []*net.IPNet) error {
 	var hostNetworkPolicyIPs []net.IP
 
-	switchName := node.Name
 	for _, hostSubnet := range hostSubnets {
 		mgmtIfAddr := util.GetNodeManagementIfAddr(hostSubnet)
 		hostNetworkPolicyIPs = append(hostNetworkPolicyIPs, mgmtIfAddr.IP)
 	}
 
 	// also add the join switch IPs for this node - needed in shared gateway mode
-	lrpIPs, err := oc.joinSwIPManager.EnsureJoinLRPIPs(switchName)
+	lrpIPs, err := util.ParseNodeGatewayRouterLRPAddrs(node, types.DefaultNetworkName)
 	if err != nil {
-		return fmt.Errorf("failed to get join switch port IP address for switch %s: %v", switchName, err)
+		return fmt.Errorf("failed to get join switch port IP address for node %s: %v", node.Name, err)
 	}
 
 	for _, lrpIP := range lrpIPs {
@@ -413,26 +424,8 @@ func (oc *DefaultNetworkController) addNode(node *kapi.Node) ([]*net.IPNet, erro
 			node.Name, config.IPv4Mode, haveV4, config.IPv6Mode, haveV6)
 	}
 
-	gwLRPIPs, err := oc.joinSwIPManager.EnsureJoinLRPIPs(node.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to allocate join switch port IP address for node %s: %v", node.Name, err)
-	}
-	var v4Addr, v6Addr *net.IPNet
-	for _, ip := range gwLRPIPs {
-		if ip.IP.To4() != nil {
-			v4Addr = ip
-		} else if ip.IP.To16() != nil {
-			v6Addr = ip
-		}
-	}
-	updatedNodeAnnotation, err := util.CreateNodeGatewayRouterLRPAddrAnnotation(nil, v4Addr, v6Addr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal node %q annotation for Gateway LRP IP %v",
-			node.Name, gwLRPIPs)
-	}
-
-	err = oc.UpdateNodeAnnotationWithRetry(node.Name, updatedNodeAnnotation)
-	if err != nil {
+	// The node gateway router join IPs are allocated by cluster manager as well.
+	if _, err = util.ParseNodeGatewayRouterLRPAddrs(node, types.DefaultNetworkName); err != nil {
 		return nil, err
 	}
 
@@ -453,12 +446,17 @@ func (oc *DefaultNetworkController) addNode(node *kapi.Node) ([]*net.IPNet, erro
 	return hostSubnets, nil
 }
 
-// check if any existing chassis entries in the SBDB mismatches with node's chassisID annotation
+// check if any existing chassis entries in the SBDB mismatches with node's chassisID annotation, or
+// with the chassis IDs of the DPUs of the node when it has several
 func (oc *DefaultNetworkController) checkNodeChassisMismatch(node *kapi.Node) (string, error) {
 	chassisID, err := util.ParseNodeChassisIDAnnotation(node)
 	if err != nil {
 		return "", nil
 	}
+	chassisIDs := sets.NewString(chassisID)
+	for _, dpuChassisID := range util.ParseNodeDPUChassisIDs(node) {
+		chassisIDs.Insert(dpuChassisID)
+	}
 
 	chassisList, err := libovsdbops.ListChassis(oc.sbClient)
 	if err != nil {
@@ -466,7 +464,7 @@ func (oc *DefaultNetworkController) checkNodeChassisMismatch(node *kapi.Node) (s
 	}
 
 	for _, chassis := range chassisList {
-		if chassis.Hostname == node.Name && chassis.Name != chassisID {
+		if chassis.Hostname == node.Name && !chassisIDs.Has(chassis.Name) {
 			return chassis.Name, nil
 		}
 	}
@@ -503,10 +501,6 @@ func (oc *DefaultNetworkController) deleteNode(nodeName string) error {
 		return fmt.Errorf("failed to clean up node %s gateway: (%v)", nodeName, err)
 	}
 
-	if err := oc.joinSwIPManager.ReleaseJoinLRPIPs(nodeName); err != nil {
-		return fmt.Errorf("failed to clean up GR LRP IPs for node %s: %v", nodeName, err)
-	}
-
 	p := func(item *sbdb.Chassis) bool {
 		return item.Hostname == nodeName
 	}
@@ -623,12 +617,6 @@ func (oc *DefaultNetworkController) syncNodes(nodes []interface{}) error {
 			continue
 		}
 		foundNodes.Insert(node.Name)
-
-		// For each existing node, reserve its joinSwitch LRP IPs if they already exist.
-		if _, err := oc.joinSwIPManager.EnsureJoinLRPIPs(node.Name); err != nil {
-			// TODO (flaviof): keep going even if EnsureJoinLRPIPs returned an error. Maybe we should not.
-			klog.Errorf("Failed to get join switch port IP address for node %s: %v", node.Name, err)
-		}
 	}
 
 	defaultNetworkPredicate := func(item *nbdb.LogicalSwitch) bool {
@@ -839,13 +827,14 @@ func (oc *DefaultNetworkController) deleteNodeEvent(node *kapi.Node) error {
 	oc.mgmtPortFailed.Delete(node.Name)
 	oc.gatewaysFailed.Delete(node.Name)
 	oc.nodeClusterRouterPortFailed.Delete(node.Name)
+	oc.gatewayModes.Delete(node.Name)
 	return nil
 }
 
 func (oc *DefaultNetworkController) createACLLoggingMeter() error {
 	band := &nbdb.MeterBand{
 		Action: types.MeterAction,
-		Rate:   config.Logging.ACLLoggingRateLimit,
+		Rate:   config.GetACLLoggingRateLimit(),
 	}
 	ops, err := libovsdbops.CreateMeterBandOps(oc.nbClient, nil, band)
 	if err != nil {
@@ -21,7 +21,6 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
-	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -77,6 +76,11 @@ func (n tNode) k8sNode() v1.Node {
 			Addresses: []kapi.NodeAddress{{Type: kapi.NodeExternalIP, Address: n.NodeIP}},
 		},
 	}
+	if n.LrpIP != "" {
+		// the gateway router join IPs are allocated by cluster manager
+		node.Annotations, _ = util.UpdateNodeGatewayRouterLRPAddrsAnnotation(nil,
+			[]*net.IPNet{ovntest.MustParseIPNet(n.LrpIP + "/16")}, types.DefaultNetworkName)
+	}
 
 	return node
 }
@@ -1023,10 +1027,6 @@ var _ = ginkgo.Describe("Default network controller operations", func() {
 		}()
 
 		oc.SCTPSupport = true
-		oc.joinSwIPManager, err = lsm.NewJoinLogicalSwitchIPManager(oc.nbClient, expectedNodeSwitch.UUID, []string{node1.Name})
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		_, err = oc.joinSwIPManager.EnsureJoinLRPIPs(types.OVNClusterRouter)
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 		expectedNBDatabaseState = addNodeLogicalFlows(nil, expectedOVNClusterRouter, expectedNodeSwitch, expectedClusterRouterPortGroup, expectedClusterPortGroup, &node1)
 	})
@@ -1243,6 +1243,12 @@ var _ = ginkgo.Describe("Default network controller operations", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := config.InitConfig(ctx, nil, nil)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			// the node runs in the shared gateway mode of the cluster
+			l3GatewayConfig = node1.gatewayConfig(config.GatewayModeShared, uint(vlanID))
+			err = util.SetL3GatewayConfig(nodeAnnotator, l3GatewayConfig)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = nodeAnnotator.Run()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			clusterSubnets := startFakeController(oc, wg)
 
 			skipSnat := false
@@ -1290,6 +1296,12 @@ var _ = ginkgo.Describe("Default network controller operations", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := config.InitConfig(ctx, nil, nil)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			// the node runs in the shared gateway mode of the cluster
+			l3GatewayConfig = node1.gatewayConfig(config.GatewayModeShared, uint(vlanID))
+			err = util.SetL3GatewayConfig(nodeAnnotator, l3GatewayConfig)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = nodeAnnotator.Run()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			clusterSubnets := startFakeController(oc, wg)
 
 			skipSnat := false
@@ -1467,7 +1479,8 @@ var _ = ginkgo.Describe("Default network controller operations", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "newNode",
 					Annotations: map[string]string{
-						"k8s.ovn.org/node-subnets": fmt.Sprintf("{\"default\":[\"%s\", \"fd02:0:0:2::2895/64\"]}", newNodeSubnet),
+						"k8s.ovn.org/node-subnets":                   fmt.Sprintf("{\"default\":[\"%s\", \"fd02:0:0:2::2895/64\"]}", newNodeSubnet),
+						"k8s.ovn.org/node-gateway-router-lrp-ifaddr": "{\"ipv4\":\"100.64.0.3/16\"}",
 					},
 				},
 			}
@@ -1648,11 +1661,6 @@ func TestController_syncNodes(t *testing.T) {
 				record.NewFakeRecorder(0),
 				wg)
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
-			controller.joinSwIPManager, err = lsm.NewJoinLogicalSwitchIPManager(nbClient, "", []string{})
-			if err != nil {
-				t.Fatalf("%s: Error creating joinSwIPManager: %v", tt.name, err)
-			}
-
 			err = controller.syncNodes([]interface{}{&testNode})
 			if err != nil {
 				t.Fatalf("%s: Error on syncNodes: %v", tt.name, err)
@@ -1699,6 +1707,33 @@ func TestController_deleteStaleNodeChassis(t *testing.T) {
 				&sbdb.ChassisPrivate{Name: "chassis-node1-dpu"},
 			},
 		},
+		{
+			node: v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						"k8s.ovn.org/node-chassis-id":      "chassis-node1-dpu0",
+						"k8s.ovn.org/node-chassis-id.dpu0": "chassis-node1-dpu0",
+						"k8s.ovn.org/node-chassis-id.dpu1": "chassis-node1-dpu1",
+					},
+				},
+			},
+			name: "keeps the chassis of every DPU of the node",
+			initialSBDB: []libovsdbtest.TestData{
+				&sbdb.Chassis{Name: "chassis-node1-dpu0", Hostname: "node1"},
+				&sbdb.ChassisPrivate{Name: "chassis-node1-dpu0"},
+				&sbdb.Chassis{Name: "chassis-node1-dpu1", Hostname: "node1"},
+				&sbdb.ChassisPrivate{Name: "chassis-node1-dpu1"},
+				&sbdb.Chassis{Name: "chassis-node1", Hostname: "node1"},
+				&sbdb.ChassisPrivate{Name: "chassis-node1"},
+			},
+			expectedSBDB: []libovsdbtest.TestData{
+				&sbdb.Chassis{Name: "chassis-node1-dpu0", Hostname: "node1"},
+				&sbdb.ChassisPrivate{Name: "chassis-node1-dpu0"},
+				&sbdb.Chassis{Name: "chassis-node1-dpu1", Hostname: "node1"},
+				&sbdb.ChassisPrivate{Name: "chassis-node1-dpu1"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1741,11 +1776,6 @@ func TestController_deleteStaleNodeChassis(t *testing.T) {
 				record.NewFakeRecorder(0),
 				wg)
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
-			controller.joinSwIPManager, err = lsm.NewJoinLogicalSwitchIPManager(nbClient, "", []string{})
-			if err != nil {
-				t.Fatalf("%s: Error creating joinSwIPManager: %v", tt.name, err)
-			}
-
 			err = controller.deleteStaleNodeChassis(&tt.node)
 			if err != nil {
 				t.Fatalf("%s: Error on syncNodes: %v", tt.name, err)
@@ -2,15 +2,25 @@ package ovn
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	mcastpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 )
 
 const (
@@ -20,8 +30,27 @@ const (
 	ipv6DynamicMulticastMatch = "(ip6.dst[120..127] == 0xff && ip6.dst[116] == 1)"
 	// Legacy multicastDefaultDeny port group removed by commit 40a90f0
 	legacyMulticastDefaultDenyPortGroup = "mcastPortGroupDeny"
+	// multicastPolicyName is the name of the MulticastPolicy of a namespace,
+	// MulticastPolicies with other names are ignored
+	multicastPolicyName = "default"
+	// mcastFloodOption and mcastFloodReportsOption are the options of the
+	// logical switch ports of the pods of a namespace that its MulticastPolicy
+	// sets to disable IGMP/MLD snooping and to forward the IGMP/MLD reports to
+	// them
+	mcastFloodOption        = "mcast_flood"
+	mcastFloodReportsOption = "mcast_flood_reports"
 )
 
+// multicastPolicyMatch holds the multicast groups and the address sets of
+// the peer namespaces of the MulticastPolicy of a namespace
+type multicastPolicyMatch struct {
+	// groups are the allowed groups, nil if any group is allowed
+	v4Groups, v6Groups []string
+	// v4PeerAddrSets and v6PeerAddrSets are the hashed names of the address
+	// sets of the namespace and of its peer namespaces
+	v4PeerAddrSets, v6PeerAddrSets []string
+}
+
 func getACLMatchAF(ipv4Match, ipv6Match string) string {
 	if config.IPv4Mode && config.IPv6Mode {
 		return "(" + ipv4Match + " || " + ipv6Match + ")"
@@ -49,33 +78,223 @@ func getMulticastACLIgrMatchV6(addrSetName string) string {
 	return "(mldv1 || mldv2 || (ip6.src == $" + addrSetName + " && " + ipv6DynamicMulticastMatch + "))"
 }
 
+// getMulticastGroupsMatch returns the match on the destination groups of the
+// multicast traffic, an empty string if any group is allowed
+func getMulticastGroupsMatch(field string, groups []string) string {
+	if groups == nil {
+		return ""
+	}
+	if len(groups) == 0 {
+		return " && 0"
+	}
+	return " && " + field + " == {" + strings.Join(groups, ", ") + "}"
+}
+
+// getAddrSetsMatch returns the set of the address sets for an ACL match
+func getAddrSetsMatch(addrSets []string) string {
+	if len(addrSets) == 1 {
+		return "$" + addrSets[0]
+	}
+	return "{$" + strings.Join(addrSets, ", $") + "}"
+}
+
+// Allow IGMP traffic and the multicast traffic of the namespace and of its
+// peer namespaces to the allowed groups towards pods.
+func (m *multicastPolicyMatch) getMulticastACLIgrMatchV4() string {
+	return "(igmp || (ip4.src == " + getAddrSetsMatch(m.v4PeerAddrSets) + " && ip4.mcast" +
+		getMulticastGroupsMatch("ip4.dst", m.v4Groups) + "))"
+}
+
+// Allow MLD traffic and the multicast traffic of the namespace and of its
+// peer namespaces to the allowed groups towards pods.
+func (m *multicastPolicyMatch) getMulticastACLIgrMatchV6() string {
+	return "(mldv1 || mldv2 || (ip6.src == " + getAddrSetsMatch(m.v6PeerAddrSets) + " && " +
+		ipv6DynamicMulticastMatch + getMulticastGroupsMatch("ip6.dst", m.v6Groups) + "))"
+}
+
 // Creates the match string used for ACLs allowing incoming multicast into a
-// namespace, that is, from IPs that are in the namespace's address set.
-func getMulticastACLIgrMatch(nsInfo *namespaceInfo) string {
+// namespace, that is, from IPs that are in the namespace's address set or in
+// the address sets of its peer namespaces.
+func getMulticastACLIgrMatch(nsInfo *namespaceInfo, policyMatch *multicastPolicyMatch) string {
 	var ipv4Match, ipv6Match string
-	addrSetNameV4, addrSetNameV6 := nsInfo.addressSet.GetASHashNames()
-	if config.IPv4Mode {
-		ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
-	}
-	if config.IPv6Mode {
-		ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+	if policyMatch == nil {
+		addrSetNameV4, addrSetNameV6 := nsInfo.addressSet.GetASHashNames()
+		if config.IPv4Mode {
+			ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
+		}
+		if config.IPv6Mode {
+			ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+		}
+	} else {
+		if config.IPv4Mode {
+			ipv4Match = policyMatch.getMulticastACLIgrMatchV4()
+		}
+		if config.IPv6Mode {
+			ipv6Match = policyMatch.getMulticastACLIgrMatchV6()
+		}
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match)
 }
 
 // Creates the match string used for ACLs allowing outgoing multicast from a
-// namespace.
-func getMulticastACLEgrMatch() string {
+// namespace. IGMP and MLD reports are always allowed, the multicast traffic is
+// restricted to the allowed groups of the MulticastPolicy of the namespace, if
+// any.
+func getMulticastACLEgrMatch(policyMatch *multicastPolicyMatch) string {
 	var ipv4Match, ipv6Match string
-	if config.IPv4Mode {
-		ipv4Match = "ip4.mcast"
-	}
-	if config.IPv6Mode {
-		ipv6Match = "(mldv1 || mldv2 || " + ipv6DynamicMulticastMatch + ")"
+	if policyMatch == nil {
+		if config.IPv4Mode {
+			ipv4Match = "ip4.mcast"
+		}
+		if config.IPv6Mode {
+			ipv6Match = "(mldv1 || mldv2 || " + ipv6DynamicMulticastMatch + ")"
+		}
+	} else {
+		if config.IPv4Mode {
+			ipv4Match = "(igmp || (ip4.mcast" + getMulticastGroupsMatch("ip4.dst", policyMatch.v4Groups) + "))"
+		}
+		if config.IPv6Mode {
+			ipv6Match = "(mldv1 || mldv2 || (" + ipv6DynamicMulticastMatch +
+				getMulticastGroupsMatch("ip6.dst", policyMatch.v6Groups) + "))"
+		}
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match)
 }
 
+// parseMulticastGroups validates the multicast group ranges of a
+// MulticastPolicy and splits them by IP family. It returns nil slices if any
+// group is allowed.
+func parseMulticastGroups(groups []string) ([]string, []string, error) {
+	if len(groups) == 0 {
+		return nil, nil, nil
+	}
+	v4Groups, v6Groups := []string{}, []string{}
+	for _, group := range groups {
+		ip, ipNet, err := net.ParseCIDR(group)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid multicast group range %q: %v", group, err)
+		}
+		// 224.0.0.0/4 and ff00::/8
+		ones, _ := ipNet.Mask.Size()
+		if !ip.IsMulticast() || (utilnet.IsIPv6(ip) && ones < 8) || (!utilnet.IsIPv6(ip) && ones < 4) {
+			return nil, nil, fmt.Errorf("group range %q is not a multicast range", group)
+		}
+		if utilnet.IsIPv6(ip) {
+			v6Groups = append(v6Groups, ipNet.String())
+		} else {
+			v4Groups = append(v4Groups, ipNet.String())
+		}
+	}
+	return v4Groups, v6Groups, nil
+}
+
+// getMulticastPolicy returns the MulticastPolicy of namespace ns, nil if the
+// namespace has no MulticastPolicy.
+func (oc *DefaultNetworkController) getMulticastPolicy(ns string) (*mcastpolicyapi.MulticastPolicy, error) {
+	if !config.OVNKubernetesFeature.EnableMulticastPolicy {
+		return nil, nil
+	}
+	policy, err := oc.watchFactory.GetMulticastPolicy(ns, multicastPolicyName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the MulticastPolicy of namespace %s: %v", ns, err)
+	}
+	return policy, nil
+}
+
+// getMulticastPolicyMatch returns the groups and the peer address sets of the
+// MulticastPolicy of namespace ns, nil if the namespace has no
+// MulticastPolicy. Peer namespaces that don't exist are ignored, their address
+// sets are added when they are created.
+func (oc *DefaultNetworkController) getMulticastPolicyMatch(ns string, nsInfo *namespaceInfo,
+	policy *mcastpolicyapi.MulticastPolicy) (*multicastPolicyMatch, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	var err error
+	m := &multicastPolicyMatch{}
+	m.v4Groups, m.v6Groups, err = parseMulticastGroups(policy.Spec.Groups)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MulticastPolicy in namespace %s: %v", ns, err)
+	}
+	v4AddrSet, v6AddrSet := nsInfo.addressSet.GetASHashNames()
+	m.v4PeerAddrSets = append(m.v4PeerAddrSets, v4AddrSet)
+	m.v6PeerAddrSets = append(m.v6PeerAddrSets, v6AddrSet)
+	for _, peer := range policy.Spec.PeerNamespaces {
+		if peer == ns {
+			continue
+		}
+		// namespaces that do not exist yet are added to the policy when
+		// they get created, see syncMulticastPeerNamespace
+		if _, err := oc.watchFactory.GetNamespace(peer); err != nil {
+			continue
+		}
+		v4AddrSet, v6AddrSet = addressset.GetHashNamesForAS(getNamespaceAddrSetDbIDs(peer, oc.controllerName))
+		m.v4PeerAddrSets = append(m.v4PeerAddrSets, v4AddrSet)
+		m.v6PeerAddrSets = append(m.v6PeerAddrSets, v6AddrSet)
+	}
+	return m, nil
+}
+
+// getMulticastPortOptions returns the options of the logical switch ports of
+// the pods of a namespace requested by its MulticastPolicy, policy is nil if
+// the namespace has none. IGMP/MLD snooping and the querier are options of the
+// node switches, shared by all the namespaces, so snooping is disabled for the
+// pods of the namespace by flooding the multicast traffic to their ports, and
+// the IGMP/MLD reports are flooded to their ports for multicast routers to
+// learn the group memberships. The querier can't be configured per namespace.
+// Options that are not requested are set to an empty value to remove them.
+func getMulticastPortOptions(policy *mcastpolicyapi.MulticastPolicy) map[string]string {
+	options := map[string]string{
+		mcastFloodOption:        "",
+		mcastFloodReportsOption: "",
+	}
+	if policy == nil {
+		return options
+	}
+	if policy.Spec.IGMPSnooping != nil && !*policy.Spec.IGMPSnooping {
+		options[mcastFloodOption] = "true"
+	}
+	if policy.Spec.FloodReports {
+		options[mcastFloodReportsOption] = "true"
+	}
+	return options
+}
+
+// getNamespacePortUUIDs returns the UUIDs of the logical switch ports of the
+// pods of namespace ns
+func (oc *DefaultNetworkController) getNamespacePortUUIDs(ns string) []string {
+	portUUIDs := []string{}
+	pods, err := oc.watchFactory.GetPods(ns)
+	if err != nil {
+		klog.Warningf("Failed to get pods for namespace %q: %v", ns, err)
+	}
+	for _, pod := range pods {
+		if util.PodCompleted(pod) {
+			continue
+		}
+		if portInfo, err := oc.logicalPortCache.get(pod, types.DefaultNetworkName); err != nil {
+			klog.Errorf(err.Error())
+		} else {
+			portUUIDs = append(portUUIDs, portInfo.uuid)
+		}
+	}
+	return portUUIDs
+}
+
+// setMulticastPortOptionsOps returns the ops to set the multicast options on
+// the logical switch ports with the given UUIDs
+func (oc *DefaultNetworkController) setMulticastPortOptionsOps(ops []ovsdb.Operation, portUUIDs []string,
+	options map[string]string) ([]ovsdb.Operation, error) {
+	lsps := make([]*nbdb.LogicalSwitchPort, 0, len(portUUIDs))
+	for _, portUUID := range portUUIDs {
+		lsps = append(lsps, &nbdb.LogicalSwitchPort{UUID: portUUID, Options: options})
+	}
+	return libovsdbops.UpdateLogicalSwitchPortsSetOptionsOps(oc.nbClient, ops, lsps...)
+}
+
 func getMcastACLName(nsORpg, mcastSuffix string) string {
 	return joinACLName(nsORpg, mcastSuffix)
 }
@@ -87,17 +306,30 @@ func getMcastACLName(nsORpg, mcastSuffix string) string {
 //   - one "to-lport" ACL allowing ingress multicast traffic to pods in 'ns'.
 //     This matches only traffic originated by pods in 'ns' (based on the
 //     namespace address set).
+//
+// The MulticastPolicy of 'ns', if any, restricts both ACLs to its groups and
+// extends the ingress ACL to the traffic originated by pods in its peer
+// namespaces, and sets the multicast options of the ports of the pods in 'ns'.
 func (oc *DefaultNetworkController) createMulticastAllowPolicy(ns string, nsInfo *namespaceInfo) error {
 	portGroupName := hashedPortGroup(ns)
 
+	policy, err := oc.getMulticastPolicy(ns)
+	if err != nil {
+		return err
+	}
+	policyMatch, err := oc.getMulticastPolicyMatch(ns, nsInfo, policy)
+	if err != nil {
+		return err
+	}
+
 	aclT := lportEgressAfterLB
-	egressMatch := getACLMatch(portGroupName, getMulticastACLEgrMatch(), aclT)
+	egressMatch := getACLMatch(portGroupName, getMulticastACLEgrMatch(policyMatch), aclT)
 	egressACL := BuildACL(getMcastACLName(ns, "MulticastAllowEgress"),
 		types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclT,
 		getDefaultDenyPolicyExternalIDs(aclT))
 
 	aclT = lportIngress
-	ingressMatch := getACLMatch(portGroupName, getMulticastACLIgrMatch(nsInfo), aclT)
+	ingressMatch := getACLMatch(portGroupName, getMulticastACLIgrMatch(nsInfo, policyMatch), aclT)
 	ingressACL := BuildACL(getMcastACLName(ns, "MulticastAllowIngress"),
 		types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow, nil, aclT,
 		getDefaultDenyPolicyExternalIDs(aclT))
@@ -109,20 +341,10 @@ func (oc *DefaultNetworkController) createMulticastAllowPolicy(ns string, nsInfo
 	}
 
 	// Add all ports from this namespace to the multicast allow group.
-	ports := []*nbdb.LogicalSwitchPort{}
-	pods, err := oc.watchFactory.GetPods(ns)
-	if err != nil {
-		klog.Warningf("Failed to get pods for namespace %q: %v", ns, err)
-	}
-	for _, pod := range pods {
-		if util.PodCompleted(pod) {
-			continue
-		}
-		if portInfo, err := oc.logicalPortCache.get(pod, types.DefaultNetworkName); err != nil {
-			klog.Errorf(err.Error())
-		} else {
-			ports = append(ports, &nbdb.LogicalSwitchPort{UUID: portInfo.uuid})
-		}
+	portUUIDs := oc.getNamespacePortUUIDs(ns)
+	ports := make([]*nbdb.LogicalSwitchPort, 0, len(portUUIDs))
+	for _, portUUID := range portUUIDs {
+		ports = append(ports, &nbdb.LogicalSwitchPort{UUID: portUUID})
 	}
 
 	pg := libovsdbops.BuildPortGroup(portGroupName, ns, ports, acls)
@@ -131,6 +353,11 @@ func (oc *DefaultNetworkController) createMulticastAllowPolicy(ns string, nsInfo
 		return err
 	}
 
+	ops, err = oc.setMulticastPortOptionsOps(ops, portUUIDs, getMulticastPortOptions(policy))
+	if err != nil {
+		return err
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
 		return err
@@ -139,10 +366,81 @@ func (oc *DefaultNetworkController) createMulticastAllowPolicy(ns string, nsInfo
 	return nil
 }
 
-func deleteMulticastAllowPolicy(nbClient libovsdbclient.Client, ns string) error {
+// updateMulticastAllowPolicy updates the multicast allow policy of namespace
+// ns, if it has multicast enabled, after its MulticastPolicy changed.
+func (oc *DefaultNetworkController) updateMulticastAllowPolicy(ns string) error {
+	if !oc.multicastSupport {
+		return nil
+	}
+	nsInfo, nsUnlock := oc.getNamespaceLocked(ns, false)
+	if nsInfo == nil {
+		return nil
+	}
+	defer nsUnlock()
+	if !nsInfo.multicastEnabled {
+		return nil
+	}
+	return oc.createMulticastAllowPolicy(ns, nsInfo)
+}
+
+// reconcileMulticastPolicy updates the multicast allow policy of the namespace
+// of the MulticastPolicy after it was added, updated or deleted. The policy is
+// read from the informer cache, so that a deleted policy no longer applies.
+func (oc *DefaultNetworkController) reconcileMulticastPolicy(policy *mcastpolicyapi.MulticastPolicy) error {
+	if policy.Name != multicastPolicyName {
+		klog.Warningf("Ignoring MulticastPolicy %s/%s, only MulticastPolicies named %q are supported",
+			policy.Namespace, policy.Name, multicastPolicyName)
+		return nil
+	}
+	return oc.updateMulticastAllowPolicy(policy.Namespace)
+}
+
+// syncMulticastPeerNamespace updates the multicast allow policies of the
+// namespaces whose MulticastPolicy has namespace ns as a peer, after ns was
+// added or deleted. The caller must not hold the lock of any namespace.
+func (oc *DefaultNetworkController) syncMulticastPeerNamespace(ns string) error {
+	if !oc.multicastSupport || !config.OVNKubernetesFeature.EnableMulticastPolicy {
+		return nil
+	}
+	policies, err := oc.watchFactory.GetMulticastPolicies()
+	if err != nil {
+		return fmt.Errorf("failed to list MulticastPolicies: %v", err)
+	}
+	var errs []error
+	for _, policy := range policies {
+		if policy.Name != multicastPolicyName || policy.Namespace == ns || !isMulticastPeerNamespace(policy, ns) {
+			continue
+		}
+		if err := oc.updateMulticastAllowPolicy(policy.Namespace); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func isMulticastPeerNamespace(policy *mcastpolicyapi.MulticastPolicy, ns string) bool {
+	for _, peer := range policy.Spec.PeerNamespaces {
+		if peer == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteMulticastAllowPolicy deletes the multicast allow policy of namespace
+// ns and removes the multicast options of the ports of its pods.
+func (oc *DefaultNetworkController) deleteMulticastAllowPolicy(ns string) error {
 	portGroupName := hashedPortGroup(ns)
+	ops, err := oc.setMulticastPortOptionsOps(nil, oc.getNamespacePortUUIDs(ns), getMulticastPortOptions(nil))
+	if err != nil {
+		return err
+	}
 	// ACLs referenced by the port group wil be deleted by db if there are no other references
-	err := libovsdbops.DeletePortGroups(nbClient, portGroupName)
+	ops, err = libovsdbops.DeletePortGroupsOps(oc.nbClient, ops, portGroupName)
+	if err != nil {
+		return err
+	}
+	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed deleting port group %s: %v", portGroupName, err)
 	}
@@ -237,10 +535,24 @@ func (oc *DefaultNetworkController) createDefaultAllowMulticastPolicy() error {
 }
 
 // podAddAllowMulticastPolicy adds the pod's logical switch port to the namespace's
-// multicast port group. Caller must hold the namespace's namespaceInfo object
-// lock.
-func podAddAllowMulticastPolicy(nbClient libovsdbclient.Client, ns string, portInfo *lpInfo) error {
-	return libovsdbops.AddPortsToPortGroup(nbClient, hashedPortGroup(ns), portInfo.uuid)
+// multicast port group and sets the multicast options of the port requested by
+// the MulticastPolicy of the namespace. Caller must hold the namespace's
+// namespaceInfo object lock.
+func (oc *DefaultNetworkController) podAddAllowMulticastPolicy(ns string, portInfo *lpInfo) error {
+	policy, err := oc.getMulticastPolicy(ns)
+	if err != nil {
+		return err
+	}
+	ops, err := libovsdbops.AddPortsToPortGroupOps(oc.nbClient, nil, hashedPortGroup(ns), portInfo.uuid)
+	if err != nil {
+		return err
+	}
+	ops, err = oc.setMulticastPortOptionsOps(ops, []string{portInfo.uuid}, getMulticastPortOptions(policy))
+	if err != nil {
+		return err
+	}
+	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
+	return err
 }
 
 // podDeleteAllowMulticastPolicy removes the pod's logical switch port from the
@@ -249,3 +561,235 @@ func podAddAllowMulticastPolicy(nbClient libovsdbclient.Client, ns string, portI
 func podDeleteAllowMulticastPolicy(nbClient libovsdbclient.Client, ns string, portUUID string) error {
 	return libovsdbops.DeletePortsFromPortGroup(nbClient, hashedPortGroup(ns), portUUID)
 }
+
+// getClusterRtrPortGroupName returns the name of the port group of the node
+// switch ports connected to the cluster router of the network
+func (bnc *BaseNetworkController) getClusterRtrPortGroupName() string {
+	if !bnc.IsSecondary() {
+		return types.ClusterRtrPortGroupName
+	}
+	return hashedPortGroup(bnc.GetNetworkScopedName(types.ClusterRtrPortGroupName))
+}
+
+// getClusterPortGroupName returns the name of the port group of all the pod
+// ports of the secondary network
+func (bsnc *BaseSecondaryNetworkController) getClusterPortGroupName() string {
+	return hashedPortGroup(bsnc.GetNetworkScopedName(types.ClusterPortGroupName))
+}
+
+// getMulticastNamespacePortGroupName returns the name of the port group of
+// the pod ports of namespace ns on the secondary network
+func (bsnc *BaseSecondaryNetworkController) getMulticastNamespacePortGroupName(ns string) string {
+	return hashedPortGroup(bsnc.GetNetworkScopedName(ns))
+}
+
+// getMulticastACLExternalIDs returns the external IDs of the multicast ACLs
+// of the secondary network
+func (bsnc *BaseSecondaryNetworkController) getMulticastACLExternalIDs(aclT aclType) map[string]string {
+	externalIDs := getDefaultDenyPolicyExternalIDs(aclT)
+	externalIDs[types.NetworkExternalID] = bsnc.GetNetworkName()
+	return externalIDs
+}
+
+// ensurePortGroupOps returns the ops to create the port group of the
+// secondary network with the provided ports and ACLs, or to add the ports and
+// ACLs to the port group if it already exists, so that its other ports are
+// preserved.
+func (bsnc *BaseSecondaryNetworkController) ensurePortGroupOps(ops []ovsdb.Operation, hashName, name string,
+	ports []*nbdb.LogicalSwitchPort, acls []*nbdb.ACL) ([]ovsdb.Operation, error) {
+	ops, err := libovsdbops.CreateOrUpdateACLsOps(bsnc.nbClient, ops, acls...)
+	if err != nil {
+		return nil, err
+	}
+	_, err = libovsdbops.GetPortGroup(bsnc.nbClient, &nbdb.PortGroup{Name: hashName})
+	if err == libovsdbclient.ErrNotFound {
+		pg := libovsdbops.BuildPortGroup(hashName, name, ports, acls)
+		pg.ExternalIDs[types.NetworkExternalID] = bsnc.GetNetworkName()
+		return libovsdbops.CreateOrUpdatePortGroupsOps(bsnc.nbClient, ops, pg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ops, err = libovsdbops.AddACLsToPortGroupOps(bsnc.nbClient, ops, hashName, acls...)
+	if err != nil {
+		return nil, err
+	}
+	portUUIDs := make([]string, 0, len(ports))
+	for _, port := range ports {
+		portUUIDs = append(portUUIDs, port.UUID)
+	}
+	return libovsdbops.AddPortsToPortGroupOps(bsnc.nbClient, ops, hashName, portUUIDs...)
+}
+
+// createSecondaryNetworkMulticastPolicy creates the default deny and allow
+// multicast policies of a secondary network, as for the default network:
+//   - a port group with all the pod ports of the network and two ACLs
+//     dropping egress and ingress multicast traffic of the pods.
+//   - for layer3 networks, a port group with the node switch ports connected
+//     to the cluster router of the network and two ACLs allowing multicast
+//     traffic from and to the router.
+func (bsnc *BaseSecondaryNetworkController) createSecondaryNetworkMulticastPolicy() error {
+	match := getMulticastACLMatch()
+	clusterPortGroupName := bsnc.getClusterPortGroupName()
+	readableName := bsnc.GetNetworkScopedName(types.ClusterPortGroupName)
+
+	aclT := lportEgressAfterLB
+	egressACL := BuildACL(getMcastACLName(readableName, "DefaultDenyMulticastEgress"),
+		types.DefaultMcastDenyPriority, getACLMatch(clusterPortGroupName, match, aclT), nbdb.ACLActionDrop, nil,
+		aclT, bsnc.getMulticastACLExternalIDs(aclT))
+
+	aclT = lportIngress
+	ingressACL := BuildACL(getMcastACLName(readableName, "DefaultDenyMulticastIngress"),
+		types.DefaultMcastDenyPriority, getACLMatch(clusterPortGroupName, match, aclT), nbdb.ACLActionDrop, nil,
+		aclT, bsnc.getMulticastACLExternalIDs(aclT))
+
+	ops, err := bsnc.ensurePortGroupOps(nil, clusterPortGroupName, readableName, nil,
+		[]*nbdb.ACL{egressACL, ingressACL})
+	if err != nil {
+		return err
+	}
+
+	if bsnc.TopologyType() == types.Layer3Topology {
+		clusterRtrPortGroupName := bsnc.getClusterRtrPortGroupName()
+		readableName = bsnc.GetNetworkScopedName(types.ClusterRtrPortGroupName)
+
+		aclT = lportEgressAfterLB
+		egressACL = BuildACL(getMcastACLName(readableName, "DefaultAllowMulticastEgress"),
+			types.DefaultMcastAllowPriority, getACLMatch(clusterRtrPortGroupName, match, aclT), nbdb.ACLActionAllow, nil,
+			aclT, bsnc.getMulticastACLExternalIDs(aclT))
+
+		aclT = lportIngress
+		ingressACL = BuildACL(getMcastACLName(readableName, "DefaultAllowMulticastIngress"),
+			types.DefaultMcastAllowPriority, getACLMatch(clusterRtrPortGroupName, match, aclT), nbdb.ACLActionAllow, nil,
+			aclT, bsnc.getMulticastACLExternalIDs(aclT))
+
+		ops, err = bsnc.ensurePortGroupOps(ops, clusterRtrPortGroupName, readableName, nil,
+			[]*nbdb.ACL{egressACL, ingressACL})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = libovsdbops.TransactAndCheck(bsnc.nbClient, ops)
+	return err
+}
+
+// getMulticastNamespaceACLsForSecondaryNetwork returns the ACLs of the
+// multicast port group of namespace ns on the secondary network. They allow
+// multicast traffic from the pods of the namespace and multicast traffic
+// towards the pods of the namespace that is originated by pods of the same
+// namespace, based on the addresses of the namespace port group.
+func (bsnc *BaseSecondaryNetworkController) getMulticastNamespaceACLsForSecondaryNetwork(ns string) []*nbdb.ACL {
+	portGroupName := bsnc.getMulticastNamespacePortGroupName(ns)
+	readableName := bsnc.GetNetworkScopedName(ns)
+
+	aclT := lportEgressAfterLB
+	egressMatch := getACLMatch(portGroupName, getMulticastACLMatch(), aclT)
+	egressACL := BuildACL(getMcastACLName(readableName, "MulticastAllowEgress"),
+		types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclT,
+		bsnc.getMulticastACLExternalIDs(aclT))
+
+	aclT = lportIngress
+	ingressMatch := getACLMatch(portGroupName, "("+getMulticastACLIgrMatchV4(portGroupName+"_ip4")+" || "+
+		getMulticastACLIgrMatchV6(portGroupName+"_ip6")+")", aclT)
+	ingressACL := BuildACL(getMcastACLName(readableName, "MulticastAllowIngress"),
+		types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow, nil, aclT,
+		bsnc.getMulticastACLExternalIDs(aclT))
+
+	return []*nbdb.ACL{egressACL, ingressACL}
+}
+
+// podAddAllowMulticastPolicyForSecondaryNetwork adds the pod's logical switch
+// port of the secondary network to the port group of all the pod ports of the
+// network and to the multicast port group of its namespace. The namespace port
+// group and its ACLs are created with the first pod of the namespace, and
+// deleted with the namespace.
+// The ports are removed from the port groups by the database when the logical
+// switch ports are deleted.
+func (bsnc *BaseSecondaryNetworkController) podAddAllowMulticastPolicyForSecondaryNetwork(ns, portUUID string) error {
+	portGroupName := bsnc.getMulticastNamespacePortGroupName(ns)
+
+	bsnc.multicastNamespacesLock.Lock()
+	defer bsnc.multicastNamespacesLock.Unlock()
+
+	var ops []ovsdb.Operation
+	var err error
+	if bsnc.multicastNamespaces.Has(ns) {
+		ops, err = libovsdbops.AddPortsToPortGroupOps(bsnc.nbClient, ops, portGroupName, portUUID)
+	} else {
+		ports := []*nbdb.LogicalSwitchPort{{UUID: portUUID}}
+		ops, err = bsnc.ensurePortGroupOps(ops, portGroupName, bsnc.GetNetworkScopedName(ns), ports,
+			bsnc.getMulticastNamespaceACLsForSecondaryNetwork(ns))
+	}
+	if err != nil {
+		return err
+	}
+	ops, err = libovsdbops.AddPortsToPortGroupOps(bsnc.nbClient, ops, bsnc.getClusterPortGroupName(), portUUID)
+	if err != nil {
+		return err
+	}
+	_, err = libovsdbops.TransactAndCheck(bsnc.nbClient, ops)
+	if err != nil {
+		return err
+	}
+	bsnc.multicastNamespaces.Insert(ns)
+	return nil
+}
+
+// deleteNamespaceMulticastPolicyForSecondaryNetwork deletes the multicast port
+// group of namespace ns on the secondary network, its ACLs are deleted by the
+// database.
+func (bsnc *BaseSecondaryNetworkController) deleteNamespaceMulticastPolicyForSecondaryNetwork(ns string) error {
+	portGroupName := bsnc.getMulticastNamespacePortGroupName(ns)
+
+	bsnc.multicastNamespacesLock.Lock()
+	defer bsnc.multicastNamespacesLock.Unlock()
+
+	if err := libovsdbops.DeletePortGroups(bsnc.nbClient, portGroupName); err != nil {
+		return fmt.Errorf("failed deleting port group %s of network %s: %v", portGroupName, bsnc.GetNetworkName(), err)
+	}
+	bsnc.multicastNamespaces.Delete(ns)
+	return nil
+}
+
+// syncNamespacesForSecondaryNetwork deletes the multicast port groups of the
+// secondary network of the namespaces that no longer exist.
+func (bsnc *BaseSecondaryNetworkController) syncNamespacesForSecondaryNetwork(namespaces []interface{}) error {
+	expectedPortGroups := sets.New[string](
+		bsnc.GetNetworkScopedName(types.ClusterPortGroupName),
+		bsnc.GetNetworkScopedName(types.ClusterRtrPortGroupName),
+	)
+	for _, nsInterface := range namespaces {
+		ns, ok := nsInterface.(*kapi.Namespace)
+		if !ok {
+			return fmt.Errorf("spurious object in syncNamespaces: %v", nsInterface)
+		}
+		expectedPortGroups.Insert(bsnc.GetNetworkScopedName(ns.Name))
+	}
+
+	netName := bsnc.GetNetworkName()
+	ops, err := libovsdbops.DeletePortGroupsWithPredicateOps(bsnc.nbClient, nil,
+		func(item *nbdb.PortGroup) bool {
+			return item.ExternalIDs[types.NetworkExternalID] == netName && !expectedPortGroups.Has(item.ExternalIDs["name"])
+		})
+	if err != nil {
+		return fmt.Errorf("failed to get ops for deleting stale port groups of network %s: %v", netName, err)
+	}
+	_, err = libovsdbops.TransactAndCheck(bsnc.nbClient, ops)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale port groups of network %s: %v", netName, err)
+	}
+	return nil
+}
+
+// deleteSecondaryNetworkMulticastPolicyOps returns the ops to delete the
+// multicast port groups of the given secondary network, their ACLs are deleted
+// by the database. It can be called from a dummy controller, so it relies on
+// the network external ID only.
+func deleteSecondaryNetworkMulticastPolicyOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation,
+	netName string) ([]ovsdb.Operation, error) {
+	return libovsdbops.DeletePortGroupsWithPredicateOps(nbClient, ops,
+		func(item *nbdb.PortGroup) bool {
+			return item.ExternalIDs[types.NetworkExternalID] == netName
+		})
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	mcastpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
@@ -20,6 +22,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	knet "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type ipMode struct {
@@ -50,12 +53,18 @@ func setIpMode(m ipMode) {
 type multicastPolicy struct{}
 
 func (p multicastPolicy) getMulticastPolicyExpectedData(ns string, ports []string) []libovsdb.TestData {
-	pg_hash := hashedPortGroup(ns)
-	egressMatch := getACLMatch(pg_hash, getMulticastACLEgrMatch(), lportEgressAfterLB)
-
 	ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(ns)
 	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4(ip4AddressSet), getMulticastACLIgrMatchV6(ip6AddressSet))
-	ingressMatch := getACLMatch(pg_hash, mcastMatch, lportIngress)
+	return p.getMulticastPolicyExpectedDataWithMatch(ns, ports, getMulticastACLEgrMatch(nil), mcastMatch)
+}
+
+// getMulticastPolicyExpectedDataWithMatch returns the expected multicast allow
+// policy of a namespace with the given multicast matches of its ACLs
+func (p multicastPolicy) getMulticastPolicyExpectedDataWithMatch(ns string, ports []string,
+	egressMcastMatch, ingressMcastMatch string) []libovsdb.TestData {
+	pg_hash := hashedPortGroup(ns)
+	egressMatch := getACLMatch(pg_hash, egressMcastMatch, lportEgressAfterLB)
+	ingressMatch := getACLMatch(pg_hash, ingressMcastMatch, lportIngress)
 
 	egressACL := libovsdbops.BuildACL(
 		getMcastACLName(ns, "MulticastAllowEgress"),
@@ -280,6 +289,175 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations with IP Address Family", f
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
 
+			ginkgo.It("tests restricting multicast with a MulticastPolicy "+ipModeStr(m), func() {
+				app.Action = func(ctx *cli.Context) error {
+					config.OVNKubernetesFeature.EnableMulticastPolicy = true
+					namespace1 := *newNamespace(namespaceName1)
+					namespace1.Annotations[util.NsMulticastAnnotation] = "true"
+					namespace2 := *newNamespace(namespaceName2)
+					policy := mcastpolicyapi.MulticastPolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: multicastPolicyName, Namespace: namespaceName1},
+						Spec: mcastpolicyapi.MulticastPolicySpec{
+							Groups:         []string{"239.1.0.0/16", "ff3e::/16"},
+							PeerNamespaces: []string{namespaceName2},
+						},
+					}
+
+					fakeOvn.startWithDBSetup(libovsdb.TestSetup{},
+						&v1.NamespaceList{
+							Items: []v1.Namespace{
+								namespace1,
+								namespace2,
+							},
+						},
+						&mcastpolicyapi.MulticastPolicyList{
+							Items: []mcastpolicyapi.MulticastPolicy{policy},
+						},
+					)
+					setIpMode(m)
+
+					err := fakeOvn.controller.WatchNamespaces()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchMulticastPolicy()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					ns1AddressSetV4, ns1AddressSetV6 := getNsAddrSetHashNames(namespaceName1)
+					ns2AddressSetV4, ns2AddressSetV6 := getNsAddrSetHashNames(namespaceName2)
+					getExpectedData := func(v4Sources, v6Sources string) []libovsdb.TestData {
+						egressMatch := "(mldv1 || mldv2 || (" + ipv6DynamicMulticastMatch + " && ip6.dst == {ff3e::/16}))"
+						ingressMatch := "(mldv1 || mldv2 || (ip6.src == " + v6Sources + " && " +
+							ipv6DynamicMulticastMatch + " && ip6.dst == {ff3e::/16}))"
+						if m.IPv4Mode {
+							egressMatch = "(igmp || (ip4.mcast && ip4.dst == {239.1.0.0/16}))"
+							ingressMatch = "(igmp || (ip4.src == " + v4Sources + " && ip4.mcast && ip4.dst == {239.1.0.0/16}))"
+						}
+						return multicastPolicy{}.getMulticastPolicyExpectedDataWithMatch(namespaceName1, nil,
+							egressMatch, ingressMatch)
+					}
+
+					// The multicast traffic is restricted to the groups of the
+					// policy and allowed from the peer namespace.
+					expectedData := getExpectedData(
+						"{$"+ns1AddressSetV4+", $"+ns2AddressSetV4+"}",
+						"{$"+ns1AddressSetV6+", $"+ns2AddressSetV6+"}")
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+					// The address set of a deleted peer namespace isn't referenced.
+					err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Delete(context.TODO(), namespaceName2, metav1.DeleteOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					expectedData = getExpectedData("$"+ns1AddressSetV4, "$"+ns1AddressSetV6)
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+					// Any group is allowed within the namespace without a policy.
+					err = fakeOvn.fakeClient.MulticastPolicyClient.K8sV1().MulticastPolicies(namespaceName1).Delete(
+						context.TODO(), multicastPolicyName, metav1.DeleteOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					expectedData = multicastPolicy{}.getMulticastPolicyExpectedData(namespaceName1, nil)
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+					return nil
+				}
+
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
+
+			ginkgo.It("tests the IGMP options of a MulticastPolicy "+ipModeStr(m), func() {
+				app.Action = func(ctx *cli.Context) error {
+					config.OVNKubernetesFeature.EnableMulticastPolicy = true
+					namespace1 := *newNamespace(namespaceName1)
+					namespace1.Annotations[util.NsMulticastAnnotation] = "true"
+					snooping := false
+					policy := mcastpolicyapi.MulticastPolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: multicastPolicyName, Namespace: namespaceName1},
+						Spec: mcastpolicyapi.MulticastPolicySpec{
+							IGMPSnooping: &snooping,
+							FloodReports: true,
+						},
+					}
+					tPod := newTPod(
+						"node1",
+						"10.128.1.0/24",
+						"10.128.1.2",
+						"10.128.1.1",
+						"myPod1",
+						"10.128.1.3",
+						"0a:58:0a:80:01:03",
+						namespace1.Name,
+					)
+					if m.IPv6Mode {
+						tPod = newTPod(
+							"node1",
+							"fd00:10:244::/64",
+							"fd00:10:244::2",
+							"fd00:10:244::1",
+							"myPod2",
+							"fd00:10:244::3",
+							"0a:58:dd:33:05:d8",
+							namespace1.Name,
+						)
+					}
+
+					fakeOvn.startWithDBSetup(initialDB,
+						&v1.NamespaceList{
+							Items: []v1.Namespace{
+								namespace1,
+							},
+						},
+						&mcastpolicyapi.MulticastPolicyList{
+							Items: []mcastpolicyapi.MulticastPolicy{policy},
+						},
+					)
+					setIpMode(m)
+
+					err := fakeOvn.controller.WatchNamespaces()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchPods()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchMulticastPolicy()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					getPortOptions := func() map[string]string {
+						lsp, err := libovsdbops.GetLogicalSwitchPort(fakeOvn.nbClient,
+							&nbdb.LogicalSwitchPort{Name: tPod.portName})
+						if err != nil {
+							return nil
+						}
+						return lsp.Options
+					}
+
+					// The port of a new pod floods the multicast traffic and
+					// the reports.
+					tPod.populateLogicalSwitchCache(fakeOvn, getLogicalSwitchUUID(fakeOvn.controller.nbClient, "node1"))
+					_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(tPod.namespace).Create(context.TODO(), newPod(
+						tPod.namespace, tPod.podName, tPod.nodeName, tPod.podIP), metav1.CreateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Eventually(getPortOptions).Should(gomega.And(
+						gomega.HaveKeyWithValue(mcastFloodOption, "true"),
+						gomega.HaveKeyWithValue(mcastFloodReportsOption, "true")))
+
+					// The options are updated with the policy.
+					policy.Spec.IGMPSnooping = nil
+					_, err = fakeOvn.fakeClient.MulticastPolicyClient.K8sV1().MulticastPolicies(namespaceName1).Update(
+						context.TODO(), &policy, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Eventually(getPortOptions).ShouldNot(gomega.HaveKey(mcastFloodOption))
+					gomega.Expect(getPortOptions()).To(gomega.HaveKeyWithValue(mcastFloodReportsOption, "true"))
+
+					// The options are removed when multicast is disabled.
+					ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					ns.Annotations[util.NsMulticastAnnotation] = "false"
+					_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Eventually(getPortOptions).ShouldNot(gomega.HaveKey(mcastFloodReportsOption))
+					gomega.Expect(getPortOptions()).To(gomega.HaveKeyWithValue("requested-chassis", tPod.nodeName))
+					return nil
+				}
+
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
+
 			ginkgo.It("tests adding a pod to a multicast enabled namespace "+ipModeStr(m), func() {
 				app.Action = func(ctx *cli.Context) error {
 					namespace1 := *newNamespace(namespaceName1)
@@ -377,3 +555,183 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations with IP Address Family", f
 		}
 	})
 })
+
+// getSecondaryNetworkMulticastACL returns the expected multicast ACL of a
+// secondary network
+func getSecondaryNetworkMulticastACL(netName, name, match, action string, priority int, aclT aclType) *nbdb.ACL {
+	direction := nbdb.ACLDirectionToLport
+	var options map[string]string
+	if aclT == lportEgressAfterLB {
+		direction = nbdb.ACLDirectionFromLport
+		options = map[string]string{"apply-after-lb": "true"}
+	}
+	acl := libovsdbops.BuildACL(
+		name,
+		direction,
+		priority,
+		match,
+		action,
+		types.OvnACLLoggingMeter,
+		"",
+		false,
+		map[string]string{
+			defaultDenyPolicyTypeACLExtIdKey: string(aclTypeToPolicyType(aclT)),
+			types.NetworkExternalID:          netName,
+		},
+		options,
+	)
+	acl.UUID = *acl.Name + "-UUID"
+	return acl
+}
+
+var _ = ginkgo.Describe("OVN multicast on secondary networks", func() {
+	const (
+		netName       = "l3-net"
+		namespaceName = "namespace1"
+		switchName    = "l3.net_node1"
+	)
+	var (
+		nbCleanup             *libovsdb.Cleanup
+		gomegaFormatMaxLength int
+	)
+
+	ginkgo.BeforeEach(func() {
+		config.PrepareTestConfig()
+		gomegaFormatMaxLength = format.MaxLength
+		format.MaxLength = 0
+	})
+
+	ginkgo.AfterEach(func() {
+		if nbCleanup != nil {
+			nbCleanup.Cleanup()
+		}
+		format.MaxLength = gomegaFormatMaxLength
+	})
+
+	ginkgo.It("creates and deletes the multicast policy of a multicast enabled layer3 network", func() {
+		podLSP := &nbdb.LogicalSwitchPort{
+			UUID:      "pod-lsp-UUID",
+			Name:      "pod-lsp",
+			Addresses: []string{"0a:58:0a:80:01:03 10.128.1.3"},
+		}
+		nodeSwitch := &nbdb.LogicalSwitch{
+			UUID:  "node1-UUID",
+			Name:  switchName,
+			Ports: []string{podLSP.UUID},
+		}
+		nbClient, cleanup, err := libovsdb.NewNBTestHarness(libovsdb.TestSetup{
+			NBData: []libovsdb.TestData{nodeSwitch, podLSP},
+		}, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		nbCleanup = cleanup
+
+		nad := &nadapi.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "l3", Namespace: namespaceName},
+			Spec: nadapi.NetworkAttachmentDefinitionSpec{
+				Config: `{"cniVersion": "0.4.0", "name": "` + netName + `", "type": "ovn-k8s-cni-overlay",
+					"topology": "layer3", "subnets": "10.128.0.0/16/24", "netAttachDefName": "namespace1/l3",
+					"enableMulticast": true}`,
+			},
+		}
+		netInfo, netconfInfo, err := util.ParseNADInfo(nad)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(netconfInfo.MulticastEnabled()).To(gomega.BeTrue())
+		bsnc := &BaseSecondaryNetworkController{
+			BaseNetworkController: BaseNetworkController{
+				CommonNetworkControllerInfo: CommonNetworkControllerInfo{
+					nbClient:         nbClient,
+					multicastSupport: true,
+				},
+				NetInfo:     netInfo,
+				NetConfInfo: netconfInfo,
+			},
+			multicastNamespaces: sets.New[string](),
+		}
+
+		err = bsnc.createSecondaryNetworkMulticastPolicy()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		lsp, err := libovsdbops.GetLogicalSwitchPort(nbClient, &nbdb.LogicalSwitchPort{Name: podLSP.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = bsnc.podAddAllowMulticastPolicyForSecondaryNetwork(namespaceName, lsp.UUID)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		mcastMatch := getMulticastACLMatch()
+
+		clusterPG := hashedPortGroup("l3.net_" + types.ClusterPortGroupName)
+		denyEgressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_clusterPortGroup_DefaultDenyMulticastEgress",
+			getACLMatch(clusterPG, mcastMatch, lportEgressAfterLB), nbdb.ACLActionDrop,
+			types.DefaultMcastDenyPriority, lportEgressAfterLB)
+		denyIngressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_clusterPortGroup_DefaultDenyMulticastIngress",
+			getACLMatch(clusterPG, mcastMatch, lportIngress), nbdb.ACLActionDrop,
+			types.DefaultMcastDenyPriority, lportIngress)
+		clusterPortGroup := libovsdbops.BuildPortGroup(clusterPG, "l3.net_"+types.ClusterPortGroupName,
+			[]*nbdb.LogicalSwitchPort{podLSP}, []*nbdb.ACL{denyEgressACL, denyIngressACL})
+		clusterPortGroup.ExternalIDs[types.NetworkExternalID] = netName
+		clusterPortGroup.UUID = clusterPortGroup.Name + "-UUID"
+
+		clusterRtrPG := hashedPortGroup("l3.net_" + types.ClusterRtrPortGroupName)
+		allowRtrEgressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_clusterRtrPortGroup_DefaultAllowMulticastEgress",
+			getACLMatch(clusterRtrPG, mcastMatch, lportEgressAfterLB), nbdb.ACLActionAllow,
+			types.DefaultMcastAllowPriority, lportEgressAfterLB)
+		allowRtrIngressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_clusterRtrPortGroup_DefaultAllowMulticastIngress",
+			getACLMatch(clusterRtrPG, mcastMatch, lportIngress), nbdb.ACLActionAllow,
+			types.DefaultMcastAllowPriority, lportIngress)
+		clusterRtrPortGroup := libovsdbops.BuildPortGroup(clusterRtrPG, "l3.net_"+types.ClusterRtrPortGroupName,
+			nil, []*nbdb.ACL{allowRtrEgressACL, allowRtrIngressACL})
+		clusterRtrPortGroup.ExternalIDs[types.NetworkExternalID] = netName
+		clusterRtrPortGroup.UUID = clusterRtrPortGroup.Name + "-UUID"
+
+		nsPG := hashedPortGroup("l3.net_" + namespaceName)
+		allowNsEgressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_namespace1_MulticastAllowEgress",
+			getACLMatch(nsPG, mcastMatch, lportEgressAfterLB), nbdb.ACLActionAllow,
+			types.DefaultMcastAllowPriority, lportEgressAfterLB)
+		allowNsIngressACL := getSecondaryNetworkMulticastACL(netName, "l3.net_namespace1_MulticastAllowIngress",
+			getACLMatch(nsPG, "((igmp || (ip4.src == $"+nsPG+"_ip4 && ip4.mcast)) || "+
+				"(mldv1 || mldv2 || (ip6.src == $"+nsPG+"_ip6 && "+ipv6DynamicMulticastMatch+")))", lportIngress),
+			nbdb.ACLActionAllow, types.DefaultMcastAllowPriority, lportIngress)
+		nsPortGroup := libovsdbops.BuildPortGroup(nsPG, "l3.net_"+namespaceName,
+			[]*nbdb.LogicalSwitchPort{podLSP}, []*nbdb.ACL{allowNsEgressACL, allowNsIngressACL})
+		nsPortGroup.ExternalIDs[types.NetworkExternalID] = netName
+		nsPortGroup.UUID = nsPortGroup.Name + "-UUID"
+
+		acls := []libovsdb.TestData{denyEgressACL, denyIngressACL, allowRtrEgressACL, allowRtrIngressACL,
+			allowNsEgressACL, allowNsIngressACL}
+		expectedData := append([]libovsdb.TestData{nodeSwitch, podLSP, clusterPortGroup, clusterRtrPortGroup,
+			nsPortGroup}, acls...)
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedData...))
+
+		// creating the policy again, e.g. after a restart, keeps the ports
+		err = bsnc.createSecondaryNetworkMulticastPolicy()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedData...))
+
+		// syncing the namespaces keeps the port groups of the existing ones
+		err = bsnc.syncNamespacesForSecondaryNetwork([]interface{}{newNamespace(namespaceName)})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedData...))
+
+		// deleting the namespace deletes its port group
+		expectedDataWithoutNs := append([]libovsdb.TestData{nodeSwitch, podLSP, clusterPortGroup,
+			clusterRtrPortGroup}, acls...)
+		err = bsnc.deleteNamespaceMulticastPolicyForSecondaryNetwork(namespaceName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedDataWithoutNs...))
+
+		// and the next pod of the namespace creates it again
+		err = bsnc.podAddAllowMulticastPolicyForSecondaryNetwork(namespaceName, lsp.UUID)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedData...))
+
+		// syncing the namespaces deletes the port groups of the deleted ones
+		err = bsnc.syncNamespacesForSecondaryNetwork(nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(expectedDataWithoutNs...))
+
+		// deleting the network deletes its port groups
+		ops, err := deleteSecondaryNetworkMulticastPolicyOps(nbClient, nil, netName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nbClient).Should(libovsdb.HaveData(append([]libovsdb.TestData{nodeSwitch, podLSP}, acls...)...))
+	})
+})
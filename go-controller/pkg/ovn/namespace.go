@@ -181,7 +181,7 @@ func (oc *DefaultNetworkController) multicastUpdateNamespace(ns *kapi.Namespace,
 	if enabled {
 		err = oc.createMulticastAllowPolicy(ns.Name, nsInfo)
 	} else {
-		err = deleteMulticastAllowPolicy(oc.nbClient, ns.Name)
+		err = oc.deleteMulticastAllowPolicy(ns.Name)
 	}
 	if err != nil {
 		return err
@@ -194,7 +194,7 @@ func (oc *DefaultNetworkController) multicastUpdateNamespace(ns *kapi.Namespace,
 func (oc *DefaultNetworkController) multicastDeleteNamespace(ns *kapi.Namespace, nsInfo *namespaceInfo) error {
 	if nsInfo.multicastEnabled {
 		nsInfo.multicastEnabled = false
-		if err := deleteMulticastAllowPolicy(oc.nbClient, ns.Name); err != nil {
+		if err := oc.deleteMulticastAllowPolicy(ns.Name); err != nil {
 			return err
 		}
 	}
@@ -214,8 +214,9 @@ func (oc *DefaultNetworkController) AddNamespace(ns *kapi.Namespace) error {
 	if err != nil {
 		return fmt.Errorf("failed to ensure namespace locked: %v", err)
 	}
-	defer nsUnlock()
-	return nil
+	nsUnlock()
+	// MulticastPolicies can now reference the address set of the namespace
+	return oc.syncMulticastPeerNamespace(ns.Name)
 }
 
 // configureNamespace ensures internal structures are updated based on namespace
@@ -379,6 +380,15 @@ func (oc *DefaultNetworkController) updateNamespace(old, newer *kapi.Namespace)
 func (oc *DefaultNetworkController) deleteNamespace(ns *kapi.Namespace) error {
 	klog.Infof("[%s] deleting namespace", ns.Name)
 
+	if err := oc.deleteNamespaceInfo(ns); err != nil {
+		return err
+	}
+	// MulticastPolicies must stop referencing the address set of the namespace
+	// before it is deleted
+	return oc.syncMulticastPeerNamespace(ns.Name)
+}
+
+func (oc *DefaultNetworkController) deleteNamespaceInfo(ns *kapi.Namespace) error {
 	nsInfo := oc.deleteNamespaceLocked(ns.Name)
 	if nsInfo == nil {
 		return nil
@@ -492,7 +502,7 @@ func (oc *DefaultNetworkController) createNamespaceAddrSetAllPods(ns string) (ad
 				}
 				// for shared gateway mode we will use LRP IPs to SNAT host network traffic
 				// so add these to the address set.
-				lrpIPs, err := oc.joinSwIPManager.EnsureJoinLRPIPs(node.Name)
+				lrpIPs, err := util.ParseNodeGatewayRouterLRPAddrs(node, types.DefaultNetworkName)
 				if err != nil {
 					klog.Errorf("Failed to get join switch port IP address for node %s: %v", node.Name, err)
 				}
@@ -37,8 +37,7 @@ import (
 )
 
 const (
-	egressFirewallDNSDefaultDuration  = 30 * time.Minute
-	egressIPReachabilityCheckInterval = 5 * time.Second
+	egressFirewallDNSDefaultDuration = 30 * time.Minute
 )
 
 // ACL logging severity levels
@@ -129,7 +128,7 @@ func (oc *DefaultNetworkController) ensurePod(oldPod, pod *kapi.Pod, addPort boo
 	if !util.PodScheduled(pod) {
 		return nil
 	}
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
@@ -137,7 +136,7 @@ func (oc *DefaultNetworkController) ensurePod(oldPod, pod *kapi.Pod, addPort boo
 			if !addPort {
 				eventName = "update"
 			}
-			metrics.RecordPodEvent(eventName, duration)
+			metrics.RecordPodEvent(oc.GetNetworkName(), eventName, duration)
 		}()
 	}
 
@@ -161,6 +160,16 @@ func (oc *DefaultNetworkController) ensurePod(oldPod, pod *kapi.Pod, addPort boo
 				return fmt.Errorf("addPodExternalGW failed for %s/%s: %w", pod.Namespace, pod.Name, err)
 			}
 		}
+		if oldPod != nil && !util.PodWantsHostNetwork(pod) && util.PodBandwidthAnnotationsChanged(oldPod.Annotations, pod.Annotations) {
+			if err := oc.updatePodQoS(pod); err != nil {
+				return fmt.Errorf("updatePodQoS failed for %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+		if oldPod != nil && !util.PodWantsHostNetwork(pod) && podDPUNameChanged(oldPod, pod) {
+			if err := oc.updatePodRequestedChassis(pod); err != nil {
+				return fmt.Errorf("updatePodRequestedChassis failed for %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -169,11 +178,11 @@ func (oc *DefaultNetworkController) ensurePod(oldPod, pod *kapi.Pod, addPort boo
 // removePod tried to tear down a pod. It returns nil on success and error on failure;
 // failure indicates the pod tear down should be retried later.
 func (oc *DefaultNetworkController) removePod(pod *kapi.Pod, portInfo *lpInfo) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordPodEvent("delete", duration)
+			metrics.RecordPodEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	if util.PodWantsHostNetwork(pod) {
@@ -204,6 +213,13 @@ func (oc *DefaultNetworkController) WatchEgressFirewall() error {
 	return err
 }
 
+// WatchMulticastPolicy starts the watching of multicastpolicy resource and calls
+// back the appropriate handler logic
+func (oc *DefaultNetworkController) WatchMulticastPolicy() error {
+	_, err := oc.retryMulticastPolicies.WatchResource()
+	return err
+}
+
 // WatchEgressNodes starts the watching of egress assignable nodes and calls
 // back the appropriate handler logic.
 func (oc *DefaultNetworkController) WatchEgressNodes() error {
@@ -268,12 +284,9 @@ func (oc *DefaultNetworkController) syncNodeGateway(node *kapi.Node, hostSubnets
 		if err := oc.gatewayCleanup(node.Name); err != nil {
 			return fmt.Errorf("error cleaning up gateway for node %s: %v", node.Name, err)
 		}
-		if err := oc.joinSwIPManager.ReleaseJoinLRPIPs(node.Name); err != nil {
-			return err
-		}
 	} else if hostSubnets != nil {
 		var hostAddrs sets.Set[string]
-		if config.Gateway.Mode == config.GatewayModeShared {
+		if l3GatewayConfig.Mode == config.GatewayModeShared {
 			hostAddrs, err = util.ParseNodeHostAddresses(node)
 			if err != nil && !util.IsAnnotationNotSetError(err) {
 				return fmt.Errorf("failed to get host addresses for node: %s: %v", node.Name, err)
@@ -282,6 +295,9 @@ func (oc *DefaultNetworkController) syncNodeGateway(node *kapi.Node, hostSubnets
 		if err := oc.syncGatewayLogicalNetwork(node, l3GatewayConfig, hostSubnets, hostAddrs); err != nil {
 			return fmt.Errorf("error creating gateway for node %s: %v", node.Name, err)
 		}
+		if err := oc.syncNodeGatewayMode(node.Name, l3GatewayConfig.Mode); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -351,6 +367,30 @@ func gatewayChanged(oldNode, newNode *kapi.Node) bool {
 	return !reflect.DeepEqual(oldL3GatewayConfig, l3GatewayConfig)
 }
 
+// nodeGatewayMode returns the gateway mode the node runs in as published in its l3 gateway
+// config, which may differ from the cluster gateway mode while nodes are migrated one by one.
+func nodeGatewayMode(node *kapi.Node) config.GatewayMode {
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return config.Gateway.Mode
+	}
+	return l3GatewayConfig.Mode
+}
+
+// getNodeGatewayMode returns the gateway mode of the node with the given name.
+func (oc *DefaultNetworkController) getNodeGatewayMode(nodeName string) config.GatewayMode {
+	node, err := oc.watchFactory.GetNode(nodeName)
+	if err != nil {
+		return config.Gateway.Mode
+	}
+	return nodeGatewayMode(node)
+}
+
+// nodeGatewayModeChanged returns true if the node moved to another gateway mode.
+func nodeGatewayModeChanged(oldNode, node *kapi.Node) bool {
+	return nodeGatewayMode(oldNode) != nodeGatewayMode(node)
+}
+
 // hostAddressesChanged compares old annotations to new and returns true if the something has changed.
 func hostAddressesChanged(oldNode, newNode *kapi.Node) bool {
 	oldAddrs, _ := util.ParseNodeHostAddresses(oldNode)
@@ -371,10 +411,19 @@ func nodeSubnetChanged(oldNode, node *kapi.Node) bool {
 	return !reflect.DeepEqual(oldSubnets, newSubnets)
 }
 
+// nodeGatewayRouterLRPAddrsChanged returns true if the gateway router join IPs allocated to the node
+// by cluster manager were updated.
+func nodeGatewayRouterLRPAddrsChanged(oldNode, node *kapi.Node) bool {
+	oldIPs, _ := util.ParseNodeGatewayRouterLRPAddrs(oldNode, ovntypes.DefaultNetworkName)
+	newIPs, _ := util.ParseNodeGatewayRouterLRPAddrs(node, ovntypes.DefaultNetworkName)
+	return !reflect.DeepEqual(oldIPs, newIPs)
+}
+
 func nodeChassisChanged(oldNode, node *kapi.Node) bool {
 	oldChassis, _ := util.ParseNodeChassisIDAnnotation(oldNode)
 	newChassis, _ := util.ParseNodeChassisIDAnnotation(node)
-	return oldChassis != newChassis
+	return oldChassis != newChassis ||
+		!reflect.DeepEqual(util.ParseNodeDPUChassisIDs(oldNode), util.ParseNodeDPUChassisIDs(node))
 }
 
 // nodeGatewayMTUSupportChanged returns true if annotation "k8s.ovn.org/gateway-mtu-support" on the node was updated.
@@ -8,10 +8,14 @@ import (
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
 	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
+	egressinterface "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1"
+	egressinterfacefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/clientset/versioned/fake"
 	egressip "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
 	egressqos "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
 	egressqosfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/fake"
+	mcastpolicy "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1"
+	mcastpolicyfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/clientset/versioned/fake"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -52,6 +56,7 @@ type FakeOVN struct {
 	nbsbCleanup  *libovsdbtest.Cleanup
 	egressQoSWg  *sync.WaitGroup
 	egressSVCWg  *sync.WaitGroup
+	egressIFWg   *sync.WaitGroup
 }
 
 func NewFakeOVN() *FakeOVN {
@@ -60,6 +65,7 @@ func NewFakeOVN() *FakeOVN {
 		fakeRecorder: record.NewFakeRecorder(10),
 		egressQoSWg:  &sync.WaitGroup{},
 		egressSVCWg:  &sync.WaitGroup{},
+		egressIFWg:   &sync.WaitGroup{},
 	}
 }
 
@@ -71,6 +77,8 @@ func (o *FakeOVN) start(objects ...runtime.Object) {
 	egressIPObjects := []runtime.Object{}
 	egressFirewallObjects := []runtime.Object{}
 	egressQoSObjects := []runtime.Object{}
+	egressInterfaceObjects := []runtime.Object{}
+	mcastPolicyObjects := []runtime.Object{}
 	v1Objects := []runtime.Object{}
 	for _, object := range objects {
 		if _, isEgressIPObject := object.(*egressip.EgressIPList); isEgressIPObject {
@@ -79,15 +87,21 @@ func (o *FakeOVN) start(objects ...runtime.Object) {
 			egressFirewallObjects = append(egressFirewallObjects, object)
 		} else if _, isEgressQoSObject := object.(*egressqos.EgressQoSList); isEgressQoSObject {
 			egressQoSObjects = append(egressQoSObjects, object)
+		} else if _, isEgressInterfaceObject := object.(*egressinterface.EgressInterfaceList); isEgressInterfaceObject {
+			egressInterfaceObjects = append(egressInterfaceObjects, object)
+		} else if _, isMcastPolicyObject := object.(*mcastpolicy.MulticastPolicyList); isMcastPolicyObject {
+			mcastPolicyObjects = append(mcastPolicyObjects, object)
 		} else {
 			v1Objects = append(v1Objects, object)
 		}
 	}
 	o.fakeClient = &util.OVNMasterClientset{
-		KubeClient:           fake.NewSimpleClientset(v1Objects...),
-		EgressIPClient:       egressipfake.NewSimpleClientset(egressIPObjects...),
-		EgressFirewallClient: egressfirewallfake.NewSimpleClientset(egressFirewallObjects...),
-		EgressQoSClient:      egressqosfake.NewSimpleClientset(egressQoSObjects...),
+		KubeClient:            fake.NewSimpleClientset(v1Objects...),
+		EgressIPClient:        egressipfake.NewSimpleClientset(egressIPObjects...),
+		EgressFirewallClient:  egressfirewallfake.NewSimpleClientset(egressFirewallObjects...),
+		EgressQoSClient:       egressqosfake.NewSimpleClientset(egressQoSObjects...),
+		EgressInterfaceClient: egressinterfacefake.NewSimpleClientset(egressInterfaceObjects...),
+		MulticastPolicyClient: mcastpolicyfake.NewSimpleClientset(mcastPolicyObjects...),
 	}
 	o.init()
 }
@@ -103,6 +117,7 @@ func (o *FakeOVN) shutdown() {
 	o.wg.Wait()
 	o.egressQoSWg.Wait()
 	o.egressSVCWg.Wait()
+	o.egressIFWg.Wait()
 	o.nbsbCleanup.Cleanup()
 }
 
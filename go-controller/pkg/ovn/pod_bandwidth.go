@@ -0,0 +1,213 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// podQoSPriority is the priority of the QoS rules limiting the bandwidth of the pods and marking
+	// their traffic, above the EgressQoS ones
+	podQoSPriority = 2000
+	// maxPodQoSBandwidth is the largest rate, in kbps, and burst, in kbits, of an OVN QoS rule
+	maxPodQoSBandwidth = 4294967295
+
+	podQoSIngress = "ingress"
+	podQoSEgress  = "egress"
+)
+
+func getPodQoSDbIDs(podNamespace, podName, direction, controller string) *libovsdbops.DbObjectIDs {
+	objectIDs := map[libovsdbops.ExternalIDKey]string{
+		libovsdbops.ObjectNameKey: podNamespace + "/" + podName,
+	}
+	// the direction is left empty to look up the rules of both directions
+	if direction != "" {
+		objectIDs[libovsdbops.PolicyDirectionKey] = direction
+	}
+	return libovsdbops.NewDbObjectIDs(libovsdbops.QoSPodBandwidth, controller, objectIDs)
+}
+
+// podQoSBandwidth converts a rate in bits per second and a burst in bits to the bandwidth of an OVN
+// QoS rule, in kbps and kbits
+func podQoSBandwidth(rate, burst int64) map[string]int {
+	toKbits := func(value int64) int {
+		if value/1000 > maxPodQoSBandwidth {
+			return maxPodQoSBandwidth
+		}
+		return int(value / 1000)
+	}
+	bandwidth := map[string]int{nbdb.QoSBandwidthRate: toKbits(rate)}
+	if burst > 0 {
+		bandwidth[nbdb.QoSBandwidthBurst] = toKbits(burst)
+	}
+	return bandwidth
+}
+
+// getPodQoSes returns the QoS rules of the logical switch port of a pod limiting its bandwidth and
+// marking its traffic, as requested by its annotations
+func (oc *DefaultNetworkController) getPodQoSes(pod *kapi.Pod, portName string) ([]*nbdb.QoS, error) {
+	bw, err := util.GetPodBandwidth(pod.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bandwidth annotations on pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+
+	qoses := []*nbdb.QoS{}
+	if bw.IngressRate > 0 {
+		qoses = append(qoses, &nbdb.QoS{
+			Direction:   nbdb.QoSDirectionToLport,
+			Match:       fmt.Sprintf("outport == %q", portName),
+			Priority:    podQoSPriority,
+			Bandwidth:   podQoSBandwidth(bw.IngressRate, bw.IngressBurst),
+			ExternalIDs: getPodQoSDbIDs(pod.Namespace, pod.Name, podQoSIngress, oc.controllerName).GetExternalIDs(),
+		})
+	}
+	if bw.EgressRate > 0 || bw.EgressDSCP >= 0 {
+		qos := &nbdb.QoS{
+			Direction:   nbdb.QoSDirectionFromLport,
+			Match:       fmt.Sprintf("inport == %q", portName),
+			Priority:    podQoSPriority,
+			ExternalIDs: getPodQoSDbIDs(pod.Namespace, pod.Name, podQoSEgress, oc.controllerName).GetExternalIDs(),
+		}
+		if bw.EgressRate > 0 {
+			qos.Bandwidth = podQoSBandwidth(bw.EgressRate, bw.EgressBurst)
+		}
+		if bw.EgressDSCP >= 0 {
+			qos.Action = map[string]int{nbdb.QoSActionDSCP: bw.EgressDSCP}
+		}
+		qoses = append(qoses, qos)
+	}
+	return qoses, nil
+}
+
+// findPodQoSes returns the QoS rules of a pod, of both directions
+func (oc *DefaultNetworkController) findPodQoSes(pod *kapi.Pod) ([]*nbdb.QoS, error) {
+	predicateIDs := getPodQoSDbIDs(pod.Namespace, pod.Name, "", oc.controllerName)
+	qoses, err := libovsdbops.FindQoSesWithPredicate(oc.nbClient, libovsdbops.GetPredicate[*nbdb.QoS](predicateIDs, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the QoS rules of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	return qoses, nil
+}
+
+// ensurePodQoSOps returns the ops to create, update or remove the QoS rules of the logical switch port
+// of a pod on the given switch so that they match its bandwidth annotations
+func (oc *DefaultNetworkController) ensurePodQoSOps(pod *kapi.Pod, portName, switchName string,
+	ops []ovsdb.Operation) ([]ovsdb.Operation, error) {
+	qoses, err := oc.getPodQoSes(pod, portName)
+	if err != nil {
+		return nil, err
+	}
+	existingQoSes, err := oc.findPodQoSes(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	directions := sets.NewString()
+	for _, qos := range qoses {
+		directions.Insert(qos.ExternalIDs[libovsdbops.PolicyDirectionKey.String()])
+	}
+	staleQoSes := []*nbdb.QoS{}
+	for _, qos := range existingQoSes {
+		if !directions.Has(qos.ExternalIDs[libovsdbops.PolicyDirectionKey.String()]) {
+			staleQoSes = append(staleQoSes, qos)
+		}
+	}
+	if len(staleQoSes) > 0 {
+		if ops, err = oc.deletePodQoSesOps(staleQoSes, ops); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(qoses) == 0 {
+		return ops, nil
+	}
+	if ops, err = libovsdbops.CreateOrUpdateQoSesOps(oc.nbClient, ops, qoses...); err != nil {
+		return nil, err
+	}
+	return libovsdbops.AddQoSesToLogicalSwitchOps(oc.nbClient, ops, switchName, qoses...)
+}
+
+// updatePodQoS updates the QoS rules of the logical switch port of a running pod after a change of its
+// bandwidth annotations. The ports not created yet get their rules from addLogicalPort.
+func (oc *DefaultNetworkController) updatePodQoS(pod *kapi.Pod) error {
+	portInfo, err := oc.logicalPortCache.get(pod, oc.GetNetworkName())
+	if err != nil {
+		klog.V(5).Infof("Skipping the QoS update of pod %s/%s without a logical port yet", pod.Namespace, pod.Name)
+		return nil
+	}
+	ops, err := oc.ensurePodQoSOps(pod, portInfo.name, portInfo.logicalSwitch, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to update the QoS rules of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// deletePodQoSesOps returns the ops to remove the given pod QoS rules from the switches they are on
+func (oc *DefaultNetworkController) deletePodQoSesOps(qoses []*nbdb.QoS, ops []ovsdb.Operation) ([]ovsdb.Operation, error) {
+	uuids := sets.NewString()
+	for _, qos := range qoses {
+		uuids.Insert(qos.UUID)
+	}
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(oc.nbClient, func(sw *nbdb.LogicalSwitch) bool {
+		return uuids.HasAny(sw.QOSRules...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the switches of the pod QoS rules: %v", err)
+	}
+	for _, sw := range switches {
+		if ops, err = libovsdbops.RemoveQoSesFromLogicalSwitchOps(oc.nbClient, ops, sw.Name, qoses...); err != nil {
+			return nil, err
+		}
+	}
+	return libovsdbops.DeleteQoSesOps(oc.nbClient, ops, qoses...)
+}
+
+// deletePodQoS removes the QoS rules of the logical switch port of a pod
+func (oc *DefaultNetworkController) deletePodQoS(pod *kapi.Pod) error {
+	qoses, err := oc.findPodQoSes(pod)
+	if err != nil || len(qoses) == 0 {
+		return err
+	}
+	ops, err := oc.deletePodQoSesOps(qoses, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to delete the QoS rules of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// deleteStalePodQoSes removes the QoS rules of the pods that are not in expectedPods, in the
+// namespace/name form
+func (oc *DefaultNetworkController) deleteStalePodQoSes(expectedPods sets.String) error {
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.QoSPodBandwidth, oc.controllerName, nil)
+	staleQoSes, err := libovsdbops.FindQoSesWithPredicate(oc.nbClient, libovsdbops.GetPredicate[*nbdb.QoS](predicateIDs,
+		func(qos *nbdb.QoS) bool {
+			return !expectedPods.Has(qos.ExternalIDs[libovsdbops.ObjectNameKey.String()])
+		}))
+	if err != nil {
+		return fmt.Errorf("failed to find the stale pod QoS rules: %v", err)
+	}
+	if len(staleQoSes) == 0 {
+		return nil
+	}
+	ops, err := oc.deletePodQoSesOps(staleQoSes, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to delete the stale pod QoS rules: %v", err)
+	}
+	return nil
+}
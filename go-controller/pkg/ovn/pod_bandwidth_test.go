@@ -0,0 +1,200 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getPodQoS(t testPod, direction string, bandwidth, action map[string]int, controller string) *nbdb.QoS {
+	portName := util.GetLogicalPortName(t.namespace, t.podName)
+	qos := &nbdb.QoS{
+		UUID:        fmt.Sprintf("%s-%s-qos-UUID", portName, direction),
+		Direction:   nbdb.QoSDirectionFromLport,
+		Match:       fmt.Sprintf("inport == %q", portName),
+		Priority:    podQoSPriority,
+		Bandwidth:   bandwidth,
+		Action:      action,
+		ExternalIDs: getPodQoSDbIDs(t.namespace, t.podName, direction, controller).GetExternalIDs(),
+	}
+	if direction == podQoSIngress {
+		qos.Direction = nbdb.QoSDirectionToLport
+		qos.Match = fmt.Sprintf("outport == %q", portName)
+	}
+	return qos
+}
+
+// getExpectedDataPodsSwitchesAndQoSes returns the expected data of the pods with the given QoS rules
+// on the switch of the first node
+func getExpectedDataPodsSwitchesAndQoSes(pods []testPod, nodes []string, qoses ...*nbdb.QoS) []libovsdbtest.TestData {
+	data := getExpectedDataPodsAndSwitches(pods, nodes)
+	for _, item := range data {
+		if sw, ok := item.(*nbdb.LogicalSwitch); ok && sw.Name == nodes[0] {
+			for _, qos := range qoses {
+				sw.QOSRules = append(sw.QOSRules, qos.UUID)
+			}
+		}
+	}
+	for _, qos := range qoses {
+		data = append(data, qos)
+	}
+	return data
+}
+
+var _ = ginkgo.Describe("OVN Pod bandwidth", func() {
+	var (
+		app       *cli.App
+		fakeOvn   *FakeOVN
+		initialDB libovsdbtest.TestSetup
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+
+		fakeOvn = NewFakeOVN()
+		initialDB = libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{
+				&nbdb.LogicalSwitch{
+					Name: "node1",
+				},
+			},
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOvn.shutdown()
+	})
+
+	ginkgo.It("programs, updates and removes the QoS rules of a pod from its annotations", func() {
+		app.Action = func(ctx *cli.Context) error {
+			namespaceT := *newNamespace("namespace1")
+			t := newTPod(
+				"node1",
+				"10.128.1.0/24",
+				"10.128.1.2",
+				"10.128.1.1",
+				"myPod",
+				"10.128.1.3",
+				"0a:58:0a:80:01:03",
+				namespaceT.Name,
+			)
+
+			fakeOvn.startWithDBSetup(initialDB,
+				&v1.NamespaceList{
+					Items: []v1.Namespace{
+						namespaceT,
+					},
+				},
+				&v1.PodList{
+					Items: []v1.Pod{},
+				},
+			)
+			controller := fakeOvn.controller.controllerName
+
+			t.populateLogicalSwitchCache(fakeOvn, getLogicalSwitchUUID(fakeOvn.controller.nbClient, "node1"))
+			err := fakeOvn.controller.WatchNamespaces()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = fakeOvn.controller.WatchPods()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			pod := newPod(t.namespace, t.podName, t.nodeName, t.podIP)
+			pod.Annotations = map[string]string{
+				util.PodIngressBandwidthAnnotation: "10M",
+				util.PodIngressBurstAnnotation:     "1M",
+				util.PodEgressBandwidthAnnotation:  "20M",
+				util.PodEgressDSCPAnnotation:       "46",
+			}
+			_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ingressQoS := getPodQoS(t, podQoSIngress, map[string]int{nbdb.QoSBandwidthRate: 10000, nbdb.QoSBandwidthBurst: 1000}, nil, controller)
+			egressQoS := getPodQoS(t, podQoSEgress, map[string]int{nbdb.QoSBandwidthRate: 20000}, map[string]int{nbdb.QoSActionDSCP: 46}, controller)
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(
+				getExpectedDataPodsSwitchesAndQoSes([]testPod{t}, []string{"node1"}, ingressQoS, egressQoS)))
+
+			// drop the ingress limit and only mark the egress traffic
+			pod, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Get(context.TODO(), t.podName, metav1.GetOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			delete(pod.Annotations, util.PodIngressBandwidthAnnotation)
+			delete(pod.Annotations, util.PodEgressBandwidthAnnotation)
+			pod.Annotations[util.PodEgressDSCPAnnotation] = "8"
+			_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			egressQoS = getPodQoS(t, podQoSEgress, nil, map[string]int{nbdb.QoSActionDSCP: 8}, controller)
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(
+				getExpectedDataPodsSwitchesAndQoSes([]testPod{t}, []string{"node1"}, egressQoS)))
+
+			err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(t.namespace).Delete(context.TODO(), t.podName, *metav1.NewDeleteOptions(0))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedDataPodsAndSwitches([]testPod{}, []string{"node1"})))
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("removes the QoS rules of the pods deleted while it was down", func() {
+		app.Action = func(ctx *cli.Context) error {
+			namespaceT := *newNamespace("namespace1")
+			t := newTPod(
+				"node1",
+				"10.128.1.0/24",
+				"10.128.1.2",
+				"10.128.1.1",
+				"myPod",
+				"10.128.1.3",
+				"0a:58:0a:80:01:03",
+				namespaceT.Name,
+			)
+			staleQoS := getPodQoS(t, podQoSEgress, map[string]int{nbdb.QoSBandwidthRate: 20000}, nil, DefaultNetworkControllerName)
+			initialDB = libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					staleQoS,
+					&nbdb.LogicalSwitch{
+						Name:     "node1",
+						QOSRules: []string{staleQoS.UUID},
+					},
+				},
+			}
+
+			fakeOvn.startWithDBSetup(initialDB,
+				&v1.NamespaceList{
+					Items: []v1.Namespace{
+						namespaceT,
+					},
+				},
+				&v1.PodList{
+					Items: []v1.Pod{},
+				},
+			)
+			err := fakeOvn.controller.WatchNamespaces()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = fakeOvn.controller.WatchPods()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedDataPodsAndSwitches([]testPod{}, []string{"node1"})))
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
@@ -364,11 +364,11 @@ func (handlerInfo *PodSelectorAddrSetHandlerInfo) deletePod(pod *v1.Pod) error {
 // handlePodAddUpdate adds the IP address of a pod that has been
 // selected by PodSelectorAddressSet.
 func (oc *DefaultNetworkController) handlePodAddUpdate(podHandlerInfo *PodSelectorAddrSetHandlerInfo, objs ...interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordPodSelectorAddrSetPodEvent("add", duration)
+			metrics.RecordPodSelectorAddrSetPodEvent(oc.GetNetworkName(), "add", duration)
 		}()
 	}
 	podHandlerInfo.RLock()
@@ -392,11 +392,11 @@ func (oc *DefaultNetworkController) handlePodAddUpdate(podHandlerInfo *PodSelect
 // handlePodDelete removes the IP address of a pod that no longer
 // matches a selector
 func (oc *DefaultNetworkController) handlePodDelete(podHandlerInfo *PodSelectorAddrSetHandlerInfo, obj interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordPodSelectorAddrSetPodEvent("delete", duration)
+			metrics.RecordPodSelectorAddrSetPodEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	podHandlerInfo.RLock()
@@ -498,11 +498,11 @@ func (oc *DefaultNetworkController) podSelectorPodNeedsDelete(pod *kapi.Pod, pod
 }
 
 func (oc *DefaultNetworkController) handleNamespaceAddUpdate(podHandlerInfo *PodSelectorAddrSetHandlerInfo, obj interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordPodSelectorAddrSetNamespaceEvent("add", duration)
+			metrics.RecordPodSelectorAddrSetNamespaceEvent(oc.GetNetworkName(), "add", duration)
 		}()
 	}
 	namespace := obj.(*kapi.Namespace)
@@ -550,11 +550,11 @@ func (oc *DefaultNetworkController) handleNamespaceAddUpdate(podHandlerInfo *Pod
 }
 
 func (oc *DefaultNetworkController) handleNamespaceDel(podHandlerInfo *PodSelectorAddrSetHandlerInfo, obj interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordPodSelectorAddrSetNamespaceEvent("delete", duration)
+			metrics.RecordPodSelectorAddrSetNamespaceEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	podHandlerInfo.RLock()
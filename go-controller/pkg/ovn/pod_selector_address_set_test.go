@@ -262,6 +262,27 @@ var _ = ginkgo.Describe("OVN PodSelectorAddressSet", func() {
 		_, loaded = fakeOvn.controller.podSelectorAddressSets.Load(peerASKey)
 		gomega.Expect(loaded).To(gomega.BeFalse())
 	})
+	ginkgo.It("is reported stale to the db garbage collector once it is not used", func() {
+		startOvn(initialDB, nil, nil, nil, nil)
+		namespace := *newNamespace(namespaceName1)
+		networkPolicy := getMatchLabelsNetworkPolicy(netPolicyName1, namespace.Name,
+			"", "label1", true, true)
+		peer := networkPolicy.Spec.Ingress[0].From[0]
+		peerASKey, _, _, err := fakeOvn.controller.EnsurePodSelectorAddressSet(
+			peer.PodSelector, peer.NamespaceSelector, networkPolicy.Namespace, getPolicyKeyWithKind(networkPolicy))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		peerASIDs := getPodSelectorAddrSetDbIDs(peerASKey, DefaultNetworkControllerName)
+		stale, err := fakeOvn.controller.IsStaleObject(peerASIDs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(stale).To(gomega.BeFalse())
+
+		err = fakeOvn.controller.DeletePodSelectorAddressSet(peerASKey, getPolicyKeyWithKind(networkPolicy))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		stale, err = fakeOvn.controller.IsStaleObject(peerASIDs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(stale).To(gomega.BeTrue())
+	})
+
 	ginkgo.It("is cleaned up with second GetPodSelectorAddressSet call", func() {
 		// start ovn without any objects
 		startOvn(initialDB, nil, nil, nil, nil)
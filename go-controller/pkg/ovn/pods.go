@@ -17,6 +17,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	kapi "k8s.io/api/core/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
@@ -26,11 +27,13 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 	//
 	// TBD: Before this succeeds, add Pod handler should not continue to allocate IPs for the new Pods.
 	expectedLogicalPorts := make(map[string]bool)
+	expectedPods := sets.NewString()
 	for _, podInterface := range pods {
 		pod, ok := podInterface.(*kapi.Pod)
 		if !ok {
 			return fmt.Errorf("spurious object in syncPods: %v", podInterface)
 		}
+		expectedPods.Insert(pod.Namespace + "/" + pod.Name)
 		annotations, err := util.UnmarshalPodAnnotation(pod.Annotations, ovntypes.DefaultNetworkName)
 		if err != nil {
 			continue
@@ -87,6 +90,9 @@ func (oc *DefaultNetworkController) syncPods(pods []interface{}) error {
 		}
 	}
 
+	if err := oc.deleteStalePodQoSes(expectedPods); err != nil {
+		return err
+	}
 	return oc.deleteStaleLogicalSwitchPorts(expectedLogicalPorts)
 }
 
@@ -104,6 +110,9 @@ func (oc *DefaultNetworkController) deleteLogicalPort(pod *kapi.Pod, portInfo *l
 		return nil
 	}
 
+	if err := oc.deletePodQoS(pod); err != nil {
+		return err
+	}
 	pInfo, err := oc.deletePodLogicalPort(pod, portInfo, ovntypes.DefaultNetworkName)
 	if err != nil {
 		return err
@@ -181,6 +190,12 @@ func (oc *DefaultNetworkController) addLogicalPort(pod *kapi.Pod) (err error) {
 	}
 	ops = append(ops, addOps...)
 
+	// limit the bandwidth and mark the traffic of the pod as requested by its annotations
+	ops, err = oc.ensurePodQoSOps(pod, lsp.Name, switchName, ops)
+	if err != nil {
+		return err
+	}
+
 	// if we have any external or pod Gateways, add routes
 	gateways := make([]*gatewayInfo, 0, len(routingExternalGWs.gws)+len(routingPodGWs))
 
@@ -252,7 +267,7 @@ func (oc *DefaultNetworkController) addLogicalPort(pod *kapi.Pod) (err error) {
 		return err
 	}
 	if oc.multicastSupport && isNamespaceMulticastEnabled(ns.Annotations) {
-		if err := podAddAllowMulticastPolicy(oc.nbClient, pod.Namespace, portInfo); err != nil {
+		if err := oc.podAddAllowMulticastPolicy(pod.Namespace, portInfo); err != nil {
 			return err
 		}
 	}
@@ -262,3 +277,39 @@ func (oc *DefaultNetworkController) addLogicalPort(pod *kapi.Pod) (err error) {
 	}
 	return nil
 }
+
+// podDPUNameChanged returns true if the DPU the VF of the pod is bound to changed
+func podDPUNameChanged(oldPod, pod *kapi.Pod) bool {
+	oldDPUCD, _ := util.UnmarshalPodDPUConnDetails(oldPod.Annotations, ovntypes.DefaultNetworkName)
+	newDPUCD, _ := util.UnmarshalPodDPUConnDetails(pod.Annotations, ovntypes.DefaultNetworkName)
+	var oldDPUName, newDPUName string
+	if oldDPUCD != nil {
+		oldDPUName = oldDPUCD.DPUName
+	}
+	if newDPUCD != nil {
+		newDPUName = newDPUCD.DPUName
+	}
+	return oldDPUName != newDPUName
+}
+
+// updatePodRequestedChassis binds the logical switch port of the pod to the chassis of the DPU it was
+// bound to once its VF was set up on the host
+func (oc *DefaultNetworkController) updatePodRequestedChassis(pod *kapi.Pod) error {
+	portInfo, err := oc.logicalPortCache.get(pod, oc.GetNetworkName())
+	if err != nil {
+		klog.V(5).Infof("Skipping the chassis update of pod %s/%s without a logical port yet", pod.Namespace, pod.Name)
+		return nil
+	}
+	chassis, err := oc.podRequestedChassis(pod, ovntypes.DefaultNetworkName)
+	if err != nil {
+		return err
+	}
+	lsp := &nbdb.LogicalSwitchPort{
+		Name:    portInfo.name,
+		Options: map[string]string{"requested-chassis": chassis},
+	}
+	if err := libovsdbops.UpdateLogicalSwitchPortSetOptions(oc.nbClient, lsp); err != nil {
+		return fmt.Errorf("failed to set the requested chassis of pod %s/%s to %s: %v", pod.Namespace, pod.Name, chassis, err)
+	}
+	return nil
+}
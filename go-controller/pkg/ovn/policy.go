@@ -41,12 +41,6 @@ const (
 	policyTypeACLExtIdKey = "policy_type"
 	// policyTypeNumACLExtIdKey external ID key for policy index by type on 'gress policy ACLs
 	policyTypeNumACLExtIdKey = "%s_num"
-	// ingressDefaultDenySuffix is the suffix used when creating the ingress port group for a namespace
-	ingressDefaultDenySuffix = "ingressDefaultDeny"
-	// egressDefaultDenySuffix is the suffix used when creating the ingress port group for a namespace
-	egressDefaultDenySuffix = "egressDefaultDeny"
-	// arpAllowPolicySuffix is the suffix used when creating default ACLs for a namespace
-	arpAllowPolicySuffix = "ARPallowPolicy"
 	// arpAllowPolicyMatch is the match used when creating default allow ARP ACLs for a namespace
 	arpAllowPolicyMatch = "(arp || nd)"
 	// staleArpAllowPolicyMatch "was" the old match used when creating default allow ARP ACLs for a namespace
@@ -255,8 +249,8 @@ func (oc *DefaultNetworkController) updateStaleDefaultDenyACLNames(npType knet.P
 		newName := getDefaultDenyPolicyACLName(namespace, aclT)
 		if len(aclList) > 1 {
 			// this should never be the case but delete everything except 1st ACL
-			ingressPGName := defaultDenyPortGroupName(namespace, ingressDefaultDenySuffix)
-			egressPGName := defaultDenyPortGroupName(namespace, egressDefaultDenySuffix)
+			ingressPGName := defaultDenyPortGroupName(namespace, types.IngressDefaultDenySuffix)
+			egressPGName := defaultDenyPortGroupName(namespace, types.EgressDefaultDenySuffix)
 			err := libovsdbops.DeleteACLsFromPortGroups(oc.nbClient, []string{ingressPGName, egressPGName}, aclList[1:]...)
 			if err != nil {
 				return err
@@ -327,8 +321,8 @@ func (oc *DefaultNetworkController) syncNetworkPolicies(networkPolicies []interf
 				namespace := strings.Split(*netpolACL.Name, "_")[0]
 				if _, ok := expectedPolicies[namespace]; !ok {
 					// no policies in that namespace are found, delete default deny port group
-					stalePGs.Insert(defaultDenyPortGroupName(namespace, ingressDefaultDenySuffix))
-					stalePGs.Insert(defaultDenyPortGroupName(namespace, egressDefaultDenySuffix))
+					stalePGs.Insert(defaultDenyPortGroupName(namespace, types.IngressDefaultDenySuffix))
+					stalePGs.Insert(defaultDenyPortGroupName(namespace, types.EgressDefaultDenySuffix))
 				}
 			}
 
@@ -411,10 +405,10 @@ func (oc *DefaultNetworkController) syncNetworkPolicies(networkPolicies []interf
 		return fmt.Errorf("cannot delete stale arp allow ACLs: %v", err)
 	}
 
-	if err := oc.updateStaleDefaultDenyACLNames(knet.PolicyTypeEgress, egressDefaultDenySuffix); err != nil {
+	if err := oc.updateStaleDefaultDenyACLNames(knet.PolicyTypeEgress, types.EgressDefaultDenySuffix); err != nil {
 		return fmt.Errorf("cannot clean up egress default deny ACL name: %v", err)
 	}
-	if err := oc.updateStaleDefaultDenyACLNames(knet.PolicyTypeIngress, ingressDefaultDenySuffix); err != nil {
+	if err := oc.updateStaleDefaultDenyACLNames(knet.PolicyTypeIngress, types.IngressDefaultDenySuffix); err != nil {
 		return fmt.Errorf("cannot clean up ingress default deny ACL name: %v", err)
 	}
 
@@ -463,9 +457,9 @@ func getDefaultDenyPolicyACLName(ns string, aclT aclType) string {
 	var defaultDenySuffix string
 	switch aclT {
 	case lportIngress:
-		defaultDenySuffix = ingressDefaultDenySuffix
+		defaultDenySuffix = types.IngressDefaultDenySuffix
 	case lportEgressAfterLB:
-		defaultDenySuffix = egressDefaultDenySuffix
+		defaultDenySuffix = types.EgressDefaultDenySuffix
 	default:
 		panic(fmt.Sprintf("Unknown acl type %s", aclT))
 	}
@@ -477,7 +471,7 @@ func getDefaultDenyPolicyExternalIDs(aclT aclType) map[string]string {
 }
 
 func getARPAllowACLName(ns string) string {
-	return joinACLName(ns, arpAllowPolicySuffix)
+	return joinACLName(ns, types.ARPAllowPolicySuffix)
 }
 
 func defaultDenyPortGroupName(namespace, gressSuffix string) string {
@@ -536,9 +530,9 @@ func (oc *DefaultNetworkController) delPolicyFromDefaultPortGroups(np *networkPo
 // createDefaultDenyPGAndACLs creates the default port groups and acls for a namespace
 // must be called with defaultDenyPortGroups lock
 func (oc *DefaultNetworkController) createDefaultDenyPGAndACLs(namespace, policy string, aclLogging *ACLLoggingLevels) error {
-	ingressPGName := defaultDenyPortGroupName(namespace, ingressDefaultDenySuffix)
+	ingressPGName := defaultDenyPortGroupName(namespace, types.IngressDefaultDenySuffix)
 	ingressDenyACL, ingressAllowACL := buildDenyACLs(namespace, ingressPGName, aclLogging, lportIngress)
-	egressPGName := defaultDenyPortGroupName(namespace, egressDefaultDenySuffix)
+	egressPGName := defaultDenyPortGroupName(namespace, types.EgressDefaultDenySuffix)
 	egressDenyACL, egressAllowACL := buildDenyACLs(namespace, egressPGName, aclLogging, lportEgressAfterLB)
 	ops, err := libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, nil, ingressDenyACL, ingressAllowACL, egressDenyACL, egressAllowACL)
 	if err != nil {
@@ -569,8 +563,8 @@ func (oc *DefaultNetworkController) createDefaultDenyPGAndACLs(namespace, policy
 // deleteDefaultDenyPGAndACLs deletes the default port groups and acls for a namespace
 // must be called with defaultDenyPortGroups lock
 func (oc *DefaultNetworkController) deleteDefaultDenyPGAndACLs(namespace, policy string) error {
-	ingressPGName := defaultDenyPortGroupName(namespace, ingressDefaultDenySuffix)
-	egressPGName := defaultDenyPortGroupName(namespace, egressDefaultDenySuffix)
+	ingressPGName := defaultDenyPortGroupName(namespace, types.IngressDefaultDenySuffix)
+	egressPGName := defaultDenyPortGroupName(namespace, types.EgressDefaultDenySuffix)
 
 	ops, err := libovsdbops.DeletePortGroupsOps(oc.nbClient, nil, ingressPGName, egressPGName)
 	if err != nil {
@@ -607,9 +601,9 @@ func (oc *DefaultNetworkController) updateACLLoggingForDefaultACLs(ns string, ns
 			// shared port group doesn't exist, nothing to update
 			return nil
 		}
-		denyEgressACL, _ := buildDenyACLs(ns, defaultDenyPortGroupName(ns, egressDefaultDenySuffix),
+		denyEgressACL, _ := buildDenyACLs(ns, defaultDenyPortGroupName(ns, types.EgressDefaultDenySuffix),
 			&nsInfo.aclLogging, lportEgressAfterLB)
-		denyIngressACL, _ := buildDenyACLs(ns, defaultDenyPortGroupName(ns, ingressDefaultDenySuffix),
+		denyIngressACL, _ := buildDenyACLs(ns, defaultDenyPortGroupName(ns, types.IngressDefaultDenySuffix),
 			&nsInfo.aclLogging, lportIngress)
 		if err := UpdateACLLogging(oc.nbClient, []*nbdb.ACL{denyIngressACL, denyEgressACL}, &nsInfo.aclLogging); err != nil {
 			return fmt.Errorf("unable to update ACL logging for namespace %s: %w", ns, err)
@@ -749,8 +743,8 @@ func (oc *DefaultNetworkController) getExistingLocalPolicyPorts(np *networkPolic
 // It only adds new ports that do not already exist in the deny port groups.
 func (oc *DefaultNetworkController) denyPGAddPorts(np *networkPolicy, portNamesToUUIDs map[string]string, ops []ovsdb.Operation) error {
 	var err error
-	ingressDenyPGName := defaultDenyPortGroupName(np.namespace, ingressDefaultDenySuffix)
-	egressDenyPGName := defaultDenyPortGroupName(np.namespace, egressDefaultDenySuffix)
+	ingressDenyPGName := defaultDenyPortGroupName(np.namespace, types.IngressDefaultDenySuffix)
+	egressDenyPGName := defaultDenyPortGroupName(np.namespace, types.EgressDefaultDenySuffix)
 
 	pgKey := np.namespace
 	// this lock guarantees that sharedPortGroup counters will be updated atomically
@@ -813,8 +807,8 @@ func (oc *DefaultNetworkController) denyPGDeletePorts(np *networkPolicy, portNam
 		})
 	}
 	if len(portNamesToUUIDs) != 0 {
-		ingressDenyPGName := defaultDenyPortGroupName(np.namespace, ingressDefaultDenySuffix)
-		egressDenyPGName := defaultDenyPortGroupName(np.namespace, egressDefaultDenySuffix)
+		ingressDenyPGName := defaultDenyPortGroupName(np.namespace, types.IngressDefaultDenySuffix)
+		egressDenyPGName := defaultDenyPortGroupName(np.namespace, types.EgressDefaultDenySuffix)
 
 		pgKey := np.namespace
 		// this lock guarantees that sharedPortGroup counters will be updated atomically
@@ -867,11 +861,11 @@ func (oc *DefaultNetworkController) denyPGDeletePorts(np *networkPolicy, portNam
 
 // handleLocalPodSelectorAddFunc adds a new pod to an existing NetworkPolicy, should be retriable.
 func (oc *DefaultNetworkController) handleLocalPodSelectorAddFunc(np *networkPolicy, objs ...interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolLocalPodEvent("add", duration)
+			metrics.RecordNetpolLocalPodEvent(oc.GetNetworkName(), "add", duration)
 		}()
 	}
 	np.RLock()
@@ -918,11 +912,11 @@ func (oc *DefaultNetworkController) handleLocalPodSelectorAddFunc(np *networkPol
 
 // handleLocalPodSelectorDelFunc handles delete event for local pod, should be retriable
 func (oc *DefaultNetworkController) handleLocalPodSelectorDelFunc(np *networkPolicy, objs ...interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolLocalPodEvent("delete", duration)
+			metrics.RecordNetpolLocalPodEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	np.RLock()
@@ -1256,11 +1250,11 @@ func (oc *DefaultNetworkController) setupGressPolicy(np *networkPolicy, gp *gres
 // if addNetworkPolicy fails, create or delete operation can be retried
 func (oc *DefaultNetworkController) addNetworkPolicy(policy *knet.NetworkPolicy) error {
 	klog.Infof("Adding network policy %s", getPolicyKey(policy))
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolEvent("add", duration)
+			metrics.RecordNetpolEvent(oc.GetNetworkName(), "add", duration)
 		}()
 	}
 	// To not hold nsLock for the whole process on network policy creation, we do the following:
@@ -1367,11 +1361,11 @@ func (oc *DefaultNetworkController) buildNetworkPolicyACLs(np *networkPolicy, ac
 func (oc *DefaultNetworkController) deleteNetworkPolicy(policy *knet.NetworkPolicy) error {
 	npKey := getPolicyKey(policy)
 	klog.Infof("Deleting network policy %s", npKey)
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolEvent("delete", duration)
+			metrics.RecordNetpolEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	// First lock and update namespace
@@ -1461,11 +1455,11 @@ type NetworkPolicyExtraParameters struct {
 }
 
 func (oc *DefaultNetworkController) handlePeerNamespaceSelectorAdd(np *networkPolicy, gp *gressPolicy, objs ...interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolPeerNamespaceEvent("add", duration)
+			metrics.RecordNetpolPeerNamespaceEvent(oc.GetNetworkName(), "add", duration)
 		}()
 	}
 	np.RLock()
@@ -1498,11 +1492,11 @@ func (oc *DefaultNetworkController) handlePeerNamespaceSelectorAdd(np *networkPo
 }
 
 func (oc *DefaultNetworkController) handlePeerNamespaceSelectorDel(np *networkPolicy, gp *gressPolicy, objs ...interface{}) error {
-	if config.Metrics.EnableScaleMetrics {
+	if config.ScaleMetricsEnabled() {
 		start := time.Now()
 		defer func() {
 			duration := time.Since(start)
-			metrics.RecordNetpolPeerNamespaceEvent("delete", duration)
+			metrics.RecordNetpolPeerNamespaceEvent(oc.GetNetworkName(), "delete", duration)
 		}()
 	}
 	np.RLock()
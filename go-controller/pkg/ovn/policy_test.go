@@ -72,7 +72,7 @@ func newNetworkPolicy(name, namespace string, podSelector metav1.LabelSelector,
 
 func getDefaultDenyData(networkPolicy *knet.NetworkPolicy, ports []string,
 	denyLogSeverity nbdb.ACLSeverity, stale bool) []libovsdb.TestData {
-	egressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, egressDefaultDenySuffix)
+	egressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, types.EgressDefaultDenySuffix)
 	policyTypeIngress, policyTypeEgress := getPolicyType(networkPolicy)
 	shouldBeLogged := denyLogSeverity != ""
 	aclName := getDefaultDenyPolicyACLName(networkPolicy.Namespace, lportEgressAfterLB)
@@ -113,7 +113,7 @@ func getDefaultDenyData(networkPolicy *knet.NetworkPolicy, ports []string,
 	)
 	egressAllowACL.UUID = aclName + "-egressAllowACL-UUID"
 
-	ingressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, ingressDefaultDenySuffix)
+	ingressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, types.IngressDefaultDenySuffix)
 	aclName = getDefaultDenyPolicyACLName(networkPolicy.Namespace, lportIngress)
 	ingressDenyACL := libovsdbops.BuildACL(
 		aclName,
@@ -1178,7 +1178,7 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 				egressOptions := map[string]string{
 					"apply-after-lb": "true",
 				}
-				egressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, egressDefaultDenySuffix)
+				egressPGName := defaultDenyPortGroupName(networkPolicy.Namespace, types.EgressDefaultDenySuffix)
 				aclName := getARPAllowACLName(networkPolicy.Namespace)
 				leftOverACLFromUpgrade1 := libovsdbops.BuildACL(
 					aclName,
@@ -1252,8 +1252,8 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 				networkPolicy2 := getPortNetworkPolicy(netPolicyName2, namespace1.Name, labelName, labelVal, portNum+1)
 				// network policy should exist for port group to not be cleaned up
 				networkPolicy3 := getPortNetworkPolicy(netPolicyName1, "leftover1", labelName, labelVal, portNum)
-				egressPGName := defaultDenyPortGroupName("leftover1", egressDefaultDenySuffix)
-				ingressPGName := defaultDenyPortGroupName("leftover1", ingressDefaultDenySuffix)
+				egressPGName := defaultDenyPortGroupName("leftover1", types.EgressDefaultDenySuffix)
+				ingressPGName := defaultDenyPortGroupName("leftover1", types.IngressDefaultDenySuffix)
 				egressOptions := map[string]string{
 					// older versions of ACLs don't have, should be added by syncNetworkPolicies on startup
 					//	"apply-after-lb": "true",
@@ -1416,11 +1416,11 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 				longLeftOverNameSpaceName2 := "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxy1" // namespace is >45 characters long
 				// network policy should exist for port group to not be cleaned up
 				networkPolicy3 := getPortNetworkPolicy(netPolicyName1, longLeftOverNameSpaceName, labelName, labelVal, portNum)
-				egressPGName := defaultDenyPortGroupName(longLeftOverNameSpaceName, egressDefaultDenySuffix)
-				ingressPGName := defaultDenyPortGroupName(longLeftOverNameSpaceName, ingressDefaultDenySuffix)
+				egressPGName := defaultDenyPortGroupName(longLeftOverNameSpaceName, types.EgressDefaultDenySuffix)
+				ingressPGName := defaultDenyPortGroupName(longLeftOverNameSpaceName, types.IngressDefaultDenySuffix)
 				// ACL1: leftover arp allow ACL egress with old match (arp)
 				leftOverACL1FromUpgrade := libovsdbops.BuildACL(
-					longLeftOverNameSpaceName+"_"+arpAllowPolicySuffix,
+					longLeftOverNameSpaceName+"_"+types.ARPAllowPolicySuffix,
 					nbdb.ACLDirectionFromLport,
 					types.DefaultAllowPriority,
 					"inport == @"+egressPGName+" && "+staleArpAllowPolicyMatch,
@@ -1443,7 +1443,7 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 				testOnlyEgressDenyPG.UUID = testOnlyEgressDenyPG.Name + "-UUID"
 				// ACL2: leftover arp allow ACL ingress with old match (arp)
 				leftOverACL2FromUpgrade := libovsdbops.BuildACL(
-					longLeftOverNameSpaceName+"_"+arpAllowPolicySuffix,
+					longLeftOverNameSpaceName+"_"+types.ARPAllowPolicySuffix,
 					nbdb.ACLDirectionToLport,
 					types.DefaultAllowPriority,
 					"outport == @"+ingressPGName+" && "+staleArpAllowPolicyMatch,
@@ -1460,7 +1460,7 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 
 				// ACL3: leftover arp allow ACL ingress with new match (arp || nd)
 				leftOverACL3FromUpgrade := libovsdbops.BuildACL(
-					longLeftOverNameSpaceName+"blah"+"_"+arpAllowPolicySuffix,
+					longLeftOverNameSpaceName+"blah"+"_"+types.ARPAllowPolicySuffix,
 					nbdb.ACLDirectionToLport,
 					types.DefaultAllowPriority,
 					"outport == @"+ingressPGName+" && "+arpAllowPolicyMatch, // new match! this ACL should be left as is!
@@ -75,6 +75,21 @@ func (c *portCache) getAll(pod *kapi.Pod) (map[string]*lpInfo, error) {
 	return nil, fmt.Errorf("logical port cache for pod %s not found", podName)
 }
 
+// count returns the number of logical ports in the cache, excluding the ones scheduled for removal
+func (c *portCache) count() int {
+	c.RLock()
+	defer c.RUnlock()
+	count := 0
+	for _, infoMap := range c.cache {
+		for _, info := range infoMap {
+			if info.expires.IsZero() {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (c *portCache) add(pod *kapi.Pod, logicalSwitch, nadName, uuid string, mac net.HardwareAddr, ips []*net.IPNet) *lpInfo {
 	var logicalPort string
 
@@ -8,6 +8,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
@@ -42,8 +43,9 @@ func NewSecondaryLayer2NetworkController(cnci *CommonNetworkControllerInfo, netI
 		},
 	}
 
-	// disable multicast support for secondary networks
-	oc.multicastSupport = false
+	// multicast is enabled on secondary networks through their netconf
+	oc.multicastSupport = cnci.multicastSupport && netconfInfo.MulticastEnabled()
+	oc.multicastNamespaces = sets.New[string]()
 
 	oc.initRetryFramework()
 	return oc
@@ -55,6 +57,7 @@ func (oc *SecondaryLayer2NetworkController) Start(ctx context.Context) error {
 	if err := oc.Init(); err != nil {
 		return err
 	}
+	oc.registerNetworkMetrics()
 
 	return oc.Run()
 }
@@ -70,5 +73,11 @@ func (oc *SecondaryLayer2NetworkController) Init() error {
 	layer2NetConfInfo := oc.NetConfInfo.(*util.Layer2NetConfInfo)
 
 	_, err := oc.InitializeLogicalSwitch(switchName, layer2NetConfInfo.ClusterSubnets, layer2NetConfInfo.ExcludeSubnets)
-	return err
+	if err != nil {
+		return err
+	}
+	if oc.multicastSupport {
+		return oc.createSecondaryNetworkMulticastPolicy()
+	}
+	return nil
 }
@@ -167,6 +167,9 @@ func (h *secondaryLayer3NetworkControllerEventHandler) SyncFunc(objs []interface
 		case factory.NodeType:
 			syncFunc = h.oc.syncNodes
 
+		case factory.NamespaceType:
+			syncFunc = h.oc.syncNamespacesForSecondaryNetwork
+
 		default:
 			return fmt.Errorf("no sync function for object type %s", h.objType)
 		}
@@ -217,8 +220,9 @@ func NewSecondaryLayer3NetworkController(cnci *CommonNetworkControllerInfo, netI
 		addNodeFailed:               sync.Map{},
 		nodeClusterRouterPortFailed: sync.Map{},
 	}
-	// disable multicast support for secondary networks
-	oc.multicastSupport = false
+	// multicast is enabled on secondary networks through their netconf
+	oc.multicastSupport = cnci.multicastSupport && netconfInfo.MulticastEnabled()
+	oc.multicastNamespaces = sets.New[string]()
 
 	oc.initRetryFramework()
 	return oc
@@ -227,6 +231,9 @@ func NewSecondaryLayer3NetworkController(cnci *CommonNetworkControllerInfo, netI
 func (oc *SecondaryLayer3NetworkController) initRetryFramework() {
 	oc.retryPods = oc.newRetryFramework(factory.PodType)
 	oc.retryNodes = oc.newRetryFramework(factory.NodeType)
+	if oc.multicastSupport {
+		oc.retryNamespaces = oc.newRetryFramework(factory.NamespaceType)
+	}
 }
 
 // newRetryFramework builds and returns a retry framework for the input resource type;
@@ -243,6 +250,7 @@ func (oc *SecondaryLayer3NetworkController) newRetryFramework(
 		HasUpdateFunc:          hasResourceAnUpdateFunc(objectType),
 		NeedsUpdateDuringRetry: needsUpdateDuringRetry(objectType),
 		ObjType:                objectType,
+		NetworkName:            oc.GetNetworkName(),
 		EventHandler:           eventHandler,
 	}
 	return retry.NewRetryFramework(
@@ -256,9 +264,13 @@ func (oc *SecondaryLayer3NetworkController) newRetryFramework(
 // Start starts the secondary layer3 controller, handles all events and creates all needed logical entities
 func (oc *SecondaryLayer3NetworkController) Start(ctx context.Context) error {
 	klog.Infof("Start secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
+	if err := oc.startPodIPQuarantine(); err != nil {
+		return err
+	}
 	if err := oc.Init(); err != nil {
 		return err
 	}
+	oc.registerNetworkMetrics()
 
 	return oc.Run()
 }
@@ -268,6 +280,7 @@ func (oc *SecondaryLayer3NetworkController) Stop() {
 	klog.Infof("Stop secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
 	close(oc.stopChan)
 	oc.wg.Wait()
+	oc.unregisterNetworkMetrics()
 
 	if oc.podHandler != nil {
 		oc.watchFactory.RemovePodHandler(oc.podHandler)
@@ -276,6 +289,10 @@ func (oc *SecondaryLayer3NetworkController) Stop() {
 	if oc.nodeHandler != nil {
 		oc.watchFactory.RemoveNodeHandler(oc.nodeHandler)
 	}
+
+	if oc.namespaceHandler != nil {
+		oc.watchFactory.RemoveNamespaceHandler(oc.namespaceHandler)
+	}
 }
 
 // Cleanup cleans up logical entities for the given network, called from net-attach-def routine
@@ -306,9 +323,19 @@ func (oc *SecondaryLayer3NetworkController) Cleanup(netName string) error {
 		return fmt.Errorf("failed to get ops for deleting routers of network %s: %v", netName, err)
 	}
 
+	// and the multicast port groups
+	ops, err = deleteSecondaryNetworkMulticastPolicyOps(oc.nbClient, ops, netName)
+	if err != nil {
+		return fmt.Errorf("failed to get ops for deleting port groups of network %s: %v", netName, err)
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
-		return fmt.Errorf("failed to deleting routers/switches of network %s: %v", netName, err)
+		return fmt.Errorf("failed to deleting routers/switches/port groups of network %s: %v", netName, err)
+	}
+
+	if err = oc.purgePodIPQuarantine(); err != nil {
+		return fmt.Errorf("failed to purge the quarantined pod IPs of network %s: %v", netName, err)
 	}
 	return nil
 }
@@ -321,6 +348,12 @@ func (oc *SecondaryLayer3NetworkController) Run() error {
 		return err
 	}
 
+	if oc.multicastSupport {
+		if err := oc.WatchNamespaces(); err != nil {
+			return err
+		}
+	}
+
 	if err := oc.WatchPods(); err != nil {
 		return err
 	}
@@ -350,7 +383,13 @@ func (oc *SecondaryLayer3NetworkController) WatchNodes() error {
 
 func (oc *SecondaryLayer3NetworkController) Init() error {
 	_, err := oc.createOvnClusterRouter()
-	return err
+	if err != nil {
+		return err
+	}
+	if oc.multicastSupport {
+		return oc.createSecondaryNetworkMulticastPolicy()
+	}
+	return nil
 }
 
 func (oc *SecondaryLayer3NetworkController) addUpdateNodeEvent(node *kapi.Node, nSyncs *nodeSyncs) error {
@@ -43,7 +43,7 @@ func NewSecondaryLocalnetNetworkController(cnci *CommonNetworkControllerInfo, ne
 		},
 	}
 
-	// disable multicast support for secondary networks
+	// multicast is not supported on localnet networks
 	oc.multicastSupport = false
 
 	oc.initRetryFramework()
@@ -53,9 +53,13 @@ func NewSecondaryLocalnetNetworkController(cnci *CommonNetworkControllerInfo, ne
 // Start starts the secondary localnet controller, handles all events and creates all needed logical entities
 func (oc *SecondaryLocalnetNetworkController) Start(ctx context.Context) error {
 	klog.Infof("Start secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
+	if err := oc.startPodIPQuarantine(); err != nil {
+		return err
+	}
 	if err := oc.Init(); err != nil {
 		return err
 	}
+	oc.registerNetworkMetrics()
 
 	return oc.Run()
 }
@@ -0,0 +1,231 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	backupFileSuffix = ".db"
+	backupTimeFormat = "20060102T150405Z"
+)
+
+// dbBackup takes periodic standalone snapshots of a clustered database
+type dbBackup struct {
+	db         *util.OvsDbProperties
+	serverSock string
+	dir        string
+	retention  int
+
+	sync.Mutex
+	lastBackup time.Time
+}
+
+// runDBBackup periodically backs up the NB database into config.OvnNorth.BackupDir,
+// keeping the config.OvnNorth.BackupRetention most recent backups. It does nothing if
+// no backup directory is configured.
+func runDBBackup(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	if config.OvnNorth.BackupDir == "" {
+		return
+	}
+	dbProperties, err := util.GetOvsDbProperties(util.OvnNbdbLocation)
+	if err != nil {
+		klog.Errorf("Failed to init db properties, NB backups disabled: %v", err)
+		return
+	}
+	if err := os.MkdirAll(config.OvnNorth.BackupDir, 0o750); err != nil {
+		klog.Errorf("Failed to create NB backup directory %s, NB backups disabled: %v", config.OvnNorth.BackupDir, err)
+		return
+	}
+	b := &dbBackup{
+		db:         dbProperties,
+		serverSock: nbdbServerSock,
+		dir:        config.OvnNorth.BackupDir,
+		retention:  int(config.OvnNorth.BackupRetention),
+	}
+	// pick up the backups taken before a restart so the backup age stays accurate
+	if backups, err := listDBBackups(b.db, b.dir); err != nil {
+		klog.Warningf("Unable to list existing backups of %s: %v", b.db.DbName, err)
+	} else if len(backups) > 0 {
+		if fi, err := os.Stat(backups[len(backups)-1]); err == nil {
+			b.lastBackup = fi.ModTime()
+		}
+	}
+	metrics.RegisterOvnDBBackupMetrics(b.db.DbName, b.getLastBackup)
+
+	interval := time.Duration(config.OvnNorth.BackupInterval) * time.Second
+	klog.Infof("Starting backups of %s to %s every %v", b.db.DbName, b.dir, interval)
+	wait.Until(b.run, interval, stopCh)
+}
+
+func (b *dbBackup) getLastBackup() time.Time {
+	b.Lock()
+	defer b.Unlock()
+	return b.lastBackup
+}
+
+func (b *dbBackup) run() {
+	now := time.Now()
+	backupFile, err := backupDB(b.db, b.serverSock, b.dir, now)
+	if err != nil {
+		klog.Error(err)
+		metrics.RecordOvnDBBackupFailure(b.db.DbName)
+		return
+	}
+	b.Lock()
+	b.lastBackup = now
+	b.Unlock()
+	klog.Infof("Backed up %s to %s", b.db.DbName, backupFile)
+
+	if err := pruneDBBackups(b.db, b.dir, b.retention); err != nil {
+		klog.Warningf("Failed to prune backups of %s: %v", b.db.DbName, err)
+	}
+}
+
+// backupFilePrefix returns the prefix of the backups of db, based on the db file name
+func backupFilePrefix(db *util.OvsDbProperties) string {
+	dbFile := filepath.Base(db.DbAlias)
+	return strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) + "-"
+}
+
+// backupDB takes a standalone snapshot of the db served on serverSock, compacts it and
+// stores it into dir. Returns the path of the backup.
+func backupDB(db *util.OvsDbProperties, serverSock, dir string, now time.Time) (string, error) {
+	backupFile := filepath.Join(dir, backupFilePrefix(db)+now.UTC().Format(backupTimeFormat)+backupFileSuffix)
+	// write to a temporary file first so that an incomplete backup is never mistaken for a valid one
+	tmpFile := backupFile + ".tmp"
+	defer os.Remove(tmpFile)
+
+	out, stderr, err := util.RunOVSDBClientRawOutput("-t", "30", "backup", serverSock, db.DbName)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to back up %s, stderr: %q, error: %v", DBError, db.DbName, stderr, err)
+	}
+	if err := os.WriteFile(tmpFile, []byte(out), 0o640); err != nil {
+		return "", fmt.Errorf("failed to write backup of %s to %s: %v", db.DbName, tmpFile, err)
+	}
+	_, stderr, err = util.RunOVSDBTool("compact", tmpFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to compact backup of %s, stderr: %q, error: %v", db.DbName, stderr, err)
+	}
+	if err := os.Rename(tmpFile, backupFile); err != nil {
+		return "", fmt.Errorf("failed to move backup of %s to %s: %v", db.DbName, backupFile, err)
+	}
+	return backupFile, nil
+}
+
+// listDBBackups returns the backups of db found in dir, oldest first
+func listDBBackups(db *util.OvsDbProperties, dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	prefix := backupFilePrefix(db)
+	backups := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, backupFileSuffix) {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	// the timestamp format sorts lexicographically
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// pruneDBBackups removes the oldest backups of db from dir so that at most retention remain
+func pruneDBBackups(db *util.OvsDbProperties, dir string, retention int) error {
+	backups, err := listDBBackups(db, dir)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= retention {
+		return nil
+	}
+	var errs []error
+	for _, backup := range backups[:len(backups)-retention] {
+		klog.V(5).Infof("Removing expired backup %s", backup)
+		if err := os.Remove(backup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to remove expired backups: %v", errs)
+	}
+	return nil
+}
+
+// RestoreDB replaces the clustered database in dbFile with a new single member raft cluster
+// created from the standalone snapshot, with localAddress (e.g. "ssl:10.1.1.185:9643") as the
+// raft address of this server. The current database is kept next to dbFile and the database
+// server is stopped so that it restarts with the restored database. The remaining members of
+// the former cluster must then be reset with ResetDB so that they join the restored cluster.
+func RestoreDB(dbFile, snapshot, localAddress string) error {
+	db, err := util.GetOvsDbProperties(dbFile)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(snapshot); err != nil {
+		return fmt.Errorf("unable to find snapshot %s: %v", snapshot, err)
+	}
+	if _, stderr, err := util.RunOVSDBTool("db-is-standalone", snapshot); err != nil {
+		return fmt.Errorf("snapshot %s is not a standalone database, stderr: %q, error: %v", snapshot, stderr, err)
+	}
+	dbName, stderr, err := util.RunOVSDBTool("db-name", snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to get the database name of snapshot %s, stderr: %q, error: %v", snapshot, stderr, err)
+	}
+	if dbName != db.DbName {
+		return fmt.Errorf("snapshot %s holds database %s, expected %s", snapshot, dbName, db.DbName)
+	}
+
+	restoredFile := dbFile + ".restore"
+	if err := os.Remove(restoredFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale restored database %s: %v", restoredFile, err)
+	}
+	_, stderr, err = util.RunOVSDBTool("create-cluster", restoredFile, snapshot, localAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster from snapshot %s, stderr: %q, error: %v", snapshot, stderr, err)
+	}
+
+	if _, err := os.Stat(dbFile); err == nil {
+		if _, err := moveDBAside(db); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(restoredFile, dbFile); err != nil {
+		return fmt.Errorf("failed to move restored database %s to %s: %v", restoredFile, dbFile, err)
+	}
+	klog.Infof("Restored %s from snapshot %s", db.DbName, snapshot)
+
+	_, stderr, err = db.AppCtl(5, "exit")
+	if err != nil {
+		// the server may simply not be running, it will pick up the restored db when started
+		klog.Warningf("Unable to stop the %s db server, stderr: %v, err: %v", db.DbName, stderr, err)
+	}
+	return nil
+}
+
+// ResetDB backs up and removes the clustered database in dbFile and stops the database
+// server so that it rejoins the raft cluster from scratch when restarted.
+func ResetDB(dbFile string) error {
+	db, err := util.GetOvsDbProperties(dbFile)
+	if err != nil {
+		return err
+	}
+	return resetRaftDB(db)
+}
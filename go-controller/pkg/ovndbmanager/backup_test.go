@@ -0,0 +1,250 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const backupContent = `OVSDB JSON 32 0123456789abcdef
+{"name":"OVN_Northbound"}
+`
+
+func TestBackupDB(t *testing.T) {
+	now := time.Date(2023, 3, 14, 15, 9, 26, 0, time.UTC)
+	db := &util.OvsDbProperties{
+		DbName:  "OVN_Northbound",
+		DbAlias: "/etc/ovn/ovnnb_db.db",
+	}
+
+	tests := []struct {
+		desc        string
+		backupErr   error
+		compactErr  error
+		errorString string
+	}{
+		{
+			desc:        "Test error: unable to take the snapshot",
+			backupErr:   fmt.Errorf("failure"),
+			errorString: "failed to back up OVN_Northbound",
+		},
+		{
+			desc:        "Test error: unable to compact the snapshot",
+			compactErr:  fmt.Errorf("failure"),
+			errorString: "failed to compact backup of OVN_Northbound",
+		},
+		{
+			desc: "Successful backup",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			expectedFile := filepath.Join(tmpDir, "ovnnb_db-20230314T150926Z.db")
+
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovsdb-client -t 30 backup " + nbdbServerSock + " OVN_Northbound",
+				Output: backupContent,
+				Err:    tc.backupErr,
+			})
+			if tc.backupErr == nil {
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovsdb-tool compact " + expectedFile + ".tmp",
+					Err: tc.compactErr,
+				})
+			}
+			if err := util.SetExec(fexec); err != nil {
+				t.Fatalf("Failed to set exec: %v", err)
+			}
+
+			backupFile, err := backupDB(db, nbdbServerSock, tmpDir, now)
+			failOnErrorMismatch(t, err, tc.errorString)
+			if !fexec.CalledMatchesExpected() {
+				t.Error(fexec.ErrorDesc())
+			}
+
+			if _, err := os.Stat(expectedFile + ".tmp"); !os.IsNotExist(err) {
+				t.Errorf("Temporary backup file was not removed: %v", err)
+			}
+			if tc.errorString != "" {
+				if _, err := os.Stat(expectedFile); !os.IsNotExist(err) {
+					t.Errorf("Backup file should not exist after a failure: %v", err)
+				}
+				return
+			}
+			if backupFile != expectedFile {
+				t.Errorf("Expected backup file %s, got %s", expectedFile, backupFile)
+			}
+			content, err := os.ReadFile(backupFile)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			// the snapshot must be stored as is since records are length prefixed
+			if string(content) != backupContent {
+				t.Errorf("Expected backup content %q, got %q", backupContent, string(content))
+			}
+		})
+	}
+}
+
+func TestPruneDBBackups(t *testing.T) {
+	db := &util.OvsDbProperties{
+		DbName:  "OVN_Northbound",
+		DbAlias: "/etc/ovn/ovnnb_db.db",
+	}
+
+	tests := []struct {
+		desc      string
+		files     []string
+		retention int
+		remaining []string
+	}{
+		{
+			desc:      "Less backups than the retention",
+			files:     []string{"ovnnb_db-20230314T150926Z.db"},
+			retention: 2,
+			remaining: []string{"ovnnb_db-20230314T150926Z.db"},
+		},
+		{
+			desc: "Oldest backups are removed",
+			files: []string{
+				"ovnnb_db-20230314T170926Z.db",
+				"ovnnb_db-20230314T150926Z.db",
+				"ovnnb_db-20230314T160926Z.db",
+			},
+			retention: 2,
+			remaining: []string{
+				"ovnnb_db-20230314T160926Z.db",
+				"ovnnb_db-20230314T170926Z.db",
+			},
+		},
+		{
+			desc: "Unrelated files are ignored",
+			files: []string{
+				"ovnsb_db-20230314T150926Z.db",
+				"ovnnb_db-20230314T160926Z.db.tmp",
+				"ovnnb_db-20230314T170926Z.db",
+				"ovnnb_db-20230314T180926Z.db",
+			},
+			retention: 1,
+			remaining: []string{
+				"ovnnb_db-20230314T160926Z.db.tmp",
+				"ovnnb_db-20230314T180926Z.db",
+				"ovnsb_db-20230314T150926Z.db",
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for _, file := range tc.files {
+				createDbFile(t, filepath.Join(tmpDir, file))
+			}
+
+			if err := pruneDBBackups(db, tmpDir, tc.retention); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			entries, err := os.ReadDir(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			remaining := []string{}
+			for _, entry := range entries {
+				remaining = append(remaining, entry.Name())
+			}
+			if fmt.Sprint(remaining) != fmt.Sprint(tc.remaining) {
+				t.Errorf("Expected remaining files %v, got %v", tc.remaining, remaining)
+			}
+		})
+	}
+}
+
+func TestRestoreDB(t *testing.T) {
+	const localAddress = "ssl:10.1.1.185:9643"
+
+	tests := []struct {
+		desc        string
+		dbName      string
+		standalone  error
+		errorString string
+	}{
+		{
+			desc:        "Test error: snapshot is clustered",
+			standalone:  fmt.Errorf("exit status 2"),
+			errorString: "is not a standalone database",
+		},
+		{
+			desc:        "Test error: snapshot of another database",
+			dbName:      "OVN_Southbound",
+			errorString: "holds database OVN_Southbound, expected OVN_Northbound",
+		},
+		{
+			desc:   "Successful restore",
+			dbName: "OVN_Northbound",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			dbFile := filepath.Join(tmpDir, "ovnnb_db.db")
+			snapshot := filepath.Join(tmpDir, "ovnnb_db-20230314T150926Z.db")
+			createDbFile(t, dbFile)
+			createDbFile(t, snapshot)
+
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: "ovsdb-tool db-is-standalone " + snapshot,
+				Err: tc.standalone,
+			})
+			if tc.standalone == nil {
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ovsdb-tool db-name " + snapshot,
+					Output: tc.dbName,
+				})
+			}
+			if tc.errorString == "" {
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovsdb-tool create-cluster " + dbFile + ".restore " + snapshot + " " + localAddress,
+					Action: func() error {
+						return os.WriteFile(dbFile+".restore", []byte(backupContent), 0o640)
+					},
+				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovn-appctl -t /var/run/ovn/ovnnb_db.ctl --timeout=5 exit",
+				})
+			}
+			if err := util.SetExec(fexec); err != nil {
+				t.Fatalf("Failed to set exec: %v", err)
+			}
+
+			err := RestoreDB(dbFile, snapshot, localAddress)
+			failOnErrorMismatch(t, err, tc.errorString)
+			if !fexec.CalledMatchesExpected() {
+				t.Error(fexec.ErrorDesc())
+			}
+			if tc.errorString != "" {
+				return
+			}
+
+			content, err := os.ReadFile(dbFile)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(content) != backupContent {
+				t.Errorf("Database was not replaced by the restored one")
+			}
+			// the former database is kept next to the restored one
+			backups, err := filepath.Glob(filepath.Join(tmpDir, "ovnnb_db*db_bak"))
+			if err != nil || len(backups) != 1 {
+				t.Errorf("Expected the former database to be kept, found %v: %v", backups, err)
+			}
+		})
+	}
+}
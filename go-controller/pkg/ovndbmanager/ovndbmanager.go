@@ -55,6 +55,12 @@ func RunDBChecker(kclient kube.Interface, stopCh <-chan struct{}) {
 		}
 		ensureOvnDBState(util.OvnSbdbLocation, kclient, stopCh)
 	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runDBBackup(stopCh)
+	}()
 	<-stopCh
 	klog.Info("Shutting down db checker")
 	wg.Wait()
@@ -318,16 +324,11 @@ func ensureElectionTimeout(db *util.OvsDbProperties) error {
 // resetRaftDB backs up the db by renaming it and then stops the nb/sb ovsdb process.
 // Returns an error if anything goes wrong.
 func resetRaftDB(db *util.OvsDbProperties) error {
-	dbFile := filepath.Base(db.DbAlias)
-	backupFile := strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) +
-		time.Now().UTC().Format("2006-01-02_150405") + "db_bak"
-	backupDB := filepath.Join(filepath.Dir(db.DbAlias), backupFile)
-	err := os.Rename(db.DbAlias, backupDB)
+	backupFile, err := moveDBAside(db)
 	if err != nil {
-		return fmt.Errorf("failed to back up the db to backupFile: %s, error: %s", backupDB, err)
+		return err
 	}
 
-	klog.Infof("Backed up the db to backupFile: %s", backupFile)
 	_, stderr, err := db.AppCtl(5, "exit")
 	if err != nil {
 		return fmt.Errorf("unable to restart the ovn db: %s ,"+
@@ -338,6 +339,22 @@ func resetRaftDB(db *util.OvsDbProperties) error {
 	return nil
 }
 
+// moveDBAside backs up the db by renaming it next to its current location.
+// Returns the name of the backup file.
+func moveDBAside(db *util.OvsDbProperties) (string, error) {
+	dbFile := filepath.Base(db.DbAlias)
+	backupFile := strings.TrimSuffix(dbFile, filepath.Ext(dbFile)) +
+		time.Now().UTC().Format("2006-01-02_150405") + "db_bak"
+	backupDB := filepath.Join(filepath.Dir(db.DbAlias), backupFile)
+	err := os.Rename(db.DbAlias, backupDB)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up the db to backupFile: %s, error: %s", backupDB, err)
+	}
+
+	klog.Infof("Backed up the db to backupFile: %s", backupFile)
+	return backupFile, nil
+}
+
 func convertNBDBSchema() error {
 	return convertDBSchemaWithRetries(nbdbSchema, nbdbServerSock, "OVN_Northbound")
 }
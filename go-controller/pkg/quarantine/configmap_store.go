@@ -0,0 +1,114 @@
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// configMapStore persists each quarantine list as a key of a ConfigMap, holding a JSON
+// object that maps the quarantined addresses to their release time in RFC3339 format
+type configMapStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapStore returns a Store persisting quarantine lists in the given ConfigMap,
+// which is created if it doesn't exist
+func NewConfigMapStore(client kubernetes.Interface, namespace, name string) Store {
+	return &configMapStore{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (s *configMapStore) Load(name string) (map[string]time.Time, error) {
+	entries := map[string]time.Time{}
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quarantine ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	data, ok := cm.Data[name]
+	if !ok {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine %s of ConfigMap %s/%s: %v", name, s.namespace, s.name, err)
+	}
+	return entries, nil
+}
+
+func (s *configMapStore) Save(name string, entries map[string]time.Time) error {
+	var data string
+	if len(entries) > 0 {
+		bytes, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		data = string(bytes)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+		cm, err := configMaps.Get(context.TODO(), s.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if data == "" {
+				return nil
+			}
+			cm = &kapi.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.name,
+					Namespace: s.namespace,
+				},
+				Data: map[string]string{name: data},
+			}
+			_, err = configMaps.Create(context.TODO(), cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// let RetryOnConflict update the ConfigMap instead
+				return apierrors.NewConflict(kapi.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data[name] == data {
+			return nil
+		}
+		cm = cm.DeepCopy()
+		if data == "" {
+			delete(cm.Data, name)
+		} else {
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[name] = data
+		}
+		_, err = configMaps.Update(context.TODO(), cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// NewConfiguredStore returns the Store of the address-quarantine-configmap option, or nil if the
+// option isn't set and quarantines are only kept in memory
+func NewConfiguredStore(client kubernetes.Interface) Store {
+	if config.Kubernetes.AddressQuarantineConfigMap == "" {
+		return nil
+	}
+	return NewConfigMapStore(client, config.Kubernetes.OVNConfigNamespace, config.Kubernetes.AddressQuarantineConfigMap)
+}
@@ -0,0 +1,238 @@
+// Package quarantine holds released addresses back from allocation for a hold-down period, so that
+// stale ARP and conntrack entries or external firewall rules that still refer to the previous owner
+// of an address expire before the address is handed over to a new owner.
+package quarantine
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// syncInterval is how often expired addresses are released and changes are persisted
+const syncInterval = time.Second
+
+// Store persists quarantine lists so that they survive restarts and leader changes
+type Store interface {
+	// Load returns the addresses of the named list and the time they were released at
+	Load(name string) (map[string]time.Time, error)
+	// Save replaces the addresses of the named list
+	Save(name string, entries map[string]time.Time) error
+}
+
+// List is a set of quarantined addresses of an allocator, along with the time they were
+// released at. The allocator keeps quarantined addresses allocated and releases them for
+// good once their quarantine period expired.
+type List struct {
+	sync.Mutex
+
+	name    string
+	period  time.Duration
+	store   Store
+	entries map[string]time.Time
+	// dirty is set when entries changed since they were last persisted
+	dirty bool
+	// record is called with the number of quarantined addresses when it changes
+	record func(count int)
+	now    func() time.Time
+}
+
+// NewList returns a quarantine list holding addresses back for period. The list is persisted
+// with the given name in store, if not nil. record, if not nil, is called with the number of
+// quarantined addresses whenever it changes.
+func NewList(name string, period time.Duration, store Store, record func(count int)) *List {
+	return &List{
+		name:    name,
+		period:  period,
+		store:   store,
+		entries: map[string]time.Time{},
+		record:  record,
+		now:     time.Now,
+	}
+}
+
+// Name returns the name of the list
+func (l *List) Name() string {
+	return l.name
+}
+
+// Load reads the list from its store and returns the quarantined addresses, which the allocator
+// must keep allocated until they are released by Run.
+func (l *List) Load() ([]string, error) {
+	if l.store == nil {
+		return nil, nil
+	}
+	entries, err := l.store.Load(l.name)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	addrs := make([]string, 0, len(entries))
+	for addr, releasedAt := range entries {
+		if _, ok := l.entries[addr]; !ok {
+			l.entries[addr] = releasedAt
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	l.recordLocked()
+	return addrs, nil
+}
+
+// Add quarantines addr from now on. An address that is already quarantined keeps its
+// original release time.
+func (l *List) Add(addr string) {
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.entries[addr]; ok {
+		return
+	}
+	l.entries[addr] = l.now()
+	l.dirty = true
+	l.recordLocked()
+}
+
+// Remove takes addr out of quarantine without releasing it, and returns whether it was quarantined
+func (l *List) Remove(addr string) bool {
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.entries[addr]; !ok {
+		return false
+	}
+	delete(l.entries, addr)
+	l.dirty = true
+	l.recordLocked()
+	return true
+}
+
+// Has returns whether addr is quarantined
+func (l *List) Has(addr string) bool {
+	l.Lock()
+	defer l.Unlock()
+	_, ok := l.entries[addr]
+	return ok
+}
+
+// Addrs returns the quarantined addresses, sorted
+func (l *List) Addrs() []string {
+	l.Lock()
+	defer l.Unlock()
+	addrs := make([]string, 0, len(l.entries))
+	for addr := range l.entries {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// Len returns the number of quarantined addresses
+func (l *List) Len() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.entries)
+}
+
+// PopOldest takes the address that was released first among the ones matching filter out of
+// quarantine and returns it. The caller is responsible for releasing it. It is meant to be used
+// when the pool of an allocator is exhausted.
+func (l *List) PopOldest(filter func(addr string) bool) (string, bool) {
+	l.Lock()
+	defer l.Unlock()
+	var oldest string
+	var oldestTime time.Time
+	for addr, releasedAt := range l.entries {
+		if filter != nil && !filter(addr) {
+			continue
+		}
+		if oldest == "" || releasedAt.Before(oldestTime) || (releasedAt.Equal(oldestTime) && addr < oldest) {
+			oldest = addr
+			oldestTime = releasedAt
+		}
+	}
+	if oldest == "" {
+		return "", false
+	}
+	klog.Warningf("Releasing %s of quarantine %s %v early", oldest, l.name, l.period-l.now().Sub(oldestTime))
+	delete(l.entries, oldest)
+	l.dirty = true
+	l.recordLocked()
+	return oldest, true
+}
+
+// Purge drops all the addresses of the list, from its store as well, without releasing them.
+// It is meant to be used when the allocator itself goes away.
+func (l *List) Purge() error {
+	l.Lock()
+	l.entries = map[string]time.Time{}
+	l.dirty = false
+	l.recordLocked()
+	l.Unlock()
+	if l.store == nil {
+		return nil
+	}
+	return l.store.Save(l.name, nil)
+}
+
+// popExpired takes the addresses whose quarantine period expired out of quarantine and
+// returns them
+func (l *List) popExpired() []string {
+	l.Lock()
+	defer l.Unlock()
+	var expired []string
+	now := l.now()
+	for addr, releasedAt := range l.entries {
+		if now.Sub(releasedAt) >= l.period {
+			expired = append(expired, addr)
+			delete(l.entries, addr)
+		}
+	}
+	if len(expired) > 0 {
+		sort.Strings(expired)
+		l.dirty = true
+		l.recordLocked()
+	}
+	return expired
+}
+
+// Sync releases the expired addresses with release and persists the list if it changed
+func (l *List) Sync(release func(addr string)) {
+	for _, addr := range l.popExpired() {
+		klog.V(5).Infof("Quarantine of %s in %s expired", addr, l.name)
+		release(addr)
+	}
+
+	l.Lock()
+	if !l.dirty || l.store == nil {
+		l.Unlock()
+		return
+	}
+	entries := make(map[string]time.Time, len(l.entries))
+	for addr, releasedAt := range l.entries {
+		entries[addr] = releasedAt
+	}
+	l.dirty = false
+	l.Unlock()
+
+	if err := l.store.Save(l.name, entries); err != nil {
+		klog.Errorf("Failed to persist quarantine %s: %v", l.name, err)
+		l.Lock()
+		l.dirty = true
+		l.Unlock()
+	}
+}
+
+// Run syncs the list periodically until stopChan is closed
+func (l *List) Run(stopChan <-chan struct{}, release func(addr string)) {
+	wait.Until(func() { l.Sync(release) }, syncInterval, stopChan)
+}
+
+func (l *List) recordLocked() {
+	if l.record != nil {
+		l.record(len(l.entries))
+	}
+}
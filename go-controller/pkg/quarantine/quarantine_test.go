@@ -0,0 +1,110 @@
+package quarantine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListExpiry(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	count := -1
+	l := NewList("test", time.Minute, nil, func(c int) { count = c })
+	l.now = func() time.Time { return now }
+
+	l.Add("10.1.0.0/24")
+	now = now.Add(30 * time.Second)
+	l.Add("10.1.1.0/24")
+	l.Add("10.1.0.0/24")
+	if count != 2 {
+		t.Fatalf("expected 2 quarantined addresses to be recorded but got %d", count)
+	}
+
+	var released []string
+	release := func(addr string) { released = append(released, addr) }
+	l.Sync(release)
+	if len(released) != 0 {
+		t.Fatalf("expected no address to be released but got %v", released)
+	}
+
+	// the first address keeps its original release time
+	now = now.Add(30 * time.Second)
+	l.Sync(release)
+	if !reflect.DeepEqual(released, []string{"10.1.0.0/24"}) {
+		t.Fatalf("expected 10.1.0.0/24 to be released but got %v", released)
+	}
+	if !reflect.DeepEqual(l.Addrs(), []string{"10.1.1.0/24"}) || count != 1 {
+		t.Fatalf("expected 10.1.1.0/24 to stay quarantined but got %v", l.Addrs())
+	}
+}
+
+func TestListPopOldest(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewList("test", time.Hour, nil, nil)
+	l.now = func() time.Time { return now }
+	for _, addr := range []string{"node1 10.1.0.5", "node2 10.1.1.5", "node1 10.1.0.6"} {
+		l.Add(addr)
+		now = now.Add(time.Second)
+	}
+
+	addr, ok := l.PopOldest(func(addr string) bool { return addr[:5] == "node1" })
+	if !ok || addr != "node1 10.1.0.5" {
+		t.Fatalf("expected node1 10.1.0.5 to be popped but got %q", addr)
+	}
+	if _, ok := l.PopOldest(func(addr string) bool { return addr[:5] == "node3" }); ok {
+		t.Fatalf("expected no address to be popped")
+	}
+	if !reflect.DeepEqual(l.Addrs(), []string{"node1 10.1.0.6", "node2 10.1.1.5"}) {
+		t.Fatalf("unexpected quarantined addresses %v", l.Addrs())
+	}
+}
+
+func TestConfigMapStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapStore(client, "ovn-kubernetes", "address-quarantine")
+	releasedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := NewList("subnets.default", time.Hour, store, nil)
+	l.now = func() time.Time { return releasedAt }
+	l.Add("10.1.0.0/24")
+	l.Sync(func(string) {})
+	other := NewList("pod-ips.default", time.Hour, store, nil)
+	other.Add("node1 10.1.0.5")
+	other.Sync(func(string) {})
+
+	cm, err := client.CoreV1().ConfigMaps("ovn-kubernetes").Get(context.TODO(), "address-quarantine", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cm.Data) != 2 {
+		t.Fatalf("expected a key for each list but got %v", cm.Data)
+	}
+
+	// a new list, as built by the next leader, restores the entries and their release time
+	restored := NewList("subnets.default", time.Hour, store, nil)
+	addrs, err := restored.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(addrs, []string{"10.1.0.0/24"}) {
+		t.Fatalf("expected 10.1.0.0/24 to be restored but got %v", addrs)
+	}
+	if !restored.entries["10.1.0.0/24"].Equal(releasedAt) {
+		t.Fatalf("expected release time %v but got %v", releasedAt, restored.entries["10.1.0.0/24"])
+	}
+
+	if err := restored.Purge(); err != nil {
+		t.Fatal(err)
+	}
+	cm, err = client.CoreV1().ConfigMaps("ovn-kubernetes").Get(context.TODO(), "address-quarantine", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Data["subnets.default"]; ok || len(cm.Data) != 1 {
+		t.Fatalf("expected only the pod IPs to be left but got %v", cm.Data)
+	}
+}
@@ -67,6 +67,9 @@ type ResourceHandler struct {
 	HasUpdateFunc          bool
 	NeedsUpdateDuringRetry bool
 	ObjType                reflect.Type
+	// NetworkName is the network of the controller handling the resource, used to report resource
+	// event metrics. Resources handled by no network controller leave it empty.
+	NetworkName string
 	EventHandler
 }
 
@@ -181,11 +184,22 @@ func (r *RetryFramework) DeleteRetryObj(lockedKey string) {
 
 // setRetryObjWithNoBackoff sets an object's backoff to be retried
 // immediately during the next retry iteration
-// Used only for testing right now
 func (r *RetryFramework) setRetryObjWithNoBackoff(entry *retryObjEntry) {
 	entry.backoffSec = noBackoff
 }
 
+// SetRetryObjsWithNoBackoff sets the backoff of all the objects in the retry cache
+// so that they are retried immediately during the next retry iteration
+func (r *RetryFramework) SetRetryObjsWithNoBackoff() {
+	for _, key := range r.retryEntries.GetKeys() {
+		r.DoWithLock(key, func(key string) {
+			if entry, found := r.getRetryObj(key); found {
+				r.setRetryObjWithNoBackoff(entry)
+			}
+		})
+	}
+}
+
 // removeDeleteFromRetryObj removes any old object from a retry entry
 func (r *RetryFramework) removeDeleteFromRetryObj(entry *retryObjEntry) {
 	entry.oldObj = nil
@@ -447,7 +461,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 	handler, err := addHandlerFunc(
 		namespaceForFilteredHandler,     // filter out objects not in this namespace
 		labelSelectorForFilteredHandler, // filter out objects not matching these labels
-		cache.ResourceEventHandlerFuncs{
+		factory.WithNetwork(r.ResourceHandler.NetworkName, cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 				r.ResourceHandler.RecordAddEvent(obj)
 
@@ -680,7 +694,7 @@ func (r *RetryFramework) WatchResourceFiltered(namespaceForFilteredHandler strin
 					r.ResourceHandler.RecordSuccessEvent(obj)
 				})
 			},
-		},
+		}),
 		r.ResourceHandler.SyncFunc) // processes all existing objects at startup
 
 	if err != nil {
@@ -15,6 +15,9 @@ const (
 	// access to physical/external network
 	PhysicalNetworkName     = "physnet"
 	PhysicalNetworkExGwName = "exgwphysnet"
+	// PhysicalNetworkEgressInterfacePrefix is the prefix of the names that map to the
+	// OVS bridges of the EgressInterfaces, followed by the EgressInterface name
+	PhysicalNetworkEgressInterfacePrefix = "eifphysnet-"
 
 	// LocalNetworkName is the name that maps to an OVS bridge that provides
 	// access to local service
@@ -43,6 +46,9 @@ const (
 	EXTSwitchToGWRouterPrefix    = "etor-"
 	GWRouterToExtSwitchPrefix    = "rtoe-"
 	EgressGWSwitchPrefix         = "exgw-"
+	// EgressInterfaceSwitchPrefix is followed by the EgressInterface name and "_" in the
+	// names of the external switches and ports of the EgressInterfaces
+	EgressInterfaceSwitchPrefix = "eif-"
 
 	NodeLocalSwitch = "node_local_switch"
 
@@ -68,6 +74,18 @@ const (
 	// Default deny acl rule priority
 	DefaultDenyPriority = 1000
 
+	// IngressDefaultDenySuffix is the suffix of the names of the ingress default deny ACLs and
+	// port group of a namespace
+	IngressDefaultDenySuffix = "ingressDefaultDeny"
+	// EgressDefaultDenySuffix is the suffix of the names of the egress default deny ACLs and
+	// port group of a namespace
+	EgressDefaultDenySuffix = "egressDefaultDeny"
+	// ARPAllowPolicySuffix is the suffix of the names of the default allow ARP ACLs of a namespace
+	ARPAllowPolicySuffix = "ARPallowPolicy"
+	// EgressFirewallACLPrefix is the prefix of the names of the egress firewall ACLs, followed by
+	// the namespace and the priority of the rule
+	EgressFirewallACLPrefix = "egressFirewall"
+
 	// priority of logical router policies on the OVNClusterRouter
 	EgressFirewallStartPriority           = 10000
 	MinimumReservedEgressFirewallPriority = 2000
@@ -80,6 +98,10 @@ const (
 	EgressSVCReroutePriority              = 101
 	EgressIPReroutePriority               = 100
 
+	// priority of the logical router policies on the gateway routers that reroute
+	// the egress traffic of the pods selected by an EgressInterface
+	EgressInterfaceReroutePriority = 100
+
 	V6NodeLocalNATSubnet           = "fd99::/64"
 	V6NodeLocalNATSubnetPrefix     = 64
 	V6NodeLocalNATSubnetNextHop    = "fd99::1"
@@ -129,7 +151,8 @@ const (
 	OvnHostToSvcOFTopoVersion      = 3
 	OvnPortBindingTopoVersion      = 4
 	OvnRoutingViaHostTopoVersion   = 5
-	OvnCurrentTopologyVersion      = OvnRoutingViaHostTopoVersion
+	OvnPodQoSTopoVersion           = 6
+	OvnCurrentTopologyVersion      = OvnPodQoSTopoVersion
 
 	// OVN-K8S annotation & taint constants
 	OvnK8sPrefix = "k8s.ovn.org"
@@ -21,7 +21,8 @@ Example:
 				{
                 	"pfId": “0”,
                 	“vfId”: "3",
-                	"sandboxId": "35b82dbe2c39768d9874861aee38cf569766d4855b525ae02bff2bfbda73392a"
+                	"sandboxId": "35b82dbe2c39768d9874861aee38cf569766d4855b525ae02bff2bfbda73392a",
+                	"dpuName": "dpu0"
 				}
             }
 
@@ -52,6 +53,8 @@ type DPUConnectionDetails struct {
 	VfId         string `json:"vfId"`
 	SandboxId    string `json:"sandboxId"`
 	VfNetdevName string `json:"vfNetdevName,omitempty"`
+	// DPUName is the DPU of the host owning the VF, empty when the host has a single DPU
+	DPUName string `json:"dpuName,omitempty"`
 }
 
 type DPUConnectionStatus struct {
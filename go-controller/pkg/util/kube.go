@@ -28,8 +28,10 @@ import (
 	ocpcloudnetworkclientset "github.com/openshift/client-go/cloudnetwork/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressfirewallclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned"
+	egressinterfaceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressinterface/v1/apis/clientset/versioned"
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
+	multicastpolicyclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/multicastpolicy/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
@@ -40,20 +42,26 @@ type OVNClientset struct {
 	EgressFirewallClient  egressfirewallclientset.Interface
 	CloudNetworkClient    ocpcloudnetworkclientset.Interface
 	EgressQoSClient       egressqosclientset.Interface
+	EgressInterfaceClient egressinterfaceclientset.Interface
+	MulticastPolicyClient multicastpolicyclientset.Interface
 	NetworkAttchDefClient networkattchmentdefclientset.Interface
 }
 
 // OVNMasterClientset
 type OVNMasterClientset struct {
-	KubeClient           kubernetes.Interface
-	EgressIPClient       egressipclientset.Interface
-	EgressFirewallClient egressfirewallclientset.Interface
-	CloudNetworkClient   ocpcloudnetworkclientset.Interface
-	EgressQoSClient      egressqosclientset.Interface
+	KubeClient            kubernetes.Interface
+	EgressIPClient        egressipclientset.Interface
+	EgressFirewallClient  egressfirewallclientset.Interface
+	CloudNetworkClient    ocpcloudnetworkclientset.Interface
+	EgressQoSClient       egressqosclientset.Interface
+	EgressInterfaceClient egressinterfaceclientset.Interface
+	MulticastPolicyClient multicastpolicyclientset.Interface
 }
 
 type OVNNodeClientset struct {
-	KubeClient kubernetes.Interface
+	KubeClient            kubernetes.Interface
+	EgressIPClient        egressipclientset.Interface
+	EgressInterfaceClient egressinterfaceclientset.Interface
 }
 
 type OVNClusterManagerClientset struct {
@@ -63,11 +71,13 @@ type OVNClusterManagerClientset struct {
 
 func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 	return &OVNMasterClientset{
-		KubeClient:           cs.KubeClient,
-		EgressIPClient:       cs.EgressIPClient,
-		EgressFirewallClient: cs.EgressFirewallClient,
-		CloudNetworkClient:   cs.CloudNetworkClient,
-		EgressQoSClient:      cs.EgressQoSClient,
+		KubeClient:            cs.KubeClient,
+		EgressIPClient:        cs.EgressIPClient,
+		EgressFirewallClient:  cs.EgressFirewallClient,
+		CloudNetworkClient:    cs.CloudNetworkClient,
+		EgressQoSClient:       cs.EgressQoSClient,
+		EgressInterfaceClient: cs.EgressInterfaceClient,
+		MulticastPolicyClient: cs.MulticastPolicyClient,
 	}
 }
 
@@ -80,13 +90,17 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 
 func (cs *OVNClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient: cs.KubeClient,
+		KubeClient:            cs.KubeClient,
+		EgressIPClient:        cs.EgressIPClient,
+		EgressInterfaceClient: cs.EgressInterfaceClient,
 	}
 }
 
 func (cs *OVNMasterClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient: cs.KubeClient,
+		KubeClient:            cs.KubeClient,
+		EgressIPClient:        cs.EgressIPClient,
+		EgressInterfaceClient: cs.EgressInterfaceClient,
 	}
 }
 
@@ -190,6 +204,14 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	egressInterfaceClientset, err := egressinterfaceclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+	multicastPolicyClientset, err := multicastpolicyclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
 	networkAttchmntDefClientset, err := networkattchmentdefclientset.NewForConfig(kconfig)
 	if err != nil {
 		return nil, err
@@ -201,6 +223,8 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		EgressFirewallClient:  egressFirewallClientset,
 		CloudNetworkClient:    cloudNetworkClientset,
 		EgressQoSClient:       egressqosClientset,
+		EgressInterfaceClient: egressInterfaceClientset,
+		MulticastPolicyClient: multicastPolicyClientset,
 		NetworkAttchDefClient: networkAttchmntDefClientset,
 	}, nil
 }
@@ -30,6 +30,7 @@ type NetInfo interface {
 	AddNAD(nadName string)
 	DeleteNAD(nadName string)
 	HasNAD(nadName string) bool
+	GetNADs() []string
 }
 
 type DefaultNetInfo struct{}
@@ -65,6 +66,11 @@ func (nInfo *DefaultNetInfo) HasNAD(nadName string) bool {
 	panic("unexpected call for default network")
 }
 
+// GetNADs returns all the NADs associated with this network, no op for default network
+func (nInfo *DefaultNetInfo) GetNADs() []string {
+	panic("unexpected call for default network")
+}
+
 // SecondaryNetInfo holds the network name information for secondary network if non-nil
 type SecondaryNetInfo struct {
 	// network name
@@ -106,6 +112,16 @@ func (nInfo *SecondaryNetInfo) HasNAD(nadName string) bool {
 	return ok
 }
 
+// GetNADs returns all the NADs associated with this network
+func (nInfo *SecondaryNetInfo) GetNADs() []string {
+	nadNames := []string{}
+	nInfo.nadNames.Range(func(key, value interface{}) bool {
+		nadNames = append(nadNames, key.(string))
+		return true
+	})
+	return nadNames
+}
+
 // NetConfInfo is structure which holds specific per-network configuration
 type NetConfInfo interface {
 	CompareNetConf(NetConfInfo) bool
@@ -113,6 +129,7 @@ type NetConfInfo interface {
 	MTU() int
 	Subnets() []string
 	IPMode() (bool, bool)
+	MulticastEnabled() bool
 }
 
 // DefaultNetConfInfo is structure which holds specific default network information
@@ -149,6 +166,11 @@ func (defaultNetConfInfo *DefaultNetConfInfo) IPMode() (bool, bool) {
 	return config.IPv4Mode, config.IPv6Mode
 }
 
+// MulticastEnabled returns true if multicast is enabled for the default network
+func (defaultNetConfInfo *DefaultNetConfInfo) MulticastEnabled() bool {
+	return config.EnableMulticast
+}
+
 func isSubnetsStringEqual(subnetsString, newSubnetsString string) bool {
 	subnetsStringList := strings.Split(subnetsString, ",")
 	newSubnetsStringList := strings.Split(newSubnetsString, ",")
@@ -194,9 +216,10 @@ func parseSubnetsString(clusterSubnetString string) ([]*net.IPNet, error) {
 
 // Layer3NetConfInfo is structure which holds specific secondary layer3 network information
 type Layer3NetConfInfo struct {
-	subnets        string
-	mtu            int
-	ClusterSubnets []config.CIDRNetworkEntry
+	subnets         string
+	mtu             int
+	enableMulticast bool
+	ClusterSubnets  []config.CIDRNetworkEntry
 }
 
 // CompareNetConf compares the layer3NetConfInfo with the given newNetConfInfo and returns true
@@ -223,6 +246,11 @@ func (layer3NetConfInfo *Layer3NetConfInfo) CompareNetConf(newNetConfInfo NetCon
 			types.Layer3Topology, newLayer3NetConfInfo.mtu, layer3NetConfInfo.mtu)
 		errs = append(errs, err)
 	}
+	if layer3NetConfInfo.enableMulticast != newLayer3NetConfInfo.enableMulticast {
+		err = fmt.Errorf("new %s netconf enableMulticast %v has changed, expect %v",
+			types.Layer3Topology, newLayer3NetConfInfo.enableMulticast, layer3NetConfInfo.enableMulticast)
+		errs = append(errs, err)
+	}
 	if len(errs) != 0 {
 		err = kerrors.NewAggregate(errs)
 		klog.V(5).Infof(err.Error())
@@ -238,9 +266,10 @@ func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (*Layer3NetConfInfo, err
 	}
 
 	return &Layer3NetConfInfo{
-		subnets:        netconf.Subnets,
-		mtu:            netconf.MTU,
-		ClusterSubnets: clusterSubnets,
+		subnets:         netconf.Subnets,
+		mtu:             netconf.MTU,
+		enableMulticast: netconf.EnableMulticast,
+		ClusterSubnets:  clusterSubnets,
 	}, nil
 }
 
@@ -272,11 +301,17 @@ func (layer3NetConfInfo *Layer3NetConfInfo) IPMode() (bool, bool) {
 	return ipv4Mode, ipv6Mode
 }
 
+// MulticastEnabled returns true if multicast is enabled for the layer3 network
+func (layer3NetConfInfo *Layer3NetConfInfo) MulticastEnabled() bool {
+	return layer3NetConfInfo.enableMulticast
+}
+
 // Layer2NetConfInfo is structure which holds specific secondary layer2 network information
 type Layer2NetConfInfo struct {
-	subnets        string
-	mtu            int
-	excludeSubnets string
+	subnets         string
+	mtu             int
+	excludeSubnets  string
+	enableMulticast bool
 
 	ClusterSubnets []*net.IPNet
 	ExcludeSubnets []*net.IPNet
@@ -308,6 +343,11 @@ func (layer2NetConfInfo *Layer2NetConfInfo) CompareNetConf(newNetConfInfo NetCon
 			types.Layer2Topology, newLayer2NetConfInfo.excludeSubnets, layer2NetConfInfo.excludeSubnets)
 		errs = append(errs, err)
 	}
+	if layer2NetConfInfo.enableMulticast != newLayer2NetConfInfo.enableMulticast {
+		err = fmt.Errorf("new %s netconf enableMulticast %v has changed, expect %v",
+			types.Layer2Topology, newLayer2NetConfInfo.enableMulticast, layer2NetConfInfo.enableMulticast)
+		errs = append(errs, err)
+	}
 	if len(errs) != 0 {
 		err = kerrors.NewAggregate(errs)
 		klog.V(5).Infof(err.Error())
@@ -323,11 +363,12 @@ func newLayer2NetConfInfo(netconf *ovncnitypes.NetConf) (*Layer2NetConfInfo, err
 	}
 
 	return &Layer2NetConfInfo{
-		subnets:        netconf.Subnets,
-		mtu:            netconf.MTU,
-		excludeSubnets: netconf.ExcludeSubnets,
-		ClusterSubnets: clusterSubnets,
-		ExcludeSubnets: excludeSubnets,
+		subnets:         netconf.Subnets,
+		mtu:             netconf.MTU,
+		excludeSubnets:  netconf.ExcludeSubnets,
+		enableMulticast: netconf.EnableMulticast,
+		ClusterSubnets:  clusterSubnets,
+		ExcludeSubnets:  excludeSubnets,
 	}, nil
 }
 
@@ -391,6 +432,11 @@ func (layer2NetConfInfo *Layer2NetConfInfo) IPMode() (bool, bool) {
 	return ipv4Mode, ipv6Mode
 }
 
+// MulticastEnabled returns true if multicast is enabled for the layer2 network
+func (layer2NetConfInfo *Layer2NetConfInfo) MulticastEnabled() bool {
+	return layer2NetConfInfo.enableMulticast
+}
+
 // LocalnetNetConfInfo is structure which holds specific secondary localnet network information
 type LocalnetNetConfInfo struct {
 	subnets        string
@@ -444,6 +490,9 @@ func (localnetNetConfInfo *LocalnetNetConfInfo) CompareNetConf(newNetConfInfo Ne
 }
 
 func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (*LocalnetNetConfInfo, error) {
+	if netconf.EnableMulticast {
+		return nil, fmt.Errorf("invalid %s netconf %s: multicast is not supported", netconf.Topology, netconf.Name)
+	}
 	clusterSubnets, excludeSubnets, err := verifyExcludeIPs(netconf.Subnets, netconf.ExcludeSubnets)
 	if err != nil {
 		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
@@ -491,6 +540,11 @@ func (localnetNetConfInfo *LocalnetNetConfInfo) IPMode() (bool, bool) {
 	return ipv4Mode, ipv6Mode
 }
 
+// MulticastEnabled returns false, multicast is not supported on localnet networks
+func (localnetNetConfInfo *LocalnetNetConfInfo) MulticastEnabled() bool {
+	return false
+}
+
 // GetNADName returns key of NetAttachDefInfo.NetAttachDefs map, also used as Pod annotation key
 func GetNADName(namespace, name string) string {
 	return fmt.Sprintf("%s/%s", namespace, name)
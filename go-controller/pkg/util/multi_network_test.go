@@ -5,7 +5,11 @@ import (
 	"net"
 	"testing"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/stretchr/testify/assert"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func parseIPNets(ipNetStrs ...string) []*net.IPNet {
@@ -82,3 +86,69 @@ func TestParseSubnetsString(t *testing.T) {
 		})
 	}
 }
+
+func TestNewNetConfInfoMulticast(t *testing.T) {
+	tests := []struct {
+		desc         string
+		netconf      *ovncnitypes.NetConf
+		expMulticast bool
+		expError     bool
+	}{
+		{
+			desc: "layer3 network with multicast enabled",
+			netconf: &ovncnitypes.NetConf{
+				NetConf:         cnitypes.NetConf{Name: "l3"},
+				Topology:        types.Layer3Topology,
+				Subnets:         "10.128.0.0/16/24",
+				EnableMulticast: true,
+			},
+			expMulticast: true,
+		},
+		{
+			desc: "layer2 network with multicast enabled",
+			netconf: &ovncnitypes.NetConf{
+				NetConf:         cnitypes.NetConf{Name: "l2"},
+				Topology:        types.Layer2Topology,
+				Subnets:         "10.100.200.0/24",
+				EnableMulticast: true,
+			},
+			expMulticast: true,
+		},
+		{
+			desc: "layer2 network with multicast disabled",
+			netconf: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "l2"},
+				Topology: types.Layer2Topology,
+				Subnets:  "10.100.200.0/24",
+			},
+			expMulticast: false,
+		},
+		{
+			desc: "localnet network with multicast enabled is rejected",
+			netconf: &ovncnitypes.NetConf{
+				NetConf:         cnitypes.NetConf{Name: "localnet"},
+				Topology:        types.LocalnetTopology,
+				EnableMulticast: true,
+			},
+			expError: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			netconfInfo, err := newNetConfInfo(tc.netconf)
+			if tc.expError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expMulticast, netconfInfo.MulticastEnabled())
+
+			// enabling or disabling multicast changes the netconf
+			changed := *tc.netconf
+			changed.EnableMulticast = !changed.EnableMulticast
+			changedNetconfInfo, err := newNetConfInfo(&changed)
+			assert.NoError(t, err)
+			assert.False(t, netconfInfo.CompareNetConf(changedNetconfInfo))
+		})
+	}
+}
@@ -156,6 +156,13 @@ func IPAddrToHWAddr(ip net.IP) net.HardwareAddr {
 	return net.HardwareAddr{0x0A, 0x58, hash[0], hash[1], hash[2], hash[3]}
 }
 
+// NetworkNameToHWAddr creates a MAC address (0A:58:XX:XX:XX:XX) from a hash of
+// the given network name.
+func NetworkNameToHWAddr(netName string) net.HardwareAddr {
+	hash := sha256.Sum256([]byte(netName))
+	return net.HardwareAddr{0x0A, 0x58, hash[0], hash[1], hash[2], hash[3]}
+}
+
 // HWAddrToIPv6LLA generates the IPv6 link local address from the given hwaddr,
 // with prefix 'fe80:/64'.
 func HWAddrToIPv6LLA(hwaddr net.HardwareAddr) net.IP {
@@ -6,12 +6,14 @@ import (
 	"math"
 	"net"
 	"strconv"
+	"strings"
 
 	kapi "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 // This handles the annotations used by the node to pass information about its local
@@ -34,7 +36,17 @@ import (
 //           "next-hop": "169.254.33.1",
 //         }
 //       }
+//     k8s.ovn.org/egress-interfaces: |
+//       {
+//         "storage": {
+//           "interface-id": "breth2_ip-10-0-129-64.us-east-2.compute.internal",
+//           "mac-address": "f2:20:a0:3c:26:4d",
+//           "ip-addresses": ["192.168.2.64/24"],
+//           "next-hops": ["192.168.2.1"]
+//         }
+//       }
 //     k8s.ovn.org/node-chassis-id: b1f96182-2bdd-42b6-88f9-9a1fc1c85ece
+//     k8s.ovn.org/node-chassis-id.dpu1: 5ad4e3b0-7d1c-4f0e-9c38-1f4e8e1c2a77
 //     k8s.ovn.org/node-mgmt-port-mac-address: fa:f1:27:f5:54:69
 //
 // The "ip_address" and "next_hop" fields are deprecated and will eventually go away.
@@ -48,6 +60,14 @@ const (
 	// ovnNodeGatewayMtuSupport determines if option:gateway_mtu shall be set for GR router ports.
 	ovnNodeGatewayMtuSupport = "k8s.ovn.org/gateway-mtu-support"
 
+	// ovnNodeGatewayMode selects the gateway mode of the node, overriding the cluster gateway mode so
+	// that nodes can be migrated between shared and local gateway modes one by one.
+	ovnNodeGatewayMode = "k8s.ovn.org/gateway-mode"
+
+	// ovnNodeEgressInterfaces is the gateway configuration of the additional gateway bridges set up
+	// on the node for the EgressInterfaces, by EgressInterface name
+	ovnNodeEgressInterfaces = "k8s.ovn.org/egress-interfaces"
+
 	// OvnDefaultNetworkGateway captures L3 gateway config for default OVN network interface
 	ovnDefaultNetworkGateway = "default"
 
@@ -57,12 +77,21 @@ const (
 	// ovnNodeChassisID is the systemID of the node needed for creating L3 gateway
 	ovnNodeChassisID = "k8s.ovn.org/node-chassis-id"
 
+	// ovnNodeDPUChassisIDPrefix prefixes the name of the DPU in the annotation of the chassis ID of
+	// each DPU of a host with several, on which the pods bound to the DPU are claimed
+	ovnNodeDPUChassisIDPrefix = ovnNodeChassisID + "."
+
 	// ovnNodeCIDR is the CIDR form representation of primary network interface's attached IP address (i.e: 192.168.126.31/24 or 0:0:0:0:0:feff:c0a8:8e0c/64)
 	ovnNodeIfAddr = "k8s.ovn.org/node-primary-ifaddr"
 
 	// ovnNodeGRLRPAddr is the CIDR form representation of Gate Router LRP IP address to join switch (i.e: 100.64.0.5/24)
 	ovnNodeGRLRPAddr = "k8s.ovn.org/node-gateway-router-lrp-ifaddr"
 
+	// ovnNodeGRLRPAddrs is the CIDR form representation of the Gateway Router LRP IP addresses to join switch
+	// of the secondary networks (i.e: {"blue":{"ipv4":"100.65.0.5/16"}}). The addresses of the default network
+	// are kept in ovnNodeGRLRPAddr.
+	ovnNodeGRLRPAddrs = "k8s.ovn.org/node-gateway-router-lrp-ifaddrs"
+
 	// OvnNodeEgressLabel is a user assigned node label indicating to ovn-kubernetes that the node is to be used for egress IP assignment
 	ovnNodeEgressLabel = "k8s.ovn.org/egress-assignable"
 
@@ -268,6 +297,20 @@ func ParseNodeGatewayMTUSupport(node *kapi.Node) bool {
 	return node.Annotations[ovnNodeGatewayMtuSupport] != "false"
 }
 
+// ParseNodeGatewayMode parses annotation "k8s.ovn.org/gateway-mode", the gateway mode requested for this node.
+func ParseNodeGatewayMode(node *kapi.Node) (config.GatewayMode, error) {
+	mode, ok := node.Annotations[ovnNodeGatewayMode]
+	if !ok {
+		return "", newAnnotationNotSetError("%s annotation not found for node %q", ovnNodeGatewayMode, node.Name)
+	}
+	switch config.GatewayMode(mode) {
+	case config.GatewayModeShared, config.GatewayModeLocal:
+		return config.GatewayMode(mode), nil
+	}
+	return "", fmt.Errorf("invalid %s annotation %q for node %q: expected %q or %q", ovnNodeGatewayMode, mode,
+		node.Name, config.GatewayModeShared, config.GatewayModeLocal)
+}
+
 // ParseNodeL3GatewayAnnotation returns the parsed l3-gateway-config annotation
 func ParseNodeL3GatewayAnnotation(node *kapi.Node) (*L3GatewayConfig, error) {
 	l3GatewayAnnotation, ok := node.Annotations[ovnNodeL3GatewayConfig]
@@ -298,6 +341,102 @@ func NodeL3GatewayAnnotationChanged(oldNode, newNode *kapi.Node) bool {
 	return oldNode.Annotations[ovnNodeL3GatewayConfig] != newNode.Annotations[ovnNodeL3GatewayConfig]
 }
 
+// EgressInterfaceGatewayConfig is the gateway configuration of the gateway bridge set up on
+// a node for an EgressInterface
+type EgressInterfaceGatewayConfig struct {
+	InterfaceID string
+	MACAddress  net.HardwareAddr
+	IPAddresses []*net.IPNet
+	NextHops    []net.IP
+}
+
+type egressInterfaceGatewayConfigJSON struct {
+	InterfaceID string   `json:"interface-id"`
+	MACAddress  string   `json:"mac-address"`
+	IPAddresses []string `json:"ip-addresses"`
+	NextHops    []string `json:"next-hops,omitempty"`
+}
+
+func (cfg *EgressInterfaceGatewayConfig) MarshalJSON() ([]byte, error) {
+	cfgjson := egressInterfaceGatewayConfigJSON{
+		InterfaceID: cfg.InterfaceID,
+		MACAddress:  cfg.MACAddress.String(),
+		IPAddresses: make([]string, len(cfg.IPAddresses)),
+	}
+	for i, ip := range cfg.IPAddresses {
+		cfgjson.IPAddresses[i] = ip.String()
+	}
+	for _, nh := range cfg.NextHops {
+		cfgjson.NextHops = append(cfgjson.NextHops, nh.String())
+	}
+	return json.Marshal(&cfgjson)
+}
+
+func (cfg *EgressInterfaceGatewayConfig) UnmarshalJSON(bytes []byte) error {
+	cfgjson := egressInterfaceGatewayConfigJSON{}
+	if err := json.Unmarshal(bytes, &cfgjson); err != nil {
+		return err
+	}
+
+	if cfgjson.InterfaceID == "" {
+		return fmt.Errorf("missing 'interface-id' value")
+	}
+	cfg.InterfaceID = cfgjson.InterfaceID
+
+	var err error
+	cfg.MACAddress, err = net.ParseMAC(cfgjson.MACAddress)
+	if err != nil {
+		return fmt.Errorf("bad 'mac-address' value %q: %v", cfgjson.MACAddress, err)
+	}
+
+	cfg.IPAddresses = make([]*net.IPNet, len(cfgjson.IPAddresses))
+	for i, ipStr := range cfgjson.IPAddresses {
+		ip, ipnet, err := net.ParseCIDR(ipStr)
+		if err != nil {
+			return fmt.Errorf("bad 'ip-addresses' value %q: %v", ipStr, err)
+		}
+		cfg.IPAddresses[i] = &net.IPNet{IP: ip, Mask: ipnet.Mask}
+	}
+
+	cfg.NextHops = make([]net.IP, len(cfgjson.NextHops))
+	for i, nextHopStr := range cfgjson.NextHops {
+		cfg.NextHops[i] = net.ParseIP(nextHopStr)
+		if cfg.NextHops[i] == nil {
+			return fmt.Errorf("bad 'next-hops' value %q", nextHopStr)
+		}
+	}
+	return nil
+}
+
+// SetNodeEgressInterfaces sets the gateway configuration of the EgressInterfaces set up on the
+// node, by EgressInterface name, or removes the annotation if there are none
+func SetNodeEgressInterfaces(nodeAnnotator kube.Annotator, cfgs map[string]*EgressInterfaceGatewayConfig) error {
+	if len(cfgs) == 0 {
+		nodeAnnotator.Delete(ovnNodeEgressInterfaces)
+		return nil
+	}
+	return nodeAnnotator.Set(ovnNodeEgressInterfaces, cfgs)
+}
+
+// ParseNodeEgressInterfaces returns the gateway configuration of the EgressInterfaces set up on
+// the node, by EgressInterface name
+func ParseNodeEgressInterfaces(node *kapi.Node) (map[string]*EgressInterfaceGatewayConfig, error) {
+	annotation, ok := node.Annotations[ovnNodeEgressInterfaces]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", ovnNodeEgressInterfaces, node.Name)
+	}
+	cfgs := map[string]*EgressInterfaceGatewayConfig{}
+	if err := json.Unmarshal([]byte(annotation), &cfgs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %s for node %q: %v", ovnNodeEgressInterfaces,
+			annotation, node.Name, err)
+	}
+	return cfgs, nil
+}
+
+func NodeEgressInterfacesAnnotationChanged(oldNode, newNode *kapi.Node) bool {
+	return oldNode.Annotations[ovnNodeEgressInterfaces] != newNode.Annotations[ovnNodeEgressInterfaces]
+}
+
 // ParseNodeChassisIDAnnotation returns the node's ovnNodeChassisID annotation
 func ParseNodeChassisIDAnnotation(node *kapi.Node) (string, error) {
 	chassisID, ok := node.Annotations[ovnNodeChassisID]
@@ -308,6 +447,22 @@ func ParseNodeChassisIDAnnotation(node *kapi.Node) (string, error) {
 	return chassisID, nil
 }
 
+// SetNodeDPUChassisID sets the chassis ID of the DPU of the host with the given name
+func SetNodeDPUChassisID(nodeAnnotator kube.Annotator, dpuName, chassisID string) error {
+	return nodeAnnotator.Set(ovnNodeDPUChassisIDPrefix+dpuName, chassisID)
+}
+
+// ParseNodeDPUChassisIDs returns the chassis IDs of the DPUs of the node by DPU name
+func ParseNodeDPUChassisIDs(node *kapi.Node) map[string]string {
+	chassisIDs := map[string]string{}
+	for key, value := range node.Annotations {
+		if dpuName := strings.TrimPrefix(key, ovnNodeDPUChassisIDPrefix); dpuName != key && dpuName != "" {
+			chassisIDs[dpuName] = value
+		}
+	}
+	return chassisIDs
+}
+
 func SetNodeManagementPortMACAddress(nodeAnnotator kube.Annotator, macAddress net.HardwareAddr) error {
 	return nodeAnnotator.Set(ovnNodeManagementPortMacAddress, macAddress.String())
 }
@@ -341,27 +496,103 @@ func SetNodePrimaryIfAddrs(nodeAnnotator kube.Annotator, ifAddrs []*net.IPNet) (
 	return nodeAnnotator.Set(ovnNodeIfAddr, primaryIfAddrAnnotation)
 }
 
-// CreateNodeGatewayRouterLRPAddrAnnotation sets the IPv4 / IPv6 values of the node's Gatewary Router LRP to join switch.
-func CreateNodeGatewayRouterLRPAddrAnnotation(nodeAnnotation map[string]string, nodeIPNetv4,
-	nodeIPNetv6 *net.IPNet) (map[string]string, error) {
+// UpdateNodeGatewayRouterLRPAddrsAnnotation sets the IPv4 / IPv6 values of the node's Gateway Router LRP
+// to join switch of the given network, or removes them if gwLRPIPs is empty.
+func UpdateNodeGatewayRouterLRPAddrsAnnotation(nodeAnnotation map[string]string, gwLRPIPs []*net.IPNet,
+	netName string) (map[string]string, error) {
 	if nodeAnnotation == nil {
 		nodeAnnotation = map[string]string{}
 	}
-	primaryIfAddrAnnotation := primaryIfAddrAnnotation{}
-	if nodeIPNetv4 != nil {
-		primaryIfAddrAnnotation.IPv4 = nodeIPNetv4.String()
+	ifAddrs := primaryIfAddrAnnotation{}
+	for _, ip := range gwLRPIPs {
+		if ip.IP.To4() != nil {
+			ifAddrs.IPv4 = ip.String()
+		} else {
+			ifAddrs.IPv6 = ip.String()
+		}
 	}
-	if nodeIPNetv6 != nil {
-		primaryIfAddrAnnotation.IPv6 = nodeIPNetv6.String()
+
+	if netName == types.DefaultNetworkName {
+		if len(gwLRPIPs) == 0 {
+			delete(nodeAnnotation, ovnNodeGRLRPAddr)
+			return nodeAnnotation, nil
+		}
+		bytes, err := json.Marshal(ifAddrs)
+		if err != nil {
+			return nil, err
+		}
+		nodeAnnotation[ovnNodeGRLRPAddr] = string(bytes)
+		return nodeAnnotation, nil
+	}
+
+	ifAddrsMap := map[string]primaryIfAddrAnnotation{}
+	if annotation, ok := nodeAnnotation[ovnNodeGRLRPAddrs]; ok {
+		if err := json.Unmarshal([]byte(annotation), &ifAddrsMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotation %s: %v", ovnNodeGRLRPAddrs, err)
+		}
+	}
+	if len(gwLRPIPs) == 0 {
+		delete(ifAddrsMap, netName)
+	} else {
+		ifAddrsMap[netName] = ifAddrs
 	}
-	bytes, err := json.Marshal(primaryIfAddrAnnotation)
+	if len(ifAddrsMap) == 0 {
+		delete(nodeAnnotation, ovnNodeGRLRPAddrs)
+		return nodeAnnotation, nil
+	}
+	bytes, err := json.Marshal(ifAddrsMap)
 	if err != nil {
 		return nil, err
 	}
-	nodeAnnotation[ovnNodeGRLRPAddr] = string(bytes)
+	nodeAnnotation[ovnNodeGRLRPAddrs] = string(bytes)
 	return nodeAnnotation, nil
 }
 
+// ParseNodeGatewayRouterLRPAddrs returns the IPv4 / IPv6 values of the node's Gateway Router LRP to
+// join switch of the given network, IPv4 first.
+func ParseNodeGatewayRouterLRPAddrs(node *kapi.Node, netName string) ([]*net.IPNet, error) {
+	ifAddrs := primaryIfAddrAnnotation{}
+	if netName == types.DefaultNetworkName {
+		annotation, ok := node.Annotations[ovnNodeGRLRPAddr]
+		if !ok {
+			return nil, newAnnotationNotSetError("%s annotation not found for node %q", ovnNodeGRLRPAddr, node.Name)
+		}
+		if err := json.Unmarshal([]byte(annotation), &ifAddrs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotation: %s for node %q, err: %v", ovnNodeGRLRPAddr, node.Name, err)
+		}
+	} else {
+		annotation, ok := node.Annotations[ovnNodeGRLRPAddrs]
+		if !ok {
+			return nil, newAnnotationNotSetError("%s annotation not found for node %q", ovnNodeGRLRPAddrs, node.Name)
+		}
+		ifAddrsMap := map[string]primaryIfAddrAnnotation{}
+		if err := json.Unmarshal([]byte(annotation), &ifAddrsMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal annotation: %s for node %q, err: %v", ovnNodeGRLRPAddrs, node.Name, err)
+		}
+		if ifAddrs, ok = ifAddrsMap[netName]; !ok {
+			return nil, newAnnotationNotSetError("node %q has no %q annotation for network %s", node.Name, ovnNodeGRLRPAddrs, netName)
+		}
+	}
+
+	var gwLRPIPs []*net.IPNet
+	for _, ifAddr := range []string{ifAddrs.IPv4, ifAddrs.IPv6} {
+		if ifAddr == "" {
+			continue
+		}
+		ip, ipNet, err := net.ParseCIDR(ifAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse gateway router LRP address %q of node %q for network %s: %v",
+				ifAddr, node.Name, netName, err)
+		}
+		ipNet.IP = ip
+		gwLRPIPs = append(gwLRPIPs, ipNet)
+	}
+	if len(gwLRPIPs) == 0 {
+		return nil, fmt.Errorf("node: %q does not have any gateway router LRP IP information set for network %s", node.Name, netName)
+	}
+	return gwLRPIPs, nil
+}
+
 const UnlimitedNodeCapacity = math.MaxInt32
 
 type ifAddr struct {
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"reflect"
@@ -12,6 +13,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	annotatorMock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -514,6 +516,102 @@ func TestParseNodeGatewayRouterLRPAddr(t *testing.T) {
 	}
 }
 
+func TestUpdateNodeGatewayRouterLRPAddrsAnnotation(t *testing.T) {
+	tests := []struct {
+		desc           string
+		inpAnnotations map[string]string
+		inpIPs         []*net.IPNet
+		inpNetName     string
+		expAnnotations map[string]string
+	}{
+		{
+			desc:           "success: default network uses the legacy annotation",
+			inpIPs:         ovntest.MustParseIPNets("fd98::2/64", "100.64.0.2/16"),
+			inpNetName:     types.DefaultNetworkName,
+			expAnnotations: map[string]string{ovnNodeGRLRPAddr: `{"ipv4":"100.64.0.2/16","ipv6":"fd98::2/64"}`},
+		},
+		{
+			desc:           "success: empty IPs remove the default network annotation",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddr: `{"ipv4":"100.64.0.2/16"}`},
+			inpNetName:     types.DefaultNetworkName,
+			expAnnotations: map[string]string{},
+		},
+		{
+			desc:           "success: secondary networks are keyed by network name",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddrs: `{"red":{"ipv4":"100.65.0.3/16"}}`},
+			inpIPs:         ovntest.MustParseIPNets("100.65.0.2/16"),
+			inpNetName:     "blue",
+			expAnnotations: map[string]string{ovnNodeGRLRPAddrs: `{"blue":{"ipv4":"100.65.0.2/16"},"red":{"ipv4":"100.65.0.3/16"}}`},
+		},
+		{
+			desc:           "success: removing the last secondary network removes the annotation",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddrs: `{"blue":{"ipv4":"100.65.0.2/16"}}`},
+			inpNetName:     "blue",
+			expAnnotations: map[string]string{},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			annotations, err := UpdateNodeGatewayRouterLRPAddrsAnnotation(tc.inpAnnotations, tc.inpIPs, tc.inpNetName)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expAnnotations, annotations)
+			if len(tc.inpIPs) == 0 {
+				return
+			}
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+			ips, err := ParseNodeGatewayRouterLRPAddrs(node, tc.inpNetName)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.inpIPs, ips)
+			assert.NotNil(t, ips[0].IP.To4(), "expected the IPv4 address first")
+		})
+	}
+}
+
+func TestParseNodeGatewayRouterLRPAddrs(t *testing.T) {
+	tests := []struct {
+		desc           string
+		inpAnnotations map[string]string
+		inpNetName     string
+		notSetExpected bool
+		errExpected    bool
+	}{
+		{
+			desc:           "error: annotation not set for the default network",
+			inpNetName:     types.DefaultNetworkName,
+			notSetExpected: true,
+		},
+		{
+			desc:           "error: annotation not set for the secondary network",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddrs: `{"red":{"ipv4":"100.65.0.3/16"}}`},
+			inpNetName:     "blue",
+			notSetExpected: true,
+		},
+		{
+			desc:           "error: IP without prefix length",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddr: `{"ipv4":"100.64.0.5"}`},
+			inpNetName:     types.DefaultNetworkName,
+			errExpected:    true,
+		},
+		{
+			desc:           "error: no IPs",
+			inpAnnotations: map[string]string{ovnNodeGRLRPAddr: `{}`},
+			inpNetName:     types.DefaultNetworkName,
+			errExpected:    true,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: tc.inpAnnotations}}
+			ips, err := ParseNodeGatewayRouterLRPAddrs(node, tc.inpNetName)
+			assert.Error(t, err)
+			assert.Nil(t, ips)
+			assert.Equal(t, tc.notSetExpected, IsAnnotationNotSetError(err))
+		})
+	}
+}
+
 func TestSetGatewayMTUSupport(t *testing.T) {
 	mockAnnotator := new(annotatorMock.Annotator)
 
@@ -616,3 +714,116 @@ func TestParseNodeGatewayMTUSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNodeGatewayMode(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations map[string]string
+		res         config.GatewayMode
+		notSet      bool
+		expErr      bool
+	}{
+		{
+			desc:   "annotation not found for node",
+			notSet: true,
+			expErr: true,
+		},
+		{
+			desc:        "parse completed for local gateway mode",
+			annotations: map[string]string{"k8s.ovn.org/gateway-mode": "local"},
+			res:         config.GatewayModeLocal,
+		},
+		{
+			desc:        "parse completed for shared gateway mode",
+			annotations: map[string]string{"k8s.ovn.org/gateway-mode": "shared"},
+			res:         config.GatewayModeShared,
+		},
+		{
+			desc:        "parse failed for invalid gateway mode",
+			annotations: map[string]string{"k8s.ovn.org/gateway-mode": "disabled"},
+			expErr:      true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: tc.annotations}}
+			res, err := ParseNodeGatewayMode(node)
+			if tc.expErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.notSet, IsAnnotationNotSetError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestParseNodeEgressInterfaces(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations map[string]string
+		res         map[string]*EgressInterfaceGatewayConfig
+		notSet      bool
+		expErr      bool
+	}{
+		{
+			desc:   "annotation not found for node",
+			notSet: true,
+			expErr: true,
+		},
+		{
+			desc: "parse completed",
+			annotations: map[string]string{"k8s.ovn.org/egress-interfaces": `{"storage":{"interface-id":"breth2_node1",` +
+				`"mac-address":"7e:57:f8:f0:3c:49","ip-addresses":["192.168.2.10/24","fd02::10/64"],"next-hops":["192.168.2.1"]}}`},
+			res: map[string]*EgressInterfaceGatewayConfig{
+				"storage": {
+					InterfaceID: "breth2_node1",
+					MACAddress:  ovntest.MustParseMAC("7e:57:f8:f0:3c:49"),
+					IPAddresses: []*net.IPNet{
+						ovntest.MustParseIPNet("192.168.2.10/24"),
+						ovntest.MustParseIPNet("fd02::10/64"),
+					},
+					NextHops: []net.IP{ovntest.MustParseIP("192.168.2.1")},
+				},
+			},
+		},
+		{
+			desc: "parse failed for missing interface ID",
+			annotations: map[string]string{"k8s.ovn.org/egress-interfaces": `{"storage":{"mac-address":"7e:57:f8:f0:3c:49",` +
+				`"ip-addresses":["192.168.2.10/24"]}}`},
+			expErr: true,
+		},
+		{
+			desc: "parse failed for invalid next hop",
+			annotations: map[string]string{"k8s.ovn.org/egress-interfaces": `{"storage":{"interface-id":"breth2_node1",` +
+				`"mac-address":"7e:57:f8:f0:3c:49","ip-addresses":["192.168.2.10/24"],"next-hops":["192.168.2"]}}`},
+			expErr: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: tc.annotations}}
+			res, err := ParseNodeEgressInterfaces(node)
+			if tc.expErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.notSet, IsAnnotationNotSetError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, res)
+
+			// the configuration set on the node is parsed back unchanged
+			mockAnnotator := &annotatorMock.Annotator{}
+			mockAnnotator.On("Set", ovnNodeEgressInterfaces, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				data, err := json.Marshal(args.Get(1))
+				assert.NoError(t, err)
+				node.Annotations[ovnNodeEgressInterfaces] = string(data)
+			})
+			assert.NoError(t, SetNodeEgressInterfaces(mockAnnotator, res))
+			reparsed, err := ParseNodeEgressInterfaces(node)
+			assert.NoError(t, err)
+			assert.Equal(t, res, reparsed)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// NodeExternalConnectivityUnavailable is the node condition reported by ovnkube-node, like
+	// NetworkUnavailable it is True while the node can't reach the external network through its
+	// gateway, e.g. because the next hop of the gateway is unreachable or the gateway bridge is
+	// no longer plugged to br-int.
+	NodeExternalConnectivityUnavailable corev1.NodeConditionType = "ExternalConnectivityUnavailable"
+
+	// NodeExternalConnectivityAvailableReason is the reason of the external connectivity condition
+	// when all the health checks of the node passed
+	NodeExternalConnectivityAvailableReason = "HealthChecksPassed"
+)
+
+// GetNodeCondition returns the condition of the given type of the node, or nil if the node
+// doesn't report it
+func GetNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == conditionType {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsNodeExternalConnectivityAvailable returns false if ovnkube-node reported that the node lost
+// its external connectivity. Nodes that don't report the condition yet are considered connected.
+func IsNodeExternalConnectivityAvailable(node *corev1.Node) bool {
+	condition := GetNodeCondition(node, NodeExternalConnectivityUnavailable)
+	return condition == nil || condition.Status != corev1.ConditionTrue
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestIsNodeExternalConnectivityAvailable(t *testing.T) {
+	tests := []struct {
+		desc       string
+		conditions []v1.NodeCondition
+		expOutput  bool
+	}{
+		{
+			desc:      "node without the condition is considered connected",
+			expOutput: true,
+		},
+		{
+			desc: "node reporting its external connectivity available",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: NodeExternalConnectivityUnavailable, Status: v1.ConditionFalse},
+			},
+			expOutput: true,
+		},
+		{
+			desc: "node reporting its external connectivity unavailable",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: NodeExternalConnectivityUnavailable, Status: v1.ConditionTrue, Reason: "NextHopUnreachable"},
+			},
+			expOutput: false,
+		},
+		{
+			desc: "node with an unknown external connectivity is considered connected",
+			conditions: []v1.NodeCondition{
+				{Type: NodeExternalConnectivityUnavailable, Status: v1.ConditionUnknown},
+			},
+			expOutput: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			node := &v1.Node{Status: v1.NodeStatus{Conditions: tc.conditions}}
+			res := IsNodeExternalConnectivityAvailable(node)
+			assert.Equal(t, tc.expOutput, res, "test case %d", i)
+		})
+	}
+}
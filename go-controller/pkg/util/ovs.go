@@ -41,6 +41,7 @@ const (
 	netshCommand       = "netsh"
 	routeCommand       = "route"
 	sysctlCommand      = "sysctl"
+	vtyshCommand       = "vtysh"
 	osRelease          = "/etc/os-release"
 	rhel               = "RHEL"
 	ubuntu             = "Ubuntu"
@@ -360,6 +361,17 @@ func RunOVSVsctl(args ...string) (string, string, error) {
 	return strings.Trim(strings.TrimSpace(stdout.String()), "\""), stderr.String(), err
 }
 
+// RunVtysh runs a command via the FRR vtysh shell. vtysh is only required when
+// routes are advertised with BGP, so its path is looked up on use.
+func RunVtysh(args ...string) (string, string, error) {
+	vtyshPath, err := runner.exec.LookPath(vtyshCommand)
+	if err != nil {
+		return "", "", err
+	}
+	stdout, stderr, err := run(vtyshPath, args...)
+	return strings.TrimSpace(stdout.String()), stderr.String(), err
+}
+
 // GetOVSOfPort runs get ofport via ovs-vsctl and handle special return strings.
 func GetOVSOfPort(args ...string) (string, string, error) {
 	stdout, stderr, err := RunOVSVsctl(args...)
@@ -509,6 +521,13 @@ func RunOVSDBClient(args ...string) (string, string, error) {
 	return strings.Trim(strings.TrimSpace(stdout.String()), "\""), stderr.String(), err
 }
 
+// RunOVSDBClientRawOutput runs an 'ovsdb-client [OPTIONS] COMMAND [ARG...] command'
+// and returns the output with no trimming or other string manipulation
+func RunOVSDBClientRawOutput(args ...string) (string, string, error) {
+	stdout, stderr, err := runOVNretry(runner.ovsdbClientPath, nil, args...)
+	return stdout.String(), stderr.String(), err
+}
+
 // RunOVSDBTool runs an 'ovsdb-tool [OPTIONS] COMMAND [ARG...] command'.
 func RunOVSDBTool(args ...string) (string, string, error) {
 	stdout, stderr, err := run(runner.ovsdbToolPath, args...)
@@ -673,6 +692,44 @@ func ReplaceOFFlows(bridgeName string, flows []string) (string, string, error) {
 	return strings.Trim(stdout.String(), "\" \n"), stderr.String(), err
 }
 
+// ModifyOFFlows adds and deletes flows of the bridge in a single bundle. Each of the given
+// flows is prefixed with the flow_mod command to use, e.g. "add" or "delete_strict".
+func ModifyOFFlows(bridgeName string, flowMods []string) (string, string, error) {
+	args := []string{"-O", "OpenFlow13", "--bundle", "add-flows", bridgeName, "-"}
+	stdin := &bytes.Buffer{}
+	stdin.Write([]byte(strings.Join(flowMods, "\n")))
+
+	cmd := runner.exec.Command(runner.ofctlPath, args...)
+	cmd.SetStdin(stdin)
+	stdout, stderr, err := runCmd(cmd, runner.ofctlPath, args...)
+	return strings.Trim(stdout.String(), "\" \n"), stderr.String(), err
+}
+
+// DiffOFFlows returns the differences between the flows of the bridge and the given flows as
+// printed by ovs-ofctl diff-flows, which parses both with OVS's own flow syntax: the flows only
+// found on the bridge are prefixed with "-", the given flows missing from it with "+", and a flow
+// whose actions differ is printed both ways. It returns no differences when they are the same.
+func DiffOFFlows(bridgeName string, flows []string) ([]string, error) {
+	args := []string{"-O", "OpenFlow13", "diff-flows", bridgeName, "-"}
+	stdin := &bytes.Buffer{}
+	stdin.Write([]byte(strings.Join(flows, "\n")))
+
+	cmd := runner.exec.Command(runner.ofctlPath, args...)
+	cmd.SetStdin(stdin)
+	stdout, stderr, err := runCmd(cmd, runner.ofctlPath, args...)
+	var diff []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			diff = append(diff, line)
+		}
+	}
+	// ovs-ofctl exits with status 2 when it found differences
+	if err != nil && len(diff) == 0 {
+		return nil, fmt.Errorf("failed to diff the flows of bridge %s, stderr: %q, error: %v", bridgeName, stderr, err)
+	}
+	return diff, nil
+}
+
 // Get OpenFlow Port names or numbers for a given bridge
 func GetOpenFlowPorts(bridgeName string, namedPorts bool) ([]string, error) {
 	stdout, stderr, err := RunOVSOfctl("show", bridgeName)
@@ -0,0 +1,112 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// This handles the bandwidth annotations on Pods. The rates are the ones of the upstream
+// bandwidth CNI plugin, the bursts and the DSCP marking are specific to ovn-kubernetes:
+//
+//   annotations:
+//     kubernetes.io/ingress-bandwidth: 10M
+//     kubernetes.io/egress-bandwidth: 20M
+//     k8s.ovn.org/ingress-burst: 1M
+//     k8s.ovn.org/egress-burst: 2M
+//     k8s.ovn.org/egress-dscp: "46"
+//
+// The rates are in bits per second and the bursts in bits.
+
+const (
+	// PodIngressBandwidthAnnotation is the rate limit of the traffic to the pod
+	PodIngressBandwidthAnnotation = "kubernetes.io/ingress-bandwidth"
+	// PodEgressBandwidthAnnotation is the rate limit of the traffic from the pod
+	PodEgressBandwidthAnnotation = "kubernetes.io/egress-bandwidth"
+	// PodIngressBurstAnnotation is the burst size of the traffic to the pod, over the ingress rate
+	PodIngressBurstAnnotation = "k8s.ovn.org/ingress-burst"
+	// PodEgressBurstAnnotation is the burst size of the traffic from the pod, over the egress rate
+	PodEgressBurstAnnotation = "k8s.ovn.org/egress-burst"
+	// PodEgressDSCPAnnotation is the DSCP value the traffic from the pod is marked with
+	PodEgressDSCPAnnotation = "k8s.ovn.org/egress-dscp"
+)
+
+var (
+	minBandwidth = resource.MustParse("1k")
+	maxBandwidth = resource.MustParse("1P")
+)
+
+// PodBandwidth is the bandwidth of a pod parsed from its annotations. Zero rates and bursts are unset,
+// as is a negative EgressDSCP.
+type PodBandwidth struct {
+	IngressRate  int64
+	IngressBurst int64
+	EgressRate   int64
+	EgressBurst  int64
+	EgressDSCP   int
+}
+
+// IsEmpty returns true if the pod neither limits nor marks its traffic
+func (bw *PodBandwidth) IsEmpty() bool {
+	return bw.IngressRate == 0 && bw.EgressRate == 0 && bw.EgressDSCP < 0
+}
+
+func parseBandwidthAnnotation(annotations map[string]string, annotation string) (int64, error) {
+	str, ok := annotations[annotation]
+	if !ok {
+		return 0, nil
+	}
+	value, err := resource.ParseQuantity(str)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s %q: %v", annotation, str, err)
+	}
+	if value.Value() < minBandwidth.Value() {
+		return 0, fmt.Errorf("%s %q is unreasonably small (< 1kbit)", annotation, str)
+	}
+	if value.Value() > maxBandwidth.Value() {
+		return 0, fmt.Errorf("%s %q is unreasonably large (> 1Pbit)", annotation, str)
+	}
+	return value.Value(), nil
+}
+
+// GetPodBandwidth parses the bandwidth annotations of a pod. The bursts are only used along with their rate.
+func GetPodBandwidth(annotations map[string]string) (*PodBandwidth, error) {
+	var err error
+	bw := &PodBandwidth{EgressDSCP: -1}
+	if bw.IngressRate, err = parseBandwidthAnnotation(annotations, PodIngressBandwidthAnnotation); err != nil {
+		return nil, err
+	}
+	if bw.EgressRate, err = parseBandwidthAnnotation(annotations, PodEgressBandwidthAnnotation); err != nil {
+		return nil, err
+	}
+	if bw.IngressRate > 0 {
+		if bw.IngressBurst, err = parseBandwidthAnnotation(annotations, PodIngressBurstAnnotation); err != nil {
+			return nil, err
+		}
+	}
+	if bw.EgressRate > 0 {
+		if bw.EgressBurst, err = parseBandwidthAnnotation(annotations, PodEgressBurstAnnotation); err != nil {
+			return nil, err
+		}
+	}
+	if str, ok := annotations[PodEgressDSCPAnnotation]; ok {
+		dscp, err := strconv.Atoi(str)
+		if err != nil || dscp < 0 || dscp > 63 {
+			return nil, fmt.Errorf("invalid %s %q: must be an integer between 0 and 63", PodEgressDSCPAnnotation, str)
+		}
+		bw.EgressDSCP = dscp
+	}
+	return bw, nil
+}
+
+// PodBandwidthAnnotationsChanged returns true if any of the bandwidth annotations of a pod changed
+func PodBandwidthAnnotationsChanged(oldAnnotations, newAnnotations map[string]string) bool {
+	for _, annotation := range []string{PodIngressBandwidthAnnotation, PodEgressBandwidthAnnotation,
+		PodIngressBurstAnnotation, PodEgressBurstAnnotation, PodEgressDSCPAnnotation} {
+		if oldAnnotations[annotation] != newAnnotations[annotation] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPodBandwidth(t *testing.T) {
+	tests := []struct {
+		desc           string
+		annotations    map[string]string
+		errExpected    bool
+		expectedOutput *PodBandwidth
+	}{
+		{
+			desc:           "no bandwidth annotations",
+			annotations:    map[string]string{"foo": "bar"},
+			expectedOutput: &PodBandwidth{EgressDSCP: -1},
+		},
+		{
+			desc: "rates, bursts and DSCP",
+			annotations: map[string]string{
+				PodIngressBandwidthAnnotation: "10M",
+				PodEgressBandwidthAnnotation:  "20M",
+				PodIngressBurstAnnotation:     "1M",
+				PodEgressBurstAnnotation:      "2M",
+				PodEgressDSCPAnnotation:       "46",
+			},
+			expectedOutput: &PodBandwidth{
+				IngressRate:  10000000,
+				IngressBurst: 1000000,
+				EgressRate:   20000000,
+				EgressBurst:  2000000,
+				EgressDSCP:   46,
+			},
+		},
+		{
+			desc: "bursts without their rate are ignored",
+			annotations: map[string]string{
+				PodIngressBandwidthAnnotation: "10M",
+				PodEgressBurstAnnotation:      "2M",
+			},
+			expectedOutput: &PodBandwidth{IngressRate: 10000000, EgressDSCP: -1},
+		},
+		{
+			desc:           "DSCP only",
+			annotations:    map[string]string{PodEgressDSCPAnnotation: "0"},
+			expectedOutput: &PodBandwidth{EgressDSCP: 0},
+		},
+		{
+			desc:        "unparsable rate",
+			annotations: map[string]string{PodIngressBandwidthAnnotation: "fast"},
+			errExpected: true,
+		},
+		{
+			desc:        "rate too small",
+			annotations: map[string]string{PodEgressBandwidthAnnotation: "10"},
+			errExpected: true,
+		},
+		{
+			desc:        "burst too large",
+			annotations: map[string]string{PodEgressBandwidthAnnotation: "10M", PodEgressBurstAnnotation: "2P"},
+			errExpected: true,
+		},
+		{
+			desc:        "DSCP out of range",
+			annotations: map[string]string{PodEgressDSCPAnnotation: "64"},
+			errExpected: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := GetPodBandwidth(tc.annotations)
+			t.Log(res, err)
+			if tc.errExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOutput, res, "test case %d", i)
+			}
+		})
+	}
+}
+
+func TestPodBandwidthAnnotationsChanged(t *testing.T) {
+	old := map[string]string{PodEgressBandwidthAnnotation: "10M", "foo": "bar"}
+	assert.False(t, PodBandwidthAnnotationsChanged(old, map[string]string{PodEgressBandwidthAnnotation: "10M"}))
+	assert.True(t, PodBandwidthAnnotationsChanged(old, map[string]string{PodEgressBandwidthAnnotation: "20M"}))
+	assert.True(t, PodBandwidthAnnotationsChanged(old, map[string]string{PodEgressBandwidthAnnotation: "10M", PodEgressDSCPAnnotation: "8"}))
+	assert.True(t, PodBandwidthAnnotationsChanged(old, nil))
+}
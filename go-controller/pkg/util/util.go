@@ -94,6 +94,26 @@ func GetGatewayRouterFromNode(node string) string {
 	return types.GWRouterPrefix + node
 }
 
+// GetJoinSubnets returns the join subnets of the enabled IP families
+func GetJoinSubnets() ([]*net.IPNet, error) {
+	var joinSubnets []*net.IPNet
+	joinSubnetStrings := []string{}
+	if config.IPv4Mode {
+		joinSubnetStrings = append(joinSubnetStrings, config.Gateway.V4JoinSubnet)
+	}
+	if config.IPv6Mode {
+		joinSubnetStrings = append(joinSubnetStrings, config.Gateway.V6JoinSubnet)
+	}
+	for _, joinSubnetString := range joinSubnetStrings {
+		_, joinSubnet, err := net.ParseCIDR(joinSubnetString)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing join subnet string %s: %v", joinSubnetString, err)
+		}
+		joinSubnets = append(joinSubnets, joinSubnet)
+	}
+	return joinSubnets, nil
+}
+
 // GetNodeInternalAddrs returns the first IPv4 and/or IPv6 InternalIP defined
 // for the node. On certain cloud providers (AWS) the egress IP will be added to
 // the list of node IPs as an InternalIP address, we don't want to create the